@@ -0,0 +1,182 @@
+package main
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/url"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/port"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/infrastructure/config"
+	datasource_request "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/infrastructure/datasource/request"
+	datasource_response "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/infrastructure/datasource/response"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/infrastructure/httpclient"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/infrastructure/logger"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/infrastructure/service"
+)
+
+// Mock of the Mercado Pago in-store QR and refunds API. Every payment created is notified to its notification_url
+// after MOCK_CALLBACK_DELAY, signed with MERCADO_PAGO_WEBHOOK_SECRET the same way Mercado Pago does. The notified
+// payment then reports the status MOCK_PAYMENT_STATUS when looked up.
+func main() {
+	cfg := config.LoadConfig()
+	loggerInstance := logger.NewLogger(cfg.Environment)
+
+	callbackDelay, err := time.ParseDuration(getEnv("MOCK_CALLBACK_DELAY", "1s"))
+	if err != nil {
+		loggerInstance.Error("invalid MOCK_CALLBACK_DELAY", "error", err)
+		os.Exit(1)
+	}
+
+	m := &mockServer{
+		httpClient:       httpclient.NewRestyClient(cfg, loggerInstance),
+		signatureService: service.NewWebhookSignatureService(cfg),
+		logger:           loggerInstance,
+		callbackDelay:    callbackDelay,
+		paymentStatus:    getEnv("MOCK_PAYMENT_STATUS", "CONFIRMED"),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /mercadopago/instore/orders/qr", m.createPayment)
+	mux.HandleFunc("POST /mercadopago/refunds", m.createRefund)
+	mux.HandleFunc("GET /mercadopago/payments/{resource}", m.getPayment)
+	mux.HandleFunc("GET /mercadopago/healthCheck", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	addr := ":" + getEnv("MOCK_SERVER_PORT", "3001")
+	loggerInstance.Info("mock server is running", "port", addr)
+	if err := http.ListenAndServe(addr, mux); err != nil {
+		loggerInstance.Error("mock server failed", "error", err)
+		os.Exit(1)
+	}
+}
+
+type mockServer struct {
+	httpClient       *httpclient.HTTPClient
+	signatureService port.WebhookSignatureService
+	logger           *logger.Logger
+	callbackDelay    time.Duration
+	paymentStatus    string
+	notified         sync.Map // resources already notified
+	refunds          sync.Map // refund IDs by idempotency key
+}
+
+type callbackBody struct {
+	ID       string `json:"id"`
+	Resource string `json:"resource"`
+	Topic    string `json:"topic"`
+}
+
+func (m *mockServer) createPayment(w http.ResponseWriter, r *http.Request) {
+	var payment datasource_request.FakeMercadoPagoRequest
+	if err := json.NewDecoder(r.Body).Decode(&payment); err != nil {
+		http.Error(w, "invalid body", http.StatusBadRequest)
+		return
+	}
+
+	qrData, err := randomDigits(20)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response := datasource_response.MercadoPagoResponse{
+		InStoreOrderID: payment.ExternalReference,
+		QrData:         qrData + "com.mercadolibre0201306" + uuid.NewString(),
+	}
+
+	go m.notify(payment.NotificationUrl, callbackBody{
+		ID:       uuid.NewString(),
+		Resource: response.InStoreOrderID,
+		Topic:    payment.Title,
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+func (m *mockServer) createRefund(w http.ResponseWriter, r *http.Request) {
+	var refund datasource_request.FakeMercadoPagoRefundRequest
+	if err := json.NewDecoder(r.Body).Decode(&refund); err != nil || !refund.Amount.IsPositive() || r.Header.Get("X-Idempotency-Key") == "" {
+		http.Error(w, "invalid body", http.StatusBadRequest)
+		return
+	}
+
+	// A retry with the same idempotency key gets the refund already made, the money is given back once
+	refundID, loaded := m.refunds.LoadOrStore(r.Header.Get("X-Idempotency-Key"), uuid.NewString())
+	if !loaded {
+		m.logger.Info("payment refunded", "resource", refund.InStoreOrderID, "amount", refund.Amount)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(datasource_response.FakeMercadoPagoRefundResponse{ID: refundID.(string)})
+}
+
+// getPayment reports the status of a payment already notified
+func (m *mockServer) getPayment(w http.ResponseWriter, r *http.Request) {
+	resource := r.PathValue("resource")
+	if _, ok := m.notified.Load(resource); !ok {
+		http.Error(w, "payment not found", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(datasource_response.FakeMercadoPagoStatusResponse{Status: m.paymentStatus})
+}
+
+// notify sends the signed payment notification, as the x-signature and x-request-id headers, the signed resource
+// goes in the data.id query param
+func (m *mockServer) notify(notificationURL string, body callbackBody) {
+	time.Sleep(m.callbackDelay)
+	m.notified.Store(body.Resource, struct{}{})
+
+	u, err := url.Parse(notificationURL)
+	if err != nil {
+		m.logger.Error("invalid notification url", "url", notificationURL, "error", err)
+		return
+	}
+	query := u.Query()
+	query.Set("data.id", body.Resource)
+	u.RawQuery = query.Encode()
+
+	requestID := uuid.NewString()
+	resp, err := m.httpClient.NewRequest().
+		SetHeader("x-request-id", requestID).
+		SetHeader("x-signature", m.signatureService.Sign(body.Resource, requestID, time.Now())).
+		SetBody(body).
+		Post(u.String())
+	if err != nil {
+		m.logger.Error("failed to notify payment", "resource", body.Resource, "error", err)
+		return
+	}
+
+	m.logger.Info("payment notified", "resource", body.Resource, "status", m.paymentStatus, "response_status", resp.StatusCode())
+}
+
+func randomDigits(n int) (string, error) {
+	digits := make([]byte, n)
+	for i := range digits {
+		d, err := rand.Int(rand.Reader, big.NewInt(10))
+		if err != nil {
+			return "", fmt.Errorf("error generating qr data: %w", err)
+		}
+		digits[i] = byte('0' + d.Int64())
+	}
+	return string(digits), nil
+}
+
+func getEnv(key, defaultValue string) string {
+	if value, exists := os.LookupEnv(key); exists {
+		return value
+	}
+	return defaultValue
+}
@@ -1,11 +1,19 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"os"
+	"strconv"
 
 	_ "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/docs"
 	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/adapter/controller"
 	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/adapter/gateway"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
+	valueobject "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/value_object"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/dto"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/port"
 	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/usecase"
 	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/infrastructure/config"
 	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/infrastructure/database"
@@ -65,16 +73,153 @@ func main() {
 
 	httpClient := httpclient.NewRestyClient(cfg, loggerInstance)
 
-	handlers := setupHandlers(db, httpClient, cfg)
+	revokedTokenGateway := gateway.NewRevokedTokenGateway(datasource.NewRevokedTokenDataSource(db.DB))
+	revocationService := service.NewTokenRevocationService(revokedTokenGateway, cfg)
+	keyStore, err := service.NewJWTKeyStore(cfg)
+	if err != nil {
+		loggerInstance.Error("failed to load JWT keys", "error", err)
+		os.Exit(1)
+	}
+	stopKeyRotation := service.ScheduleKeyRotation(keyStore, cfg.JWTKeyRotationInterval, loggerInstance)
+	defer stopKeyRotation()
+
+	jwtService := service.NewJWTService(cfg, keyStore, revocationService)
+	passwordService := service.NewPasswordService()
+	refreshTokenService := service.NewRefreshTokenService(cfg)
+
+	handlers, jobs, err := setupHandlers(db, httpClient, cfg, loggerInstance, jwtService, keyStore, passwordService, refreshTokenService, revocationService)
+	if err != nil {
+		loggerInstance.Error("failed to setup handlers", "error", err)
+		os.Exit(1)
+	}
+
+	// A subcommand runs once instead of starting the server, ex: `app reconcile`
+	if len(os.Args) > 1 {
+		os.Exit(runCommand(os.Args[1:], jobs, cfg, loggerInstance))
+	}
+
+	stopPaymentExpiry := service.SchedulePaymentExpiry(jobs.payment, cfg.PaymentExpirySweepInterval, loggerInstance)
+	defer stopPaymentExpiry()
 
-	srv := server.NewServer(cfg, loggerInstance, handlers)
+	stopPaymentReconciliation := service.SchedulePaymentReconciliation(
+		jobs.paymentReconciliation,
+		cfg.PaymentReconciliationInterval,
+		cfg.PaymentReconciliationReportDir,
+		loggerInstance,
+	)
+	defer stopPaymentReconciliation()
+
+	stopOrderSLAMonitor := service.ScheduleOrderSLAMonitor(jobs.orderTiming, cfg.OrderSLACheckInterval, loggerInstance)
+	defer stopOrderSLAMonitor()
+
+	stopOrderExpiry := service.ScheduleOrderExpiry(jobs.orderExpiry, cfg.OrderExpiryInterval, loggerInstance)
+	defer stopOrderExpiry()
+
+	srv := server.NewServer(cfg, loggerInstance, handlers, jwtService)
 	if err := srv.Start(); err != nil {
 		loggerInstance.Error("server failed to start", "error", err)
 		os.Exit(1)
 	}
 }
 
-func setupHandlers(db *database.Database, httpClient *httpclient.HTTPClient, cfg *config.Config) *route.Handlers {
+// backgroundJobs are the use cases run on a schedule or from the command line
+type backgroundJobs struct {
+	payment               port.PaymentUseCase
+	paymentReconciliation port.PaymentReconciliationUseCase
+	orderTiming           port.OrderTimingUseCase
+	orderExpiry           port.OrderExpiryUseCase
+	staff                 port.StaffUseCase
+}
+
+// runCommand runs a subcommand, given with its arguments, and returns the exit code of the process
+func runCommand(args []string, jobs *backgroundJobs, cfg *config.Config, loggerInstance *logger.Logger) int {
+	switch args[0] {
+	case "reconcile":
+		report, err := jobs.paymentReconciliation.Reconcile(context.Background())
+		if report != nil {
+			service.LogPaymentReconciliationReport(loggerInstance, report)
+
+			if cfg.PaymentReconciliationReportDir != "" {
+				path, writeErr := service.WritePaymentReconciliationReport(cfg.PaymentReconciliationReportDir, report)
+				if writeErr != nil {
+					loggerInstance.Error("failed to write payment reconciliation report", "error", writeErr)
+					return 1
+				}
+				loggerInstance.Info("payment reconciliation report written", "path", path)
+			}
+		}
+		if err != nil {
+			loggerInstance.Error("failed to reconcile payments", "error", err)
+			return 1
+		}
+		return 0
+	case "set-staff-password":
+		// The password is read from the environment, so it is not left in the shell history
+		if len(args) != 2 {
+			loggerInstance.Error("usage: set-staff-password <staff_id>, with the password in STAFF_PASSWORD")
+			return 2
+		}
+		if err := setStaffPassword(context.Background(), jobs.staff, args[1], os.Getenv("STAFF_PASSWORD")); err != nil {
+			loggerInstance.Error("failed to set staff password", "error", err)
+			return 1
+		}
+		loggerInstance.Info("staff password set", "staff_id", args[1])
+		return 0
+	default:
+		loggerInstance.Error("unknown command", "command", args[0], "commands", "reconcile, set-staff-password")
+		return 2
+	}
+}
+
+// setStaffPassword sets the password of a staff, the way to give the first manager access to the system
+func setStaffPassword(ctx context.Context, staffUC port.StaffUseCase, staffID, password string) error {
+	id, err := strconv.ParseUint(staffID, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid staff id: %w", err)
+	}
+
+	if len(password) < 4 || len(password) > 72 {
+		return errors.New("STAFF_PASSWORD must have between 4 and 72 characters")
+	}
+
+	staff, err := staffUC.Get(ctx, dto.GetStaffInput{ID: id})
+	if err != nil {
+		return err
+	}
+
+	_, err = staffUC.Update(ctx, dto.UpdateStaffInput{ID: staff.ID, Name: staff.Name, Role: staff.Role, Password: password})
+	return err
+}
+
+func setupHandlers(
+	db *database.Database,
+	httpClient *httpclient.HTTPClient,
+	cfg *config.Config,
+	loggerInstance *logger.Logger,
+	jwtService port.JWTService,
+	keyStore port.JWTKeyStore,
+	passwordService port.PasswordService,
+	refreshTokenService port.RefreshTokenService,
+	revocationService port.TokenRevocationService,
+) (*route.Handlers, *backgroundJobs, error) {
+	// The expiry moves the orders through the state machine as well
+	var expiryTransitions []entity.OrderTransition
+	readyTimeoutStatus, ok := valueobject.ToOrderStatus(cfg.ReadyOrderTimeoutStatus)
+	if cfg.ReadyOrderTimeout > 0 {
+		if !ok {
+			return nil, nil, fmt.Errorf("invalid READY_ORDER_TIMEOUT_STATUS %q", cfg.ReadyOrderTimeoutStatus)
+		}
+		expiryTransitions = append(expiryTransitions, entity.OrderTransition{From: valueobject.READY, To: readyTimeoutStatus})
+	}
+	if cfg.OpenOrderTimeout > 0 {
+		expiryTransitions = append(expiryTransitions, entity.OrderTransition{From: valueobject.OPEN, To: valueobject.CANCELLED})
+	}
+
+	orderStateMachine, err := config.LoadOrderStateMachine(cfg.OrderStateMachineFile, expiryTransitions...)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	// Datasources
 	productDS := datasource.NewProductDataSource(db.DB)
 	customerDS := datasource.NewCustomerDataSource(db.DB)
@@ -83,12 +228,24 @@ func setupHandlers(db *database.Database, httpClient *httpclient.HTTPClient, cfg
 	staffDS := datasource.NewStaffDataSource(db.DB)
 	orderHistoryDS := datasource.NewOrderHistoryDataSource(db.DB)
 	paymentDS := datasource.NewPaymentDataSource(db.DB)
-	// paymentExternalDS := datasource.NewPaymentExternalDataSource(httpClient.Client) // Mercado Pago
-	paymentExternalDS := datasource.NewFakePaymentExternalDataSource(httpClient, cfg) // Fake Mercado Pago
+	paymentNotificationDS := datasource.NewPaymentNotificationDataSource(db.DB)
+	paymentProviders, err := datasource.NewPaymentProviderRegistry(
+		valueobject.ToPaymentProvider(cfg.PaymentProvider),
+		map[valueobject.PaymentProvider]port.PaymentExternalDatasource{
+			valueobject.MERCADO_PAGO_PROVIDER: datasource.NewPaymentExternalDataSource(httpClient.Client),
+			valueobject.FAKE_PROVIDER:         datasource.NewFakePaymentExternalDataSource(httpClient, cfg),
+			valueobject.CASH_PROVIDER:         datasource.NewCashPaymentExternalDataSource(),
+			valueobject.PIX_STATIC_PROVIDER:   datasource.NewPixStaticPaymentExternalDataSource(cfg),
+		},
+	)
+	if err != nil {
+		return nil, nil, err
+	}
 	categoryDS := datasource.NewCategoryDataSource(db.DB)
-
-	// Services
-	jwtService := service.NewJWTService(cfg)
+	refreshTokenDS := datasource.NewRefreshTokenDataSource(db.DB)
+	kitchenTicketBumpDS := datasource.NewKitchenTicketBumpDataSource(db.DB)
+	unitOfWork := datasource.NewUnitOfWork(db.DB, loggerInstance)
+	orderEventBus := service.NewOrderEventBus(cfg.OrderStreamBufferSize)
 
 	// Gateways
 	productGateway := gateway.NewProductGateway(productDS)
@@ -97,42 +254,75 @@ func setupHandlers(db *database.Database, httpClient *httpclient.HTTPClient, cfg
 	orderGateway := gateway.NewOrderGateway(orderDS)
 	orderProductGateway := gateway.NewOrderProductGateway(orderProductDS)
 	staffGateway := gateway.NewStaffGateway(staffDS)
-	paymentGateway := gateway.NewPaymentGateway(paymentDS, paymentExternalDS)
+	paymentGateway := gateway.NewPaymentGateway(paymentDS, paymentProviders)
+	paymentNotificationGateway := gateway.NewPaymentNotificationGateway(paymentNotificationDS)
 	categoryGateway := gateway.NewCategoryGateway(categoryDS)
+	refreshTokenGateway := gateway.NewRefreshTokenGateway(refreshTokenDS)
+	kitchenTicketBumpGateway := gateway.NewKitchenTicketBumpGateway(kitchenTicketBumpDS)
 
 	// Use cases
 	productUC := usecase.NewProductUseCase(productGateway)
 	customerUC := usecase.NewCustomerUseCase(customerGateway)
 	orderHistoryUC := usecase.NewOrderHistoryUseCase(orderHistoryGateway)
-	orderUC := usecase.NewOrderUseCase(orderGateway, orderHistoryUC)
-	orderProductUC := usecase.NewOrderProductUseCase(orderProductGateway)
-	staffUC := usecase.NewStaffUseCase(staffGateway)
-	paymentUC := usecase.NewPaymentUseCase(paymentGateway, orderUC)
+	paymentRefundUC := usecase.NewPaymentRefundUseCase(paymentGateway, unitOfWork)
+	orderEstimateUC := usecase.NewOrderEstimateUseCase(orderGateway, orderHistoryGateway, cfg.KitchenCapacity, cfg.OrderPrepEstimate)
 	categoryUC := usecase.NewCategoryUseCase(categoryGateway)
-	authUC := usecase.NewAuthUseCase(customerUC, jwtService)
+	orderUC := usecase.NewOrderUseCase(
+		orderGateway,
+		orderHistoryUC,
+		customerUC,
+		paymentRefundUC,
+		paymentGateway,
+		unitOfWork,
+		orderEventBus,
+		orderEstimateUC,
+		categoryUC,
+		service.NewGuestTokenService(),
+		orderStateMachine,
+	)
+	orderStatusStreamUC := usecase.NewOrderStatusStreamUseCase(orderHistoryGateway, orderEventBus)
+	orderProductUC := usecase.NewOrderProductUseCase(orderProductGateway, orderUC, productUC, unitOfWork)
+	staffUC := usecase.NewStaffUseCase(staffGateway, passwordService)
+	paymentUC := usecase.NewPaymentUseCase(paymentGateway, paymentNotificationGateway, orderUC, orderStateMachine, unitOfWork, cfg.PaymentMaxAttempts, cfg.PaymentExpiration)
+	paymentReconciliationUC := usecase.NewPaymentReconciliationUseCase(paymentGateway, paymentNotificationGateway, paymentUC)
+	orderTimingUC := usecase.NewOrderTimingUseCase(orderUC, orderHistoryGateway, categoryUC, cfg.OrderPrepTarget)
+	orderExpiryUC := usecase.NewOrderExpiryUseCase(orderGateway, orderUC, unitOfWork, cfg.ReadyOrderTimeout, readyTimeoutStatus, cfg.OpenOrderTimeout)
+	kitchenUC := usecase.NewKitchenUseCase(orderUC, categoryUC, kitchenTicketBumpGateway, orderStateMachine, unitOfWork)
+	authUC := usecase.NewAuthUseCase(
+		customerUC,
+		staffUC,
+		jwtService,
+		passwordService,
+		refreshTokenService,
+		refreshTokenGateway,
+		revocationService,
+	)
 
 	// Controllers
 	productController := controller.NewProductController(productUC)
 	customerController := controller.NewCustomerController(customerUC)
-	orderController := controller.NewOrderController(orderUC)
+	orderController := controller.NewOrderController(orderUC, orderStatusStreamUC, orderTimingUC)
 	orderProductController := controller.NewOrderProductController(orderProductUC)
 	staffController := controller.NewStaffController(staffUC)
 	orderHistoryController := controller.NewOrderHistoryController(orderHistoryUC)
-	paymentController := controller.NewPaymentController(paymentUC)
+	paymentController := controller.NewPaymentController(paymentUC, paymentRefundUC)
 	categoryController := controller.NewCategoryController(categoryUC)
+	kitchenController := controller.NewKitchenController(kitchenUC)
 	authController := controller.NewAuthController(authUC)
 
 	// Handlers
 	productHandler := handler.NewProductHandler(productController)
 	customerHandler := handler.NewCustomerHandler(customerController)
-	orderHandler := handler.NewOrderHandler(orderController)
+	orderHandler := handler.NewOrderHandler(orderController, cfg.OrderStreamOrigins)
 	orderProductHandler := handler.NewOrderProductHandler(orderProductController)
 	staffHandler := handler.NewStaffHandler(staffController)
 	healthCheckHandler := handler.NewHealthCheckHandler()
 	orderHistoryHandler := handler.NewOrderHistoryHandler(orderHistoryController)
-	paymentHandler := handler.NewPaymentHandler(paymentController)
+	paymentHandler := handler.NewPaymentHandler(paymentController, service.NewWebhookSignatureService(cfg))
 	categoryHandler := handler.NewCategoryHandler(categoryController)
+	kitchenHandler := handler.NewKitchenHandler(kitchenController)
 	authHandler := handler.NewAuthHandler(authController)
+	jwksHandler := handler.NewJWKSHandler(keyStore)
 
 	handlers := &route.Handlers{
 		Product:      productHandler,
@@ -144,8 +334,18 @@ func setupHandlers(db *database.Database, httpClient *httpclient.HTTPClient, cfg
 		HealthCheck:  healthCheckHandler,
 		Payment:      paymentHandler,
 		Category:     categoryHandler,
+		Kitchen:      kitchenHandler,
 		Auth:         authHandler,
+		JWKS:         jwksHandler,
+	}
+
+	jobs := &backgroundJobs{
+		payment:               paymentUC,
+		paymentReconciliation: paymentReconciliationUC,
+		orderTiming:           orderTimingUC,
+		orderExpiry:           orderExpiryUC,
+		staff:                 staffUC,
 	}
 
-	return handlers
+	return handlers, jobs, nil
 }
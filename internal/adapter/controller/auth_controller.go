@@ -25,3 +25,25 @@ func (c *authController) Authenticate(ctx context.Context, presenter port.Presen
 
 	return presenter.Present(dto.PresenterInput{Result: token})
 }
+
+func (c *authController) AuthenticateStaff(ctx context.Context, presenter port.Presenter, input dto.AuthenticateStaffInput) ([]byte, error) {
+	token, err := c.authUseCase.AuthenticateStaff(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return presenter.Present(dto.PresenterInput{Result: token})
+}
+
+func (c *authController) Refresh(ctx context.Context, presenter port.Presenter, input dto.RefreshTokenInput) ([]byte, error) {
+	token, err := c.authUseCase.Refresh(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return presenter.Present(dto.PresenterInput{Result: token})
+}
+
+func (c *authController) Logout(ctx context.Context, input dto.LogoutInput) error {
+	return c.authUseCase.Logout(ctx, input)
+}
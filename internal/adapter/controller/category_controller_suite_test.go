@@ -8,6 +8,7 @@ import (
 	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/adapter/controller"
 	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/adapter/presenter"
 	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
+	valueobject "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/value_object"
 	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/port"
 	mockport "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/port/mocks"
 	"github.com/stretchr/testify/suite"
@@ -34,6 +35,7 @@ func (s *CategoryControllerSuiteTest) SetupTest() {
 	s.mockCategory = &entity.Category{
 		ID:        6,
 		Name:      "Foods",
+		Station:   valueobject.GRILL,
 		CreatedAt: mockDateAt2,
 		UpdatedAt: mockDateAt3,
 	}
@@ -42,12 +44,14 @@ func (s *CategoryControllerSuiteTest) SetupTest() {
 		{
 			ID:        1,
 			Name:      "Foods",
+			Station:   valueobject.GRILL,
 			CreatedAt: mockDateAt,
 			UpdatedAt: mockDateAt,
 		},
 		{
 			ID:        2,
 			Name:      "Beverages",
+			Station:   valueobject.DRINKS,
 			CreatedAt: mockDateAt,
 			UpdatedAt: mockDateAt,
 		},
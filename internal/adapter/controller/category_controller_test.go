@@ -132,6 +132,7 @@ func (s *CategoryControllerSuiteTest) TestCategoryController_UpdateCategory() {
 	categoryUpdated := &entity.Category{
 		ID:        6,
 		Name:      "Foods UPDATED",
+		Station:   s.mockCategory.Station,
 		CreatedAt: s.mockCategory.CreatedAt,
 		UpdatedAt: s.mockCategory.UpdatedAt,
 	}
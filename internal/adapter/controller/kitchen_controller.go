@@ -0,0 +1,34 @@
+package controller
+
+import (
+	"context"
+
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/dto"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/port"
+)
+
+type KitchenController struct {
+	useCase port.KitchenUseCase
+}
+
+func NewKitchenController(useCase port.KitchenUseCase) port.KitchenController {
+	return &KitchenController{useCase}
+}
+
+func (c *KitchenController) ListTickets(ctx context.Context, p port.Presenter, i dto.ListKitchenTicketsInput) ([]byte, error) {
+	tickets, err := c.useCase.ListTickets(ctx, i)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.Present(dto.PresenterInput{Result: tickets})
+}
+
+func (c *KitchenController) BumpTicket(ctx context.Context, p port.Presenter, i dto.BumpKitchenTicketInput) ([]byte, error) {
+	ticket, err := c.useCase.BumpTicket(ctx, i)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.Present(dto.PresenterInput{Result: ticket})
+}
@@ -3,16 +3,23 @@ package controller
 import (
 	"context"
 
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
 	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/dto"
 	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/port"
 )
 
 type OrderController struct {
-	useCase port.OrderUseCase
+	useCase             port.OrderUseCase
+	statusStreamUseCase port.OrderStatusStreamUseCase
+	timingUseCase       port.OrderTimingUseCase
 }
 
-func NewOrderController(useCase port.OrderUseCase) port.OrderController {
-	return &OrderController{useCase}
+func NewOrderController(
+	useCase port.OrderUseCase,
+	statusStreamUseCase port.OrderStatusStreamUseCase,
+	timingUseCase port.OrderTimingUseCase,
+) port.OrderController {
+	return &OrderController{useCase, statusStreamUseCase, timingUseCase}
 }
 
 func (c *OrderController) List(ctx context.Context, p port.Presenter, i dto.ListOrdersInput) ([]byte, error) {
@@ -21,6 +28,10 @@ func (c *OrderController) List(ctx context.Context, p port.Presenter, i dto.List
 		return nil, err
 	}
 
+	if err := c.timingUseCase.Track(ctx, orders); err != nil {
+		return nil, err
+	}
+
 	return p.Present(dto.PresenterInput{
 		Total:  total,
 		Page:   i.Page,
@@ -44,6 +55,10 @@ func (c *OrderController) Get(ctx context.Context, p port.Presenter, i dto.GetOr
 		return nil, err
 	}
 
+	if err := c.timingUseCase.Track(ctx, []*entity.Order{order}); err != nil {
+		return nil, err
+	}
+
 	return p.Present(dto.PresenterInput{Result: order})
 }
 
@@ -56,6 +71,15 @@ func (c *OrderController) Update(ctx context.Context, p port.Presenter, i dto.Up
 	return p.Present(dto.PresenterInput{Result: order})
 }
 
+func (c *OrderController) AttachCustomer(ctx context.Context, p port.Presenter, i dto.AttachOrderCustomerInput) ([]byte, error) {
+	order, err := c.useCase.AttachCustomer(ctx, i)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.Present(dto.PresenterInput{Result: order})
+}
+
 func (c *OrderController) Delete(ctx context.Context, p port.Presenter, i dto.DeleteOrderInput) ([]byte, error) {
 	order, err := c.useCase.Delete(ctx, i)
 	if err != nil {
@@ -64,3 +88,32 @@ func (c *OrderController) Delete(ctx context.Context, p port.Presenter, i dto.De
 
 	return p.Present(dto.PresenterInput{Result: order})
 }
+
+// SubscribeStatus presents each status change of the orders, until ctx is done
+func (c *OrderController) SubscribeStatus(ctx context.Context, p port.Presenter, i dto.SubscribeOrderStatusInput) (<-chan dto.OrderStatusMessage, error) {
+	events, err := c.statusStreamUseCase.Subscribe(ctx, i)
+	if err != nil {
+		return nil, err
+	}
+
+	messages := make(chan dto.OrderStatusMessage)
+
+	go func() {
+		defer close(messages)
+
+		for event := range events {
+			data, err := p.Present(dto.PresenterInput{Result: event})
+			if err != nil {
+				return
+			}
+
+			select {
+			case messages <- dto.OrderStatusMessage{ID: event.ID, Data: data}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return messages, nil
+}
@@ -12,6 +12,7 @@ import (
 	valueobject "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/value_object"
 	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/dto"
 	mockport "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/port/mocks"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/util"
 )
 
 // TODO: Add more test cenarios
@@ -21,7 +22,8 @@ func TestOrderController_ListOrders(t *testing.T) {
 
 	mokOrdercUseCase := mockport.NewMockOrderUseCase(ctrl)
 	mockPresenter := mockport.NewMockPresenter(ctrl)
-	controller := controller.NewOrderController(mokOrdercUseCase)
+	mockTimingUseCase := mockport.NewMockOrderTimingUseCase(ctrl)
+	controller := controller.NewOrderController(mokOrdercUseCase, mockport.NewMockOrderStatusStreamUseCase(ctrl), mockTimingUseCase)
 
 	ctx := context.Background()
 	input := dto.ListOrdersInput{
@@ -34,12 +36,12 @@ func TestOrderController_ListOrders(t *testing.T) {
 	mockOrders := []*entity.Order{
 		{
 			ID:         1,
-			CustomerID: 1,
+			CustomerID: util.Ptr(uint64(1)),
 			Status:     "PENDING",
 		},
 		{
 			ID:         2,
-			CustomerID: 1,
+			CustomerID: util.Ptr(uint64(1)),
 			Status:     "PENDING",
 		},
 	}
@@ -48,6 +50,10 @@ func TestOrderController_ListOrders(t *testing.T) {
 		List(ctx, input).
 		Return(mockOrders, int64(2), nil)
 
+	mockTimingUseCase.EXPECT().
+		Track(ctx, mockOrders).
+		Return(nil)
+
 	mockPresenter.EXPECT().
 		Present(dto.PresenterInput{
 			Result: mockOrders,
@@ -68,7 +74,8 @@ func TestOrderController_CreateOrder(t *testing.T) {
 
 	mokOrdercUseCase := mockport.NewMockOrderUseCase(ctrl)
 	mockPresenter := mockport.NewMockPresenter(ctrl)
-	controller := controller.NewOrderController(mokOrdercUseCase)
+	mockTimingUseCase := mockport.NewMockOrderTimingUseCase(ctrl)
+	controller := controller.NewOrderController(mokOrdercUseCase, mockport.NewMockOrderStatusStreamUseCase(ctrl), mockTimingUseCase)
 
 	ctx := context.Background()
 	input := dto.CreateOrderInput{
@@ -77,7 +84,7 @@ func TestOrderController_CreateOrder(t *testing.T) {
 
 	mockOrder := &entity.Order{
 		ID:         1,
-		CustomerID: 1,
+		CustomerID: util.Ptr(uint64(1)),
 		Status:     "OPEN",
 	}
 
@@ -100,7 +107,8 @@ func TestOrderController_GetOrder(t *testing.T) {
 
 	mokOrdercUseCase := mockport.NewMockOrderUseCase(ctrl)
 	mockPresenter := mockport.NewMockPresenter(ctrl)
-	controller := controller.NewOrderController(mokOrdercUseCase)
+	mockTimingUseCase := mockport.NewMockOrderTimingUseCase(ctrl)
+	controller := controller.NewOrderController(mokOrdercUseCase, mockport.NewMockOrderStatusStreamUseCase(ctrl), mockTimingUseCase)
 
 	ctx := context.Background()
 	input := dto.GetOrderInput{
@@ -109,7 +117,7 @@ func TestOrderController_GetOrder(t *testing.T) {
 
 	mockOrder := &entity.Order{
 		ID:         1,
-		CustomerID: 1,
+		CustomerID: util.Ptr(uint64(1)),
 		Status:     "PENDING",
 	}
 
@@ -117,6 +125,10 @@ func TestOrderController_GetOrder(t *testing.T) {
 		Get(ctx, input).
 		Return(mockOrder, nil)
 
+	mockTimingUseCase.EXPECT().
+		Track(ctx, []*entity.Order{mockOrder}).
+		Return(nil)
+
 	mockPresenter.EXPECT().
 		Present(dto.PresenterInput{Result: mockOrder}).
 		Return([]byte{}, nil)
@@ -132,7 +144,8 @@ func TestOrderController_UpdateOrder(t *testing.T) {
 
 	mokOrdercUseCase := mockport.NewMockOrderUseCase(ctrl)
 	mockPresenter := mockport.NewMockPresenter(ctrl)
-	controller := controller.NewOrderController(mokOrdercUseCase)
+	mockTimingUseCase := mockport.NewMockOrderTimingUseCase(ctrl)
+	controller := controller.NewOrderController(mokOrdercUseCase, mockport.NewMockOrderStatusStreamUseCase(ctrl), mockTimingUseCase)
 
 	ctx := context.Background()
 	input := dto.UpdateOrderInput{
@@ -143,7 +156,7 @@ func TestOrderController_UpdateOrder(t *testing.T) {
 
 	mockOrder := &entity.Order{
 		ID:         1,
-		CustomerID: 1,
+		CustomerID: util.Ptr(uint64(1)),
 		Status:     "PENDING",
 	}
 
@@ -160,13 +173,48 @@ func TestOrderController_UpdateOrder(t *testing.T) {
 	assert.NotNil(t, output)
 }
 
+func TestOrderController_AttachCustomer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mokOrdercUseCase := mockport.NewMockOrderUseCase(ctrl)
+	mockPresenter := mockport.NewMockPresenter(ctrl)
+	mockTimingUseCase := mockport.NewMockOrderTimingUseCase(ctrl)
+	controller := controller.NewOrderController(mokOrdercUseCase, mockport.NewMockOrderStatusStreamUseCase(ctrl), mockTimingUseCase)
+
+	ctx := context.Background()
+	input := dto.AttachOrderCustomerInput{
+		ID:         uint64(1),
+		CustomerID: 1,
+	}
+
+	mockOrder := &entity.Order{
+		ID:         1,
+		CustomerID: util.Ptr(uint64(1)),
+		Status:     "OPEN",
+	}
+
+	mokOrdercUseCase.EXPECT().
+		AttachCustomer(ctx, input).
+		Return(mockOrder, nil)
+
+	mockPresenter.EXPECT().
+		Present(dto.PresenterInput{Result: mockOrder}).
+		Return([]byte{}, nil)
+
+	output, err := controller.AttachCustomer(ctx, mockPresenter, input)
+	assert.NoError(t, err)
+	assert.NotNil(t, output)
+}
+
 func TestOrderController_DeleteOrder(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mokOrdercUseCase := mockport.NewMockOrderUseCase(ctrl)
 	mockPresenter := mockport.NewMockPresenter(ctrl)
-	controller := controller.NewOrderController(mokOrdercUseCase)
+	mockTimingUseCase := mockport.NewMockOrderTimingUseCase(ctrl)
+	controller := controller.NewOrderController(mokOrdercUseCase, mockport.NewMockOrderStatusStreamUseCase(ctrl), mockTimingUseCase)
 
 	ctx := context.Background()
 	input := dto.DeleteOrderInput{
@@ -175,7 +223,7 @@ func TestOrderController_DeleteOrder(t *testing.T) {
 
 	mockOrder := &entity.Order{
 		ID:         1,
-		CustomerID: 1,
+		CustomerID: util.Ptr(uint64(1)),
 		Status:     "PENDING",
 	}
 
@@ -64,3 +64,12 @@ func (c *OrderProductController) Delete(ctx context.Context, p port.Presenter, i
 
 	return p.Present(dto.PresenterInput{Result: order})
 }
+
+func (c *OrderProductController) Reprice(ctx context.Context, p port.Presenter, i dto.RepriceOrderProductsInput) ([]byte, error) {
+	order, err := c.useCase.Reprice(ctx, i)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.Present(dto.PresenterInput{Result: order})
+}
@@ -193,3 +193,36 @@ func TestOrderProductController_DeleteOrderProduct(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, output)
 }
+
+func TestOrderProductController_RepriceOrderProducts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockOrderProductUseCase := mockport.NewMockOrderProductUseCase(ctrl)
+	mockPresenter := mockport.NewMockPresenter(ctrl)
+	controller := controller.NewOrderProductController(mockOrderProductUseCase)
+
+	ctx := context.Background()
+	input := dto.RepriceOrderProductsInput{
+		OrderID: 1,
+	}
+
+	mockOrder := &entity.Order{
+		ID: 1,
+		OrderProducts: []entity.OrderProduct{
+			{OrderID: 1, ProductID: 1, Quantity: 1},
+		},
+	}
+
+	mockOrderProductUseCase.EXPECT().
+		Reprice(ctx, input).
+		Return(mockOrder, nil)
+
+	mockPresenter.EXPECT().
+		Present(dto.PresenterInput{Result: mockOrder}).
+		Return([]byte{}, nil)
+
+	output, err := controller.Reprice(ctx, mockPresenter, input)
+	assert.NoError(t, err)
+	assert.NotNil(t, output)
+}
@@ -8,11 +8,12 @@ import (
 )
 
 type PaymentController struct {
-	useCase port.PaymentUseCase
+	useCase       port.PaymentUseCase
+	refundUseCase port.PaymentRefundUseCase
 }
 
-func NewPaymentController(useCase port.PaymentUseCase) port.PaymentController {
-	return &PaymentController{useCase}
+func NewPaymentController(useCase port.PaymentUseCase, refundUseCase port.PaymentRefundUseCase) port.PaymentController {
+	return &PaymentController{useCase, refundUseCase}
 }
 
 func (c *PaymentController) Create(ctx context.Context, p port.Presenter, i dto.CreatePaymentInput) ([]byte, error) {
@@ -41,3 +42,21 @@ func (c *PaymentController) Get(ctx context.Context, p port.Presenter, i dto.Get
 
 	return p.Present(dto.PresenterInput{Result: payment})
 }
+
+func (c *PaymentController) Refund(ctx context.Context, p port.Presenter, i dto.CreateRefundInput) ([]byte, error) {
+	payment, err := c.refundUseCase.Create(ctx, i)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.Present(dto.PresenterInput{Result: payment})
+}
+
+func (c *PaymentController) ConfirmCash(ctx context.Context, p port.Presenter, i dto.ConfirmCashPaymentInput) ([]byte, error) {
+	payment, err := c.useCase.ConfirmCash(ctx, i)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.Present(dto.PresenterInput{Result: payment})
+}
@@ -2,6 +2,7 @@ package controller
 
 import (
 	"context"
+	valueobject "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/value_object"
 	"testing"
 
 	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
@@ -17,7 +18,7 @@ func TestPaymentController_Create(t *testing.T) {
 
 	mockPaymentUseCase := mockport.NewMockPaymentUseCase(ctrl)
 	mockPresenter := mockport.NewMockPresenter(ctrl)
-	controller := NewPaymentController(mockPaymentUseCase)
+	controller := NewPaymentController(mockPaymentUseCase, mockport.NewMockPaymentRefundUseCase(ctrl))
 
 	ctx := context.Background()
 
@@ -46,7 +47,7 @@ func TestPaymentController_Get(t *testing.T) {
 
 	mockPaymentUseCase := mockport.NewMockPaymentUseCase(ctrl)
 	mockPresenter := mockport.NewMockPresenter(ctrl)
-	controller := NewPaymentController(mockPaymentUseCase)
+	controller := NewPaymentController(mockPaymentUseCase, mockport.NewMockPaymentRefundUseCase(ctrl))
 
 	ctx := context.Background()
 
@@ -75,7 +76,7 @@ func TestPaymentController_Update(t *testing.T) {
 
 	mockPaymentUseCase := mockport.NewMockPaymentUseCase(ctrl)
 	mockPresenter := mockport.NewMockPresenter(ctrl)
-	controller := NewPaymentController(mockPaymentUseCase)
+	controller := NewPaymentController(mockPaymentUseCase, mockport.NewMockPaymentRefundUseCase(ctrl))
 
 	ctx := context.Background()
 
@@ -98,3 +99,67 @@ func TestPaymentController_Update(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, output)
 }
+
+func TestPaymentController_Refund(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRefundUseCase := mockport.NewMockPaymentRefundUseCase(ctrl)
+	mockPresenter := mockport.NewMockPresenter(ctrl)
+	controller := NewPaymentController(mockport.NewMockPaymentUseCase(ctrl), mockRefundUseCase)
+
+	ctx := context.Background()
+
+	input := dto.CreateRefundInput{
+		OrderID: uint64(1),
+		Amount:  valueobject.NewMoney(1000),
+		Reason:  "Customer complaint",
+		StaffID: uint64(1),
+	}
+
+	mockPayment := &entity.Payment{}
+
+	mockRefundUseCase.EXPECT().
+		Create(ctx, input).
+		Return(mockPayment, nil)
+
+	mockPresenter.EXPECT().
+		Present(dto.PresenterInput{Result: mockPayment}).
+		Return([]byte{}, nil)
+
+	output, err := controller.Refund(ctx, mockPresenter, input)
+	assert.NoError(t, err)
+	assert.NotNil(t, output)
+}
+
+func TestPaymentController_ConfirmCash(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPaymentUseCase := mockport.NewMockPaymentUseCase(ctrl)
+	mockPresenter := mockport.NewMockPresenter(ctrl)
+	controller := NewPaymentController(mockPaymentUseCase, mockport.NewMockPaymentRefundUseCase(ctrl))
+
+	ctx := context.Background()
+
+	input := dto.ConfirmCashPaymentInput{
+		OrderID:        uint64(1),
+		AmountReceived: valueobject.NewMoney(5000),
+		ChangeGiven:    valueobject.NewMoney(910),
+		StaffID:        uint64(1),
+	}
+
+	mockPayment := &entity.Payment{}
+
+	mockPaymentUseCase.EXPECT().
+		ConfirmCash(ctx, input).
+		Return(mockPayment, nil)
+
+	mockPresenter.EXPECT().
+		Present(dto.PresenterInput{Result: mockPayment}).
+		Return([]byte{}, nil)
+
+	output, err := controller.ConfirmCash(ctx, mockPresenter, input)
+	assert.NoError(t, err)
+	assert.NotNil(t, output)
+}
@@ -2,6 +2,7 @@ package controller_test
 
 import (
 	"context"
+	valueobject "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/value_object"
 	"testing"
 	"time"
 
@@ -37,7 +38,7 @@ func TestProductController_ListProducts(t *testing.T) {
 			ID:          1,
 			Name:        "Test Product 1",
 			Description: "Description 1",
-			Price:       99.99,
+			Price:       valueobject.NewMoney(9999),
 			CategoryID:  1,
 			CreatedAt:   currentTime,
 			UpdatedAt:   currentTime,
@@ -46,7 +47,7 @@ func TestProductController_ListProducts(t *testing.T) {
 			ID:          2,
 			Name:        "Test Product 2",
 			Description: "Description 2",
-			Price:       199.99,
+			Price:       valueobject.NewMoney(19999),
 			CategoryID:  1,
 			CreatedAt:   currentTime,
 			UpdatedAt:   currentTime,
@@ -83,7 +84,7 @@ func TestProductController_CreateProduct(t *testing.T) {
 	input := dto.CreateProductInput{
 		Name:        "Test Product",
 		Description: "Test Description",
-		Price:       99.99,
+		Price:       valueobject.NewMoney(9999),
 		CategoryID:  1,
 	}
 
@@ -91,7 +92,7 @@ func TestProductController_CreateProduct(t *testing.T) {
 		ID:          1,
 		Name:        "Test Product",
 		Description: "Test Description",
-		Price:       99.99,
+		Price:       valueobject.NewMoney(9999),
 		CategoryID:  1,
 	}
 
@@ -125,7 +126,7 @@ func TestProductController_GetProduct(t *testing.T) {
 		ID:          1,
 		Name:        "Test Product",
 		Description: "Test Description",
-		Price:       99.99,
+		Price:       valueobject.NewMoney(9999),
 		CategoryID:  1,
 	}
 
@@ -155,7 +156,7 @@ func TestProductController_UpdateProduct(t *testing.T) {
 		ID:          uint64(1),
 		Name:        "Product",
 		Description: "Description",
-		Price:       99.99,
+		Price:       valueobject.NewMoney(9999),
 		CategoryID:  2,
 	}
 
@@ -163,7 +164,7 @@ func TestProductController_UpdateProduct(t *testing.T) {
 		ID:          1,
 		Name:        "Updated Product",
 		Description: "Updated Description",
-		Price:       199.99,
+		Price:       valueobject.NewMoney(19999),
 		CategoryID:  2,
 	}
 
@@ -197,7 +198,7 @@ func TestProductController_DeleteProduct(t *testing.T) {
 		ID:          1,
 		Name:        "Test Product",
 		Description: "Test Description",
-		Price:       99.99,
+		Price:       valueobject.NewMoney(9999),
 		CategoryID:  1,
 	}
 
@@ -0,0 +1,33 @@
+package gateway
+
+import (
+	"context"
+	"time"
+
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/port"
+)
+
+type kitchenTicketBumpGateway struct {
+	dataSource port.KitchenTicketBumpDataSource
+}
+
+func NewKitchenTicketBumpGateway(dataSource port.KitchenTicketBumpDataSource) port.KitchenTicketBumpGateway {
+	return &kitchenTicketBumpGateway{dataSource}
+}
+
+func (g *kitchenTicketBumpGateway) FindByOrderIDs(ctx context.Context, orderIDs []uint64) ([]*entity.KitchenTicketBump, error) {
+	if len(orderIDs) == 0 {
+		return nil, nil
+	}
+
+	filters := make(map[string]interface{})
+	filters["orderIDs"] = orderIDs
+
+	return g.dataSource.FindAll(ctx, filters)
+}
+
+func (g *kitchenTicketBumpGateway) Create(ctx context.Context, bump *entity.KitchenTicketBump) (bool, error) {
+	bump.CreatedAt = time.Now()
+	return g.dataSource.Create(ctx, bump)
+}
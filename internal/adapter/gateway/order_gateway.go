@@ -3,6 +3,7 @@ package gateway
 import (
 	"context"
 	"strings"
+	"time"
 
 	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
 	valueobject "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/value_object"
@@ -21,6 +22,10 @@ func (g *orderGateway) FindByID(ctx context.Context, id uint64) (*entity.Order,
 	return g.dataSource.FindByID(ctx, id)
 }
 
+func (g *orderGateway) FindByIDForUpdate(ctx context.Context, id uint64) (*entity.Order, error) {
+	return g.dataSource.FindByIDForUpdate(ctx, id)
+}
+
 func (g *orderGateway) FindAll(
 	ctx context.Context,
 	customerId uint64,
@@ -49,14 +54,22 @@ func (g *orderGateway) FindAll(
 	return g.dataSource.FindAll(ctx, filters, sortFormatted, page, limit)
 }
 
+func (g *orderGateway) FindIdle(ctx context.Context, status valueobject.OrderStatus, idleSince time.Time, limit int) ([]*entity.Order, error) {
+	return g.dataSource.FindIdle(ctx, status, idleSince, limit)
+}
+
 func (g *orderGateway) Create(ctx context.Context, order *entity.Order) error {
 	return g.dataSource.Create(ctx, order)
 }
 
-func (g *orderGateway) Update(ctx context.Context, order *entity.Order) error {
+func (g *orderGateway) Update(ctx context.Context, order *entity.Order) (bool, error) {
 	return g.dataSource.Update(ctx, order)
 }
 
+func (g *orderGateway) UpdateEstimatedReadyAt(ctx context.Context, id uint64, estimatedReadyAt time.Time) error {
+	return g.dataSource.UpdateEstimatedReadyAt(ctx, id, estimatedReadyAt)
+}
+
 func (g *orderGateway) Delete(ctx context.Context, id uint64) error {
 	return g.dataSource.Delete(ctx, id)
 }
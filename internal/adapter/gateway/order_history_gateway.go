@@ -34,6 +34,34 @@ func (g *orderHistoryGateway) FindAll(ctx context.Context, orderID uint64, statu
 	return g.dataSource.FindAll(ctx, filters, page, limit)
 }
 
+func (g *orderHistoryGateway) FindAfter(ctx context.Context, afterID uint64, status []valueobject.OrderStatus, customerID uint64, limit int) ([]*entity.OrderHistory, error) {
+	filters := make(map[string]interface{})
+
+	if len(status) > 0 {
+		filters["statuses"] = status
+	}
+	if customerID != 0 {
+		filters["customerID"] = customerID
+	}
+
+	return g.dataSource.FindAfter(ctx, afterID, filters, limit)
+}
+
+func (g *orderHistoryGateway) FindLastID(ctx context.Context) (uint64, error) {
+	return g.dataSource.FindLastID(ctx)
+}
+
+func (g *orderHistoryGateway) FindByOrderIDs(ctx context.Context, orderIDs []uint64) ([]*entity.OrderHistory, error) {
+	if len(orderIDs) == 0 {
+		return nil, nil
+	}
+	return g.dataSource.FindByOrderIDs(ctx, orderIDs)
+}
+
+func (g *orderHistoryGateway) AveragePrepTime(ctx context.Context, sampleSize int) (time.Duration, error) {
+	return g.dataSource.AveragePrepTime(ctx, sampleSize)
+}
+
 func (g *orderHistoryGateway) Create(ctx context.Context, orderHistory *entity.OrderHistory) error {
 	orderHistory.CreatedAt = time.Now()
 	if orderHistory.StaffID != nil && *orderHistory.StaffID <= 0 {
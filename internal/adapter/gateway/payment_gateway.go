@@ -2,6 +2,7 @@ package gateway
 
 import (
 	"context"
+	"time"
 
 	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
 	valueobject "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/value_object"
@@ -9,15 +10,16 @@ import (
 )
 
 type paymentGayeway struct {
-	dataSource       port.PaymentDataSource
-	dataSourceRemote port.PaymentExternalDatasource
+	dataSource port.PaymentDataSource
+	providers  port.PaymentProviderRegistry
 }
 
+// NewPaymentGateway creates a payment gateway, the external calls go to the provider of each payment
 func NewPaymentGateway(
 	dataSource port.PaymentDataSource,
-	dataSourceRemote port.PaymentExternalDatasource,
+	providers port.PaymentProviderRegistry,
 ) port.PaymentGateway {
-	return &paymentGayeway{dataSource, dataSourceRemote}
+	return &paymentGayeway{dataSource, providers}
 }
 
 func (g *paymentGayeway) FindByOrderID(ctx context.Context, orderID uint64) (*entity.Payment, error) {
@@ -40,6 +42,67 @@ func (g *paymentGayeway) Update(ctx context.Context, status valueobject.PaymentS
 	return g.dataSource.UpdateStatus(ctx, status, resource)
 }
 
+func (g *paymentGayeway) CountByOrderIDAndStatus(ctx context.Context, orderID uint64, statuses ...valueobject.PaymentStatus) (int64, error) {
+	return g.dataSource.CountByOrderIDAndStatus(ctx, orderID, statuses)
+}
+
+func (g *paymentGayeway) FindExternalStatus(ctx context.Context, payment *entity.Payment) (valueobject.PaymentStatus, error) {
+	_, dataSourceRemote, err := g.providers.Get(payment.Provider)
+	if err != nil {
+		return valueobject.UNDEFINDED_P, err
+	}
+
+	return dataSourceRemote.GetStatus(ctx, payment)
+}
+
+func (g *paymentGayeway) FindExpiredProcessing(ctx context.Context, now time.Time, limit int) ([]*entity.Payment, error) {
+	return g.dataSource.GetExpiredProcessing(ctx, now, limit)
+}
+
+func (g *paymentGayeway) UpdateIfProcessing(ctx context.Context, id uint64, status valueobject.PaymentStatus) (bool, error) {
+	return g.dataSource.UpdateStatusIfProcessing(ctx, id, status)
+}
+
+func (g *paymentGayeway) FindByOrderIDAndStatus(ctx context.Context, orderID uint64, statuses ...valueobject.PaymentStatus) (*entity.Payment, error) {
+	return g.dataSource.GetLastByOrderIDAndStatus(ctx, orderID, statuses)
+}
+
+func (g *paymentGayeway) RefundExternal(ctx context.Context, refund *entity.RefundPaymentExternalInput) (*entity.RefundPaymentExternalOutput, error) {
+	_, dataSourceRemote, err := g.providers.Get(refund.Provider)
+	if err != nil {
+		return nil, err
+	}
+
+	return dataSourceRemote.Refund(ctx, refund)
+}
+
+func (g *paymentGayeway) CreatePendingRefund(ctx context.Context, refund *entity.PaymentRefund, refundedAmount valueobject.Money) (bool, error) {
+	return g.dataSource.CreatePendingRefund(ctx, refund, refundedAmount)
+}
+
+func (g *paymentGayeway) CompleteRefund(ctx context.Context, refund *entity.PaymentRefund, status valueobject.PaymentStatus) error {
+	return g.dataSource.CompleteRefund(ctx, refund, status)
+}
+
+func (g *paymentGayeway) FindProcessing(ctx context.Context, afterID uint64, limit int) ([]*entity.Payment, error) {
+	return g.dataSource.GetProcessing(ctx, afterID, limit)
+}
+
+func (g *paymentGayeway) ConfirmCash(ctx context.Context, receipt *entity.CashReceipt) (bool, error) {
+	return g.dataSource.ConfirmCash(ctx, receipt)
+}
+
 func (g *paymentGayeway) CreateExternal(ctx context.Context, payment *entity.CreatePaymentExternalInput) (*entity.CreatePaymentExternalOutput, error) {
-	return g.dataSourceRemote.Create(ctx, payment)
+	provider, dataSourceRemote, err := g.providers.Get(payment.Provider)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := dataSourceRemote.Create(ctx, payment)
+	if err != nil {
+		return nil, err
+	}
+
+	output.Provider = provider
+	return output, nil
 }
@@ -0,0 +1,37 @@
+package gateway
+
+import (
+	"context"
+	"time"
+
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/port"
+)
+
+type paymentNotificationGateway struct {
+	dataSource port.PaymentNotificationDataSource
+}
+
+func NewPaymentNotificationGateway(dataSource port.PaymentNotificationDataSource) port.PaymentNotificationGateway {
+	return &paymentNotificationGateway{dataSource}
+}
+
+func (g *paymentNotificationGateway) FindByEventID(ctx context.Context, eventID string) (*entity.PaymentNotification, error) {
+	return g.dataSource.FindByEventID(ctx, eventID)
+}
+
+func (g *paymentNotificationGateway) FindByEventIDForUpdate(ctx context.Context, eventID string) (*entity.PaymentNotification, error) {
+	return g.dataSource.FindByEventIDForUpdate(ctx, eventID)
+}
+
+func (g *paymentNotificationGateway) Create(ctx context.Context, notification *entity.PaymentNotification) (bool, error) {
+	return g.dataSource.Create(ctx, notification)
+}
+
+func (g *paymentNotificationGateway) MarkProcessed(ctx context.Context, id uint64, processedAt time.Time) error {
+	return g.dataSource.MarkProcessed(ctx, id, processedAt)
+}
+
+func (g *paymentNotificationGateway) FindUnprocessed(ctx context.Context, receivedBefore time.Time, afterID uint64, limit int) ([]*entity.PaymentNotification, error) {
+	return g.dataSource.FindUnprocessed(ctx, receivedBefore, afterID, limit)
+}
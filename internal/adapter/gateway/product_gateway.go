@@ -36,7 +36,7 @@ func (g *productGateway) Create(ctx context.Context, product *entity.Product) er
 	return g.dataSource.Create(ctx, product)
 }
 
-func (g *productGateway) Update(ctx context.Context, product *entity.Product) error {
+func (g *productGateway) Update(ctx context.Context, product *entity.Product) (bool, error) {
 	return g.dataSource.Update(ctx, product)
 }
 
@@ -0,0 +1,37 @@
+package gateway
+
+import (
+	"context"
+	"time"
+
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/port"
+)
+
+type refreshTokenGateway struct {
+	dataSource port.RefreshTokenDataSource
+}
+
+func NewRefreshTokenGateway(dataSource port.RefreshTokenDataSource) port.RefreshTokenGateway {
+	return &refreshTokenGateway{dataSource}
+}
+
+func (g *refreshTokenGateway) FindByHash(ctx context.Context, hash string) (*entity.RefreshToken, error) {
+	return g.dataSource.FindByHash(ctx, hash)
+}
+
+func (g *refreshTokenGateway) FindByFamilyID(ctx context.Context, familyID string) ([]*entity.RefreshToken, error) {
+	return g.dataSource.FindByFamilyID(ctx, familyID)
+}
+
+func (g *refreshTokenGateway) Create(ctx context.Context, token *entity.RefreshToken) error {
+	return g.dataSource.Create(ctx, token)
+}
+
+func (g *refreshTokenGateway) MarkUsed(ctx context.Context, id uint64, usedAt time.Time) (bool, error) {
+	return g.dataSource.MarkUsed(ctx, id, usedAt)
+}
+
+func (g *refreshTokenGateway) RevokeFamily(ctx context.Context, familyID string, revokedAt time.Time) error {
+	return g.dataSource.RevokeFamily(ctx, familyID, revokedAt)
+}
@@ -0,0 +1,24 @@
+package gateway
+
+import (
+	"context"
+
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/port"
+)
+
+type revokedTokenGateway struct {
+	dataSource port.RevokedTokenDataSource
+}
+
+func NewRevokedTokenGateway(dataSource port.RevokedTokenDataSource) port.RevokedTokenGateway {
+	return &revokedTokenGateway{dataSource}
+}
+
+func (g *revokedTokenGateway) ExistsByJTI(ctx context.Context, jti string) (bool, error) {
+	return g.dataSource.ExistsByJTI(ctx, jti)
+}
+
+func (g *revokedTokenGateway) Create(ctx context.Context, token *entity.RevokedToken) error {
+	return g.dataSource.Create(ctx, token)
+}
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 
 	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
 	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/dto"
 	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/port"
 )
@@ -16,15 +17,18 @@ func NewAuthPresenter() port.Presenter {
 	return &authPresenter{}
 }
 
-func ToTokenResponse(token string) AuthenticationResponse {
+func ToTokenResponse(token *entity.AuthToken) AuthenticationResponse {
 	return AuthenticationResponse{
-		AccessToken: token,
+		AccessToken:  token.AccessToken,
+		RefreshToken: token.RefreshToken,
+		TokenType:    "Bearer",
+		ExpiresAt:    token.ExpiresAt.UTC().Format("2006-01-02T15:04:05Z07:00"),
 	}
 }
 
 func (p *authPresenter) Present(input dto.PresenterInput) ([]byte, error) {
 	switch v := input.Result.(type) {
-	case string:
+	case *entity.AuthToken:
 		output := ToTokenResponse(v)
 		return json.Marshal(output)
 	default:
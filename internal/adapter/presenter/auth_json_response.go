@@ -3,7 +3,10 @@ package presenter
 import "encoding/json"
 
 type AuthenticationResponse struct {
-	AccessToken string `json:"access_token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+	AccessToken  string `json:"access_token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+	RefreshToken string `json:"refresh_token" example:"6Jk0m1Xc..."`
+	TokenType    string `json:"token_type" example:"Bearer"`
+	ExpiresAt    string `json:"expires_at" example:"2024-02-09T10:00:00Z"`
 }
 
 func (r AuthenticationResponse) String() string {
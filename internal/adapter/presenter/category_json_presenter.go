@@ -20,10 +20,12 @@ func NewCategoryJsonPresenter() port.Presenter {
 // ToCategoryJsonResponse convert entity.Category to CategoryJsonResponse
 func ToCategoryJsonResponse(category *entity.Category) CategoryJsonResponse {
 	return CategoryJsonResponse{
-		ID:        category.ID,
-		Name:      category.Name,
-		CreatedAt: category.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
-		UpdatedAt: category.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		ID:                category.ID,
+		Name:              category.Name,
+		Station:           category.Station.String(),
+		PrepTargetMinutes: category.PrepTargetMinutes,
+		CreatedAt:         category.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		UpdatedAt:         category.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
 	}
 }
 
@@ -3,10 +3,12 @@ package presenter
 import "encoding/json"
 
 type CategoryJsonResponse struct {
-	ID        uint64 `json:"id" example:"1"`
-	Name      string `json:"name" example:"John Doe"`
-	CreatedAt string `json:"created_at" example:"2024-02-09T10:00:00Z"`
-	UpdatedAt string `json:"updated_at" example:"2024-02-09T10:00:00Z"`
+	ID                uint64 `json:"id" example:"1"`
+	Name              string `json:"name" example:"John Doe"`
+	Station           string `json:"station" example:"GRILL"`
+	PrepTargetMinutes uint32 `json:"prep_target_minutes" example:"15"`
+	CreatedAt         string `json:"created_at" example:"2024-02-09T10:00:00Z"`
+	UpdatedAt         string `json:"updated_at" example:"2024-02-09T10:00:00Z"`
 }
 
 func (r CategoryJsonResponse) String() string {
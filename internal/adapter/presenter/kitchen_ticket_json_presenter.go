@@ -0,0 +1,63 @@
+package presenter
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/dto"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/port"
+)
+
+type kitchenTicketJsonPresenter struct{}
+
+// NewKitchenTicketJsonPresenter creates the presenter of the kitchen display tickets
+func NewKitchenTicketJsonPresenter() port.Presenter {
+	return &kitchenTicketJsonPresenter{}
+}
+
+// toKitchenTicketJsonResponse convert entity.KitchenTicket to KitchenTicketJsonResponse
+func toKitchenTicketJsonResponse(ticket *entity.KitchenTicket) KitchenTicketJsonResponse {
+	items := make([]KitchenTicketItemJsonResponse, len(ticket.Items))
+	for i, item := range ticket.Items {
+		items[i] = KitchenTicketItemJsonResponse{
+			ProductID:   item.ProductID,
+			ProductName: item.ProductName,
+			Quantity:    item.Quantity,
+		}
+	}
+
+	output := KitchenTicketJsonResponse{
+		OrderID:     ticket.OrderID,
+		Station:     ticket.Station.String(),
+		OrderStatus: ticket.OrderStatus.String(),
+		Items:       items,
+		CreatedAt:   ticket.CreatedAt.UTC().Format("2006-01-02T15:04:05Z07:00"),
+	}
+
+	if ticket.IsBumped() {
+		bumpedAt := ticket.Bump.CreatedAt.UTC().Format("2006-01-02T15:04:05Z07:00")
+		output.StaffID = &ticket.Bump.StaffID
+		output.BumpedAt = &bumpedAt
+	}
+
+	return output
+}
+
+// Present write the response to the client
+func (p *kitchenTicketJsonPresenter) Present(pp dto.PresenterInput) ([]byte, error) {
+	switch v := pp.Result.(type) {
+	case *entity.KitchenTicket:
+		output := toKitchenTicketJsonResponse(v)
+		return json.Marshal(output)
+	case []*entity.KitchenTicket:
+		tickets := make([]KitchenTicketJsonResponse, len(v))
+		for i, ticket := range v {
+			tickets[i] = toKitchenTicketJsonResponse(ticket)
+		}
+		return json.Marshal(&KitchenTicketsJsonResponse{Tickets: tickets})
+	default:
+		return nil, domain.NewInternalError(errors.New(domain.ErrInternalError))
+	}
+}
@@ -0,0 +1,21 @@
+package presenter
+
+type KitchenTicketJsonResponse struct {
+	OrderID     uint64                          `json:"order_id" example:"1"`
+	Station     string                          `json:"station" example:"GRILL, DRINKS, DESSERTS"`
+	OrderStatus string                          `json:"order_status" example:"RECEIVED, PREPARING, READY"`
+	Items       []KitchenTicketItemJsonResponse `json:"items"`
+	StaffID     *uint64                         `json:"staff_id,omitempty" example:"1"`
+	BumpedAt    *string                         `json:"bumped_at,omitempty" example:"2024-02-09T10:05:00Z"`
+	CreatedAt   string                          `json:"created_at" example:"2024-02-09T10:00:00Z"`
+}
+
+type KitchenTicketItemJsonResponse struct {
+	ProductID   uint64 `json:"product_id" example:"1"`
+	ProductName string `json:"product_name" example:"Product A"`
+	Quantity    uint32 `json:"quantity" example:"2"`
+}
+
+type KitchenTicketsJsonResponse struct {
+	Tickets []KitchenTicketJsonResponse `json:"tickets"`
+}
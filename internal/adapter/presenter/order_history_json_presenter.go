@@ -19,12 +19,26 @@ func NewOrderHistoryJsonPresenter() port.Presenter {
 
 // toOrderHistoryJsonResponse convert entity.OrderHistory to OrderHistoryJsonResponse
 func toOrderHistoryJsonResponse(orderHistory *entity.OrderHistory) OrderHistoryJsonResponse {
+	var paymentStatus *string
+	if orderHistory.PaymentStatus != nil {
+		status := orderHistory.PaymentStatus.String()
+		paymentStatus = &status
+	}
+
+	var systemActor *string
+	if orderHistory.SystemActor != nil {
+		actor := orderHistory.SystemActor.String()
+		systemActor = &actor
+	}
+
 	return OrderHistoryJsonResponse{
-		ID:        orderHistory.ID,
-		OrderID:   orderHistory.OrderID,
-		StaffID:   orderHistory.StaffID,
-		Status:    orderHistory.Status.String(),
-		CreatedAt: orderHistory.CreatedAt.UTC().Format("2006-01-02T15:04:05Z07:00"),
+		ID:            orderHistory.ID,
+		OrderID:       orderHistory.OrderID,
+		StaffID:       orderHistory.StaffID,
+		Status:        orderHistory.Status.String(),
+		PaymentStatus: paymentStatus,
+		SystemActor:   systemActor,
+		CreatedAt:     orderHistory.CreatedAt.UTC().Format("2006-01-02T15:04:05Z07:00"),
 	}
 }
 
@@ -3,7 +3,6 @@ package presenter
 import (
 	"encoding/json"
 	"errors"
-	"fmt"
 
 	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain"
 	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
@@ -51,15 +50,33 @@ func ToOrderJsonResponse(order *entity.Order) OrderJsonResponse {
 	if order.Customer.ID == 0 {
 		c = nil
 	}
+	var timing *OrderTimingJsonResponse
+	if order.Timing != nil {
+		timing = &OrderTimingJsonResponse{
+			QueueWaitSeconds:    int64(order.Timing.QueueWait.Seconds()),
+			PrepTimeSeconds:     int64(order.Timing.PrepTime.Seconds()),
+			TimeToPickupSeconds: int64(order.Timing.TimeToPickup.Seconds()),
+			TargetSeconds:       int64(order.Timing.Target.Seconds()),
+			Late:                order.Timing.Late,
+		}
+	}
+	var estimatedReadyAt *string
+	if order.EstimatedReadyAt != nil {
+		formatted := order.EstimatedReadyAt.UTC().Format("2006-01-02T15:04:05Z07:00")
+		estimatedReadyAt = &formatted
+	}
 	return OrderJsonResponse{
-		ID:         order.ID,
-		CustomerID: order.CustomerID,
-		TotalBill:  calculateTotalBill(order.OrderProducts),
-		Status:     string(order.Status),
-		Customer:   c,
-		Products:   ToProductsJsonResponse(order.OrderProducts),
-		CreatedAt:  order.CreatedAt.UTC().Format("2006-01-02T15:04:05Z07:00"),
-		UpdatedAt:  order.UpdatedAt.UTC().Format("2006-01-02T15:04:05Z07:00"),
+		ID:               order.ID,
+		CustomerID:       order.CustomerID,
+		TotalBill:        order.TotalAmount().String(),
+		Status:           string(order.Status),
+		Customer:         c,
+		Products:         ToProductsJsonResponse(order.OrderProducts),
+		Timing:           timing,
+		EstimatedReadyAt: estimatedReadyAt,
+		GuestToken:       order.GuestToken,
+		CreatedAt:        order.CreatedAt.UTC().Format("2006-01-02T15:04:05Z07:00"),
+		UpdatedAt:        order.UpdatedAt.UTC().Format("2006-01-02T15:04:05Z07:00"),
 	}
 }
 
@@ -67,20 +84,17 @@ func ToOrderJsonResponse(order *entity.Order) OrderJsonResponse {
 func ToProductsJsonResponse(orderProducts []entity.OrderProduct) []ProductsJsonResponse {
 	products := make([]ProductsJsonResponse, len(orderProducts))
 	for i, orderProduct := range orderProducts {
+		product := ToProductJsonResponse(&orderProduct.Product)
+		// The price, name and category are the ones the product had when it was added to the order
+		product.ID = orderProduct.ProductID
+		product.Name = orderProduct.ProductName
+		product.Price = orderProduct.UnitPrice
+		product.CategoryID = orderProduct.CategoryID
 		products[i] = ProductsJsonResponse{
-			ProductJsonResponse: ToProductJsonResponse(&orderProduct.Product),
+			ProductJsonResponse: product,
 			Quantity:            orderProduct.Quantity,
+			Subtotal:            orderProduct.Subtotal(),
 		}
 	}
 	return products
 }
-
-// calculateTotalBill calculate the total bill of an order
-func calculateTotalBill(orderProducts []entity.OrderProduct) string {
-	var total float64
-	for _, orderProduct := range orderProducts {
-		total += orderProduct.Product.Price * float64(orderProduct.Quantity)
-	}
-	// 2 decimal places
-	return fmt.Sprintf("%.2f", total)
-}
@@ -1,14 +1,28 @@
 package presenter
 
+import valueobject "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/value_object"
+
 type OrderJsonResponse struct {
-	ID         uint64                 `json:"id"`
-	CustomerID uint64                 `json:"customer_id" example:"1"`
-	TotalBill  string                 `json:"total_bill,omitempty" example:"100.00"`
-	Status     string                 `json:"status" example:"PENDING"`
-	Customer   *CustomerJsonResponse  `json:"customer,omitempty"`
-	Products   []ProductsJsonResponse `json:"products,omitempty"`
-	CreatedAt  string                 `json:"created_at" example:"2024-02-09T10:00:00Z"`
-	UpdatedAt  string                 `json:"updated_at" example:"2024-02-09T10:00:00Z"`
+	ID               uint64                   `json:"id"`
+	CustomerID       *uint64                  `json:"customer_id" example:"1"`
+	TotalBill        string                   `json:"total_bill,omitempty" example:"100.00"`
+	Status           string                   `json:"status" example:"PENDING"`
+	Customer         *CustomerJsonResponse    `json:"customer,omitempty"`
+	Products         []ProductsJsonResponse   `json:"products,omitempty"`
+	Timing           *OrderTimingJsonResponse `json:"timing,omitempty"`
+	EstimatedReadyAt *string                  `json:"estimated_ready_at,omitempty" example:"2024-02-09T10:15:00Z"` // estimated once paid, and again as the kitchen queue changes
+	GuestToken       string                   `json:"guest_token,omitempty" example:"Zm9vYmFy"`                    // only returned when the guest order is created
+	CreatedAt        string                   `json:"created_at" example:"2024-02-09T10:00:00Z"`
+	UpdatedAt        string                   `json:"updated_at" example:"2024-02-09T10:00:00Z"`
+}
+
+// OrderTimingJsonResponse is the time the order spent in each status after the payment, in seconds
+type OrderTimingJsonResponse struct {
+	QueueWaitSeconds    int64 `json:"queue_wait_seconds" example:"120"`
+	PrepTimeSeconds     int64 `json:"prep_time_seconds" example:"480"`
+	TimeToPickupSeconds int64 `json:"time_to_pickup_seconds" example:"60"`
+	TargetSeconds       int64 `json:"target_seconds" example:"900"`
+	Late                bool  `json:"late" example:"false"`
 }
 
 type OrderJsonPaginatedResponse struct {
@@ -18,5 +32,6 @@ type OrderJsonPaginatedResponse struct {
 
 type ProductsJsonResponse struct {
 	ProductJsonResponse
-	Quantity uint32 `json:"quantity"`
+	Quantity uint32            `json:"quantity"`
+	Subtotal valueobject.Money `json:"subtotal" example:"99.99" swaggertype:"number"`
 }
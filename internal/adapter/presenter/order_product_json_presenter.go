@@ -48,12 +48,16 @@ func ToOrderProductJsonResponse(orderProduct *entity.OrderProduct) OrderProductJ
 	order := ToOrderJsonResponse(&orderProduct.Order)
 	order.TotalBill = ""
 	return OrderProductJsonResponse{
-		OrderID:   orderProduct.OrderID,
-		ProductID: orderProduct.ProductID,
-		Quantity:  orderProduct.Quantity,
-		Order:     order,
-		Product:   ToProductJsonResponse(&orderProduct.Product),
-		CreatedAt: orderProduct.CreatedAt.UTC().Format("2006-01-02T15:04:05Z07:00"),
-		UpdatedAt: orderProduct.UpdatedAt.UTC().Format("2006-01-02T15:04:05Z07:00"),
+		OrderID:     orderProduct.OrderID,
+		ProductID:   orderProduct.ProductID,
+		Quantity:    orderProduct.Quantity,
+		UnitPrice:   orderProduct.UnitPrice,
+		ProductName: orderProduct.ProductName,
+		CategoryID:  orderProduct.CategoryID,
+		Subtotal:    orderProduct.Subtotal(),
+		Order:       order,
+		Product:     ToProductJsonResponse(&orderProduct.Product),
+		CreatedAt:   orderProduct.CreatedAt.UTC().Format("2006-01-02T15:04:05Z07:00"),
+		UpdatedAt:   orderProduct.UpdatedAt.UTC().Format("2006-01-02T15:04:05Z07:00"),
 	}
 }
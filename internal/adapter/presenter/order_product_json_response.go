@@ -1,13 +1,19 @@
 package presenter
 
+import valueobject "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/value_object"
+
 type OrderProductJsonResponse struct {
-	OrderID   uint64              `json:"order_id"`
-	ProductID uint64              `json:"product_id"`
-	Quantity  uint32              `json:"quantity"`
-	Order     OrderJsonResponse   `json:"order,omitempty"`
-	Product   ProductJsonResponse `json:"product,omitempty"`
-	CreatedAt string              `json:"created_at" example:"2024-02-09T10:00:00Z"`
-	UpdatedAt string              `json:"updated_at" example:"2024-02-09T10:00:00Z"`
+	OrderID     uint64              `json:"order_id"`
+	ProductID   uint64              `json:"product_id"`
+	Quantity    uint32              `json:"quantity"`
+	UnitPrice   valueobject.Money   `json:"unit_price" example:"99.99" swaggertype:"number"`
+	ProductName string              `json:"product_name" example:"Product A"`
+	CategoryID  uint64              `json:"category_id" example:"1"`
+	Subtotal    valueobject.Money   `json:"subtotal" example:"99.99" swaggertype:"number"`
+	Order       OrderJsonResponse   `json:"order,omitempty"`
+	Product     ProductJsonResponse `json:"product,omitempty"`
+	CreatedAt   string              `json:"created_at" example:"2024-02-09T10:00:00Z"`
+	UpdatedAt   string              `json:"updated_at" example:"2024-02-09T10:00:00Z"`
 }
 
 func NewOrderProductJsonResponse(orderID uint64, productID uint64, quantity uint32) *OrderProductJsonResponse {
@@ -0,0 +1,39 @@
+package presenter
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/dto"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/port"
+)
+
+type orderStatusEventJsonPresenter struct{}
+
+// NewOrderStatusEventJsonPresenter presents the order status events of the stream
+func NewOrderStatusEventJsonPresenter() port.Presenter {
+	return &orderStatusEventJsonPresenter{}
+}
+
+// toOrderStatusEventJsonResponse convert entity.OrderStatusEvent to OrderStatusEventJsonResponse
+func toOrderStatusEventJsonResponse(event *entity.OrderStatusEvent) OrderStatusEventJsonResponse {
+	return OrderStatusEventJsonResponse{
+		ID:         event.ID,
+		OrderID:    event.OrderID,
+		CustomerID: event.CustomerID,
+		Status:     event.Status.String(),
+		CreatedAt:  event.CreatedAt.UTC().Format("2006-01-02T15:04:05Z07:00"),
+	}
+}
+
+// Present write the response to the client
+func (p *orderStatusEventJsonPresenter) Present(pp dto.PresenterInput) ([]byte, error) {
+	switch v := pp.Result.(type) {
+	case *entity.OrderStatusEvent:
+		return json.Marshal(toOrderStatusEventJsonResponse(v))
+	default:
+		return nil, domain.NewInternalError(errors.New(domain.ErrInternalError))
+	}
+}
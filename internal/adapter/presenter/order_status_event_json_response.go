@@ -0,0 +1,10 @@
+package presenter
+
+type OrderStatusEventJsonResponse struct {
+	// Cursor to resume the stream from, sent as the event ID
+	ID         uint64  `json:"id" example:"1"`
+	OrderID    uint64  `json:"order_id" example:"1"`
+	CustomerID *uint64 `json:"customer_id" example:"1"`
+	Status     string  `json:"status" example:"RECEIVED, PREPARING, READY"`
+	CreatedAt  string  `json:"created_at" example:"2024-02-09T10:00:00Z"`
+}
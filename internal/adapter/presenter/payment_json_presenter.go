@@ -46,11 +46,21 @@ func (p *paymentJsonPresenter) Present(pp dto.PresenterInput) ([]byte, error) {
 
 // ToPaymentJsonResponse convert entity.Payment to PaymentJsonResponse
 func ToPaymentJsonResponse(p *entity.Payment) PaymentJsonResponse {
-	return PaymentJsonResponse{
+	response := PaymentJsonResponse{
 		ID:                p.ID,
 		Status:            p.Status,
+		Provider:          p.Provider,
 		OrderID:           p.OrderID,
 		ExternalPaymentID: p.ExternalPaymentID,
 		QrData:            p.QrData,
+		Amount:            p.Amount,
+		RefundedAmount:    p.RefundedAmount,
 	}
+
+	if p.ExpiresAt != nil {
+		expiresAt := p.ExpiresAt.UTC().Format("2006-01-02T15:04:05Z07:00")
+		response.ExpiresAt = &expiresAt
+	}
+
+	return response
 }
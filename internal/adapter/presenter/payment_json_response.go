@@ -3,11 +3,15 @@ package presenter
 import valueobject "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/value_object"
 
 type PaymentJsonResponse struct {
-	ID                uint64                    `json:"id" example:"1"`
-	Status            valueobject.PaymentStatus `json:"status" example:"pending"`
-	OrderID           uint64                    `json:"order_id" example:"1"`
-	ExternalPaymentID string                    `json:"external_payment_id" example:"a0aa0f26-6e0a-4b90-8c49-9f1a9c03ebcc"`
-	QrData            string                    `json:"qr_data" example:"qr_data_a0aa0f26-6e0a-4b90-8c49-9f1a9c03ebcc"`
+	ID                uint64                      `json:"id" example:"1"`
+	Status            valueobject.PaymentStatus   `json:"status" example:"pending"`
+	Provider          valueobject.PaymentProvider `json:"provider" example:"mercadopago"`
+	OrderID           uint64                      `json:"order_id" example:"1"`
+	ExternalPaymentID string                      `json:"external_payment_id" example:"a0aa0f26-6e0a-4b90-8c49-9f1a9c03ebcc"`
+	QrData            string                      `json:"qr_data" example:"qr_data_a0aa0f26-6e0a-4b90-8c49-9f1a9c03ebcc"`
+	Amount            valueobject.Money           `json:"amount" example:"25.80" swaggertype:"number"`
+	RefundedAmount    valueobject.Money           `json:"refunded_amount" example:"0" swaggertype:"number"`
+	ExpiresAt         *string                     `json:"expires_at,omitempty" example:"2024-02-09T10:15:00Z"`
 }
 
 type PaymentJsonPaginatedResponse struct {
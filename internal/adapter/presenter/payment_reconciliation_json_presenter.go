@@ -0,0 +1,58 @@
+package presenter
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
+	valueobject "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/value_object"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/dto"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/port"
+)
+
+type paymentReconciliationJsonPresenter struct{}
+
+// NewPaymentReconciliationJsonPresenter presents a payment reconciliation report
+func NewPaymentReconciliationJsonPresenter() port.Presenter {
+	return &paymentReconciliationJsonPresenter{}
+}
+
+// Present write the report, indented to be read as a file
+func (p *paymentReconciliationJsonPresenter) Present(pp dto.PresenterInput) ([]byte, error) {
+	switch v := pp.Result.(type) {
+	case *entity.PaymentReconciliationReport:
+		output := ToPaymentReconciliationJsonResponse(v)
+		return json.MarshalIndent(output, "", "  ")
+	default:
+		return nil, domain.NewInternalError(errors.New(domain.ErrInternalError))
+	}
+}
+
+// ToPaymentReconciliationJsonResponse convert entity.PaymentReconciliationReport to PaymentReconciliationJsonResponse
+func ToPaymentReconciliationJsonResponse(r *entity.PaymentReconciliationReport) PaymentReconciliationJsonResponse {
+	entries := make([]PaymentReconciliationEntryJsonResponse, len(r.Entries))
+	for i, entry := range r.Entries {
+		entries[i] = PaymentReconciliationEntryJsonResponse{
+			PaymentID:      entry.PaymentID,
+			OrderID:        entry.OrderID,
+			Resource:       entry.Resource,
+			Provider:       entry.Provider,
+			LocalStatus:    entry.LocalStatus,
+			ProviderStatus: entry.ProviderStatus,
+			Result:         entry.Result,
+			Reason:         entry.Reason,
+		}
+	}
+
+	return PaymentReconciliationJsonResponse{
+		StartedAt:  r.StartedAt.UTC().Format("2006-01-02T15:04:05Z07:00"),
+		FinishedAt: r.FinishedAt.UTC().Format("2006-01-02T15:04:05Z07:00"),
+		Matched:    r.Count(valueobject.RECONCILIATION_MATCHED),
+		Fixed:      r.Count(valueobject.RECONCILIATION_FIXED),
+		Orphaned:   r.Count(valueobject.RECONCILIATION_ORPHANED),
+		Skipped:    r.Count(valueobject.RECONCILIATION_SKIPPED),
+		Failed:     r.Count(valueobject.RECONCILIATION_FAILED),
+		Entries:    entries,
+	}
+}
@@ -0,0 +1,25 @@
+package presenter
+
+import valueobject "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/value_object"
+
+type PaymentReconciliationJsonResponse struct {
+	StartedAt  string                                   `json:"started_at" example:"2024-02-09T10:00:00Z"`
+	FinishedAt string                                   `json:"finished_at" example:"2024-02-09T10:00:05Z"`
+	Matched    int                                      `json:"matched" example:"10"`
+	Fixed      int                                      `json:"fixed" example:"1"`
+	Orphaned   int                                      `json:"orphaned" example:"0"`
+	Skipped    int                                      `json:"skipped" example:"2"`
+	Failed     int                                      `json:"failed" example:"0"`
+	Entries    []PaymentReconciliationEntryJsonResponse `json:"entries"`
+}
+
+type PaymentReconciliationEntryJsonResponse struct {
+	PaymentID      uint64                           `json:"payment_id,omitempty" example:"1"`
+	OrderID        uint64                           `json:"order_id,omitempty" example:"1"`
+	Resource       string                           `json:"resource" example:"a0aa0f26-6e0a-4b90-8c49-9f1a9c03ebcc"`
+	Provider       valueobject.PaymentProvider      `json:"provider,omitempty" example:"mercadopago"`
+	LocalStatus    valueobject.PaymentStatus        `json:"local_status,omitempty" example:"PROCESSING"`
+	ProviderStatus valueobject.PaymentStatus        `json:"provider_status,omitempty" example:"CONFIRMED"`
+	Result         valueobject.ReconciliationResult `json:"result" example:"FIXED"`
+	Reason         string                           `json:"reason,omitempty"`
+}
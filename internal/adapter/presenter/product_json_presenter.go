@@ -51,6 +51,7 @@ func ToProductJsonResponse(product *entity.Product) ProductJsonResponse {
 		Description: product.Description,
 		Price:       product.Price,
 		CategoryID:  product.CategoryID,
+		PrepMinutes: product.PrepMinutes,
 		CreatedAt:   product.CreatedAt.UTC().Format("2006-01-02T15:04:05Z07:00"),
 		UpdatedAt:   product.UpdatedAt.UTC().Format("2006-01-02T15:04:05Z07:00"),
 	}
@@ -1,13 +1,16 @@
 package presenter
 
+import valueobject "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/value_object"
+
 type ProductJsonResponse struct {
-	ID          uint64  `json:"id" example:"1"`
-	Name        string  `json:"name" example:"Product A"`
-	Description string  `json:"description" example:"Description of product A"`
-	Price       float64 `json:"price" example:"99.99"`
-	CategoryID  uint64  `json:"category_id" example:"1"`
-	CreatedAt   string  `json:"created_at" example:"2024-02-09T10:00:00Z"`
-	UpdatedAt   string  `json:"updated_at" example:"2024-02-09T10:00:00Z"`
+	ID          uint64            `json:"id" example:"1"`
+	Name        string            `json:"name" example:"Product A"`
+	Description string            `json:"description" example:"Description of product A"`
+	Price       valueobject.Money `json:"price" example:"99.99" swaggertype:"number"`
+	CategoryID  uint64            `json:"category_id" example:"1"`
+	PrepMinutes uint32            `json:"prep_minutes" example:"8"`
+	CreatedAt   string            `json:"created_at" example:"2024-02-09T10:00:00Z"`
+	UpdatedAt   string            `json:"updated_at" example:"2024-02-09T10:00:00Z"`
 }
 
 type ProductJsonPaginatedResponse struct {
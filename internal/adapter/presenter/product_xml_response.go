@@ -1,13 +1,16 @@
 package presenter
 
+import valueobject "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/value_object"
+
 type ProductXmlResponse struct {
-	ID          uint64  `xml:"id" example:"1"`
-	Name        string  `xml:"name" example:"Product A"`
-	Description string  `xml:"description" example:"Description of product A"`
-	Price       float64 `xml:"price" example:"99.99"`
-	CategoryID  uint64  `xml:"category_id" example:"1"`
-	CreatedAt   string  `xml:"created_at" example:"2024-02-09T10:00:00Z"`
-	UpdatedAt   string  `xml:"updated_at" example:"2024-02-09T10:00:00Z"`
+	ID          uint64            `xml:"id" example:"1"`
+	Name        string            `xml:"name" example:"Product A"`
+	Description string            `xml:"description" example:"Description of product A"`
+	Price       valueobject.Money `xml:"price" example:"99.99" swaggertype:"number"`
+	CategoryID  uint64            `xml:"category_id" example:"1"`
+	PrepMinutes uint32            `xml:"prep_minutes" example:"8"`
+	CreatedAt   string            `xml:"created_at" example:"2024-02-09T10:00:00Z"`
+	UpdatedAt   string            `xml:"updated_at" example:"2024-02-09T10:00:00Z"`
 }
 
 type ProductXmlPaginatedResponse struct {
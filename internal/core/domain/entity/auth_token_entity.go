@@ -0,0 +1,17 @@
+package entity
+
+import "time"
+
+// AccessToken is a signed access token and its identifying claims
+type AccessToken struct {
+	Token     string
+	ID        string // jti claim
+	ExpiresAt time.Time
+}
+
+// AuthToken is the pair of tokens handed to an authenticated client
+type AuthToken struct {
+	AccessToken  string
+	RefreshToken string
+	ExpiresAt    time.Time
+}
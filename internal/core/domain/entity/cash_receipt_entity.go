@@ -0,0 +1,17 @@
+package entity
+
+import (
+	"time"
+
+	valueobject "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/value_object"
+)
+
+// CashReceipt is the money an attendant took at the counter to confirm a cash payment
+type CashReceipt struct {
+	ID             uint64
+	PaymentID      uint64
+	AmountReceived valueobject.Money
+	ChangeGiven    valueobject.Money
+	StaffID        uint64
+	CreatedAt      time.Time
+}
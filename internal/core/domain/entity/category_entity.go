@@ -1,15 +1,28 @@
 package entity
 
-import "time"
+import (
+	"time"
+
+	valueobject "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/value_object"
+)
 
 type Category struct {
-	ID        uint64
-	Name      string
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	ID                uint64
+	Name              string
+	Station           valueobject.KitchenStation // kitchen station that prepares the products of the category
+	PrepTargetMinutes uint32                     // time to get the orders with its products READY, 0 uses the default
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
 }
 
-func (p *Category) Update(name string) {
+// Update changes the name, and the station and preparation target when given
+func (p *Category) Update(name string, station valueobject.KitchenStation, prepTargetMinutes *uint32) {
 	p.Name = name
+	if station != valueobject.UNDEFINED_STATION {
+		p.Station = station
+	}
+	if prepTargetMinutes != nil {
+		p.PrepTargetMinutes = *prepTargetMinutes
+	}
 	p.UpdatedAt = time.Now()
 }
@@ -0,0 +1,70 @@
+package entity
+
+import (
+	"slices"
+	"time"
+
+	valueobject "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/value_object"
+)
+
+// KitchenQueue estimates when the orders in the kitchen get READY, as the kitchen prepares up to Capacity orders at a
+// time, the ones being prepared first and then the others in the order they were paid
+type KitchenQueue struct {
+	Capacity        int
+	AveragePrepTime time.Duration // historical time from PREPARING to READY, DefaultPrepTime is used when 0
+	DefaultPrepTime time.Duration
+}
+
+// Estimate returns the estimated ready time of each order by ID. The orders being prepared must have their Timing.
+func (q KitchenQueue) Estimate(orders []*Order, now time.Time) map[uint64]time.Time {
+	queue := slices.Clone(orders)
+	slices.SortStableFunc(queue, func(a, b *Order) int {
+		return preparingFirst(a) - preparingFirst(b)
+	})
+
+	// the time each place in the kitchen is free
+	places := make([]time.Time, max(q.Capacity, 1))
+	for i := range places {
+		places[i] = now
+	}
+
+	estimates := make(map[uint64]time.Time, len(queue))
+	for _, order := range queue {
+		remaining := q.PrepTime(order)
+		if order.Timing != nil {
+			remaining = max(remaining-order.Timing.PrepTime, 0)
+		}
+
+		place := 0
+		for i := range places {
+			if places[i].Before(places[place]) {
+				place = i
+			}
+		}
+
+		places[place] = places[place].Add(remaining)
+		estimates[order.ID] = places[place]
+	}
+
+	return estimates
+}
+
+// PrepTime returns the time to prepare the order, the historical one or the estimate of its slowest product if longer
+func (q KitchenQueue) PrepTime(order *Order) time.Duration {
+	prepTime := q.AveragePrepTime
+	if prepTime == 0 {
+		prepTime = q.DefaultPrepTime
+	}
+
+	for _, item := range order.OrderProducts {
+		prepTime = max(prepTime, time.Duration(item.Product.PrepMinutes)*time.Minute)
+	}
+	return prepTime
+}
+
+func preparingFirst(order *Order) int {
+	if order.Status == valueobject.PREPARING {
+		return 0
+	}
+	return 1
+}
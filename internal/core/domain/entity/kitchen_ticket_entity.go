@@ -0,0 +1,71 @@
+package entity
+
+import (
+	"time"
+
+	valueobject "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/value_object"
+)
+
+// KitchenTicket is the part of an order prepared by one kitchen station, made of the order products of the
+// categories routed to it
+type KitchenTicket struct {
+	OrderID     uint64
+	Station     valueobject.KitchenStation
+	OrderStatus valueobject.OrderStatus
+	Items       []OrderProduct
+	Bump        *KitchenTicketBump // nil while the ticket is pending
+	CreatedAt   time.Time          // when the order was placed
+}
+
+func (t *KitchenTicket) IsBumped() bool {
+	return t.Bump != nil
+}
+
+// KitchenTicketBump records the cook who finished the ticket of a station
+type KitchenTicketBump struct {
+	ID        uint64
+	OrderID   uint64
+	Station   valueobject.KitchenStation
+	StaffID   uint64
+	CreatedAt time.Time
+}
+
+func NewKitchenTicketBump(orderID uint64, station valueobject.KitchenStation, staffID uint64) *KitchenTicketBump {
+	return &KitchenTicketBump{
+		OrderID: orderID,
+		Station: station,
+		StaffID: staffID,
+	}
+}
+
+// SplitKitchenTickets breaks the order into one ticket per station, routing each product by the station of its
+// category. Categories without a station go to the default one.
+func SplitKitchenTickets(order *Order, stations map[uint64]valueobject.KitchenStation) []*KitchenTicket {
+	tickets := make(map[valueobject.KitchenStation]*KitchenTicket)
+	for _, item := range order.OrderProducts {
+		station, ok := stations[item.CategoryID]
+		if !ok || station == valueobject.UNDEFINED_STATION {
+			station = valueobject.DefaultKitchenStation
+		}
+
+		ticket, ok := tickets[station]
+		if !ok {
+			ticket = &KitchenTicket{
+				OrderID:     order.ID,
+				Station:     station,
+				OrderStatus: order.Status,
+				CreatedAt:   order.CreatedAt,
+			}
+			tickets[station] = ticket
+		}
+		ticket.Items = append(ticket.Items, item)
+	}
+
+	result := make([]*KitchenTicket, 0, len(tickets))
+	for _, station := range valueobject.KitchenStations {
+		if ticket, ok := tickets[station]; ok {
+			result = append(result, ticket)
+		}
+	}
+	return result
+}
@@ -1,25 +1,31 @@
 package entity
 
 import (
+	"crypto/subtle"
 	"time"
 
 	valueobject "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/value_object"
 )
 
 type Order struct {
-	ID            uint64
-	CustomerID    uint64
-	Status        valueobject.OrderStatus
-	Payment       Payment
-	Customer      Customer
-	OrderProducts []OrderProduct
-	CreatedAt     time.Time
-	UpdatedAt     time.Time
+	ID               uint64
+	CustomerID       *uint64
+	Status           valueobject.OrderStatus
+	Payment          Payment
+	Customer         Customer
+	OrderProducts    []OrderProduct
+	Version          uint64       // incremented on every update, for optimistic concurrency control
+	Timing           *OrderTiming `gorm:"-"` // computed from the histories when listed, not stored
+	EstimatedReadyAt *time.Time   // estimated from the kitchen queue once paid, nil before
+	GuestTokenHash   string       // hash of the secret of the guest who placed the order, empty once it has a customer
+	GuestToken       string       `gorm:"-"` // the secret itself, only known when the guest order is created
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
 }
 
 func (p *Order) Update(customerID uint64, status valueobject.OrderStatus) {
 	if customerID != 0 {
-		p.CustomerID = customerID
+		p.CustomerID = &customerID
 	}
 	if status != valueobject.UNDEFINDED {
 		p.Status = status
@@ -27,3 +33,48 @@ func (p *Order) Update(customerID uint64, status valueobject.OrderStatus) {
 	p.OrderProducts = nil
 	p.UpdatedAt = time.Now()
 }
+
+// IsGuest returns true if the order has no customer attached
+func (p *Order) IsGuest() bool {
+	return p.CustomerID == nil
+}
+
+// IsGuestOwnedBy returns true if the order is a guest order placed with the guest token of the given hash
+func (p *Order) IsGuestOwnedBy(guestTokenHash string) bool {
+	return p.IsGuest() && p.GuestTokenHash != "" && subtle.ConstantTimeCompare([]byte(p.GuestTokenHash), []byte(guestTokenHash)) == 1
+}
+
+// IsIdleSince returns true if neither the order nor its products changed since the given time
+func (p *Order) IsIdleSince(since time.Time) bool {
+	if !p.UpdatedAt.Before(since) {
+		return false
+	}
+	for _, orderProduct := range p.OrderProducts {
+		if !orderProduct.UpdatedAt.Before(since) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsOwnedBy returns true if the order belongs to the given customer
+func (p *Order) IsOwnedBy(customerID uint64) bool {
+	return p.CustomerID != nil && *p.CustomerID == customerID
+}
+
+// TotalAmount returns the sum of the products of the order, at the prices they were added
+func (p *Order) TotalAmount() valueobject.Money {
+	var total valueobject.Money
+	for _, orderProduct := range p.OrderProducts {
+		total = total.Add(orderProduct.Subtotal())
+	}
+	return total
+}
+
+// AttachCustomer identifies the customer of a guest order, the guest token no longer gives access to it
+func (p *Order) AttachCustomer(customerID uint64) {
+	p.CustomerID = &customerID
+	p.GuestTokenHash = ""
+	p.OrderProducts = nil
+	p.UpdatedAt = time.Now()
+}
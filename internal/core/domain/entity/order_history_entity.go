@@ -6,13 +6,15 @@ import (
 )
 
 type OrderHistory struct {
-	ID        uint64
-	OrderID   uint64
-	StaffID   *uint64
-	Status    valueobject.OrderStatus
-	CreatedAt time.Time
-	Order     Order
-	Staff     *Staff
+	ID            uint64
+	OrderID       uint64
+	StaffID       *uint64
+	Status        valueobject.OrderStatus
+	PaymentStatus *valueobject.PaymentStatus // payment outcome that caused the status change, if any
+	SystemActor   *valueobject.SystemActor   // automatic process that made the change, nil when made by a person
+	CreatedAt     time.Time
+	Order         Order
+	Staff         *Staff
 }
 
 func NewOrderHistory(orderID uint64, status valueobject.OrderStatus, staffID *uint64) *OrderHistory {
@@ -2,16 +2,22 @@ package entity
 
 import (
 	"time"
+
+	valueobject "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/value_object"
 )
 
 type OrderProduct struct {
 	OrderID   uint64
 	ProductID uint64
 	Quantity  uint32
-	Order     Order   // Virtual field
-	Product   Product // Virtual field
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	// Snapshot of the product when it was added, so later changes to the product do not change the bill
+	UnitPrice   valueobject.Money
+	ProductName string
+	CategoryID  uint64
+	Order       Order   // Virtual field
+	Product     Product // Virtual field
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
 }
 
 func (p *OrderProduct) Update(quantity uint32) {
@@ -20,3 +26,23 @@ func (p *OrderProduct) Update(quantity uint32) {
 	p.Order = Order{}
 	p.Product = Product{}
 }
+
+// Snapshot copies the price, name and category of the product to the order product
+func (p *OrderProduct) Snapshot(product Product) {
+	p.UnitPrice = product.Price
+	p.ProductName = product.Name
+	p.CategoryID = product.CategoryID
+}
+
+// Reprice refreshes the snapshot with the current product
+func (p *OrderProduct) Reprice(product Product) {
+	p.Snapshot(product)
+	p.UpdatedAt = time.Now()
+	p.Order = Order{}
+	p.Product = Product{}
+}
+
+// Subtotal returns the snapshot price times the quantity
+func (p *OrderProduct) Subtotal() valueobject.Money {
+	return p.UnitPrice.Multiply(int64(p.Quantity))
+}
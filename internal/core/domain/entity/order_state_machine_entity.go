@@ -0,0 +1,150 @@
+package entity
+
+import (
+	"fmt"
+	"slices"
+
+	valueobject "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/value_object"
+)
+
+// OrderTransition is a change of status allowed for the orders
+type OrderTransition struct {
+	From   valueobject.OrderStatus
+	To     valueobject.OrderStatus
+	Staff  bool                     // recorded with the staff making it, or the automatic process instead
+	Roles  []valueobject.StaffRole  // staff roles allowed to make it, every caller when empty
+	Guards []valueobject.OrderGuard // conditions the order must meet
+}
+
+// AllowsRole returns true if the caller may make the transition. The callers without a principal are the automatic
+// processes (ex: payment notifications), not restricted by roles.
+func (t *OrderTransition) AllowsRole(principal *Principal) bool {
+	return len(t.Roles) == 0 || principal == nil || principal.HasAnyRole(t.Roles...)
+}
+
+// NeedsStations returns true if a guard of the transition depends on the kitchen stations of the products
+func (t *OrderTransition) NeedsStations() bool {
+	return slices.Contains(t.Guards, valueobject.DRINKS_ONLY_GUARD)
+}
+
+// GuardsPass returns true if the order meets every guard of the transition, stations routes the categories of its
+// products to the kitchen stations
+func (t *OrderTransition) GuardsPass(order *Order, stations map[uint64]valueobject.KitchenStation) bool {
+	for _, guard := range t.Guards {
+		switch guard {
+		case valueobject.HAS_PRODUCTS_GUARD:
+			if len(order.OrderProducts) == 0 {
+				return false
+			}
+		case valueobject.HAS_CUSTOMER_GUARD:
+			if order.IsGuest() {
+				return false
+			}
+		case valueobject.DRINKS_ONLY_GUARD:
+			tickets := SplitKitchenTickets(order, stations)
+			if len(tickets) != 1 || tickets[0].Station != valueobject.DRINKS {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// requiredOrderTransitions are the transitions of the checkout, every state machine must allow them: the order is
+// PENDING while paid, RECEIVED once the payment is confirmed and back to OPEN when it fails
+var requiredOrderTransitions = []OrderTransition{
+	{From: valueobject.OPEN, To: valueobject.PENDING},
+	{From: valueobject.PENDING, To: valueobject.RECEIVED},
+	{From: valueobject.PENDING, To: valueobject.OPEN},
+}
+
+// OrderStateMachine is the set of status transitions allowed for the orders
+type OrderStateMachine struct {
+	transitions map[valueobject.OrderStatus]map[valueobject.OrderStatus]*OrderTransition
+}
+
+// NewOrderStateMachine validates the transitions and creates the OrderStateMachine. Besides the transitions of the
+// checkout, it must allow the required ones (ex: the ones made by the order expiry).
+func NewOrderStateMachine(transitions []OrderTransition, required ...OrderTransition) (*OrderStateMachine, error) {
+	m := &OrderStateMachine{transitions: make(map[valueobject.OrderStatus]map[valueobject.OrderStatus]*OrderTransition)}
+
+	for i, transition := range transitions {
+		if transition.From == valueobject.UNDEFINDED || transition.To == valueobject.UNDEFINDED {
+			return nil, fmt.Errorf("transition %d: status is mandatory", i+1)
+		}
+
+		if transition.From == transition.To {
+			return nil, fmt.Errorf("transition %d: %s cannot transition to itself", i+1, transition.From)
+		}
+
+		for _, role := range transition.Roles {
+			if role == valueobject.UNDEFINED {
+				return nil, fmt.Errorf("transition %d: role is mandatory", i+1)
+			}
+		}
+
+		for _, guard := range transition.Guards {
+			if guard == valueobject.UNDEFINED_GUARD {
+				return nil, fmt.Errorf("transition %d: guard is mandatory", i+1)
+			}
+		}
+
+		if _, ok := m.Transition(transition.From, transition.To); ok {
+			return nil, fmt.Errorf("transition %d: %s -> %s is defined twice", i+1, transition.From, transition.To)
+		}
+
+		if m.transitions[transition.From] == nil {
+			m.transitions[transition.From] = make(map[valueobject.OrderStatus]*OrderTransition)
+		}
+		m.transitions[transition.From][transition.To] = &transitions[i]
+	}
+
+	for _, transition := range append(slices.Clone(requiredOrderTransitions), required...) {
+		if !m.CanTransition(transition.From, transition.To) {
+			return nil, fmt.Errorf("missing transition %s -> %s", transition.From, transition.To)
+		}
+	}
+
+	// A transition leaving a status no order can reach is never used, most likely a mistake in the definition
+	reachable := m.reachableFrom(valueobject.OPEN)
+	for i, transition := range transitions {
+		if !reachable[transition.From] {
+			return nil, fmt.Errorf("transition %d: %s is unreachable from %s", i+1, transition.From, valueobject.OPEN)
+		}
+	}
+
+	return m, nil
+}
+
+// reachableFrom returns the statuses an order in the given status may reach, itself included
+func (m *OrderStateMachine) reachableFrom(status valueobject.OrderStatus) map[valueobject.OrderStatus]bool {
+	reachable := map[valueobject.OrderStatus]bool{status: true}
+	pending := []valueobject.OrderStatus{status}
+	for len(pending) > 0 {
+		from := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+
+		for to := range m.transitions[from] {
+			if !reachable[to] {
+				reachable[to] = true
+				pending = append(pending, to)
+			}
+		}
+	}
+	return reachable
+}
+
+// Transition returns the transition from one status to another, if allowed
+func (m *OrderStateMachine) Transition(from, to valueobject.OrderStatus) (*OrderTransition, bool) {
+	transition, ok := m.transitions[from][to]
+	return transition, ok
+}
+
+// CanTransition returns true if the transition from one status to another is allowed, regardless of its roles and
+// guards
+func (m *OrderStateMachine) CanTransition(from, to valueobject.OrderStatus) bool {
+	_, ok := m.Transition(from, to)
+	return ok
+}
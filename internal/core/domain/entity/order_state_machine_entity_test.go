@@ -0,0 +1,213 @@
+package entity_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
+	valueobject "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/value_object"
+)
+
+// checkoutTransitions are the transitions every order state machine must allow
+var checkoutTransitions = []entity.OrderTransition{
+	{From: valueobject.OPEN, To: valueobject.PENDING},
+	{From: valueobject.PENDING, To: valueobject.RECEIVED},
+	{From: valueobject.PENDING, To: valueobject.OPEN},
+}
+
+func TestNewOrderStateMachine(t *testing.T) {
+	tests := []struct {
+		name        string
+		transitions []entity.OrderTransition
+		required    []entity.OrderTransition
+		wantErr     string
+	}{
+		{
+			name: "should create the state machine",
+			transitions: []entity.OrderTransition{
+				{From: valueobject.OPEN, To: valueobject.PENDING},
+				{From: valueobject.PENDING, To: valueobject.RECEIVED, Roles: []valueobject.StaffRole{valueobject.MANAGER}},
+				{From: valueobject.PENDING, To: valueobject.OPEN},
+				{From: valueobject.RECEIVED, To: valueobject.OPEN, Guards: []valueobject.OrderGuard{valueobject.HAS_CUSTOMER_GUARD}},
+			},
+		},
+		{
+			name:        "should create the state machine with the required transitions",
+			transitions: append(slices.Clone(checkoutTransitions), entity.OrderTransition{From: valueobject.OPEN, To: valueobject.CANCELLED}),
+			required:    []entity.OrderTransition{{From: valueobject.OPEN, To: valueobject.CANCELLED}},
+		},
+		{
+			name:        "should refuse a transition without status",
+			transitions: []entity.OrderTransition{{From: valueobject.OPEN, To: valueobject.UNDEFINDED}},
+			wantErr:     "transition 1: status is mandatory",
+		},
+		{
+			name:        "should refuse a transition to the same status",
+			transitions: []entity.OrderTransition{{From: valueobject.OPEN, To: valueobject.OPEN}},
+			wantErr:     "transition 1: OPEN cannot transition to itself",
+		},
+		{
+			name:        "should refuse an undefined role",
+			transitions: []entity.OrderTransition{{From: valueobject.OPEN, To: valueobject.PENDING, Roles: []valueobject.StaffRole{valueobject.UNDEFINED}}},
+			wantErr:     "transition 1: role is mandatory",
+		},
+		{
+			name:        "should refuse an undefined guard",
+			transitions: []entity.OrderTransition{{From: valueobject.OPEN, To: valueobject.PENDING, Guards: []valueobject.OrderGuard{valueobject.UNDEFINED_GUARD}}},
+			wantErr:     "transition 1: guard is mandatory",
+		},
+		{
+			name: "should refuse a transition defined twice",
+			transitions: []entity.OrderTransition{
+				{From: valueobject.OPEN, To: valueobject.PENDING},
+				{From: valueobject.OPEN, To: valueobject.PENDING, Staff: true},
+			},
+			wantErr: "transition 2: OPEN -> PENDING is defined twice",
+		},
+		{
+			name:        "should refuse a state machine the orders cannot leave OPEN",
+			transitions: []entity.OrderTransition{{From: valueobject.PENDING, To: valueobject.OPEN}},
+			wantErr:     "missing transition OPEN -> PENDING",
+		},
+		{
+			name: "should refuse a state machine without the transitions of the checkout",
+			transitions: []entity.OrderTransition{
+				{From: valueobject.OPEN, To: valueobject.PENDING},
+				{From: valueobject.PENDING, To: valueobject.RECEIVED},
+			},
+			wantErr: "missing transition PENDING -> OPEN",
+		},
+		{
+			name:        "should refuse a state machine without a required transition",
+			transitions: checkoutTransitions,
+			required:    []entity.OrderTransition{{From: valueobject.READY, To: valueobject.ABANDONED}},
+			wantErr:     "missing transition READY -> ABANDONED",
+		},
+		{
+			name: "should refuse a transition from a status that cannot be reached",
+			transitions: append(slices.Clone(checkoutTransitions),
+				entity.OrderTransition{From: valueobject.PENDING, To: valueobject.CANCELLED},
+				entity.OrderTransition{From: valueobject.READY, To: valueobject.COMPLETED},
+			),
+			wantErr: "transition 5: READY is unreachable from OPEN",
+		},
+		{
+			name: "should refuse transitions that only reach each other",
+			transitions: append(slices.Clone(checkoutTransitions),
+				entity.OrderTransition{From: valueobject.PREPARING, To: valueobject.READY},
+				entity.OrderTransition{From: valueobject.READY, To: valueobject.PREPARING},
+			),
+			wantErr: "transition 4: PREPARING is unreachable from OPEN",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stateMachine, err := entity.NewOrderStateMachine(tt.transitions, tt.required...)
+
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				assert.Nil(t, stateMachine)
+				return
+			}
+
+			assert.NoError(t, err)
+			for _, transition := range tt.transitions {
+				assert.True(t, stateMachine.CanTransition(transition.From, transition.To))
+			}
+		})
+	}
+}
+
+func TestOrderStateMachine_Transition(t *testing.T) {
+	stateMachine, err := entity.NewOrderStateMachine([]entity.OrderTransition{
+		{From: valueobject.OPEN, To: valueobject.PENDING, Guards: []valueobject.OrderGuard{valueobject.HAS_PRODUCTS_GUARD}},
+		{From: valueobject.PENDING, To: valueobject.RECEIVED, Staff: true, Roles: []valueobject.StaffRole{valueobject.ATTENDANT}},
+		{From: valueobject.PENDING, To: valueobject.OPEN},
+	})
+	assert.NoError(t, err)
+
+	customerID := uint64(1)
+	withProducts := &entity.Order{CustomerID: &customerID, OrderProducts: []entity.OrderProduct{{ProductID: 1}}}
+	attendant := &entity.Principal{SubjectType: valueobject.STAFF, ID: 1, Role: valueobject.ATTENDANT}
+	cook := &entity.Principal{SubjectType: valueobject.STAFF, ID: 2, Role: valueobject.COOK}
+
+	tests := []struct {
+		name       string
+		from       valueobject.OrderStatus
+		to         valueobject.OrderStatus
+		principal  *entity.Principal
+		order      *entity.Order
+		wantFound  bool
+		wantRole   bool
+		wantGuards bool
+	}{
+		{
+			name:       "should allow a transition whose guards pass",
+			from:       valueobject.OPEN,
+			to:         valueobject.PENDING,
+			order:      withProducts,
+			wantFound:  true,
+			wantRole:   true,
+			wantGuards: true,
+		},
+		{
+			name:      "should refuse a transition whose guards fail",
+			from:      valueobject.OPEN,
+			to:        valueobject.PENDING,
+			order:     &entity.Order{},
+			wantFound: true,
+			wantRole:  true,
+		},
+		{
+			name:       "should allow a transition to a staff of its roles",
+			from:       valueobject.PENDING,
+			to:         valueobject.RECEIVED,
+			principal:  attendant,
+			order:      withProducts,
+			wantFound:  true,
+			wantRole:   true,
+			wantGuards: true,
+		},
+		{
+			name:       "should refuse a transition to a staff of another role",
+			from:       valueobject.PENDING,
+			to:         valueobject.RECEIVED,
+			principal:  cook,
+			order:      withProducts,
+			wantFound:  true,
+			wantGuards: true,
+		},
+		{
+			name:       "should allow a transition with roles to the automatic processes",
+			from:       valueobject.PENDING,
+			to:         valueobject.RECEIVED,
+			order:      withProducts,
+			wantFound:  true,
+			wantRole:   true,
+			wantGuards: true,
+		},
+		{
+			name: "should not find a transition that is not defined",
+			from: valueobject.RECEIVED,
+			to:   valueobject.OPEN,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transition, ok := stateMachine.Transition(tt.from, tt.to)
+			assert.Equal(t, tt.wantFound, ok)
+			assert.Equal(t, tt.wantFound, stateMachine.CanTransition(tt.from, tt.to))
+			if !tt.wantFound {
+				assert.Nil(t, transition)
+				return
+			}
+
+			assert.Equal(t, tt.wantRole, transition.AllowsRole(tt.principal))
+			assert.Equal(t, tt.wantGuards, transition.GuardsPass(tt.order, nil))
+		})
+	}
+}
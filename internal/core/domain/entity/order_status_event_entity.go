@@ -0,0 +1,46 @@
+package entity
+
+import (
+	"slices"
+	"time"
+
+	valueobject "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/value_object"
+)
+
+// OrderStatusEvent is a change of status of an order, identified by the order history that recorded it
+type OrderStatusEvent struct {
+	ID         uint64 // order history ID, the cursor to resume the stream from
+	OrderID    uint64
+	CustomerID *uint64
+	Status     valueobject.OrderStatus
+	CreatedAt  time.Time
+}
+
+func NewOrderStatusEvent(history *OrderHistory, customerID *uint64) *OrderStatusEvent {
+	return &OrderStatusEvent{
+		ID:         history.ID,
+		OrderID:    history.OrderID,
+		CustomerID: customerID,
+		Status:     history.Status,
+		CreatedAt:  history.CreatedAt,
+	}
+}
+
+// OrderStatusEventFilter selects the events a subscriber receives, an empty field matches every event
+type OrderStatusEventFilter struct {
+	Status     []valueobject.OrderStatus
+	CustomerID uint64
+}
+
+// Matches returns true if the event passes the filter
+func (f OrderStatusEventFilter) Matches(event *OrderStatusEvent) bool {
+	if len(f.Status) > 0 && !slices.Contains(f.Status, event.Status) {
+		return false
+	}
+
+	if f.CustomerID != 0 && (event.CustomerID == nil || *event.CustomerID != f.CustomerID) {
+		return false
+	}
+
+	return true
+}
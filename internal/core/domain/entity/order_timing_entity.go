@@ -0,0 +1,71 @@
+package entity
+
+import (
+	"time"
+
+	valueobject "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/value_object"
+)
+
+// OrderTiming is the time an order spent in each status after the payment, computed from its histories
+type OrderTiming struct {
+	QueueWait    time.Duration // RECEIVED until PREPARING
+	PrepTime     time.Duration // PREPARING until READY
+	TimeToPickup time.Duration // READY until COMPLETED
+	Target       time.Duration // time the kitchen has to get the order READY, 0 when it has no target
+	Late         bool          // still in the kitchen after the target
+}
+
+// NewOrderTiming computes the timing of the order from its histories. The status the order is in counts until now,
+// a finished order stops counting at its last change.
+func NewOrderTiming(order *Order, histories []*OrderHistory, target time.Duration, now time.Time) *OrderTiming {
+	reached := make(map[valueobject.OrderStatus]time.Time)
+	var last time.Time
+	for _, history := range histories {
+		// an order moved back to a status keeps the first time it got there
+		if _, ok := reached[history.Status]; !ok {
+			reached[history.Status] = history.CreatedAt
+		}
+		if history.CreatedAt.After(last) {
+			last = history.CreatedAt
+		}
+	}
+
+	end := now
+	if order.Status == valueobject.COMPLETED || order.Status == valueobject.CANCELLED || order.Status == valueobject.ABANDONED {
+		end = last
+	}
+
+	timing := &OrderTiming{
+		QueueWait:    statusDuration(reached, valueobject.RECEIVED, valueobject.PREPARING, end),
+		PrepTime:     statusDuration(reached, valueobject.PREPARING, valueobject.READY, end),
+		TimeToPickup: statusDuration(reached, valueobject.READY, valueobject.COMPLETED, end),
+		Target:       target,
+	}
+
+	inKitchen := order.Status == valueobject.RECEIVED || order.Status == valueobject.PREPARING
+	timing.Late = inKitchen && target > 0 && timing.KitchenTime() > target
+
+	return timing
+}
+
+// KitchenTime is the time from the payment until the order was READY, or until now while it is in the kitchen
+func (t *OrderTiming) KitchenTime() time.Duration {
+	return t.QueueWait + t.PrepTime
+}
+
+// statusDuration returns the time from reaching the status until the next one, or until end if not reached yet
+func statusDuration(reached map[valueobject.OrderStatus]time.Time, status, next valueobject.OrderStatus, end time.Time) time.Duration {
+	start, ok := reached[status]
+	if !ok {
+		return 0
+	}
+
+	if stop, ok := reached[next]; ok {
+		end = stop
+	}
+
+	if end.Before(start) {
+		return 0
+	}
+	return end.Sub(start)
+}
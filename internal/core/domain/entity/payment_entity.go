@@ -1,6 +1,7 @@
 package entity
 
 import (
+	"fmt"
 	"time"
 
 	valueobject "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/value_object"
@@ -9,33 +10,71 @@ import (
 type Payment struct {
 	ID                uint64
 	Status            valueobject.PaymentStatus
+	Provider          valueobject.PaymentProvider
 	ExternalPaymentID string
 	QrData            string
 	OrderID           uint64
+	Amount            valueobject.Money
+	RefundedAmount    valueobject.Money
+	ExpiresAt         *time.Time
 	CreatedAt         time.Time
 	UpdatedAt         time.Time
 }
 
+// RefundableAmount returns the paid amount not refunded yet
+func (p *Payment) RefundableAmount() valueobject.Money {
+	if !p.Status.IsRefundable() {
+		return valueobject.Money{}
+	}
+	return p.Amount.Sub(p.RefundedAmount)
+}
+
+// RefundIdempotencyKey identifies the refund of amount from the payment as refunded so far, so a retry of the same
+// refund is recognized by the payment provider and the money is given back once
+func (p *Payment) RefundIdempotencyKey(amount valueobject.Money) string {
+	return fmt.Sprintf("refund-%d-%s-%s", p.ID, p.RefundedAmount, amount)
+}
+
+// IsExpired returns true if the QR code of the payment can no longer be paid
+func (p *Payment) IsExpired(now time.Time) bool {
+	return p.ExpiresAt != nil && !now.Before(*p.ExpiresAt)
+}
+
 type CreatePaymentExternalInput struct {
+	Provider          valueobject.PaymentProvider // the default provider when not set
 	ExternalReference string
-	TotalAmount       float32
+	TotalAmount       valueobject.Money
 	Items             []PaymentExternalItemsInput
 	Title             string
 	Description       string
 	NotificationUrl   string
+	ExpirationDate    *time.Time
 }
 
 type PaymentExternalItemsInput struct {
 	Category    string
 	Title       string
 	Description string
-	UnitPrice   float32
+	UnitPrice   valueobject.Money
 	Quantity    uint64
 	UnitMeasure string
-	TotalAmount float32
+	TotalAmount valueobject.Money
 }
 
 type CreatePaymentExternalOutput struct {
+	Provider       valueobject.PaymentProvider
 	InStoreOrderID string
 	QrData         string
 }
+
+type RefundPaymentExternalInput struct {
+	Provider          valueobject.PaymentProvider
+	ExternalPaymentID string
+	ExternalReference string
+	Amount            valueobject.Money
+	IdempotencyKey    string
+}
+
+type RefundPaymentExternalOutput struct {
+	ExternalRefundID string
+}
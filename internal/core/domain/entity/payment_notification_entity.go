@@ -0,0 +1,23 @@
+package entity
+
+import (
+	"time"
+
+	valueobject "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/value_object"
+)
+
+// PaymentNotification is a notification received from the payment provider, kept to process each event once
+type PaymentNotification struct {
+	ID          uint64
+	EventID     string
+	Resource    string
+	Topic       string
+	Status      valueobject.PaymentStatus
+	ProcessedAt *time.Time
+	CreatedAt   time.Time
+}
+
+// IsProcessed returns true if the notification was already applied to its payment
+func (n *PaymentNotification) IsProcessed() bool {
+	return n.ProcessedAt != nil
+}
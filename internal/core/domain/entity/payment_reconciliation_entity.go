@@ -0,0 +1,42 @@
+package entity
+
+import (
+	"time"
+
+	valueobject "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/value_object"
+)
+
+// PaymentReconciliationReport lists what a reconciliation found for each payment checked against its provider
+type PaymentReconciliationReport struct {
+	StartedAt  time.Time
+	FinishedAt time.Time
+	Entries    []PaymentReconciliationEntry
+}
+
+// PaymentReconciliationEntry is a payment checked against its provider, or a notification of a payment unknown locally
+type PaymentReconciliationEntry struct {
+	PaymentID      uint64 // zero when there is no local payment
+	OrderID        uint64
+	Resource       string
+	Provider       valueobject.PaymentProvider
+	LocalStatus    valueobject.PaymentStatus
+	ProviderStatus valueobject.PaymentStatus
+	Result         valueobject.ReconciliationResult
+	Reason         string
+}
+
+// Add appends an entry to the report
+func (r *PaymentReconciliationReport) Add(entry PaymentReconciliationEntry) {
+	r.Entries = append(r.Entries, entry)
+}
+
+// Count returns how many entries of the report have the given result
+func (r *PaymentReconciliationReport) Count(result valueobject.ReconciliationResult) int {
+	var count int
+	for _, entry := range r.Entries {
+		if entry.Result == result {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,20 @@
+package entity
+
+import (
+	"time"
+
+	valueobject "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/value_object"
+)
+
+// PaymentRefund is money given back of a paid payment, authorized by a staff or started by an automatic process
+type PaymentRefund struct {
+	ID               uint64
+	PaymentID        uint64
+	Status           valueobject.RefundStatus
+	IdempotencyKey   string // sent to the payment provider, the same on every retry of the refund
+	ExternalRefundID string
+	Amount           valueobject.Money
+	Reason           string
+	StaffID          *uint64 // nil when started by an automatic process (ex: order expiry)
+	CreatedAt        time.Time
+}
@@ -0,0 +1,56 @@
+package entity
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	valueobject "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/value_object"
+)
+
+// Principal is the authenticated caller of a request
+type Principal struct {
+	SubjectType valueobject.SubjectType
+	ID          uint64
+	Role        valueobject.StaffRole
+
+	// Secret of the order placed by a guest, sent by the guest to reach it
+	GuestToken string
+
+	// Access token the principal was authenticated with
+	TokenID        string
+	TokenExpiresAt time.Time
+}
+
+type principalKey struct{}
+
+// IsCustomer returns true if the principal is a customer
+func (p *Principal) IsCustomer() bool {
+	return p.SubjectType == valueobject.CUSTOMER
+}
+
+// IsGuest returns true if the principal is an unidentified caller
+func (p *Principal) IsGuest() bool {
+	return p.SubjectType == valueobject.GUEST
+}
+
+// IsStaff returns true if the principal is a staff member
+func (p *Principal) IsStaff() bool {
+	return p.SubjectType == valueobject.STAFF
+}
+
+// HasAnyRole returns true if the principal is a staff member with one of the given roles
+func (p *Principal) HasAnyRole(roles ...valueobject.StaffRole) bool {
+	return p.IsStaff() && slices.Contains(roles, p.Role)
+}
+
+// ContextWithPrincipal returns a copy of ctx carrying the principal
+func ContextWithPrincipal(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// PrincipalFromContext returns the principal stored in ctx, if any
+func PrincipalFromContext(ctx context.Context) (*Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(*Principal)
+	return principal, ok && principal != nil
+}
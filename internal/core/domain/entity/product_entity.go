@@ -2,22 +2,27 @@ package entity
 
 import (
 	"time"
+
+	valueobject "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/value_object"
 )
 
 type Product struct {
 	ID          uint64
 	Name        string
 	Description string
-	Price       float64
+	Price       valueobject.Money
 	CategoryID  uint64
+	PrepMinutes uint32 // estimated time to prepare the product, 0 when unknown
+	Version     uint64 // incremented on every update, for optimistic concurrency control
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
 }
 
-func (p *Product) Update(name string, description string, price float64, categoryID uint64) {
+func (p *Product) Update(name string, description string, price valueobject.Money, categoryID uint64, prepMinutes uint32) {
 	p.Name = name
 	p.Description = description
 	p.Price = price
 	p.CategoryID = categoryID
+	p.PrepMinutes = prepMinutes
 	p.UpdatedAt = time.Now()
 }
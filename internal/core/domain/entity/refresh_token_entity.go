@@ -0,0 +1,52 @@
+package entity
+
+import (
+	"time"
+
+	valueobject "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/value_object"
+)
+
+// RefreshToken is a single use token, every rotation creates a new token in the same family
+type RefreshToken struct {
+	ID                   uint64
+	FamilyID             string
+	TokenHash            string
+	SubjectType          valueobject.SubjectType
+	SubjectID            uint64
+	Role                 valueobject.StaffRole
+	AccessTokenID        string
+	AccessTokenExpiresAt time.Time
+	ExpiresAt            time.Time
+	UsedAt               *time.Time
+	RevokedAt            *time.Time
+	CreatedAt            time.Time
+}
+
+// IsExpired returns true if the token can no longer be used
+func (t *RefreshToken) IsExpired(now time.Time) bool {
+	return !now.Before(t.ExpiresAt)
+}
+
+// IsUsed returns true if the token was already exchanged for a new one
+func (t *RefreshToken) IsUsed() bool {
+	return t.UsedAt != nil
+}
+
+// IsRevoked returns true if the token family was revoked
+func (t *RefreshToken) IsRevoked() bool {
+	return t.RevokedAt != nil
+}
+
+// BelongsTo returns true if the token was issued to the principal
+func (t *RefreshToken) BelongsTo(principal *Principal) bool {
+	return t.SubjectType == principal.SubjectType && t.SubjectID == principal.ID
+}
+
+// Principal returns the principal the token was issued to
+func (t *RefreshToken) Principal() *Principal {
+	return &Principal{
+		SubjectType: t.SubjectType,
+		ID:          t.SubjectID,
+		Role:        t.Role,
+	}
+}
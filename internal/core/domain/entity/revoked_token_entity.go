@@ -0,0 +1,11 @@
+package entity
+
+import "time"
+
+// RevokedToken is an access token that must be refused before its expiration
+type RevokedToken struct {
+	ID        uint64
+	JTI       string
+	ExpiresAt time.Time
+	CreatedAt time.Time
+}
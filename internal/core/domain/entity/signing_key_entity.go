@@ -0,0 +1,24 @@
+package entity
+
+import (
+	"crypto"
+	"time"
+)
+
+// SigningKey is an asymmetric key access tokens are signed with, identified by the "kid" header
+type SigningKey struct {
+	ID         string
+	Algorithm  string // RS256 or ES256
+	PrivateKey crypto.Signer
+	RetiredAt  *time.Time
+}
+
+// PublicKey returns the public half of the key, used to verify the token signatures
+func (k *SigningKey) PublicKey() crypto.PublicKey {
+	return k.PrivateKey.Public()
+}
+
+// IsRetired returns true if the key no longer signs new tokens
+func (k *SigningKey) IsRetired() bool {
+	return k.RetiredAt != nil
+}
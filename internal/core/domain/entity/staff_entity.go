@@ -7,11 +7,12 @@ import (
 )
 
 type Staff struct {
-	ID        uint64
-	Name      string
-	Role      valueobject.StaffRole
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	ID           uint64
+	Name         string
+	Role         valueobject.StaffRole
+	PasswordHash string
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
 }
 
 func NewStaff(name string, role valueobject.StaffRole) *Staff {
@@ -29,3 +30,8 @@ func (p *Staff) Update(name string, role valueobject.StaffRole) {
 	p.Role = role
 	p.UpdatedAt = time.Now()
 }
+
+func (p *Staff) UpdatePassword(passwordHash string) {
+	p.PasswordHash = passwordHash
+	p.UpdatedAt = time.Now()
+}
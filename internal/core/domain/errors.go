@@ -1,24 +1,38 @@
 package domain
 
+import "errors"
+
 var (
 	ErrConflict           = "data conflicts with existing data"
 	ErrNotFound           = "data not found"
 	ErrUnauthorized       = "unauthorized"
+	ErrForbidden          = "forbidden"
 	ErrInvalidParam       = "invalid parameter"
 	ErrInvalidQueryParams = "invalid query parameters"
 	ErrInvalidBody        = "invalid body"
+	ErrVersionMismatch    = "data was changed since the version given in If-Match"
+	ErrConcurrentUpdate   = "data was changed by another request, read it again and retry"
 
-	ErrInvalidToken      = "access token is invalid"
-	ErrMissingAuthHeader = "authorization header is required"
-	ErrInvalidAuthHeader = "invalid authorization header format"
+	ErrInvalidToken        = "access token is invalid"
+	ErrMissingAuthHeader   = "authorization header is required"
+	ErrInvalidAuthHeader   = "invalid authorization header format"
+	ErrPermissionDenied    = "permission denied for this resource"
+	ErrInvalidCredentials  = "invalid credentials"
+	ErrInvalidRefreshToken = "refresh token is invalid or expired"
 
 	ErrOrderInvalidStatusTransition = "invalid status transition"
+	ErrOrderTransitionGuardFailed   = "order does not meet the conditions of the status transition"
 	ErrOrderWithoutProducts         = "order without products"
 	ErrProductIsMandatory           = "product is mandatory"
 	ErrStaffIdIsMandatory           = "staff is mandatory"
 	ErrOrderIsMandatory             = "order is mandatory"
 	ErrOrderIsNotOpen               = "order is not on status open"
+	ErrOrderAlreadyHasCustomer      = "order already has a customer"
+	ErrOrderCustomerCannotBeChanged = "customer can only be attached to open or pending orders"
+	ErrCustomerIsMandatory          = "customer is mandatory"
 	ErrRoleInvalid                  = "invalid role"
+	ErrOrderIsNotInKitchen          = "order is not being prepared by the kitchen"
+	ErrKitchenTicketAlreadyBumped   = "kitchen ticket already bumped"
 
 	ErrPageMustBeGreaterThanZero = "page must be greater than zero"
 	ErrLimitMustBeBetween1And100 = "limit must be between 1 and 100"
@@ -29,8 +43,22 @@ var (
 	ErrInvalidInput    = "invalid input"
 
 	ErrFailedToCreatePaymentExternal = "failed to create payment external"
+	ErrPaymentStatusNotNotified      = "payment status was not notified"
+	ErrInvalidWebhookSignature       = "invalid webhook signature"
+	ErrFailedToRefundPaymentExternal = "failed to refund payment external"
+	ErrPaymentNotRefundable          = "order has no paid payment to refund"
+	ErrInvalidRefundAmount           = "refund amount must be greater than zero and up to the refundable amount"
+	ErrRefundInProgress              = "payment was refunded meanwhile or has another refund in progress, read it again and retry"
+	ErrCashPaymentNotFound           = "order has no payment awaiting confirmation at the counter"
+	ErrCashAmountNotEnough           = "amount received is less than the payment amount"
+	ErrCashChangeMismatch            = "change given does not match the amount received"
+	ErrPaymentNotFoundExternal       = "payment not found on the payment provider"
 )
 
+// ErrPaymentStatusUnavailable is returned by the payment providers that cannot be asked for the status of a payment,
+// it is only known once notified (ex: cash, confirmed by the attendant)
+var ErrPaymentStatusUnavailable = errors.New(ErrPaymentStatusNotNotified)
+
 type ValidationError struct {
 	Message string
 	Err     error
@@ -79,6 +107,30 @@ func (e *UnauthorizedError) Error() string {
 	return e.Message
 }
 
+type ForbiddenError struct {
+	Message string
+}
+
+func (e *ForbiddenError) Error() string {
+	return e.Message
+}
+
+type ConflictError struct {
+	Message string
+}
+
+func (e *ConflictError) Error() string {
+	return e.Message
+}
+
+type PreconditionFailedError struct {
+	Message string
+}
+
+func (e *PreconditionFailedError) Error() string {
+	return e.Message
+}
+
 func NewValidationError(err error) *ValidationError {
 	return &ValidationError{
 		Message: ErrValidationError,
@@ -110,3 +162,21 @@ func NewUnauthorizedError(message string) *UnauthorizedError {
 		Message: message,
 	}
 }
+
+func NewForbiddenError(message string) *ForbiddenError {
+	return &ForbiddenError{
+		Message: message,
+	}
+}
+
+func NewConflictError(message string) *ConflictError {
+	return &ConflictError{
+		Message: message,
+	}
+}
+
+func NewPreconditionFailedError(message string) *PreconditionFailedError {
+	return &PreconditionFailedError{
+		Message: message,
+	}
+}
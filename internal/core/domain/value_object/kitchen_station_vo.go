@@ -0,0 +1,40 @@
+package valueobject
+
+import "strings"
+
+// KitchenStation is the kitchen station that prepares the products of a category
+type KitchenStation string
+
+const (
+	GRILL             KitchenStation = "GRILL"
+	DRINKS            KitchenStation = "DRINKS"
+	DESSERTS          KitchenStation = "DESSERTS"
+	UNDEFINED_STATION KitchenStation = ""
+
+	// DefaultKitchenStation prepares the products of the categories without a station
+	DefaultKitchenStation = GRILL
+)
+
+// KitchenStations are the stations in the order their tickets are listed
+var KitchenStations = []KitchenStation{GRILL, DRINKS, DESSERTS}
+
+func IsValidKitchenStation(station string) bool {
+	return ToKitchenStation(station) != UNDEFINED_STATION
+}
+
+func (s KitchenStation) String() string {
+	return strings.ToUpper(string(s))
+}
+
+func ToKitchenStation(station string) KitchenStation {
+	switch strings.ToUpper(station) {
+	case "GRILL":
+		return GRILL
+	case "DRINKS":
+		return DRINKS
+	case "DESSERTS":
+		return DESSERTS
+	default:
+		return UNDEFINED_STATION
+	}
+}
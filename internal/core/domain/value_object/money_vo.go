@@ -0,0 +1,183 @@
+package valueobject
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Money is an exact amount of money in the currency of the store (BRL), in cents, so sums and products carry no
+// floating point error. The zero value is zero.
+type Money struct {
+	cents int64
+}
+
+// NewMoney returns the amount in cents
+func NewMoney(cents int64) Money {
+	return Money{cents: cents}
+}
+
+// MoneyFromFloat rounds the amount to the nearest cent, to be used only at the boundaries that receive floats
+func MoneyFromFloat(amount float64) Money {
+	return NewMoney(int64(math.Round(amount * 100)))
+}
+
+// ParseMoney parses a decimal amount with up to 2 decimal places (ex: "10", "10.9", "-10.90"), without going
+// through a float
+func ParseMoney(amount string) (Money, error) {
+	amount = strings.TrimSpace(amount)
+	negative := strings.HasPrefix(amount, "-")
+	digits := strings.TrimPrefix(amount, "-")
+
+	units, fraction, hasFraction := strings.Cut(digits, ".")
+	// Trailing zeros are allowed beyond the cents, as returned by DECIMAL columns with a larger scale
+	if len(fraction) > 2 && strings.Trim(fraction[2:], "0") == "" {
+		fraction = fraction[:2]
+	}
+	if units == "" || (hasFraction && fraction == "") || len(fraction) > 2 {
+		return Money{}, fmt.Errorf("invalid money amount %q", amount)
+	}
+	fraction += strings.Repeat("0", 2-len(fraction))
+
+	cents, err := strconv.ParseInt(units+fraction, 10, 64)
+	if err != nil || strings.ContainsAny(units+fraction, "+-") {
+		return Money{}, fmt.Errorf("invalid money amount %q", amount)
+	}
+
+	if negative {
+		cents = -cents
+	}
+	return NewMoney(cents), nil
+}
+
+// Cents returns the amount in cents
+func (m Money) Cents() int64 {
+	return m.cents
+}
+
+// Float64 returns the amount in units, to be used only by the APIs that expect a float
+func (m Money) Float64() float64 {
+	return float64(m.cents) / 100
+}
+
+// Add returns the sum of the amounts
+func (m Money) Add(other Money) Money {
+	return Money{cents: m.cents + other.cents}
+}
+
+// Sub returns the difference of the amounts
+func (m Money) Sub(other Money) Money {
+	return Money{cents: m.cents - other.cents}
+}
+
+// Multiply returns the amount times the quantity
+func (m Money) Multiply(quantity int64) Money {
+	return Money{cents: m.cents * quantity}
+}
+
+// Cmp returns -1, 0 or +1 when the amount is less than, equal to or greater than the other one
+func (m Money) Cmp(other Money) int {
+	switch {
+	case m.cents < other.cents:
+		return -1
+	case m.cents > other.cents:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Equal returns true if both amounts are the same
+func (m Money) Equal(other Money) bool {
+	return m == other
+}
+
+// LessThan returns true if the amount is less than the other one
+func (m Money) LessThan(other Money) bool {
+	return m.Cmp(other) < 0
+}
+
+// GreaterThan returns true if the amount is greater than the other one
+func (m Money) GreaterThan(other Money) bool {
+	return m.Cmp(other) > 0
+}
+
+func (m Money) IsZero() bool {
+	return m.cents == 0
+}
+
+func (m Money) IsPositive() bool {
+	return m.cents > 0
+}
+
+func (m Money) IsNegative() bool {
+	return m.cents < 0
+}
+
+// String returns the amount with 2 decimal places (ex: "10.90")
+func (m Money) String() string {
+	sign := ""
+	cents := m.cents
+	if cents < 0 {
+		sign = "-"
+		cents = -cents
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, cents/100, cents%100)
+}
+
+// MarshalJSON writes the amount as a JSON number with 2 decimal places
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalJSON reads the amount from a JSON number, or a string holding one
+func (m *Money) UnmarshalJSON(data []byte) error {
+	text := strings.Trim(string(data), `"`)
+	if text == "null" || text == "" {
+		*m = Money{}
+		return nil
+	}
+	return m.UnmarshalText([]byte(text))
+}
+
+// MarshalText writes the amount with 2 decimal places, it is used by the XML responses
+func (m Money) MarshalText() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+func (m *Money) UnmarshalText(text []byte) error {
+	money, err := ParseMoney(string(text))
+	if err != nil {
+		return err
+	}
+	*m = money
+	return nil
+}
+
+// Value stores the amount in a DECIMAL column
+func (m Money) Value() (driver.Value, error) {
+	return m.String(), nil
+}
+
+// Scan reads the amount from a DECIMAL column
+func (m *Money) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*m = Money{}
+		return nil
+	case string:
+		return m.UnmarshalText([]byte(v))
+	case []byte:
+		return m.UnmarshalText(v)
+	case int64:
+		*m = NewMoney(v * 100)
+		return nil
+	case float64:
+		*m = MoneyFromFloat(v)
+		return nil
+	default:
+		return fmt.Errorf("money: unsupported type %T", src)
+	}
+}
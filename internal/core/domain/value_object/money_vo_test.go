@@ -0,0 +1,202 @@
+package valueobject_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	valueobject "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/value_object"
+)
+
+func TestMoneyFromFloat(t *testing.T) {
+	tests := []struct {
+		name   string
+		amount float64
+		want   int64
+	}{
+		{name: "should keep an exact amount", amount: 10.90, want: 1090},
+		{name: "should round half a cent up", amount: 0.125, want: 13},
+		{name: "should round below half a cent down", amount: 0.124, want: 12},
+		{name: "should round a negative half a cent away from zero", amount: -0.125, want: -13},
+		{name: "should not carry the floating point error of a sum", amount: 0.1 + 0.2, want: 30},
+		{name: "should keep a negative amount", amount: -10.90, want: -1090},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, valueobject.MoneyFromFloat(tt.amount).Cents())
+		})
+	}
+}
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		name    string
+		amount  string
+		want    int64
+		wantErr bool
+	}{
+		{name: "should parse units", amount: "10", want: 1000},
+		{name: "should parse one decimal place", amount: "10.9", want: 1090},
+		{name: "should parse two decimal places", amount: "10.90", want: 1090},
+		{name: "should parse a negative amount", amount: "-10.90", want: -1090},
+		{name: "should parse a negative amount below one unit", amount: "-0.05", want: -5},
+		{name: "should ignore surrounding spaces", amount: " 1.50 ", want: 150},
+		{name: "should accept trailing zeros beyond the cents", amount: "1.5000", want: 150},
+		{name: "should refuse fractions of a cent", amount: "1.005", wantErr: true},
+		{name: "should refuse an empty amount", amount: "", wantErr: true},
+		{name: "should refuse a lone sign", amount: "-", wantErr: true},
+		{name: "should refuse a double sign", amount: "--1", wantErr: true},
+		{name: "should refuse a plus sign", amount: "+1", wantErr: true},
+		{name: "should refuse a sign in the fraction", amount: "1.-5", wantErr: true},
+		{name: "should refuse a missing fraction", amount: "1.", wantErr: true},
+		{name: "should refuse missing units", amount: ".5", wantErr: true},
+		{name: "should refuse an exponent", amount: "1e2", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := valueobject.ParseMoney(tt.amount)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got.Cents())
+		})
+	}
+}
+
+func TestMoney_String(t *testing.T) {
+	tests := []struct {
+		name  string
+		money valueobject.Money
+		want  string
+	}{
+		{name: "should write zero", money: valueobject.Money{}, want: "0.00"},
+		{name: "should pad the cents", money: valueobject.NewMoney(105), want: "1.05"},
+		{name: "should write a negative amount", money: valueobject.NewMoney(-1090), want: "-10.90"},
+		{name: "should write a negative amount below one unit", money: valueobject.NewMoney(-5), want: "-0.05"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.money.String())
+
+			parsed, err := valueobject.ParseMoney(tt.money.String())
+			assert.NoError(t, err)
+			assert.Equal(t, tt.money, parsed)
+		})
+	}
+}
+
+func TestMoney_Arithmetic(t *testing.T) {
+	tests := []struct {
+		name string
+		got  valueobject.Money
+		want valueobject.Money
+	}{
+		{name: "should add", got: valueobject.NewMoney(1090).Add(valueobject.NewMoney(10)), want: valueobject.NewMoney(1100)},
+		{name: "should subtract below zero", got: valueobject.NewMoney(10).Sub(valueobject.NewMoney(1090)), want: valueobject.NewMoney(-1080)},
+		{name: "should multiply", got: valueobject.NewMoney(1999).Multiply(999), want: valueobject.NewMoney(1997001)},
+		{name: "should multiply a negative amount", got: valueobject.NewMoney(-150).Multiply(3), want: valueobject.NewMoney(-450)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.True(t, tt.want.Equal(tt.got), "want %s, got %s", tt.want, tt.got)
+		})
+	}
+}
+
+func TestMoney_Compare(t *testing.T) {
+	tests := []struct {
+		name  string
+		money valueobject.Money
+		other valueobject.Money
+		want  int
+	}{
+		{name: "should be less", money: valueobject.NewMoney(-1), other: valueobject.NewMoney(0), want: -1},
+		{name: "should be equal", money: valueobject.NewMoney(100), other: valueobject.NewMoney(100), want: 0},
+		{name: "should be greater", money: valueobject.NewMoney(100), other: valueobject.NewMoney(-100), want: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.money.Cmp(tt.other))
+			assert.Equal(t, tt.want < 0, tt.money.LessThan(tt.other))
+			assert.Equal(t, tt.want > 0, tt.money.GreaterThan(tt.other))
+		})
+	}
+}
+
+func TestMoney_ValueAndScan(t *testing.T) {
+	tests := []struct {
+		name  string
+		money valueobject.Money
+	}{
+		{name: "should store an amount", money: valueobject.NewMoney(1090)},
+		{name: "should store a negative amount", money: valueobject.NewMoney(-5)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, err := tt.money.Value()
+			assert.NoError(t, err)
+
+			var scanned valueobject.Money
+			assert.NoError(t, scanned.Scan(value))
+			assert.Equal(t, tt.money, scanned)
+		})
+	}
+}
+
+func TestMoney_Scan(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     any
+		want    valueobject.Money
+		wantErr bool
+	}{
+		{name: "should read a DECIMAL text", src: "10.90", want: valueobject.NewMoney(1090)},
+		{name: "should read a DECIMAL with a larger scale", src: []byte("10.9000"), want: valueobject.NewMoney(1090)},
+		{name: "should read an integer", src: int64(-3), want: valueobject.NewMoney(-300)},
+		{name: "should round a float", src: 0.125, want: valueobject.NewMoney(13)},
+		{name: "should read null as zero", src: nil, want: valueobject.Money{}},
+		{name: "should refuse an unsupported type", src: true, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got valueobject.Money
+			err := got.Scan(tt.src)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestMoney_JSON(t *testing.T) {
+	data, err := json.Marshal(struct {
+		Amount valueobject.Money `json:"amount"`
+	}{valueobject.NewMoney(-1090)})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"amount": -10.90}`, string(data))
+
+	var got struct {
+		Amount valueobject.Money `json:"amount"`
+	}
+	for _, input := range []string{`{"amount": -10.9}`, `{"amount": "-10.90"}`} {
+		assert.NoError(t, json.Unmarshal([]byte(input), &got))
+		assert.Equal(t, valueobject.NewMoney(-1090), got.Amount)
+	}
+}
@@ -0,0 +1,35 @@
+package valueobject
+
+import "strings"
+
+// OrderGuard is a condition the order must meet to make a status transition
+type OrderGuard string
+
+const (
+	HAS_PRODUCTS_GUARD OrderGuard = "HAS_PRODUCTS" // the order has at least one product
+	HAS_CUSTOMER_GUARD OrderGuard = "HAS_CUSTOMER" // the order is not a guest order
+	DRINKS_ONLY_GUARD  OrderGuard = "DRINKS_ONLY"  // every product of the order is prepared by the DRINKS station
+	UNDEFINED_GUARD    OrderGuard = ""
+)
+
+func IsValidOrderGuard(guard string) bool {
+	return ToOrderGuard(guard) != UNDEFINED_GUARD
+}
+
+func (o OrderGuard) String() string {
+	return strings.ToUpper(string(o))
+}
+
+// ToOrderGuard converts a string to an OrderGuard
+func ToOrderGuard(guard string) OrderGuard {
+	switch strings.ToUpper(guard) {
+	case "HAS_PRODUCTS":
+		return HAS_PRODUCTS_GUARD
+	case "HAS_CUSTOMER":
+		return HAS_CUSTOMER_GUARD
+	case "DRINKS_ONLY":
+		return DRINKS_ONLY_GUARD
+	default:
+		return UNDEFINED_GUARD
+	}
+}
@@ -1,9 +1,6 @@
 package valueobject
 
-import (
-	"slices"
-	"strings"
-)
+import "strings"
 
 type OrderStatus string
 
@@ -15,6 +12,7 @@ const (
 	PREPARING  OrderStatus = "PREPARING"
 	READY      OrderStatus = "READY"
 	COMPLETED  OrderStatus = "COMPLETED"
+	ABANDONED  OrderStatus = "ABANDONED"
 	UNDEFINDED OrderStatus = "UNDEFINDED"
 )
 
@@ -40,6 +38,8 @@ func (o OrderStatus) String() string {
 		return "READY"
 	case COMPLETED:
 		return "COMPLETED"
+	case ABANDONED:
+		return "ABANDONED"
 	default:
 		return "UNDEFINDED"
 	}
@@ -62,36 +62,9 @@ func ToOrderStatus(status string) (OrderStatus, bool) {
 		return READY, true
 	case "COMPLETED":
 		return COMPLETED, true
+	case "ABANDONED":
+		return ABANDONED, true
 	default:
 		return UNDEFINDED, false
 	}
 }
-
-// OrderStatusTransitions defines the allowed transitions between OrderStatuses
-var OrderStatusTransitions = map[OrderStatus][]OrderStatus{
-	OPEN:      {CANCELLED, PENDING},
-	CANCELLED: {},
-	PENDING:   {OPEN, RECEIVED, CANCELLED},
-	RECEIVED:  {PREPARING, CANCELLED},
-	PREPARING: {READY, CANCELLED},
-	READY:     {COMPLETED},
-	COMPLETED: {},
-}
-
-// StatusCanTransitionTo returns true if the transition from oldStatus to newStatus is allowed
-func StatusCanTransitionTo(oldStatus, newStatus OrderStatus) bool {
-	allowedStatuses := OrderStatusTransitions[oldStatus]
-	return slices.Contains(allowedStatuses, newStatus)
-}
-
-// StatusTransitionNeedsStaffID returns true if the new status requires a staff ID
-func StatusTransitionNeedsStaffID(newStatus OrderStatus) bool {
-	switch newStatus {
-	case OPEN, CANCELLED, PENDING, RECEIVED:
-		return false
-	case PREPARING, READY, COMPLETED:
-		return true
-	default:
-		return false
-	}
-}
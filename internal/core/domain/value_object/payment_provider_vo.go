@@ -0,0 +1,44 @@
+package valueobject
+
+import "strings"
+
+// PaymentProvider is the name of the provider that handles a payment
+type PaymentProvider string
+
+const (
+	MERCADO_PAGO_PROVIDER PaymentProvider = "mercadopago"
+	FAKE_PROVIDER         PaymentProvider = "fake"
+	CASH_PROVIDER         PaymentProvider = "cash"
+	PIX_STATIC_PROVIDER   PaymentProvider = "pix-static"
+	UNDEFINED_PROVIDER    PaymentProvider = ""
+)
+
+func IsValidPaymentProvider(provider string) bool {
+	return ToPaymentProvider(provider) != UNDEFINED_PROVIDER
+}
+
+// IsConfirmedAtCounter returns true if the payments of the provider are confirmed by an attendant, as the provider
+// neither notifies them nor can be asked for their status
+func (o PaymentProvider) IsConfirmedAtCounter() bool {
+	return o == CASH_PROVIDER || o == PIX_STATIC_PROVIDER
+}
+
+func (o PaymentProvider) String() string {
+	return strings.ToLower(string(o))
+}
+
+// ToPaymentProvider converts a string to a PaymentProvider
+func ToPaymentProvider(provider string) PaymentProvider {
+	switch strings.ToLower(provider) {
+	case "mercadopago":
+		return MERCADO_PAGO_PROVIDER
+	case "fake":
+		return FAKE_PROVIDER
+	case "cash":
+		return CASH_PROVIDER
+	case "pix-static":
+		return PIX_STATIC_PROVIDER
+	default:
+		return UNDEFINED_PROVIDER
+	}
+}
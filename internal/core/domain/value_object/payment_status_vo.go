@@ -5,17 +5,50 @@ import "strings"
 type PaymentStatus string
 
 const (
-	PROCESSING   PaymentStatus = "PROCESSING"
-	CONFIRMED    PaymentStatus = "CONFIRMED"
-	FAILED       PaymentStatus = "FAILED"
-	ABORTED      PaymentStatus = "ABORTED"
-	UNDEFINDED_P PaymentStatus = ""
+	PROCESSING         PaymentStatus = "PROCESSING"
+	CONFIRMED          PaymentStatus = "CONFIRMED"
+	FAILED             PaymentStatus = "FAILED"
+	ABORTED            PaymentStatus = "ABORTED"
+	PARTIALLY_REFUNDED PaymentStatus = "PARTIALLY_REFUNDED"
+	REFUNDED           PaymentStatus = "REFUNDED"
+	UNDEFINDED_P       PaymentStatus = ""
 )
 
 func IsValidPaymentStatus(status string) bool {
 	return ToPaymentStatus(status) != UNDEFINDED_P
 }
 
+// IsSettled returns true if the payment reached a final outcome
+func (o PaymentStatus) IsSettled() bool {
+	return o == CONFIRMED || o == FAILED || o == ABORTED
+}
+
+// IsUnsuccessful returns true if the payment failed or was aborted
+func (o PaymentStatus) IsUnsuccessful() bool {
+	return o == FAILED || o == ABORTED
+}
+
+// IsRefundable returns true if the payment was paid and not fully refunded yet
+func (o PaymentStatus) IsRefundable() bool {
+	return o == CONFIRMED || o == PARTIALLY_REFUNDED
+}
+
+// Overrides returns true if a notification of this status must be applied to a payment on the current status.
+// Notifications may arrive out of order: a confirmation is final, and wins over a failure or abort notified
+// before it, while a failure notified after a confirmation is ignored. A refund always comes after the confirmation.
+func (o PaymentStatus) Overrides(current PaymentStatus) bool {
+	return paymentStatusPrecedence[o] > paymentStatusPrecedence[current]
+}
+
+var paymentStatusPrecedence = map[PaymentStatus]int{
+	PROCESSING:         1,
+	FAILED:             2,
+	ABORTED:            2,
+	CONFIRMED:          3,
+	PARTIALLY_REFUNDED: 4,
+	REFUNDED:           5,
+}
+
 // String returns the string representation of the PaymentStatus
 func (o PaymentStatus) String() string {
 	return strings.ToUpper(string(o))
@@ -32,6 +65,10 @@ func ToPaymentStatus(status string) PaymentStatus {
 		return FAILED
 	case "ABORTED":
 		return ABORTED
+	case "PARTIALLY_REFUNDED":
+		return PARTIALLY_REFUNDED
+	case "REFUNDED":
+		return REFUNDED
 	default:
 		return UNDEFINDED_P
 	}
@@ -0,0 +1,16 @@
+package valueobject
+
+// ReconciliationResult is the outcome of the reconciliation of a payment with its provider
+type ReconciliationResult string
+
+const (
+	RECONCILIATION_MATCHED  ReconciliationResult = "MATCHED"  // the provider agrees with the local status
+	RECONCILIATION_FIXED    ReconciliationResult = "FIXED"    // the local status drifted and was fixed
+	RECONCILIATION_ORPHANED ReconciliationResult = "ORPHANED" // known by only one side, the provider or the local payments
+	RECONCILIATION_SKIPPED  ReconciliationResult = "SKIPPED"  // the provider does not report the status
+	RECONCILIATION_FAILED   ReconciliationResult = "FAILED"
+)
+
+func (r ReconciliationResult) String() string {
+	return string(r)
+}
@@ -0,0 +1,15 @@
+package valueobject
+
+// RefundStatus is the progress of a refund: recorded before the payment provider is asked to give the money back,
+// and completed once it did
+type RefundStatus string
+
+const (
+	REFUND_PENDING   RefundStatus = "PENDING"
+	REFUND_COMPLETED RefundStatus = "COMPLETED"
+)
+
+// String returns the string representation of the RefundStatus
+func (s RefundStatus) String() string {
+	return string(s)
+}
@@ -0,0 +1,36 @@
+package valueobject
+
+import "strings"
+
+// SubjectType identifies who is behind an authenticated request
+type SubjectType string
+
+const (
+	CUSTOMER    SubjectType = "CUSTOMER"
+	STAFF       SubjectType = "STAFF"
+	GUEST       SubjectType = "GUEST"
+	UNDEFINED_S SubjectType = ""
+)
+
+func IsValidSubjectType(subjectType string) bool {
+	return ToSubjectType(subjectType) != UNDEFINED_S
+}
+
+// String returns the string representation of the SubjectType
+func (s SubjectType) String() string {
+	return strings.ToUpper(string(s))
+}
+
+// ToSubjectType converts a string to a SubjectType
+func ToSubjectType(subjectType string) SubjectType {
+	switch strings.ToUpper(subjectType) {
+	case "CUSTOMER":
+		return CUSTOMER
+	case "STAFF":
+		return STAFF
+	case "GUEST":
+		return GUEST
+	default:
+		return UNDEFINED_S
+	}
+}
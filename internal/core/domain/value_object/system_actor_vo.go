@@ -0,0 +1,14 @@
+package valueobject
+
+// SystemActor is the automatic process that changed an order, recorded in its history instead of a staff
+type SystemActor string
+
+const (
+	ORDER_EXPIRY_ACTOR SystemActor = "ORDER_EXPIRY"
+	UNDEFINED_ACTOR    SystemActor = ""
+)
+
+// String returns the string representation of the SystemActor
+func (a SystemActor) String() string {
+	return string(a)
+}
@@ -3,3 +3,16 @@ package dto
 type AuthenticateInput struct {
 	CPF string
 }
+
+type AuthenticateStaffInput struct {
+	StaffID  uint64
+	Password string
+}
+
+type RefreshTokenInput struct {
+	RefreshToken string
+}
+
+type LogoutInput struct {
+	RefreshToken string
+}
@@ -1,6 +1,9 @@
 package dto
 
-import "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
+import (
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
+	valueobject "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/value_object"
+)
 
 type GetCategoryInput struct {
 	ID uint64
@@ -13,8 +16,10 @@ type ListCategoriesInput struct {
 }
 
 type UpdateCategoryInput struct {
-	ID   uint64
-	Name string
+	ID                uint64
+	Name              string
+	Station           valueobject.KitchenStation // unchanged when undefined
+	PrepTargetMinutes *uint32                    // unchanged when nil
 }
 
 type DeleteCategoryInput struct {
@@ -22,11 +27,20 @@ type DeleteCategoryInput struct {
 }
 
 type CreateCategoryInput struct {
-	Name string
+	Name              string
+	Station           valueobject.KitchenStation // default station when undefined
+	PrepTargetMinutes uint32                     // 0 uses the default target
 }
 
 func (c CreateCategoryInput) ToEntity() *entity.Category {
+	station := c.Station
+	if station == valueobject.UNDEFINED_STATION {
+		station = valueobject.DefaultKitchenStation
+	}
+
 	return &entity.Category{
-		Name: c.Name,
+		Name:              c.Name,
+		Station:           station,
+		PrepTargetMinutes: c.PrepTargetMinutes,
 	}
 }
@@ -0,0 +1,13 @@
+package dto
+
+import valueobject "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/value_object"
+
+type ListKitchenTicketsInput struct {
+	Station valueobject.KitchenStation // empty lists the tickets of every station
+}
+
+type BumpKitchenTicketInput struct {
+	OrderID uint64
+	Station valueobject.KitchenStation
+	StaffID uint64
+}
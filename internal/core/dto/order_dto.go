@@ -9,14 +9,24 @@ type CreateOrderInput struct {
 }
 
 type UpdateOrderInput struct {
+	ID            uint64
+	CustomerID    uint64
+	Status        valueobject.OrderStatus
+	StaffID       uint64
+	PaymentStatus valueobject.PaymentStatus // payment outcome that caused the status change, recorded in the history
+	Version       *uint64                   // version the change was based on (If-Match), nil to skip the check
+	SystemActor   valueobject.SystemActor   // automatic process making the change instead of a staff, if any
+}
+
+type AttachOrderCustomerInput struct {
 	ID         uint64
 	CustomerID uint64
-	Status     valueobject.OrderStatus
-	StaffID    uint64
+	GuestToken string // secret given on the creation of the guest order, required when a customer claims it
 }
 
 type GetOrderInput struct {
-	ID uint64
+	ID        uint64
+	ForUpdate bool // locks the order until the unit of work of the context commits
 }
 
 type DeleteOrderInput struct {
@@ -7,9 +7,11 @@ type CreateFirstOrderHistoryInput struct {
 }
 
 type CreateOrderHistoryInput struct {
-	OrderID uint64
-	StaffID *uint64
-	Status  valueobject.OrderStatus
+	OrderID       uint64
+	StaffID       *uint64
+	Status        valueobject.OrderStatus
+	PaymentStatus *valueobject.PaymentStatus
+	SystemActor   *valueobject.SystemActor
 }
 
 type GetOrderHistoryInput struct {
@@ -26,3 +28,15 @@ type ListOrderHistoriesInput struct {
 	Page    int
 	Limit   int
 }
+
+type SubscribeOrderStatusInput struct {
+	Status     []valueobject.OrderStatus
+	CustomerID uint64
+	AfterID    uint64 // ID of the last event received, to resume the stream after a reconnect
+}
+
+// OrderStatusMessage is a presented order status event, sent to the stream clients
+type OrderStatusMessage struct {
+	ID   uint64
+	Data []byte
+}
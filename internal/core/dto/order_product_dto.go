@@ -40,3 +40,7 @@ type ListOrderProductsInput struct {
 	Page      int
 	Limit     int
 }
+
+type RepriceOrderProductsInput struct {
+	OrderID uint64
+}
@@ -1,14 +1,33 @@
 package dto
 
+import valueobject "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/value_object"
+
 type CreatePaymentInput struct {
-	OrderID uint64
+	OrderID  uint64
+	Provider valueobject.PaymentProvider // the default provider when not set
 }
 
 type UpdatePaymentInput struct {
+	EventID  string // ID of the notification, the same on every redelivery
 	Resource string
 	Topic    string
+	Status   valueobject.PaymentStatus // when not notified, the status is fetched from the payment provider
 }
 
 type GetPaymentInput struct {
 	OrderID uint64
 }
+
+type CreateRefundInput struct {
+	OrderID uint64
+	Amount  valueobject.Money // zero refunds the whole refundable amount
+	Reason  string
+	StaffID uint64 // staff who authorized the refund
+}
+
+type ConfirmCashPaymentInput struct {
+	OrderID        uint64
+	AmountReceived valueobject.Money
+	ChangeGiven    valueobject.Money
+	StaffID        uint64 // attendant who took the money
+}
@@ -1,12 +1,16 @@
 package dto
 
-import "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
+import (
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
+	valueobject "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/value_object"
+)
 
 type CreateProductInput struct {
 	Name        string
 	Description string
-	Price       float64
+	Price       valueobject.Money
 	CategoryID  uint64
+	PrepMinutes uint32
 }
 
 func (i CreateProductInput) ToEntity() *entity.Product {
@@ -15,6 +19,7 @@ func (i CreateProductInput) ToEntity() *entity.Product {
 		Description: i.Description,
 		Price:       i.Price,
 		CategoryID:  i.CategoryID,
+		PrepMinutes: i.PrepMinutes,
 	}
 }
 
@@ -22,8 +27,10 @@ type UpdateProductInput struct {
 	ID          uint64
 	Name        string
 	Description string
-	Price       float64
+	Price       valueobject.Money
 	CategoryID  uint64
+	PrepMinutes uint32
+	Version     *uint64 // version the change was based on (If-Match), nil to skip the check
 }
 
 type GetProductInput struct {
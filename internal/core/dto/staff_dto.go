@@ -6,8 +6,9 @@ import (
 )
 
 type CreateStaffInput struct {
-	Name string
-	Role valueobject.StaffRole
+	Name     string
+	Role     valueobject.StaffRole
+	Password string
 }
 
 func (i CreateStaffInput) ToEntity() *entity.Staff {
@@ -18,9 +19,10 @@ func (i CreateStaffInput) ToEntity() *entity.Staff {
 }
 
 type UpdateStaffInput struct {
-	ID   uint64
-	Name string
-	Role valueobject.StaffRole
+	ID       uint64
+	Name     string
+	Role     valueobject.StaffRole
+	Password string
 }
 
 type GetStaffInput struct {
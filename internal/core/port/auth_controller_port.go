@@ -8,4 +8,7 @@ import (
 
 type AuthController interface {
 	Authenticate(ctx context.Context, presenter Presenter, input dto.AuthenticateInput) ([]byte, error)
+	AuthenticateStaff(ctx context.Context, presenter Presenter, input dto.AuthenticateStaffInput) ([]byte, error)
+	Refresh(ctx context.Context, presenter Presenter, input dto.RefreshTokenInput) ([]byte, error)
+	Logout(ctx context.Context, input dto.LogoutInput) error
 }
@@ -3,11 +3,21 @@ package port
 import (
 	"context"
 
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
 	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/dto"
 )
 
 // AuthUseCase defines the authentication use case interface
 type AuthUseCase interface {
-	// Authenticate authenticates a customer by CPF and return the token
-	Authenticate(ctx context.Context, input dto.AuthenticateInput) (string, error)
+	// Authenticate authenticates a customer by CPF and return the access and refresh tokens
+	Authenticate(ctx context.Context, input dto.AuthenticateInput) (*entity.AuthToken, error)
+
+	// AuthenticateStaff authenticates a staff by ID and password and return the access and refresh tokens
+	AuthenticateStaff(ctx context.Context, input dto.AuthenticateStaffInput) (*entity.AuthToken, error)
+
+	// Refresh exchanges a refresh token for a new pair of tokens, the refresh token can only be used once
+	Refresh(ctx context.Context, input dto.RefreshTokenInput) (*entity.AuthToken, error)
+
+	// Logout revokes the access token of the caller and the family of the given refresh token
+	Logout(ctx context.Context, input dto.LogoutInput) error
 }
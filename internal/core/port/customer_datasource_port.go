@@ -13,5 +13,4 @@ type CustomerDataSource interface {
 	Create(ctx context.Context, product *entity.Customer) error
 	Update(ctx context.Context, product *entity.Customer) error
 	Delete(ctx context.Context, id uint64) error
-	Transaction(ctx context.Context, fn func(ctx context.Context) error) error
 }
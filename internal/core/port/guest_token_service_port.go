@@ -0,0 +1,10 @@
+package port
+
+// GuestTokenService provides the secret given to the guest who placed an order, required to reach it
+type GuestTokenService interface {
+	// Generate creates a new random guest token, returning the token and its hash
+	Generate() (token string, hash string, err error)
+
+	// Hash returns the hash under which a guest token is stored
+	Hash(token string) string
+}
@@ -0,0 +1,19 @@
+package port
+
+import "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
+
+// JWTKeyStore holds the keys access tokens are signed and verified with
+type JWTKeyStore interface {
+	// SigningKey returns the key new tokens are signed with
+	SigningKey() (*entity.SigningKey, error)
+
+	// VerificationKey returns the key with the given ID, if it is still accepted to verify tokens
+	VerificationKey(keyID string) (*entity.SigningKey, error)
+
+	// PublicKeys returns every key still accepted to verify tokens, including the retired ones
+	PublicKeys() []*entity.SigningKey
+
+	// Rotate reloads the configured keys, or generates a new signing key when none is configured.
+	// The keys replaced are kept for verification until the tokens they signed expire.
+	Rotate() error
+}
@@ -1,10 +1,17 @@
 package port
 
+import (
+	"context"
+
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
+)
+
 // JWTService provides token generation and validation methods
 type JWTService interface {
-	// GenerateToken creates a new JWT token with the given claims
-	GenerateToken(customerID uint64) (string, error)
+	// GenerateToken creates a new JWT token carrying the principal claims (subject type, ID and role)
+	GenerateToken(principal *entity.Principal) (*entity.AccessToken, error)
 
-	// ValidateToken verifies if a token is valid without extracting data
-	ValidateToken(token string) error
+	// ValidateToken verifies if a token is valid and not revoked, and returns the principal it was issued to. The
+	// revocation list is read within ctx, usually the context of the request carrying the token.
+	ValidateToken(ctx context.Context, token string) (*entity.Principal, error)
 }
@@ -0,0 +1,12 @@
+package port
+
+import (
+	"context"
+
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/dto"
+)
+
+type KitchenController interface {
+	ListTickets(ctx context.Context, presenter Presenter, input dto.ListKitchenTicketsInput) ([]byte, error)
+	BumpTicket(ctx context.Context, presenter Presenter, input dto.BumpKitchenTicketInput) ([]byte, error)
+}
@@ -0,0 +1,14 @@
+package port
+
+import (
+	"context"
+
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
+)
+
+type KitchenTicketBumpGateway interface {
+	FindByOrderIDs(ctx context.Context, orderIDs []uint64) ([]*entity.KitchenTicketBump, error)
+	// Create stores the bump, returning false if the ticket of the station was already bumped.
+	// The order row stays locked until the transaction commits, serializing the bumps of its tickets
+	Create(ctx context.Context, bump *entity.KitchenTicketBump) (bool, error)
+}
@@ -0,0 +1,15 @@
+package port
+
+import (
+	"context"
+
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/dto"
+)
+
+type KitchenUseCase interface {
+	// ListTickets returns the pending tickets of the orders in the kitchen, oldest first
+	ListTickets(ctx context.Context, input dto.ListKitchenTicketsInput) ([]*entity.KitchenTicket, error)
+	// BumpTicket marks the ticket of the station as done, moving the order to READY once every ticket is bumped
+	BumpTicket(ctx context.Context, input dto.BumpKitchenTicketInput) (*entity.KitchenTicket, error)
+}
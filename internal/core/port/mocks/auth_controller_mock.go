@@ -56,3 +56,47 @@ func (mr *MockAuthControllerMockRecorder) Authenticate(ctx, presenter, input any
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authenticate", reflect.TypeOf((*MockAuthController)(nil).Authenticate), ctx, presenter, input)
 }
+
+// AuthenticateStaff mocks base method.
+func (m *MockAuthController) AuthenticateStaff(ctx context.Context, presenter port.Presenter, input dto.AuthenticateStaffInput) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthenticateStaff", ctx, presenter, input)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthenticateStaff indicates an expected call of AuthenticateStaff.
+func (mr *MockAuthControllerMockRecorder) AuthenticateStaff(ctx, presenter, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthenticateStaff", reflect.TypeOf((*MockAuthController)(nil).AuthenticateStaff), ctx, presenter, input)
+}
+
+// Logout mocks base method.
+func (m *MockAuthController) Logout(ctx context.Context, input dto.LogoutInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Logout", ctx, input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Logout indicates an expected call of Logout.
+func (mr *MockAuthControllerMockRecorder) Logout(ctx, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Logout", reflect.TypeOf((*MockAuthController)(nil).Logout), ctx, input)
+}
+
+// Refresh mocks base method.
+func (m *MockAuthController) Refresh(ctx context.Context, presenter port.Presenter, input dto.RefreshTokenInput) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Refresh", ctx, presenter, input)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Refresh indicates an expected call of Refresh.
+func (mr *MockAuthControllerMockRecorder) Refresh(ctx, presenter, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refresh", reflect.TypeOf((*MockAuthController)(nil).Refresh), ctx, presenter, input)
+}
@@ -13,6 +13,7 @@ import (
 	context "context"
 	reflect "reflect"
 
+	entity "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
 	dto "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/dto"
 	gomock "go.uber.org/mock/gomock"
 )
@@ -42,10 +43,10 @@ func (m *MockAuthUseCase) EXPECT() *MockAuthUseCaseMockRecorder {
 }
 
 // Authenticate mocks base method.
-func (m *MockAuthUseCase) Authenticate(ctx context.Context, input dto.AuthenticateInput) (string, error) {
+func (m *MockAuthUseCase) Authenticate(ctx context.Context, input dto.AuthenticateInput) (*entity.AuthToken, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Authenticate", ctx, input)
-	ret0, _ := ret[0].(string)
+	ret0, _ := ret[0].(*entity.AuthToken)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -55,3 +56,47 @@ func (mr *MockAuthUseCaseMockRecorder) Authenticate(ctx, input any) *gomock.Call
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authenticate", reflect.TypeOf((*MockAuthUseCase)(nil).Authenticate), ctx, input)
 }
+
+// AuthenticateStaff mocks base method.
+func (m *MockAuthUseCase) AuthenticateStaff(ctx context.Context, input dto.AuthenticateStaffInput) (*entity.AuthToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthenticateStaff", ctx, input)
+	ret0, _ := ret[0].(*entity.AuthToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthenticateStaff indicates an expected call of AuthenticateStaff.
+func (mr *MockAuthUseCaseMockRecorder) AuthenticateStaff(ctx, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthenticateStaff", reflect.TypeOf((*MockAuthUseCase)(nil).AuthenticateStaff), ctx, input)
+}
+
+// Logout mocks base method.
+func (m *MockAuthUseCase) Logout(ctx context.Context, input dto.LogoutInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Logout", ctx, input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Logout indicates an expected call of Logout.
+func (mr *MockAuthUseCaseMockRecorder) Logout(ctx, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Logout", reflect.TypeOf((*MockAuthUseCase)(nil).Logout), ctx, input)
+}
+
+// Refresh mocks base method.
+func (m *MockAuthUseCase) Refresh(ctx context.Context, input dto.RefreshTokenInput) (*entity.AuthToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Refresh", ctx, input)
+	ret0, _ := ret[0].(*entity.AuthToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Refresh indicates an expected call of Refresh.
+func (mr *MockAuthUseCaseMockRecorder) Refresh(ctx, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refresh", reflect.TypeOf((*MockAuthUseCase)(nil).Refresh), ctx, input)
+}
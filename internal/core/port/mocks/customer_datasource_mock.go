@@ -115,20 +115,6 @@ func (mr *MockCustomerDataSourceMockRecorder) FindByID(ctx, id any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockCustomerDataSource)(nil).FindByID), ctx, id)
 }
 
-// Transaction mocks base method.
-func (m *MockCustomerDataSource) Transaction(ctx context.Context, fn func(context.Context) error) error {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Transaction", ctx, fn)
-	ret0, _ := ret[0].(error)
-	return ret0
-}
-
-// Transaction indicates an expected call of Transaction.
-func (mr *MockCustomerDataSourceMockRecorder) Transaction(ctx, fn any) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Transaction", reflect.TypeOf((*MockCustomerDataSource)(nil).Transaction), ctx, fn)
-}
-
 // Update mocks base method.
 func (m *MockCustomerDataSource) Update(ctx context.Context, product *entity.Customer) error {
 	m.ctrl.T.Helper()
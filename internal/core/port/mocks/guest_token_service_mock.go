@@ -0,0 +1,70 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/core/port/guest_token_service_port.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/core/port/guest_token_service_port.go -destination=internal/core/port/mocks/guest_token_service_mock.go
+//
+
+// Package mock_port is a generated GoMock package.
+package mock_port
+
+import (
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockGuestTokenService is a mock of GuestTokenService interface.
+type MockGuestTokenService struct {
+	ctrl     *gomock.Controller
+	recorder *MockGuestTokenServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockGuestTokenServiceMockRecorder is the mock recorder for MockGuestTokenService.
+type MockGuestTokenServiceMockRecorder struct {
+	mock *MockGuestTokenService
+}
+
+// NewMockGuestTokenService creates a new mock instance.
+func NewMockGuestTokenService(ctrl *gomock.Controller) *MockGuestTokenService {
+	mock := &MockGuestTokenService{ctrl: ctrl}
+	mock.recorder = &MockGuestTokenServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGuestTokenService) EXPECT() *MockGuestTokenServiceMockRecorder {
+	return m.recorder
+}
+
+// Generate mocks base method.
+func (m *MockGuestTokenService) Generate() (string, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Generate")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Generate indicates an expected call of Generate.
+func (mr *MockGuestTokenServiceMockRecorder) Generate() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Generate", reflect.TypeOf((*MockGuestTokenService)(nil).Generate))
+}
+
+// Hash mocks base method.
+func (m *MockGuestTokenService) Hash(token string) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Hash", token)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Hash indicates an expected call of Hash.
+func (mr *MockGuestTokenServiceMockRecorder) Hash(token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Hash", reflect.TypeOf((*MockGuestTokenService)(nil).Hash), token)
+}
@@ -0,0 +1,99 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/core/port/jwt_key_store_port.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/core/port/jwt_key_store_port.go -destination=internal/core/port/mocks/jwt_key_store_mock.go
+//
+
+// Package mock_port is a generated GoMock package.
+package mock_port
+
+import (
+	reflect "reflect"
+
+	entity "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockJWTKeyStore is a mock of JWTKeyStore interface.
+type MockJWTKeyStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockJWTKeyStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockJWTKeyStoreMockRecorder is the mock recorder for MockJWTKeyStore.
+type MockJWTKeyStoreMockRecorder struct {
+	mock *MockJWTKeyStore
+}
+
+// NewMockJWTKeyStore creates a new mock instance.
+func NewMockJWTKeyStore(ctrl *gomock.Controller) *MockJWTKeyStore {
+	mock := &MockJWTKeyStore{ctrl: ctrl}
+	mock.recorder = &MockJWTKeyStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockJWTKeyStore) EXPECT() *MockJWTKeyStoreMockRecorder {
+	return m.recorder
+}
+
+// PublicKeys mocks base method.
+func (m *MockJWTKeyStore) PublicKeys() []*entity.SigningKey {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublicKeys")
+	ret0, _ := ret[0].([]*entity.SigningKey)
+	return ret0
+}
+
+// PublicKeys indicates an expected call of PublicKeys.
+func (mr *MockJWTKeyStoreMockRecorder) PublicKeys() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublicKeys", reflect.TypeOf((*MockJWTKeyStore)(nil).PublicKeys))
+}
+
+// Rotate mocks base method.
+func (m *MockJWTKeyStore) Rotate() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rotate")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Rotate indicates an expected call of Rotate.
+func (mr *MockJWTKeyStoreMockRecorder) Rotate() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rotate", reflect.TypeOf((*MockJWTKeyStore)(nil).Rotate))
+}
+
+// SigningKey mocks base method.
+func (m *MockJWTKeyStore) SigningKey() (*entity.SigningKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SigningKey")
+	ret0, _ := ret[0].(*entity.SigningKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SigningKey indicates an expected call of SigningKey.
+func (mr *MockJWTKeyStoreMockRecorder) SigningKey() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SigningKey", reflect.TypeOf((*MockJWTKeyStore)(nil).SigningKey))
+}
+
+// VerificationKey mocks base method.
+func (m *MockJWTKeyStore) VerificationKey(keyID string) (*entity.SigningKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerificationKey", keyID)
+	ret0, _ := ret[0].(*entity.SigningKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerificationKey indicates an expected call of VerificationKey.
+func (mr *MockJWTKeyStoreMockRecorder) VerificationKey(keyID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerificationKey", reflect.TypeOf((*MockJWTKeyStore)(nil).VerificationKey), keyID)
+}
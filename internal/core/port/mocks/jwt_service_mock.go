@@ -10,8 +10,10 @@
 package mock_port
 
 import (
+	context "context"
 	reflect "reflect"
 
+	entity "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -40,30 +42,31 @@ func (m *MockJWTService) EXPECT() *MockJWTServiceMockRecorder {
 }
 
 // GenerateToken mocks base method.
-func (m *MockJWTService) GenerateToken(customerID uint64) (string, error) {
+func (m *MockJWTService) GenerateToken(principal *entity.Principal) (*entity.AccessToken, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GenerateToken", customerID)
-	ret0, _ := ret[0].(string)
+	ret := m.ctrl.Call(m, "GenerateToken", principal)
+	ret0, _ := ret[0].(*entity.AccessToken)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GenerateToken indicates an expected call of GenerateToken.
-func (mr *MockJWTServiceMockRecorder) GenerateToken(customerID any) *gomock.Call {
+func (mr *MockJWTServiceMockRecorder) GenerateToken(principal any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateToken", reflect.TypeOf((*MockJWTService)(nil).GenerateToken), customerID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateToken", reflect.TypeOf((*MockJWTService)(nil).GenerateToken), principal)
 }
 
 // ValidateToken mocks base method.
-func (m *MockJWTService) ValidateToken(token string) error {
+func (m *MockJWTService) ValidateToken(ctx context.Context, token string) (*entity.Principal, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ValidateToken", token)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "ValidateToken", ctx, token)
+	ret0, _ := ret[0].(*entity.Principal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // ValidateToken indicates an expected call of ValidateToken.
-func (mr *MockJWTServiceMockRecorder) ValidateToken(token any) *gomock.Call {
+func (mr *MockJWTServiceMockRecorder) ValidateToken(ctx, token any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateToken", reflect.TypeOf((*MockJWTService)(nil).ValidateToken), token)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateToken", reflect.TypeOf((*MockJWTService)(nil).ValidateToken), ctx, token)
 }
@@ -0,0 +1,73 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/core/port/kitchen_controller_port.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/core/port/kitchen_controller_port.go -destination=internal/core/port/mocks/kitchen_controller_mock.go
+//
+
+// Package mock_port is a generated GoMock package.
+package mock_port
+
+import (
+	context "context"
+	reflect "reflect"
+
+	dto "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/dto"
+	port "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/port"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockKitchenController is a mock of KitchenController interface.
+type MockKitchenController struct {
+	ctrl     *gomock.Controller
+	recorder *MockKitchenControllerMockRecorder
+	isgomock struct{}
+}
+
+// MockKitchenControllerMockRecorder is the mock recorder for MockKitchenController.
+type MockKitchenControllerMockRecorder struct {
+	mock *MockKitchenController
+}
+
+// NewMockKitchenController creates a new mock instance.
+func NewMockKitchenController(ctrl *gomock.Controller) *MockKitchenController {
+	mock := &MockKitchenController{ctrl: ctrl}
+	mock.recorder = &MockKitchenControllerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockKitchenController) EXPECT() *MockKitchenControllerMockRecorder {
+	return m.recorder
+}
+
+// BumpTicket mocks base method.
+func (m *MockKitchenController) BumpTicket(ctx context.Context, presenter port.Presenter, input dto.BumpKitchenTicketInput) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BumpTicket", ctx, presenter, input)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BumpTicket indicates an expected call of BumpTicket.
+func (mr *MockKitchenControllerMockRecorder) BumpTicket(ctx, presenter, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BumpTicket", reflect.TypeOf((*MockKitchenController)(nil).BumpTicket), ctx, presenter, input)
+}
+
+// ListTickets mocks base method.
+func (m *MockKitchenController) ListTickets(ctx context.Context, presenter port.Presenter, input dto.ListKitchenTicketsInput) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTickets", ctx, presenter, input)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTickets indicates an expected call of ListTickets.
+func (mr *MockKitchenControllerMockRecorder) ListTickets(ctx, presenter, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTickets", reflect.TypeOf((*MockKitchenController)(nil).ListTickets), ctx, presenter, input)
+}
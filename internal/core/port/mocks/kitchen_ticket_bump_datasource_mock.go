@@ -0,0 +1,72 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/core/port/kitchen_ticket_bump_datasource_port.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/core/port/kitchen_ticket_bump_datasource_port.go -destination=internal/core/port/mocks/kitchen_ticket_bump_datasource_mock.go
+//
+
+// Package mock_port is a generated GoMock package.
+package mock_port
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockKitchenTicketBumpDataSource is a mock of KitchenTicketBumpDataSource interface.
+type MockKitchenTicketBumpDataSource struct {
+	ctrl     *gomock.Controller
+	recorder *MockKitchenTicketBumpDataSourceMockRecorder
+	isgomock struct{}
+}
+
+// MockKitchenTicketBumpDataSourceMockRecorder is the mock recorder for MockKitchenTicketBumpDataSource.
+type MockKitchenTicketBumpDataSourceMockRecorder struct {
+	mock *MockKitchenTicketBumpDataSource
+}
+
+// NewMockKitchenTicketBumpDataSource creates a new mock instance.
+func NewMockKitchenTicketBumpDataSource(ctrl *gomock.Controller) *MockKitchenTicketBumpDataSource {
+	mock := &MockKitchenTicketBumpDataSource{ctrl: ctrl}
+	mock.recorder = &MockKitchenTicketBumpDataSourceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockKitchenTicketBumpDataSource) EXPECT() *MockKitchenTicketBumpDataSourceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockKitchenTicketBumpDataSource) Create(ctx context.Context, bump *entity.KitchenTicketBump) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, bump)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockKitchenTicketBumpDataSourceMockRecorder) Create(ctx, bump any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockKitchenTicketBumpDataSource)(nil).Create), ctx, bump)
+}
+
+// FindAll mocks base method.
+func (m *MockKitchenTicketBumpDataSource) FindAll(ctx context.Context, filters map[string]any) ([]*entity.KitchenTicketBump, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx, filters)
+	ret0, _ := ret[0].([]*entity.KitchenTicketBump)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockKitchenTicketBumpDataSourceMockRecorder) FindAll(ctx, filters any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockKitchenTicketBumpDataSource)(nil).FindAll), ctx, filters)
+}
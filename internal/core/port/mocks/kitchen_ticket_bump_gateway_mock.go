@@ -0,0 +1,72 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/core/port/kitchen_ticket_bump_gateway_port.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/core/port/kitchen_ticket_bump_gateway_port.go -destination=internal/core/port/mocks/kitchen_ticket_bump_gateway_mock.go
+//
+
+// Package mock_port is a generated GoMock package.
+package mock_port
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockKitchenTicketBumpGateway is a mock of KitchenTicketBumpGateway interface.
+type MockKitchenTicketBumpGateway struct {
+	ctrl     *gomock.Controller
+	recorder *MockKitchenTicketBumpGatewayMockRecorder
+	isgomock struct{}
+}
+
+// MockKitchenTicketBumpGatewayMockRecorder is the mock recorder for MockKitchenTicketBumpGateway.
+type MockKitchenTicketBumpGatewayMockRecorder struct {
+	mock *MockKitchenTicketBumpGateway
+}
+
+// NewMockKitchenTicketBumpGateway creates a new mock instance.
+func NewMockKitchenTicketBumpGateway(ctrl *gomock.Controller) *MockKitchenTicketBumpGateway {
+	mock := &MockKitchenTicketBumpGateway{ctrl: ctrl}
+	mock.recorder = &MockKitchenTicketBumpGatewayMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockKitchenTicketBumpGateway) EXPECT() *MockKitchenTicketBumpGatewayMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockKitchenTicketBumpGateway) Create(ctx context.Context, bump *entity.KitchenTicketBump) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, bump)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockKitchenTicketBumpGatewayMockRecorder) Create(ctx, bump any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockKitchenTicketBumpGateway)(nil).Create), ctx, bump)
+}
+
+// FindByOrderIDs mocks base method.
+func (m *MockKitchenTicketBumpGateway) FindByOrderIDs(ctx context.Context, orderIDs []uint64) ([]*entity.KitchenTicketBump, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByOrderIDs", ctx, orderIDs)
+	ret0, _ := ret[0].([]*entity.KitchenTicketBump)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByOrderIDs indicates an expected call of FindByOrderIDs.
+func (mr *MockKitchenTicketBumpGatewayMockRecorder) FindByOrderIDs(ctx, orderIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByOrderIDs", reflect.TypeOf((*MockKitchenTicketBumpGateway)(nil).FindByOrderIDs), ctx, orderIDs)
+}
@@ -0,0 +1,73 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/core/port/kitchen_usecase_port.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/core/port/kitchen_usecase_port.go -destination=internal/core/port/mocks/kitchen_usecase_mock.go
+//
+
+// Package mock_port is a generated GoMock package.
+package mock_port
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
+	dto "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/dto"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockKitchenUseCase is a mock of KitchenUseCase interface.
+type MockKitchenUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockKitchenUseCaseMockRecorder
+	isgomock struct{}
+}
+
+// MockKitchenUseCaseMockRecorder is the mock recorder for MockKitchenUseCase.
+type MockKitchenUseCaseMockRecorder struct {
+	mock *MockKitchenUseCase
+}
+
+// NewMockKitchenUseCase creates a new mock instance.
+func NewMockKitchenUseCase(ctrl *gomock.Controller) *MockKitchenUseCase {
+	mock := &MockKitchenUseCase{ctrl: ctrl}
+	mock.recorder = &MockKitchenUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockKitchenUseCase) EXPECT() *MockKitchenUseCaseMockRecorder {
+	return m.recorder
+}
+
+// BumpTicket mocks base method.
+func (m *MockKitchenUseCase) BumpTicket(ctx context.Context, input dto.BumpKitchenTicketInput) (*entity.KitchenTicket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BumpTicket", ctx, input)
+	ret0, _ := ret[0].(*entity.KitchenTicket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BumpTicket indicates an expected call of BumpTicket.
+func (mr *MockKitchenUseCaseMockRecorder) BumpTicket(ctx, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BumpTicket", reflect.TypeOf((*MockKitchenUseCase)(nil).BumpTicket), ctx, input)
+}
+
+// ListTickets mocks base method.
+func (m *MockKitchenUseCase) ListTickets(ctx context.Context, input dto.ListKitchenTicketsInput) ([]*entity.KitchenTicket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTickets", ctx, input)
+	ret0, _ := ret[0].([]*entity.KitchenTicket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTickets indicates an expected call of ListTickets.
+func (mr *MockKitchenUseCaseMockRecorder) ListTickets(ctx, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTickets", reflect.TypeOf((*MockKitchenUseCase)(nil).ListTickets), ctx, input)
+}
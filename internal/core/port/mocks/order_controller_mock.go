@@ -42,6 +42,21 @@ func (m *MockOrderController) EXPECT() *MockOrderControllerMockRecorder {
 	return m.recorder
 }
 
+// AttachCustomer mocks base method.
+func (m *MockOrderController) AttachCustomer(ctx context.Context, presenter port.Presenter, input dto.AttachOrderCustomerInput) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AttachCustomer", ctx, presenter, input)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AttachCustomer indicates an expected call of AttachCustomer.
+func (mr *MockOrderControllerMockRecorder) AttachCustomer(ctx, presenter, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AttachCustomer", reflect.TypeOf((*MockOrderController)(nil).AttachCustomer), ctx, presenter, input)
+}
+
 // Create mocks base method.
 func (m *MockOrderController) Create(ctx context.Context, presenter port.Presenter, input dto.CreateOrderInput) ([]byte, error) {
 	m.ctrl.T.Helper()
@@ -102,6 +117,21 @@ func (mr *MockOrderControllerMockRecorder) List(ctx, presenter, input any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockOrderController)(nil).List), ctx, presenter, input)
 }
 
+// SubscribeStatus mocks base method.
+func (m *MockOrderController) SubscribeStatus(ctx context.Context, presenter port.Presenter, input dto.SubscribeOrderStatusInput) (<-chan dto.OrderStatusMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscribeStatus", ctx, presenter, input)
+	ret0, _ := ret[0].(<-chan dto.OrderStatusMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubscribeStatus indicates an expected call of SubscribeStatus.
+func (mr *MockOrderControllerMockRecorder) SubscribeStatus(ctx, presenter, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeStatus", reflect.TypeOf((*MockOrderController)(nil).SubscribeStatus), ctx, presenter, input)
+}
+
 // Update mocks base method.
 func (m *MockOrderController) Update(ctx context.Context, presenter port.Presenter, input dto.UpdateOrderInput) ([]byte, error) {
 	m.ctrl.T.Helper()
@@ -12,8 +12,10 @@ package mock_port
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	entity "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
+	valueobject "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/value_object"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -100,26 +102,43 @@ func (mr *MockOrderDataSourceMockRecorder) FindByID(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockOrderDataSource)(nil).FindByID), ctx, id)
 }
 
-// Transaction mocks base method.
-func (m *MockOrderDataSource) Transaction(ctx context.Context, fn func(context.Context) error) error {
+// FindByIDForUpdate mocks base method.
+func (m *MockOrderDataSource) FindByIDForUpdate(ctx context.Context, id uint64) (*entity.Order, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Transaction", ctx, fn)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "FindByIDForUpdate", ctx, id)
+	ret0, _ := ret[0].(*entity.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByIDForUpdate indicates an expected call of FindByIDForUpdate.
+func (mr *MockOrderDataSourceMockRecorder) FindByIDForUpdate(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByIDForUpdate", reflect.TypeOf((*MockOrderDataSource)(nil).FindByIDForUpdate), ctx, id)
+}
+
+// FindIdle mocks base method.
+func (m *MockOrderDataSource) FindIdle(ctx context.Context, status valueobject.OrderStatus, idleSince time.Time, limit int) ([]*entity.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindIdle", ctx, status, idleSince, limit)
+	ret0, _ := ret[0].([]*entity.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// Transaction indicates an expected call of Transaction.
-func (mr *MockOrderDataSourceMockRecorder) Transaction(ctx, fn any) *gomock.Call {
+// FindIdle indicates an expected call of FindIdle.
+func (mr *MockOrderDataSourceMockRecorder) FindIdle(ctx, status, idleSince, limit any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Transaction", reflect.TypeOf((*MockOrderDataSource)(nil).Transaction), ctx, fn)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindIdle", reflect.TypeOf((*MockOrderDataSource)(nil).FindIdle), ctx, status, idleSince, limit)
 }
 
 // Update mocks base method.
-func (m *MockOrderDataSource) Update(ctx context.Context, order *entity.Order) error {
+func (m *MockOrderDataSource) Update(ctx context.Context, order *entity.Order) (bool, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Update", ctx, order)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // Update indicates an expected call of Update.
@@ -127,3 +146,17 @@ func (mr *MockOrderDataSourceMockRecorder) Update(ctx, order any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockOrderDataSource)(nil).Update), ctx, order)
 }
+
+// UpdateEstimatedReadyAt mocks base method.
+func (m *MockOrderDataSource) UpdateEstimatedReadyAt(ctx context.Context, id uint64, estimatedReadyAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateEstimatedReadyAt", ctx, id, estimatedReadyAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateEstimatedReadyAt indicates an expected call of UpdateEstimatedReadyAt.
+func (mr *MockOrderDataSourceMockRecorder) UpdateEstimatedReadyAt(ctx, id, estimatedReadyAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEstimatedReadyAt", reflect.TypeOf((*MockOrderDataSource)(nil).UpdateEstimatedReadyAt), ctx, id, estimatedReadyAt)
+}
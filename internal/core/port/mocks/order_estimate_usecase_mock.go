@@ -0,0 +1,57 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/core/port/order_estimate_usecase_port.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/core/port/order_estimate_usecase_port.go -destination=internal/core/port/mocks/order_estimate_usecase_mock.go
+//
+
+// Package mock_port is a generated GoMock package.
+package mock_port
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockOrderEstimateUseCase is a mock of OrderEstimateUseCase interface.
+type MockOrderEstimateUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrderEstimateUseCaseMockRecorder
+	isgomock struct{}
+}
+
+// MockOrderEstimateUseCaseMockRecorder is the mock recorder for MockOrderEstimateUseCase.
+type MockOrderEstimateUseCaseMockRecorder struct {
+	mock *MockOrderEstimateUseCase
+}
+
+// NewMockOrderEstimateUseCase creates a new mock instance.
+func NewMockOrderEstimateUseCase(ctrl *gomock.Controller) *MockOrderEstimateUseCase {
+	mock := &MockOrderEstimateUseCase{ctrl: ctrl}
+	mock.recorder = &MockOrderEstimateUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrderEstimateUseCase) EXPECT() *MockOrderEstimateUseCaseMockRecorder {
+	return m.recorder
+}
+
+// EstimateQueue mocks base method.
+func (m *MockOrderEstimateUseCase) EstimateQueue(ctx context.Context) (map[uint64]time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EstimateQueue", ctx)
+	ret0, _ := ret[0].(map[uint64]time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EstimateQueue indicates an expected call of EstimateQueue.
+func (mr *MockOrderEstimateUseCaseMockRecorder) EstimateQueue(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EstimateQueue", reflect.TypeOf((*MockOrderEstimateUseCase)(nil).EstimateQueue), ctx)
+}
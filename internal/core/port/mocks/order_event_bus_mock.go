@@ -0,0 +1,68 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/core/port/order_event_bus_port.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/core/port/order_event_bus_port.go -destination=internal/core/port/mocks/order_event_bus_mock.go
+//
+
+// Package mock_port is a generated GoMock package.
+package mock_port
+
+import (
+	reflect "reflect"
+
+	entity "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockOrderEventBus is a mock of OrderEventBus interface.
+type MockOrderEventBus struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrderEventBusMockRecorder
+	isgomock struct{}
+}
+
+// MockOrderEventBusMockRecorder is the mock recorder for MockOrderEventBus.
+type MockOrderEventBusMockRecorder struct {
+	mock *MockOrderEventBus
+}
+
+// NewMockOrderEventBus creates a new mock instance.
+func NewMockOrderEventBus(ctrl *gomock.Controller) *MockOrderEventBus {
+	mock := &MockOrderEventBus{ctrl: ctrl}
+	mock.recorder = &MockOrderEventBusMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrderEventBus) EXPECT() *MockOrderEventBusMockRecorder {
+	return m.recorder
+}
+
+// Publish mocks base method.
+func (m *MockOrderEventBus) Publish(event *entity.OrderStatusEvent) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Publish", event)
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockOrderEventBusMockRecorder) Publish(event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockOrderEventBus)(nil).Publish), event)
+}
+
+// Subscribe mocks base method.
+func (m *MockOrderEventBus) Subscribe() (<-chan *entity.OrderStatusEvent, func()) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subscribe")
+	ret0, _ := ret[0].(<-chan *entity.OrderStatusEvent)
+	ret1, _ := ret[1].(func())
+	return ret0, ret1
+}
+
+// Subscribe indicates an expected call of Subscribe.
+func (mr *MockOrderEventBusMockRecorder) Subscribe() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockOrderEventBus)(nil).Subscribe))
+}
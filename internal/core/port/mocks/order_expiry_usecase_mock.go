@@ -0,0 +1,56 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/core/port/order_expiry_usecase_port.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/core/port/order_expiry_usecase_port.go -destination=internal/core/port/mocks/order_expiry_usecase_mock.go
+//
+
+// Package mock_port is a generated GoMock package.
+package mock_port
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockOrderExpiryUseCase is a mock of OrderExpiryUseCase interface.
+type MockOrderExpiryUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrderExpiryUseCaseMockRecorder
+	isgomock struct{}
+}
+
+// MockOrderExpiryUseCaseMockRecorder is the mock recorder for MockOrderExpiryUseCase.
+type MockOrderExpiryUseCaseMockRecorder struct {
+	mock *MockOrderExpiryUseCase
+}
+
+// NewMockOrderExpiryUseCase creates a new mock instance.
+func NewMockOrderExpiryUseCase(ctrl *gomock.Controller) *MockOrderExpiryUseCase {
+	mock := &MockOrderExpiryUseCase{ctrl: ctrl}
+	mock.recorder = &MockOrderExpiryUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrderExpiryUseCase) EXPECT() *MockOrderExpiryUseCaseMockRecorder {
+	return m.recorder
+}
+
+// ExpireOrders mocks base method.
+func (m *MockOrderExpiryUseCase) ExpireOrders(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireOrders", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpireOrders indicates an expected call of ExpireOrders.
+func (mr *MockOrderExpiryUseCaseMockRecorder) ExpireOrders(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireOrders", reflect.TypeOf((*MockOrderExpiryUseCase)(nil).ExpireOrders), ctx)
+}
@@ -12,6 +12,7 @@ package mock_port
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	entity "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
 	valueobject "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/value_object"
@@ -101,12 +102,43 @@ func (mr *MockOrderGatewayMockRecorder) FindByID(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockOrderGateway)(nil).FindByID), ctx, id)
 }
 
+// FindByIDForUpdate mocks base method.
+func (m *MockOrderGateway) FindByIDForUpdate(ctx context.Context, id uint64) (*entity.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByIDForUpdate", ctx, id)
+	ret0, _ := ret[0].(*entity.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByIDForUpdate indicates an expected call of FindByIDForUpdate.
+func (mr *MockOrderGatewayMockRecorder) FindByIDForUpdate(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByIDForUpdate", reflect.TypeOf((*MockOrderGateway)(nil).FindByIDForUpdate), ctx, id)
+}
+
+// FindIdle mocks base method.
+func (m *MockOrderGateway) FindIdle(ctx context.Context, status valueobject.OrderStatus, idleSince time.Time, limit int) ([]*entity.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindIdle", ctx, status, idleSince, limit)
+	ret0, _ := ret[0].([]*entity.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindIdle indicates an expected call of FindIdle.
+func (mr *MockOrderGatewayMockRecorder) FindIdle(ctx, status, idleSince, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindIdle", reflect.TypeOf((*MockOrderGateway)(nil).FindIdle), ctx, status, idleSince, limit)
+}
+
 // Update mocks base method.
-func (m *MockOrderGateway) Update(ctx context.Context, order *entity.Order) error {
+func (m *MockOrderGateway) Update(ctx context.Context, order *entity.Order) (bool, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Update", ctx, order)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // Update indicates an expected call of Update.
@@ -114,3 +146,17 @@ func (mr *MockOrderGatewayMockRecorder) Update(ctx, order any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockOrderGateway)(nil).Update), ctx, order)
 }
+
+// UpdateEstimatedReadyAt mocks base method.
+func (m *MockOrderGateway) UpdateEstimatedReadyAt(ctx context.Context, id uint64, estimatedReadyAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateEstimatedReadyAt", ctx, id, estimatedReadyAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateEstimatedReadyAt indicates an expected call of UpdateEstimatedReadyAt.
+func (mr *MockOrderGatewayMockRecorder) UpdateEstimatedReadyAt(ctx, id, estimatedReadyAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEstimatedReadyAt", reflect.TypeOf((*MockOrderGateway)(nil).UpdateEstimatedReadyAt), ctx, id, estimatedReadyAt)
+}
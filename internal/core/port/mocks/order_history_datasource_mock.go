@@ -12,6 +12,7 @@ package mock_port
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	entity "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
 	gomock "go.uber.org/mock/gomock"
@@ -41,6 +42,21 @@ func (m *MockOrderHistoryDataSource) EXPECT() *MockOrderHistoryDataSourceMockRec
 	return m.recorder
 }
 
+// AveragePrepTime mocks base method.
+func (m *MockOrderHistoryDataSource) AveragePrepTime(ctx context.Context, sampleSize int) (time.Duration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AveragePrepTime", ctx, sampleSize)
+	ret0, _ := ret[0].(time.Duration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AveragePrepTime indicates an expected call of AveragePrepTime.
+func (mr *MockOrderHistoryDataSourceMockRecorder) AveragePrepTime(ctx, sampleSize any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AveragePrepTime", reflect.TypeOf((*MockOrderHistoryDataSource)(nil).AveragePrepTime), ctx, sampleSize)
+}
+
 // Create mocks base method.
 func (m *MockOrderHistoryDataSource) Create(ctx context.Context, entity *entity.OrderHistory) error {
 	m.ctrl.T.Helper()
@@ -69,6 +85,21 @@ func (mr *MockOrderHistoryDataSourceMockRecorder) Delete(ctx, id any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockOrderHistoryDataSource)(nil).Delete), ctx, id)
 }
 
+// FindAfter mocks base method.
+func (m *MockOrderHistoryDataSource) FindAfter(ctx context.Context, afterID uint64, filters map[string]any, limit int) ([]*entity.OrderHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAfter", ctx, afterID, filters, limit)
+	ret0, _ := ret[0].([]*entity.OrderHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAfter indicates an expected call of FindAfter.
+func (mr *MockOrderHistoryDataSourceMockRecorder) FindAfter(ctx, afterID, filters, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAfter", reflect.TypeOf((*MockOrderHistoryDataSource)(nil).FindAfter), ctx, afterID, filters, limit)
+}
+
 // FindAll mocks base method.
 func (m *MockOrderHistoryDataSource) FindAll(ctx context.Context, filters map[string]any, page, limit int) ([]*entity.OrderHistory, int64, error) {
 	m.ctrl.T.Helper()
@@ -100,16 +131,32 @@ func (mr *MockOrderHistoryDataSourceMockRecorder) FindByID(ctx, id any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockOrderHistoryDataSource)(nil).FindByID), ctx, id)
 }
 
-// Transaction mocks base method.
-func (m *MockOrderHistoryDataSource) Transaction(ctx context.Context, fn func(context.Context) error) error {
+// FindByOrderIDs mocks base method.
+func (m *MockOrderHistoryDataSource) FindByOrderIDs(ctx context.Context, orderIDs []uint64) ([]*entity.OrderHistory, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Transaction", ctx, fn)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "FindByOrderIDs", ctx, orderIDs)
+	ret0, _ := ret[0].([]*entity.OrderHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByOrderIDs indicates an expected call of FindByOrderIDs.
+func (mr *MockOrderHistoryDataSourceMockRecorder) FindByOrderIDs(ctx, orderIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByOrderIDs", reflect.TypeOf((*MockOrderHistoryDataSource)(nil).FindByOrderIDs), ctx, orderIDs)
+}
+
+// FindLastID mocks base method.
+func (m *MockOrderHistoryDataSource) FindLastID(ctx context.Context) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindLastID", ctx)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// Transaction indicates an expected call of Transaction.
-func (mr *MockOrderHistoryDataSourceMockRecorder) Transaction(ctx, fn any) *gomock.Call {
+// FindLastID indicates an expected call of FindLastID.
+func (mr *MockOrderHistoryDataSourceMockRecorder) FindLastID(ctx any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Transaction", reflect.TypeOf((*MockOrderHistoryDataSource)(nil).Transaction), ctx, fn)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindLastID", reflect.TypeOf((*MockOrderHistoryDataSource)(nil).FindLastID), ctx)
 }
@@ -12,6 +12,7 @@ package mock_port
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	entity "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
 	valueobject "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/value_object"
@@ -42,6 +43,21 @@ func (m *MockOrderHistoryGateway) EXPECT() *MockOrderHistoryGatewayMockRecorder
 	return m.recorder
 }
 
+// AveragePrepTime mocks base method.
+func (m *MockOrderHistoryGateway) AveragePrepTime(ctx context.Context, sampleSize int) (time.Duration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AveragePrepTime", ctx, sampleSize)
+	ret0, _ := ret[0].(time.Duration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AveragePrepTime indicates an expected call of AveragePrepTime.
+func (mr *MockOrderHistoryGatewayMockRecorder) AveragePrepTime(ctx, sampleSize any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AveragePrepTime", reflect.TypeOf((*MockOrderHistoryGateway)(nil).AveragePrepTime), ctx, sampleSize)
+}
+
 // Create mocks base method.
 func (m *MockOrderHistoryGateway) Create(ctx context.Context, entity *entity.OrderHistory) error {
 	m.ctrl.T.Helper()
@@ -70,6 +86,21 @@ func (mr *MockOrderHistoryGatewayMockRecorder) Delete(ctx, id any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockOrderHistoryGateway)(nil).Delete), ctx, id)
 }
 
+// FindAfter mocks base method.
+func (m *MockOrderHistoryGateway) FindAfter(ctx context.Context, afterID uint64, status []valueobject.OrderStatus, customerID uint64, limit int) ([]*entity.OrderHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAfter", ctx, afterID, status, customerID, limit)
+	ret0, _ := ret[0].([]*entity.OrderHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAfter indicates an expected call of FindAfter.
+func (mr *MockOrderHistoryGatewayMockRecorder) FindAfter(ctx, afterID, status, customerID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAfter", reflect.TypeOf((*MockOrderHistoryGateway)(nil).FindAfter), ctx, afterID, status, customerID, limit)
+}
+
 // FindAll mocks base method.
 func (m *MockOrderHistoryGateway) FindAll(ctx context.Context, orderID uint64, status valueobject.OrderStatus, page, limit int) ([]*entity.OrderHistory, int64, error) {
 	m.ctrl.T.Helper()
@@ -100,3 +131,33 @@ func (mr *MockOrderHistoryGatewayMockRecorder) FindByID(ctx, id any) *gomock.Cal
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockOrderHistoryGateway)(nil).FindByID), ctx, id)
 }
+
+// FindByOrderIDs mocks base method.
+func (m *MockOrderHistoryGateway) FindByOrderIDs(ctx context.Context, orderIDs []uint64) ([]*entity.OrderHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByOrderIDs", ctx, orderIDs)
+	ret0, _ := ret[0].([]*entity.OrderHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByOrderIDs indicates an expected call of FindByOrderIDs.
+func (mr *MockOrderHistoryGatewayMockRecorder) FindByOrderIDs(ctx, orderIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByOrderIDs", reflect.TypeOf((*MockOrderHistoryGateway)(nil).FindByOrderIDs), ctx, orderIDs)
+}
+
+// FindLastID mocks base method.
+func (m *MockOrderHistoryGateway) FindLastID(ctx context.Context) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindLastID", ctx)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindLastID indicates an expected call of FindLastID.
+func (mr *MockOrderHistoryGatewayMockRecorder) FindLastID(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindLastID", reflect.TypeOf((*MockOrderHistoryGateway)(nil).FindLastID), ctx)
+}
@@ -102,6 +102,21 @@ func (mr *MockOrderProductControllerMockRecorder) List(ctx, presenter, input any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockOrderProductController)(nil).List), ctx, presenter, input)
 }
 
+// Reprice mocks base method.
+func (m *MockOrderProductController) Reprice(ctx context.Context, presenter port.Presenter, input dto.RepriceOrderProductsInput) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reprice", ctx, presenter, input)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reprice indicates an expected call of Reprice.
+func (mr *MockOrderProductControllerMockRecorder) Reprice(ctx, presenter, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reprice", reflect.TypeOf((*MockOrderProductController)(nil).Reprice), ctx, presenter, input)
+}
+
 // Update mocks base method.
 func (m *MockOrderProductController) Update(ctx context.Context, presenter port.Presenter, input dto.UpdateOrderProductInput) ([]byte, error) {
 	m.ctrl.T.Helper()
@@ -100,20 +100,6 @@ func (mr *MockOrderProductDataSourceMockRecorder) FindByID(ctx, orderId, product
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockOrderProductDataSource)(nil).FindByID), ctx, orderId, productId)
 }
 
-// Transaction mocks base method.
-func (m *MockOrderProductDataSource) Transaction(ctx context.Context, fn func(context.Context) error) error {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Transaction", ctx, fn)
-	ret0, _ := ret[0].(error)
-	return ret0
-}
-
-// Transaction indicates an expected call of Transaction.
-func (mr *MockOrderProductDataSourceMockRecorder) Transaction(ctx, fn any) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Transaction", reflect.TypeOf((*MockOrderProductDataSource)(nil).Transaction), ctx, fn)
-}
-
 // Update mocks base method.
 func (m *MockOrderProductDataSource) Update(ctx context.Context, order *entity.OrderProduct) error {
 	m.ctrl.T.Helper()
@@ -103,6 +103,21 @@ func (mr *MockOrderProductUseCaseMockRecorder) List(ctx, input any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockOrderProductUseCase)(nil).List), ctx, input)
 }
 
+// Reprice mocks base method.
+func (m *MockOrderProductUseCase) Reprice(ctx context.Context, input dto.RepriceOrderProductsInput) (*entity.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reprice", ctx, input)
+	ret0, _ := ret[0].(*entity.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reprice indicates an expected call of Reprice.
+func (mr *MockOrderProductUseCaseMockRecorder) Reprice(ctx, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reprice", reflect.TypeOf((*MockOrderProductUseCase)(nil).Reprice), ctx, input)
+}
+
 // Update mocks base method.
 func (m *MockOrderProductUseCase) Update(ctx context.Context, input dto.UpdateOrderProductInput) (*entity.OrderProduct, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,58 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/core/port/order_status_stream_usecase_port.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/core/port/order_status_stream_usecase_port.go -destination=internal/core/port/mocks/order_status_stream_usecase_mock.go
+//
+
+// Package mock_port is a generated GoMock package.
+package mock_port
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
+	dto "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/dto"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockOrderStatusStreamUseCase is a mock of OrderStatusStreamUseCase interface.
+type MockOrderStatusStreamUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrderStatusStreamUseCaseMockRecorder
+	isgomock struct{}
+}
+
+// MockOrderStatusStreamUseCaseMockRecorder is the mock recorder for MockOrderStatusStreamUseCase.
+type MockOrderStatusStreamUseCaseMockRecorder struct {
+	mock *MockOrderStatusStreamUseCase
+}
+
+// NewMockOrderStatusStreamUseCase creates a new mock instance.
+func NewMockOrderStatusStreamUseCase(ctrl *gomock.Controller) *MockOrderStatusStreamUseCase {
+	mock := &MockOrderStatusStreamUseCase{ctrl: ctrl}
+	mock.recorder = &MockOrderStatusStreamUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrderStatusStreamUseCase) EXPECT() *MockOrderStatusStreamUseCaseMockRecorder {
+	return m.recorder
+}
+
+// Subscribe mocks base method.
+func (m *MockOrderStatusStreamUseCase) Subscribe(ctx context.Context, input dto.SubscribeOrderStatusInput) (<-chan *entity.OrderStatusEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subscribe", ctx, input)
+	ret0, _ := ret[0].(<-chan *entity.OrderStatusEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Subscribe indicates an expected call of Subscribe.
+func (mr *MockOrderStatusStreamUseCaseMockRecorder) Subscribe(ctx, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockOrderStatusStreamUseCase)(nil).Subscribe), ctx, input)
+}
@@ -0,0 +1,71 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/core/port/order_timing_usecase_port.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/core/port/order_timing_usecase_port.go -destination=internal/core/port/mocks/order_timing_usecase_mock.go
+//
+
+// Package mock_port is a generated GoMock package.
+package mock_port
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockOrderTimingUseCase is a mock of OrderTimingUseCase interface.
+type MockOrderTimingUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrderTimingUseCaseMockRecorder
+	isgomock struct{}
+}
+
+// MockOrderTimingUseCaseMockRecorder is the mock recorder for MockOrderTimingUseCase.
+type MockOrderTimingUseCaseMockRecorder struct {
+	mock *MockOrderTimingUseCase
+}
+
+// NewMockOrderTimingUseCase creates a new mock instance.
+func NewMockOrderTimingUseCase(ctrl *gomock.Controller) *MockOrderTimingUseCase {
+	mock := &MockOrderTimingUseCase{ctrl: ctrl}
+	mock.recorder = &MockOrderTimingUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrderTimingUseCase) EXPECT() *MockOrderTimingUseCaseMockRecorder {
+	return m.recorder
+}
+
+// ListLate mocks base method.
+func (m *MockOrderTimingUseCase) ListLate(ctx context.Context) ([]*entity.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLate", ctx)
+	ret0, _ := ret[0].([]*entity.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLate indicates an expected call of ListLate.
+func (mr *MockOrderTimingUseCaseMockRecorder) ListLate(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLate", reflect.TypeOf((*MockOrderTimingUseCase)(nil).ListLate), ctx)
+}
+
+// Track mocks base method.
+func (m *MockOrderTimingUseCase) Track(ctx context.Context, orders []*entity.Order) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Track", ctx, orders)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Track indicates an expected call of Track.
+func (mr *MockOrderTimingUseCaseMockRecorder) Track(ctx, orders any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Track", reflect.TypeOf((*MockOrderTimingUseCase)(nil).Track), ctx, orders)
+}
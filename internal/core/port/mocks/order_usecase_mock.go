@@ -42,6 +42,21 @@ func (m *MockOrderUseCase) EXPECT() *MockOrderUseCaseMockRecorder {
 	return m.recorder
 }
 
+// AttachCustomer mocks base method.
+func (m *MockOrderUseCase) AttachCustomer(ctx context.Context, input dto.AttachOrderCustomerInput) (*entity.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AttachCustomer", ctx, input)
+	ret0, _ := ret[0].(*entity.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AttachCustomer indicates an expected call of AttachCustomer.
+func (mr *MockOrderUseCaseMockRecorder) AttachCustomer(ctx, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AttachCustomer", reflect.TypeOf((*MockOrderUseCase)(nil).AttachCustomer), ctx, input)
+}
+
 // Create mocks base method.
 func (m *MockOrderUseCase) Create(ctx context.Context, input dto.CreateOrderInput) (*entity.Order, error) {
 	m.ctrl.T.Helper()
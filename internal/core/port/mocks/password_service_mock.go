@@ -0,0 +1,69 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/core/port/password_service_port.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/core/port/password_service_port.go -destination=internal/core/port/mocks/password_service_mock.go
+//
+
+// Package mock_port is a generated GoMock package.
+package mock_port
+
+import (
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPasswordService is a mock of PasswordService interface.
+type MockPasswordService struct {
+	ctrl     *gomock.Controller
+	recorder *MockPasswordServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockPasswordServiceMockRecorder is the mock recorder for MockPasswordService.
+type MockPasswordServiceMockRecorder struct {
+	mock *MockPasswordService
+}
+
+// NewMockPasswordService creates a new mock instance.
+func NewMockPasswordService(ctrl *gomock.Controller) *MockPasswordService {
+	mock := &MockPasswordService{ctrl: ctrl}
+	mock.recorder = &MockPasswordServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPasswordService) EXPECT() *MockPasswordServiceMockRecorder {
+	return m.recorder
+}
+
+// Compare mocks base method.
+func (m *MockPasswordService) Compare(hash, password string) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Compare", hash, password)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// Compare indicates an expected call of Compare.
+func (mr *MockPasswordServiceMockRecorder) Compare(hash, password any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Compare", reflect.TypeOf((*MockPasswordService)(nil).Compare), hash, password)
+}
+
+// Hash mocks base method.
+func (m *MockPasswordService) Hash(password string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Hash", password)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Hash indicates an expected call of Hash.
+func (mr *MockPasswordServiceMockRecorder) Hash(password any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Hash", reflect.TypeOf((*MockPasswordService)(nil).Hash), password)
+}
@@ -42,6 +42,21 @@ func (m *MockPaymentController) EXPECT() *MockPaymentControllerMockRecorder {
 	return m.recorder
 }
 
+// ConfirmCash mocks base method.
+func (m *MockPaymentController) ConfirmCash(ctx context.Context, p port.Presenter, i dto.ConfirmCashPaymentInput) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmCash", ctx, p, i)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConfirmCash indicates an expected call of ConfirmCash.
+func (mr *MockPaymentControllerMockRecorder) ConfirmCash(ctx, p, i any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmCash", reflect.TypeOf((*MockPaymentController)(nil).ConfirmCash), ctx, p, i)
+}
+
 // Create mocks base method.
 func (m *MockPaymentController) Create(ctx context.Context, presenter port.Presenter, input dto.CreatePaymentInput) ([]byte, error) {
 	m.ctrl.T.Helper()
@@ -72,6 +87,21 @@ func (mr *MockPaymentControllerMockRecorder) Get(ctx, p, i any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockPaymentController)(nil).Get), ctx, p, i)
 }
 
+// Refund mocks base method.
+func (m *MockPaymentController) Refund(ctx context.Context, p port.Presenter, i dto.CreateRefundInput) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Refund", ctx, p, i)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Refund indicates an expected call of Refund.
+func (mr *MockPaymentControllerMockRecorder) Refund(ctx, p, i any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refund", reflect.TypeOf((*MockPaymentController)(nil).Refund), ctx, p, i)
+}
+
 // Update mocks base method.
 func (m *MockPaymentController) Update(ctx context.Context, p port.Presenter, i dto.UpdatePaymentInput) ([]byte, error) {
 	m.ctrl.T.Helper()
@@ -12,6 +12,7 @@ package mock_port
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	entity "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
 	valueobject "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/value_object"
@@ -42,6 +43,50 @@ func (m *MockPaymentDataSource) EXPECT() *MockPaymentDataSourceMockRecorder {
 	return m.recorder
 }
 
+// CompleteRefund mocks base method.
+func (m *MockPaymentDataSource) CompleteRefund(ctx context.Context, refund *entity.PaymentRefund, status valueobject.PaymentStatus) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteRefund", ctx, refund, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CompleteRefund indicates an expected call of CompleteRefund.
+func (mr *MockPaymentDataSourceMockRecorder) CompleteRefund(ctx, refund, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteRefund", reflect.TypeOf((*MockPaymentDataSource)(nil).CompleteRefund), ctx, refund, status)
+}
+
+// ConfirmCash mocks base method.
+func (m *MockPaymentDataSource) ConfirmCash(ctx context.Context, receipt *entity.CashReceipt) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmCash", ctx, receipt)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConfirmCash indicates an expected call of ConfirmCash.
+func (mr *MockPaymentDataSourceMockRecorder) ConfirmCash(ctx, receipt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmCash", reflect.TypeOf((*MockPaymentDataSource)(nil).ConfirmCash), ctx, receipt)
+}
+
+// CountByOrderIDAndStatus mocks base method.
+func (m *MockPaymentDataSource) CountByOrderIDAndStatus(ctx context.Context, orderID uint64, statuses []valueobject.PaymentStatus) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountByOrderIDAndStatus", ctx, orderID, statuses)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountByOrderIDAndStatus indicates an expected call of CountByOrderIDAndStatus.
+func (mr *MockPaymentDataSourceMockRecorder) CountByOrderIDAndStatus(ctx, orderID, statuses any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountByOrderIDAndStatus", reflect.TypeOf((*MockPaymentDataSource)(nil).CountByOrderIDAndStatus), ctx, orderID, statuses)
+}
+
 // Create mocks base method.
 func (m *MockPaymentDataSource) Create(ctx context.Context, payment *entity.Payment) (*entity.Payment, error) {
 	m.ctrl.T.Helper()
@@ -57,6 +102,21 @@ func (mr *MockPaymentDataSourceMockRecorder) Create(ctx, payment any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPaymentDataSource)(nil).Create), ctx, payment)
 }
 
+// CreatePendingRefund mocks base method.
+func (m *MockPaymentDataSource) CreatePendingRefund(ctx context.Context, refund *entity.PaymentRefund, refundedAmount valueobject.Money) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePendingRefund", ctx, refund, refundedAmount)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePendingRefund indicates an expected call of CreatePendingRefund.
+func (mr *MockPaymentDataSourceMockRecorder) CreatePendingRefund(ctx, refund, refundedAmount any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePendingRefund", reflect.TypeOf((*MockPaymentDataSource)(nil).CreatePendingRefund), ctx, refund, refundedAmount)
+}
+
 // GetByExternalPaymentID mocks base method.
 func (m *MockPaymentDataSource) GetByExternalPaymentID(ctx context.Context, externalPaymentID string) (*entity.Payment, error) {
 	m.ctrl.T.Helper()
@@ -102,6 +162,51 @@ func (mr *MockPaymentDataSourceMockRecorder) GetByOrderIDAndStatusProcessing(ctx
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByOrderIDAndStatusProcessing", reflect.TypeOf((*MockPaymentDataSource)(nil).GetByOrderIDAndStatusProcessing), ctx, orderID)
 }
 
+// GetExpiredProcessing mocks base method.
+func (m *MockPaymentDataSource) GetExpiredProcessing(ctx context.Context, now time.Time, limit int) ([]*entity.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExpiredProcessing", ctx, now, limit)
+	ret0, _ := ret[0].([]*entity.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExpiredProcessing indicates an expected call of GetExpiredProcessing.
+func (mr *MockPaymentDataSourceMockRecorder) GetExpiredProcessing(ctx, now, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExpiredProcessing", reflect.TypeOf((*MockPaymentDataSource)(nil).GetExpiredProcessing), ctx, now, limit)
+}
+
+// GetLastByOrderIDAndStatus mocks base method.
+func (m *MockPaymentDataSource) GetLastByOrderIDAndStatus(ctx context.Context, orderID uint64, statuses []valueobject.PaymentStatus) (*entity.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLastByOrderIDAndStatus", ctx, orderID, statuses)
+	ret0, _ := ret[0].(*entity.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLastByOrderIDAndStatus indicates an expected call of GetLastByOrderIDAndStatus.
+func (mr *MockPaymentDataSourceMockRecorder) GetLastByOrderIDAndStatus(ctx, orderID, statuses any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastByOrderIDAndStatus", reflect.TypeOf((*MockPaymentDataSource)(nil).GetLastByOrderIDAndStatus), ctx, orderID, statuses)
+}
+
+// GetProcessing mocks base method.
+func (m *MockPaymentDataSource) GetProcessing(ctx context.Context, afterID uint64, limit int) ([]*entity.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProcessing", ctx, afterID, limit)
+	ret0, _ := ret[0].([]*entity.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProcessing indicates an expected call of GetProcessing.
+func (mr *MockPaymentDataSourceMockRecorder) GetProcessing(ctx, afterID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProcessing", reflect.TypeOf((*MockPaymentDataSource)(nil).GetProcessing), ctx, afterID, limit)
+}
+
 // UpdateStatus mocks base method.
 func (m *MockPaymentDataSource) UpdateStatus(ctx context.Context, status valueobject.PaymentStatus, externalPaymentID string) error {
 	m.ctrl.T.Helper()
@@ -115,3 +220,18 @@ func (mr *MockPaymentDataSourceMockRecorder) UpdateStatus(ctx, status, externalP
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockPaymentDataSource)(nil).UpdateStatus), ctx, status, externalPaymentID)
 }
+
+// UpdateStatusIfProcessing mocks base method.
+func (m *MockPaymentDataSource) UpdateStatusIfProcessing(ctx context.Context, id uint64, status valueobject.PaymentStatus) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatusIfProcessing", ctx, id, status)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateStatusIfProcessing indicates an expected call of UpdateStatusIfProcessing.
+func (mr *MockPaymentDataSourceMockRecorder) UpdateStatusIfProcessing(ctx, id, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatusIfProcessing", reflect.TypeOf((*MockPaymentDataSource)(nil).UpdateStatusIfProcessing), ctx, id, status)
+}
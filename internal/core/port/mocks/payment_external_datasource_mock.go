@@ -14,6 +14,7 @@ import (
 	reflect "reflect"
 
 	entity "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
+	valueobject "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/value_object"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -55,3 +56,33 @@ func (mr *MockPaymentExternalDatasourceMockRecorder) Create(context, payment any
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPaymentExternalDatasource)(nil).Create), context, payment)
 }
+
+// GetStatus mocks base method.
+func (m *MockPaymentExternalDatasource) GetStatus(ctx context.Context, payment *entity.Payment) (valueobject.PaymentStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStatus", ctx, payment)
+	ret0, _ := ret[0].(valueobject.PaymentStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStatus indicates an expected call of GetStatus.
+func (mr *MockPaymentExternalDatasourceMockRecorder) GetStatus(ctx, payment any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatus", reflect.TypeOf((*MockPaymentExternalDatasource)(nil).GetStatus), ctx, payment)
+}
+
+// Refund mocks base method.
+func (m *MockPaymentExternalDatasource) Refund(ctx context.Context, refund *entity.RefundPaymentExternalInput) (*entity.RefundPaymentExternalOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Refund", ctx, refund)
+	ret0, _ := ret[0].(*entity.RefundPaymentExternalOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Refund indicates an expected call of Refund.
+func (mr *MockPaymentExternalDatasourceMockRecorder) Refund(ctx, refund any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refund", reflect.TypeOf((*MockPaymentExternalDatasource)(nil).Refund), ctx, refund)
+}
@@ -12,6 +12,7 @@ package mock_port
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	entity "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
 	valueobject "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/value_object"
@@ -42,6 +43,55 @@ func (m *MockPaymentGateway) EXPECT() *MockPaymentGatewayMockRecorder {
 	return m.recorder
 }
 
+// CompleteRefund mocks base method.
+func (m *MockPaymentGateway) CompleteRefund(ctx context.Context, refund *entity.PaymentRefund, status valueobject.PaymentStatus) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteRefund", ctx, refund, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CompleteRefund indicates an expected call of CompleteRefund.
+func (mr *MockPaymentGatewayMockRecorder) CompleteRefund(ctx, refund, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteRefund", reflect.TypeOf((*MockPaymentGateway)(nil).CompleteRefund), ctx, refund, status)
+}
+
+// ConfirmCash mocks base method.
+func (m *MockPaymentGateway) ConfirmCash(ctx context.Context, receipt *entity.CashReceipt) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmCash", ctx, receipt)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConfirmCash indicates an expected call of ConfirmCash.
+func (mr *MockPaymentGatewayMockRecorder) ConfirmCash(ctx, receipt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmCash", reflect.TypeOf((*MockPaymentGateway)(nil).ConfirmCash), ctx, receipt)
+}
+
+// CountByOrderIDAndStatus mocks base method.
+func (m *MockPaymentGateway) CountByOrderIDAndStatus(ctx context.Context, orderID uint64, statuses ...valueobject.PaymentStatus) (int64, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, orderID}
+	for _, a := range statuses {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CountByOrderIDAndStatus", varargs...)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountByOrderIDAndStatus indicates an expected call of CountByOrderIDAndStatus.
+func (mr *MockPaymentGatewayMockRecorder) CountByOrderIDAndStatus(ctx, orderID any, statuses ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, orderID}, statuses...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountByOrderIDAndStatus", reflect.TypeOf((*MockPaymentGateway)(nil).CountByOrderIDAndStatus), varargs...)
+}
+
 // Create mocks base method.
 func (m *MockPaymentGateway) Create(ctx context.Context, payment *entity.Payment) (*entity.Payment, error) {
 	m.ctrl.T.Helper()
@@ -72,6 +122,21 @@ func (mr *MockPaymentGatewayMockRecorder) CreateExternal(ctx, payment any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateExternal", reflect.TypeOf((*MockPaymentGateway)(nil).CreateExternal), ctx, payment)
 }
 
+// CreatePendingRefund mocks base method.
+func (m *MockPaymentGateway) CreatePendingRefund(ctx context.Context, refund *entity.PaymentRefund, refundedAmount valueobject.Money) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePendingRefund", ctx, refund, refundedAmount)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePendingRefund indicates an expected call of CreatePendingRefund.
+func (mr *MockPaymentGatewayMockRecorder) CreatePendingRefund(ctx, refund, refundedAmount any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePendingRefund", reflect.TypeOf((*MockPaymentGateway)(nil).CreatePendingRefund), ctx, refund, refundedAmount)
+}
+
 // FindByExternalPaymentID mocks base method.
 func (m *MockPaymentGateway) FindByExternalPaymentID(ctx context.Context, resource string) (*entity.Payment, error) {
 	m.ctrl.T.Helper()
@@ -102,6 +167,26 @@ func (mr *MockPaymentGatewayMockRecorder) FindByOrderID(ctx, orderID any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByOrderID", reflect.TypeOf((*MockPaymentGateway)(nil).FindByOrderID), ctx, orderID)
 }
 
+// FindByOrderIDAndStatus mocks base method.
+func (m *MockPaymentGateway) FindByOrderIDAndStatus(ctx context.Context, orderID uint64, statuses ...valueobject.PaymentStatus) (*entity.Payment, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, orderID}
+	for _, a := range statuses {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "FindByOrderIDAndStatus", varargs...)
+	ret0, _ := ret[0].(*entity.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByOrderIDAndStatus indicates an expected call of FindByOrderIDAndStatus.
+func (mr *MockPaymentGatewayMockRecorder) FindByOrderIDAndStatus(ctx, orderID any, statuses ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, orderID}, statuses...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByOrderIDAndStatus", reflect.TypeOf((*MockPaymentGateway)(nil).FindByOrderIDAndStatus), varargs...)
+}
+
 // FindByOrderIDAndStatusProcessing mocks base method.
 func (m *MockPaymentGateway) FindByOrderIDAndStatusProcessing(ctx context.Context, orderID uint64) (*entity.Payment, error) {
 	m.ctrl.T.Helper()
@@ -117,6 +202,66 @@ func (mr *MockPaymentGatewayMockRecorder) FindByOrderIDAndStatusProcessing(ctx,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByOrderIDAndStatusProcessing", reflect.TypeOf((*MockPaymentGateway)(nil).FindByOrderIDAndStatusProcessing), ctx, orderID)
 }
 
+// FindExpiredProcessing mocks base method.
+func (m *MockPaymentGateway) FindExpiredProcessing(ctx context.Context, now time.Time, limit int) ([]*entity.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindExpiredProcessing", ctx, now, limit)
+	ret0, _ := ret[0].([]*entity.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindExpiredProcessing indicates an expected call of FindExpiredProcessing.
+func (mr *MockPaymentGatewayMockRecorder) FindExpiredProcessing(ctx, now, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindExpiredProcessing", reflect.TypeOf((*MockPaymentGateway)(nil).FindExpiredProcessing), ctx, now, limit)
+}
+
+// FindExternalStatus mocks base method.
+func (m *MockPaymentGateway) FindExternalStatus(ctx context.Context, payment *entity.Payment) (valueobject.PaymentStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindExternalStatus", ctx, payment)
+	ret0, _ := ret[0].(valueobject.PaymentStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindExternalStatus indicates an expected call of FindExternalStatus.
+func (mr *MockPaymentGatewayMockRecorder) FindExternalStatus(ctx, payment any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindExternalStatus", reflect.TypeOf((*MockPaymentGateway)(nil).FindExternalStatus), ctx, payment)
+}
+
+// FindProcessing mocks base method.
+func (m *MockPaymentGateway) FindProcessing(ctx context.Context, afterID uint64, limit int) ([]*entity.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindProcessing", ctx, afterID, limit)
+	ret0, _ := ret[0].([]*entity.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindProcessing indicates an expected call of FindProcessing.
+func (mr *MockPaymentGatewayMockRecorder) FindProcessing(ctx, afterID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindProcessing", reflect.TypeOf((*MockPaymentGateway)(nil).FindProcessing), ctx, afterID, limit)
+}
+
+// RefundExternal mocks base method.
+func (m *MockPaymentGateway) RefundExternal(ctx context.Context, refund *entity.RefundPaymentExternalInput) (*entity.RefundPaymentExternalOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefundExternal", ctx, refund)
+	ret0, _ := ret[0].(*entity.RefundPaymentExternalOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RefundExternal indicates an expected call of RefundExternal.
+func (mr *MockPaymentGatewayMockRecorder) RefundExternal(ctx, refund any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefundExternal", reflect.TypeOf((*MockPaymentGateway)(nil).RefundExternal), ctx, refund)
+}
+
 // Update mocks base method.
 func (m *MockPaymentGateway) Update(ctx context.Context, status valueobject.PaymentStatus, resource string) error {
 	m.ctrl.T.Helper()
@@ -130,3 +275,18 @@ func (mr *MockPaymentGatewayMockRecorder) Update(ctx, status, resource any) *gom
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockPaymentGateway)(nil).Update), ctx, status, resource)
 }
+
+// UpdateIfProcessing mocks base method.
+func (m *MockPaymentGateway) UpdateIfProcessing(ctx context.Context, id uint64, status valueobject.PaymentStatus) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateIfProcessing", ctx, id, status)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateIfProcessing indicates an expected call of UpdateIfProcessing.
+func (mr *MockPaymentGatewayMockRecorder) UpdateIfProcessing(ctx, id, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateIfProcessing", reflect.TypeOf((*MockPaymentGateway)(nil).UpdateIfProcessing), ctx, id, status)
+}
@@ -0,0 +1,117 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/core/port/payment_notification_gateway_port.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/core/port/payment_notification_gateway_port.go -destination=internal/core/port/mocks/payment_notification_gateway_mock.go
+//
+
+// Package mock_port is a generated GoMock package.
+package mock_port
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	entity "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPaymentNotificationGateway is a mock of PaymentNotificationGateway interface.
+type MockPaymentNotificationGateway struct {
+	ctrl     *gomock.Controller
+	recorder *MockPaymentNotificationGatewayMockRecorder
+	isgomock struct{}
+}
+
+// MockPaymentNotificationGatewayMockRecorder is the mock recorder for MockPaymentNotificationGateway.
+type MockPaymentNotificationGatewayMockRecorder struct {
+	mock *MockPaymentNotificationGateway
+}
+
+// NewMockPaymentNotificationGateway creates a new mock instance.
+func NewMockPaymentNotificationGateway(ctrl *gomock.Controller) *MockPaymentNotificationGateway {
+	mock := &MockPaymentNotificationGateway{ctrl: ctrl}
+	mock.recorder = &MockPaymentNotificationGatewayMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPaymentNotificationGateway) EXPECT() *MockPaymentNotificationGatewayMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockPaymentNotificationGateway) Create(ctx context.Context, notification *entity.PaymentNotification) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, notification)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPaymentNotificationGatewayMockRecorder) Create(ctx, notification any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPaymentNotificationGateway)(nil).Create), ctx, notification)
+}
+
+// FindByEventID mocks base method.
+func (m *MockPaymentNotificationGateway) FindByEventID(ctx context.Context, eventID string) (*entity.PaymentNotification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByEventID", ctx, eventID)
+	ret0, _ := ret[0].(*entity.PaymentNotification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByEventID indicates an expected call of FindByEventID.
+func (mr *MockPaymentNotificationGatewayMockRecorder) FindByEventID(ctx, eventID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByEventID", reflect.TypeOf((*MockPaymentNotificationGateway)(nil).FindByEventID), ctx, eventID)
+}
+
+// FindByEventIDForUpdate mocks base method.
+func (m *MockPaymentNotificationGateway) FindByEventIDForUpdate(ctx context.Context, eventID string) (*entity.PaymentNotification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByEventIDForUpdate", ctx, eventID)
+	ret0, _ := ret[0].(*entity.PaymentNotification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByEventIDForUpdate indicates an expected call of FindByEventIDForUpdate.
+func (mr *MockPaymentNotificationGatewayMockRecorder) FindByEventIDForUpdate(ctx, eventID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByEventIDForUpdate", reflect.TypeOf((*MockPaymentNotificationGateway)(nil).FindByEventIDForUpdate), ctx, eventID)
+}
+
+// FindUnprocessed mocks base method.
+func (m *MockPaymentNotificationGateway) FindUnprocessed(ctx context.Context, receivedBefore time.Time, afterID uint64, limit int) ([]*entity.PaymentNotification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindUnprocessed", ctx, receivedBefore, afterID, limit)
+	ret0, _ := ret[0].([]*entity.PaymentNotification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindUnprocessed indicates an expected call of FindUnprocessed.
+func (mr *MockPaymentNotificationGatewayMockRecorder) FindUnprocessed(ctx, receivedBefore, afterID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindUnprocessed", reflect.TypeOf((*MockPaymentNotificationGateway)(nil).FindUnprocessed), ctx, receivedBefore, afterID, limit)
+}
+
+// MarkProcessed mocks base method.
+func (m *MockPaymentNotificationGateway) MarkProcessed(ctx context.Context, id uint64, processedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkProcessed", ctx, id, processedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkProcessed indicates an expected call of MarkProcessed.
+func (mr *MockPaymentNotificationGatewayMockRecorder) MarkProcessed(ctx, id, processedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkProcessed", reflect.TypeOf((*MockPaymentNotificationGateway)(nil).MarkProcessed), ctx, id, processedAt)
+}
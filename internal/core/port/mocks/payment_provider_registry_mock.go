@@ -0,0 +1,58 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/core/port/payment_provider_registry_port.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/core/port/payment_provider_registry_port.go -destination=internal/core/port/mocks/payment_provider_registry_mock.go
+//
+
+// Package mock_port is a generated GoMock package.
+package mock_port
+
+import (
+	reflect "reflect"
+
+	valueobject "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/value_object"
+	port "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/port"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPaymentProviderRegistry is a mock of PaymentProviderRegistry interface.
+type MockPaymentProviderRegistry struct {
+	ctrl     *gomock.Controller
+	recorder *MockPaymentProviderRegistryMockRecorder
+	isgomock struct{}
+}
+
+// MockPaymentProviderRegistryMockRecorder is the mock recorder for MockPaymentProviderRegistry.
+type MockPaymentProviderRegistryMockRecorder struct {
+	mock *MockPaymentProviderRegistry
+}
+
+// NewMockPaymentProviderRegistry creates a new mock instance.
+func NewMockPaymentProviderRegistry(ctrl *gomock.Controller) *MockPaymentProviderRegistry {
+	mock := &MockPaymentProviderRegistry{ctrl: ctrl}
+	mock.recorder = &MockPaymentProviderRegistryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPaymentProviderRegistry) EXPECT() *MockPaymentProviderRegistryMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockPaymentProviderRegistry) Get(provider valueobject.PaymentProvider) (valueobject.PaymentProvider, port.PaymentExternalDatasource, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", provider)
+	ret0, _ := ret[0].(valueobject.PaymentProvider)
+	ret1, _ := ret[1].(port.PaymentExternalDatasource)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockPaymentProviderRegistryMockRecorder) Get(provider any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockPaymentProviderRegistry)(nil).Get), provider)
+}
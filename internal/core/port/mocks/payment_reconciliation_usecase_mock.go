@@ -0,0 +1,57 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/core/port/payment_reconciliation_usecase_port.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/core/port/payment_reconciliation_usecase_port.go -destination=internal/core/port/mocks/payment_reconciliation_usecase_mock.go
+//
+
+// Package mock_port is a generated GoMock package.
+package mock_port
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPaymentReconciliationUseCase is a mock of PaymentReconciliationUseCase interface.
+type MockPaymentReconciliationUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockPaymentReconciliationUseCaseMockRecorder
+	isgomock struct{}
+}
+
+// MockPaymentReconciliationUseCaseMockRecorder is the mock recorder for MockPaymentReconciliationUseCase.
+type MockPaymentReconciliationUseCaseMockRecorder struct {
+	mock *MockPaymentReconciliationUseCase
+}
+
+// NewMockPaymentReconciliationUseCase creates a new mock instance.
+func NewMockPaymentReconciliationUseCase(ctrl *gomock.Controller) *MockPaymentReconciliationUseCase {
+	mock := &MockPaymentReconciliationUseCase{ctrl: ctrl}
+	mock.recorder = &MockPaymentReconciliationUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPaymentReconciliationUseCase) EXPECT() *MockPaymentReconciliationUseCaseMockRecorder {
+	return m.recorder
+}
+
+// Reconcile mocks base method.
+func (m *MockPaymentReconciliationUseCase) Reconcile(ctx context.Context) (*entity.PaymentReconciliationReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reconcile", ctx)
+	ret0, _ := ret[0].(*entity.PaymentReconciliationReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reconcile indicates an expected call of Reconcile.
+func (mr *MockPaymentReconciliationUseCaseMockRecorder) Reconcile(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reconcile", reflect.TypeOf((*MockPaymentReconciliationUseCase)(nil).Reconcile), ctx)
+}
@@ -0,0 +1,74 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/core/port/payment_refund_usecase_port.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/core/port/payment_refund_usecase_port.go -destination=internal/core/port/mocks/payment_refund_usecase_mock.go
+//
+
+// Package mock_port is a generated GoMock package.
+package mock_port
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
+	valueobject "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/value_object"
+	dto "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/dto"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPaymentRefundUseCase is a mock of PaymentRefundUseCase interface.
+type MockPaymentRefundUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockPaymentRefundUseCaseMockRecorder
+	isgomock struct{}
+}
+
+// MockPaymentRefundUseCaseMockRecorder is the mock recorder for MockPaymentRefundUseCase.
+type MockPaymentRefundUseCaseMockRecorder struct {
+	mock *MockPaymentRefundUseCase
+}
+
+// NewMockPaymentRefundUseCase creates a new mock instance.
+func NewMockPaymentRefundUseCase(ctrl *gomock.Controller) *MockPaymentRefundUseCase {
+	mock := &MockPaymentRefundUseCase{ctrl: ctrl}
+	mock.recorder = &MockPaymentRefundUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPaymentRefundUseCase) EXPECT() *MockPaymentRefundUseCaseMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockPaymentRefundUseCase) Create(ctx context.Context, input dto.CreateRefundInput) (*entity.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, input)
+	ret0, _ := ret[0].(*entity.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPaymentRefundUseCaseMockRecorder) Create(ctx, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPaymentRefundUseCase)(nil).Create), ctx, input)
+}
+
+// RefundOrder mocks base method.
+func (m *MockPaymentRefundUseCase) RefundOrder(ctx context.Context, input dto.CreateRefundInput) (valueobject.PaymentStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefundOrder", ctx, input)
+	ret0, _ := ret[0].(valueobject.PaymentStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RefundOrder indicates an expected call of RefundOrder.
+func (mr *MockPaymentRefundUseCaseMockRecorder) RefundOrder(ctx, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefundOrder", reflect.TypeOf((*MockPaymentRefundUseCase)(nil).RefundOrder), ctx, input)
+}
@@ -42,6 +42,21 @@ func (m *MockPaymentUseCase) EXPECT() *MockPaymentUseCaseMockRecorder {
 	return m.recorder
 }
 
+// ConfirmCash mocks base method.
+func (m *MockPaymentUseCase) ConfirmCash(ctx context.Context, input dto.ConfirmCashPaymentInput) (*entity.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmCash", ctx, input)
+	ret0, _ := ret[0].(*entity.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConfirmCash indicates an expected call of ConfirmCash.
+func (mr *MockPaymentUseCaseMockRecorder) ConfirmCash(ctx, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmCash", reflect.TypeOf((*MockPaymentUseCase)(nil).ConfirmCash), ctx, input)
+}
+
 // Create mocks base method.
 func (m *MockPaymentUseCase) Create(ctx context.Context, input dto.CreatePaymentInput) (*entity.Payment, error) {
 	m.ctrl.T.Helper()
@@ -57,6 +72,21 @@ func (mr *MockPaymentUseCaseMockRecorder) Create(ctx, input any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPaymentUseCase)(nil).Create), ctx, input)
 }
 
+// ExpirePayments mocks base method.
+func (m *MockPaymentUseCase) ExpirePayments(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpirePayments", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpirePayments indicates an expected call of ExpirePayments.
+func (mr *MockPaymentUseCaseMockRecorder) ExpirePayments(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpirePayments", reflect.TypeOf((*MockPaymentUseCase)(nil).ExpirePayments), ctx)
+}
+
 // Get mocks base method.
 func (m *MockPaymentUseCase) Get(ctx context.Context, payment dto.GetPaymentInput) (*entity.Payment, error) {
 	m.ctrl.T.Helper()
@@ -100,26 +100,13 @@ func (mr *MockProductDataSourceMockRecorder) FindByID(ctx, id any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockProductDataSource)(nil).FindByID), ctx, id)
 }
 
-// Transaction mocks base method.
-func (m *MockProductDataSource) Transaction(ctx context.Context, fn func(context.Context) error) error {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Transaction", ctx, fn)
-	ret0, _ := ret[0].(error)
-	return ret0
-}
-
-// Transaction indicates an expected call of Transaction.
-func (mr *MockProductDataSourceMockRecorder) Transaction(ctx, fn any) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Transaction", reflect.TypeOf((*MockProductDataSource)(nil).Transaction), ctx, fn)
-}
-
 // Update mocks base method.
-func (m *MockProductDataSource) Update(ctx context.Context, product *entity.Product) error {
+func (m *MockProductDataSource) Update(ctx context.Context, product *entity.Product) (bool, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Update", ctx, product)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // Update indicates an expected call of Update.
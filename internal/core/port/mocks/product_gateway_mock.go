@@ -101,11 +101,12 @@ func (mr *MockProductGatewayMockRecorder) FindByID(ctx, id any) *gomock.Call {
 }
 
 // Update mocks base method.
-func (m *MockProductGateway) Update(ctx context.Context, product *entity.Product) error {
+func (m *MockProductGateway) Update(ctx context.Context, product *entity.Product) (bool, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Update", ctx, product)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // Update indicates an expected call of Update.
@@ -0,0 +1,116 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/core/port/refresh_token_datasource_port.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/core/port/refresh_token_datasource_port.go -destination=internal/core/port/mocks/refresh_token_datasource_mock.go
+//
+
+// Package mock_port is a generated GoMock package.
+package mock_port
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	entity "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRefreshTokenDataSource is a mock of RefreshTokenDataSource interface.
+type MockRefreshTokenDataSource struct {
+	ctrl     *gomock.Controller
+	recorder *MockRefreshTokenDataSourceMockRecorder
+	isgomock struct{}
+}
+
+// MockRefreshTokenDataSourceMockRecorder is the mock recorder for MockRefreshTokenDataSource.
+type MockRefreshTokenDataSourceMockRecorder struct {
+	mock *MockRefreshTokenDataSource
+}
+
+// NewMockRefreshTokenDataSource creates a new mock instance.
+func NewMockRefreshTokenDataSource(ctrl *gomock.Controller) *MockRefreshTokenDataSource {
+	mock := &MockRefreshTokenDataSource{ctrl: ctrl}
+	mock.recorder = &MockRefreshTokenDataSourceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRefreshTokenDataSource) EXPECT() *MockRefreshTokenDataSourceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockRefreshTokenDataSource) Create(ctx context.Context, token *entity.RefreshToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockRefreshTokenDataSourceMockRecorder) Create(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRefreshTokenDataSource)(nil).Create), ctx, token)
+}
+
+// FindByFamilyID mocks base method.
+func (m *MockRefreshTokenDataSource) FindByFamilyID(ctx context.Context, familyID string) ([]*entity.RefreshToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByFamilyID", ctx, familyID)
+	ret0, _ := ret[0].([]*entity.RefreshToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByFamilyID indicates an expected call of FindByFamilyID.
+func (mr *MockRefreshTokenDataSourceMockRecorder) FindByFamilyID(ctx, familyID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByFamilyID", reflect.TypeOf((*MockRefreshTokenDataSource)(nil).FindByFamilyID), ctx, familyID)
+}
+
+// FindByHash mocks base method.
+func (m *MockRefreshTokenDataSource) FindByHash(ctx context.Context, hash string) (*entity.RefreshToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByHash", ctx, hash)
+	ret0, _ := ret[0].(*entity.RefreshToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByHash indicates an expected call of FindByHash.
+func (mr *MockRefreshTokenDataSourceMockRecorder) FindByHash(ctx, hash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByHash", reflect.TypeOf((*MockRefreshTokenDataSource)(nil).FindByHash), ctx, hash)
+}
+
+// MarkUsed mocks base method.
+func (m *MockRefreshTokenDataSource) MarkUsed(ctx context.Context, id uint64, usedAt time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkUsed", ctx, id, usedAt)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkUsed indicates an expected call of MarkUsed.
+func (mr *MockRefreshTokenDataSourceMockRecorder) MarkUsed(ctx, id, usedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkUsed", reflect.TypeOf((*MockRefreshTokenDataSource)(nil).MarkUsed), ctx, id, usedAt)
+}
+
+// RevokeFamily mocks base method.
+func (m *MockRefreshTokenDataSource) RevokeFamily(ctx context.Context, familyID string, revokedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeFamily", ctx, familyID, revokedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeFamily indicates an expected call of RevokeFamily.
+func (mr *MockRefreshTokenDataSourceMockRecorder) RevokeFamily(ctx, familyID, revokedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeFamily", reflect.TypeOf((*MockRefreshTokenDataSource)(nil).RevokeFamily), ctx, familyID, revokedAt)
+}
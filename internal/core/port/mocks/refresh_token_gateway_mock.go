@@ -0,0 +1,116 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/core/port/refresh_token_gateway_port.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/core/port/refresh_token_gateway_port.go -destination=internal/core/port/mocks/refresh_token_gateway_mock.go
+//
+
+// Package mock_port is a generated GoMock package.
+package mock_port
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	entity "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRefreshTokenGateway is a mock of RefreshTokenGateway interface.
+type MockRefreshTokenGateway struct {
+	ctrl     *gomock.Controller
+	recorder *MockRefreshTokenGatewayMockRecorder
+	isgomock struct{}
+}
+
+// MockRefreshTokenGatewayMockRecorder is the mock recorder for MockRefreshTokenGateway.
+type MockRefreshTokenGatewayMockRecorder struct {
+	mock *MockRefreshTokenGateway
+}
+
+// NewMockRefreshTokenGateway creates a new mock instance.
+func NewMockRefreshTokenGateway(ctrl *gomock.Controller) *MockRefreshTokenGateway {
+	mock := &MockRefreshTokenGateway{ctrl: ctrl}
+	mock.recorder = &MockRefreshTokenGatewayMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRefreshTokenGateway) EXPECT() *MockRefreshTokenGatewayMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockRefreshTokenGateway) Create(ctx context.Context, token *entity.RefreshToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockRefreshTokenGatewayMockRecorder) Create(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRefreshTokenGateway)(nil).Create), ctx, token)
+}
+
+// FindByFamilyID mocks base method.
+func (m *MockRefreshTokenGateway) FindByFamilyID(ctx context.Context, familyID string) ([]*entity.RefreshToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByFamilyID", ctx, familyID)
+	ret0, _ := ret[0].([]*entity.RefreshToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByFamilyID indicates an expected call of FindByFamilyID.
+func (mr *MockRefreshTokenGatewayMockRecorder) FindByFamilyID(ctx, familyID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByFamilyID", reflect.TypeOf((*MockRefreshTokenGateway)(nil).FindByFamilyID), ctx, familyID)
+}
+
+// FindByHash mocks base method.
+func (m *MockRefreshTokenGateway) FindByHash(ctx context.Context, hash string) (*entity.RefreshToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByHash", ctx, hash)
+	ret0, _ := ret[0].(*entity.RefreshToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByHash indicates an expected call of FindByHash.
+func (mr *MockRefreshTokenGatewayMockRecorder) FindByHash(ctx, hash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByHash", reflect.TypeOf((*MockRefreshTokenGateway)(nil).FindByHash), ctx, hash)
+}
+
+// MarkUsed mocks base method.
+func (m *MockRefreshTokenGateway) MarkUsed(ctx context.Context, id uint64, usedAt time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkUsed", ctx, id, usedAt)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkUsed indicates an expected call of MarkUsed.
+func (mr *MockRefreshTokenGatewayMockRecorder) MarkUsed(ctx, id, usedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkUsed", reflect.TypeOf((*MockRefreshTokenGateway)(nil).MarkUsed), ctx, id, usedAt)
+}
+
+// RevokeFamily mocks base method.
+func (m *MockRefreshTokenGateway) RevokeFamily(ctx context.Context, familyID string, revokedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeFamily", ctx, familyID, revokedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeFamily indicates an expected call of RevokeFamily.
+func (mr *MockRefreshTokenGatewayMockRecorder) RevokeFamily(ctx, familyID, revokedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeFamily", reflect.TypeOf((*MockRefreshTokenGateway)(nil).RevokeFamily), ctx, familyID, revokedAt)
+}
@@ -0,0 +1,72 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/core/port/refresh_token_service_port.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/core/port/refresh_token_service_port.go -destination=internal/core/port/mocks/refresh_token_service_mock.go
+//
+
+// Package mock_port is a generated GoMock package.
+package mock_port
+
+import (
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRefreshTokenService is a mock of RefreshTokenService interface.
+type MockRefreshTokenService struct {
+	ctrl     *gomock.Controller
+	recorder *MockRefreshTokenServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockRefreshTokenServiceMockRecorder is the mock recorder for MockRefreshTokenService.
+type MockRefreshTokenServiceMockRecorder struct {
+	mock *MockRefreshTokenService
+}
+
+// NewMockRefreshTokenService creates a new mock instance.
+func NewMockRefreshTokenService(ctrl *gomock.Controller) *MockRefreshTokenService {
+	mock := &MockRefreshTokenService{ctrl: ctrl}
+	mock.recorder = &MockRefreshTokenServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRefreshTokenService) EXPECT() *MockRefreshTokenServiceMockRecorder {
+	return m.recorder
+}
+
+// Generate mocks base method.
+func (m *MockRefreshTokenService) Generate() (string, string, time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Generate")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(time.Time)
+	ret3, _ := ret[3].(error)
+	return ret0, ret1, ret2, ret3
+}
+
+// Generate indicates an expected call of Generate.
+func (mr *MockRefreshTokenServiceMockRecorder) Generate() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Generate", reflect.TypeOf((*MockRefreshTokenService)(nil).Generate))
+}
+
+// Hash mocks base method.
+func (m *MockRefreshTokenService) Hash(token string) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Hash", token)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Hash indicates an expected call of Hash.
+func (mr *MockRefreshTokenServiceMockRecorder) Hash(token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Hash", reflect.TypeOf((*MockRefreshTokenService)(nil).Hash), token)
+}
@@ -0,0 +1,71 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/core/port/revoked_token_datasource_port.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/core/port/revoked_token_datasource_port.go -destination=internal/core/port/mocks/revoked_token_datasource_mock.go
+//
+
+// Package mock_port is a generated GoMock package.
+package mock_port
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRevokedTokenDataSource is a mock of RevokedTokenDataSource interface.
+type MockRevokedTokenDataSource struct {
+	ctrl     *gomock.Controller
+	recorder *MockRevokedTokenDataSourceMockRecorder
+	isgomock struct{}
+}
+
+// MockRevokedTokenDataSourceMockRecorder is the mock recorder for MockRevokedTokenDataSource.
+type MockRevokedTokenDataSourceMockRecorder struct {
+	mock *MockRevokedTokenDataSource
+}
+
+// NewMockRevokedTokenDataSource creates a new mock instance.
+func NewMockRevokedTokenDataSource(ctrl *gomock.Controller) *MockRevokedTokenDataSource {
+	mock := &MockRevokedTokenDataSource{ctrl: ctrl}
+	mock.recorder = &MockRevokedTokenDataSourceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRevokedTokenDataSource) EXPECT() *MockRevokedTokenDataSourceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockRevokedTokenDataSource) Create(ctx context.Context, token *entity.RevokedToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockRevokedTokenDataSourceMockRecorder) Create(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRevokedTokenDataSource)(nil).Create), ctx, token)
+}
+
+// ExistsByJTI mocks base method.
+func (m *MockRevokedTokenDataSource) ExistsByJTI(ctx context.Context, jti string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExistsByJTI", ctx, jti)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExistsByJTI indicates an expected call of ExistsByJTI.
+func (mr *MockRevokedTokenDataSourceMockRecorder) ExistsByJTI(ctx, jti any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExistsByJTI", reflect.TypeOf((*MockRevokedTokenDataSource)(nil).ExistsByJTI), ctx, jti)
+}
@@ -0,0 +1,71 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/core/port/revoked_token_gateway_port.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/core/port/revoked_token_gateway_port.go -destination=internal/core/port/mocks/revoked_token_gateway_mock.go
+//
+
+// Package mock_port is a generated GoMock package.
+package mock_port
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRevokedTokenGateway is a mock of RevokedTokenGateway interface.
+type MockRevokedTokenGateway struct {
+	ctrl     *gomock.Controller
+	recorder *MockRevokedTokenGatewayMockRecorder
+	isgomock struct{}
+}
+
+// MockRevokedTokenGatewayMockRecorder is the mock recorder for MockRevokedTokenGateway.
+type MockRevokedTokenGatewayMockRecorder struct {
+	mock *MockRevokedTokenGateway
+}
+
+// NewMockRevokedTokenGateway creates a new mock instance.
+func NewMockRevokedTokenGateway(ctrl *gomock.Controller) *MockRevokedTokenGateway {
+	mock := &MockRevokedTokenGateway{ctrl: ctrl}
+	mock.recorder = &MockRevokedTokenGatewayMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRevokedTokenGateway) EXPECT() *MockRevokedTokenGatewayMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockRevokedTokenGateway) Create(ctx context.Context, token *entity.RevokedToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockRevokedTokenGatewayMockRecorder) Create(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRevokedTokenGateway)(nil).Create), ctx, token)
+}
+
+// ExistsByJTI mocks base method.
+func (m *MockRevokedTokenGateway) ExistsByJTI(ctx context.Context, jti string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExistsByJTI", ctx, jti)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExistsByJTI indicates an expected call of ExistsByJTI.
+func (mr *MockRevokedTokenGatewayMockRecorder) ExistsByJTI(ctx, jti any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExistsByJTI", reflect.TypeOf((*MockRevokedTokenGateway)(nil).ExistsByJTI), ctx, jti)
+}
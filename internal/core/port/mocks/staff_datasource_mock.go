@@ -100,20 +100,6 @@ func (mr *MockStaffDataSourceMockRecorder) FindByID(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockStaffDataSource)(nil).FindByID), ctx, id)
 }
 
-// Transaction mocks base method.
-func (m *MockStaffDataSource) Transaction(ctx context.Context, fn func(context.Context) error) error {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Transaction", ctx, fn)
-	ret0, _ := ret[0].(error)
-	return ret0
-}
-
-// Transaction indicates an expected call of Transaction.
-func (mr *MockStaffDataSourceMockRecorder) Transaction(ctx, fn any) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Transaction", reflect.TypeOf((*MockStaffDataSource)(nil).Transaction), ctx, fn)
-}
-
 // Update mocks base method.
 func (m *MockStaffDataSource) Update(ctx context.Context, staff *entity.Staff) error {
 	m.ctrl.T.Helper()
@@ -0,0 +1,71 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/core/port/token_revocation_service_port.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/core/port/token_revocation_service_port.go -destination=internal/core/port/mocks/token_revocation_service_mock.go
+//
+
+// Package mock_port is a generated GoMock package.
+package mock_port
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockTokenRevocationService is a mock of TokenRevocationService interface.
+type MockTokenRevocationService struct {
+	ctrl     *gomock.Controller
+	recorder *MockTokenRevocationServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockTokenRevocationServiceMockRecorder is the mock recorder for MockTokenRevocationService.
+type MockTokenRevocationServiceMockRecorder struct {
+	mock *MockTokenRevocationService
+}
+
+// NewMockTokenRevocationService creates a new mock instance.
+func NewMockTokenRevocationService(ctrl *gomock.Controller) *MockTokenRevocationService {
+	mock := &MockTokenRevocationService{ctrl: ctrl}
+	mock.recorder = &MockTokenRevocationServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTokenRevocationService) EXPECT() *MockTokenRevocationServiceMockRecorder {
+	return m.recorder
+}
+
+// IsRevoked mocks base method.
+func (m *MockTokenRevocationService) IsRevoked(ctx context.Context, tokenID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsRevoked", ctx, tokenID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsRevoked indicates an expected call of IsRevoked.
+func (mr *MockTokenRevocationServiceMockRecorder) IsRevoked(ctx, tokenID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsRevoked", reflect.TypeOf((*MockTokenRevocationService)(nil).IsRevoked), ctx, tokenID)
+}
+
+// Revoke mocks base method.
+func (m *MockTokenRevocationService) Revoke(ctx context.Context, tokenID string, expiresAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", ctx, tokenID, expiresAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockTokenRevocationServiceMockRecorder) Revoke(ctx, tokenID, expiresAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockTokenRevocationService)(nil).Revoke), ctx, tokenID, expiresAt)
+}
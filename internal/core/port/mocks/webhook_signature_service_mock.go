@@ -0,0 +1,69 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/core/port/webhook_signature_service_port.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/core/port/webhook_signature_service_port.go -destination=internal/core/port/mocks/webhook_signature_service_mock.go
+//
+
+// Package mock_port is a generated GoMock package.
+package mock_port
+
+import (
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockWebhookSignatureService is a mock of WebhookSignatureService interface.
+type MockWebhookSignatureService struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookSignatureServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockWebhookSignatureServiceMockRecorder is the mock recorder for MockWebhookSignatureService.
+type MockWebhookSignatureServiceMockRecorder struct {
+	mock *MockWebhookSignatureService
+}
+
+// NewMockWebhookSignatureService creates a new mock instance.
+func NewMockWebhookSignatureService(ctrl *gomock.Controller) *MockWebhookSignatureService {
+	mock := &MockWebhookSignatureService{ctrl: ctrl}
+	mock.recorder = &MockWebhookSignatureServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookSignatureService) EXPECT() *MockWebhookSignatureServiceMockRecorder {
+	return m.recorder
+}
+
+// Sign mocks base method.
+func (m *MockWebhookSignatureService) Sign(dataID, requestID string, timestamp time.Time) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Sign", dataID, requestID, timestamp)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Sign indicates an expected call of Sign.
+func (mr *MockWebhookSignatureServiceMockRecorder) Sign(dataID, requestID, timestamp any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Sign", reflect.TypeOf((*MockWebhookSignatureService)(nil).Sign), dataID, requestID, timestamp)
+}
+
+// Verify mocks base method.
+func (m *MockWebhookSignatureService) Verify(signature, dataID, requestID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Verify", signature, dataID, requestID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Verify indicates an expected call of Verify.
+func (mr *MockWebhookSignatureServiceMockRecorder) Verify(signature, dataID, requestID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Verify", reflect.TypeOf((*MockWebhookSignatureService)(nil).Verify), signature, dataID, requestID)
+}
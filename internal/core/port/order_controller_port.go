@@ -11,5 +11,7 @@ type OrderController interface {
 	Create(ctx context.Context, presenter Presenter, input dto.CreateOrderInput) ([]byte, error)
 	Get(ctx context.Context, presenter Presenter, input dto.GetOrderInput) ([]byte, error)
 	Update(ctx context.Context, presenter Presenter, input dto.UpdateOrderInput) ([]byte, error)
+	AttachCustomer(ctx context.Context, presenter Presenter, input dto.AttachOrderCustomerInput) ([]byte, error)
 	Delete(ctx context.Context, presenter Presenter, input dto.DeleteOrderInput) ([]byte, error)
+	SubscribeStatus(ctx context.Context, presenter Presenter, input dto.SubscribeOrderStatusInput) (<-chan dto.OrderStatusMessage, error)
 }
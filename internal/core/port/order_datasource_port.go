@@ -2,15 +2,22 @@ package port
 
 import (
 	"context"
+	"time"
 
 	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
+	valueobject "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/value_object"
 )
 
 type OrderDataSource interface {
 	FindByID(ctx context.Context, id uint64) (*entity.Order, error)
+	// FindByIDForUpdate returns the order locked until the unit of work of ctx commits
+	FindByIDForUpdate(ctx context.Context, id uint64) (*entity.Order, error)
 	FindAll(ctx context.Context, filters map[string]any, sort string, page, limit int) ([]*entity.Order, int64, error)
+	// FindIdle returns the oldest orders on the status without any change since idleSince, up to limit
+	FindIdle(ctx context.Context, status valueobject.OrderStatus, idleSince time.Time, limit int) ([]*entity.Order, error)
 	Create(ctx context.Context, order *entity.Order) error
-	Update(ctx context.Context, order *entity.Order) error
+	Update(ctx context.Context, order *entity.Order) (bool, error)
+	// UpdateEstimatedReadyAt saves the estimated ready time of the order, without changing its version
+	UpdateEstimatedReadyAt(ctx context.Context, id uint64, estimatedReadyAt time.Time) error
 	Delete(ctx context.Context, id uint64) error
-	Transaction(ctx context.Context, fn func(ctx context.Context) error) error
 }
@@ -0,0 +1,11 @@
+package port
+
+import (
+	"context"
+	"time"
+)
+
+type OrderEstimateUseCase interface {
+	// EstimateQueue estimates when each order in the kitchen gets READY, saving the estimates that changed
+	EstimateQueue(ctx context.Context) (map[uint64]time.Time, error)
+}
@@ -0,0 +1,12 @@
+package port
+
+import "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
+
+// OrderEventBus delivers the order status changes to the subscribers of this process
+type OrderEventBus interface {
+	// Publish sends the event to every subscriber, without waiting for them
+	Publish(event *entity.OrderStatusEvent)
+	// Subscribe returns the channel of the events published from now on, closed by unsubscribe or when the subscriber
+	// falls too far behind, in which case it should resume from the last event it received
+	Subscribe() (events <-chan *entity.OrderStatusEvent, unsubscribe func())
+}
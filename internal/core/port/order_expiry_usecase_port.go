@@ -0,0 +1,11 @@
+package port
+
+import (
+	"context"
+)
+
+type OrderExpiryUseCase interface {
+	// ExpireOrders closes the READY orders never collected and cancels the OPEN orders left idle, returning how many
+	// orders were changed
+	ExpireOrders(ctx context.Context) (int, error)
+}
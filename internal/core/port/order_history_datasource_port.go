@@ -2,6 +2,7 @@ package port
 
 import (
 	"context"
+	"time"
 
 	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
 )
@@ -9,7 +10,15 @@ import (
 type OrderHistoryDataSource interface {
 	FindByID(ctx context.Context, id uint64) (*entity.OrderHistory, error)
 	FindAll(ctx context.Context, filters map[string]interface{}, page, limit int) ([]*entity.OrderHistory, int64, error)
+	// FindAfter returns the histories with an ID greater than afterID, oldest first, with their order. A history is
+	// only returned once no history with a lower ID can be committed anymore.
+	FindAfter(ctx context.Context, afterID uint64, filters map[string]interface{}, limit int) ([]*entity.OrderHistory, error)
+	// FindLastID returns the ID of the last history committed after every one with a lower ID, 0 without any
+	FindLastID(ctx context.Context) (uint64, error)
+	// FindByOrderIDs returns the histories of the orders, oldest first
+	FindByOrderIDs(ctx context.Context, orderIDs []uint64) ([]*entity.OrderHistory, error)
+	// AveragePrepTime returns the average time from PREPARING to READY of the last orders that got READY, 0 without any
+	AveragePrepTime(ctx context.Context, sampleSize int) (time.Duration, error)
 	Create(ctx context.Context, entity *entity.OrderHistory) error
 	Delete(ctx context.Context, id uint64) error
-	Transaction(ctx context.Context, fn func(ctx context.Context) error) error
 }
@@ -12,4 +12,5 @@ type OrderProductController interface {
 	Get(ctx context.Context, presenter Presenter, input dto.GetOrderProductInput) ([]byte, error)
 	Update(ctx context.Context, presenter Presenter, input dto.UpdateOrderProductInput) ([]byte, error)
 	Delete(ctx context.Context, presenter Presenter, input dto.DeleteOrderProductInput) ([]byte, error)
+	Reprice(ctx context.Context, presenter Presenter, input dto.RepriceOrderProductsInput) ([]byte, error)
 }
@@ -12,5 +12,4 @@ type OrderProductDataSource interface {
 	Create(ctx context.Context, order *entity.OrderProduct) error
 	Update(ctx context.Context, order *entity.OrderProduct) error
 	Delete(ctx context.Context, orderId uint64, productId uint64) error
-	Transaction(ctx context.Context, fn func(ctx context.Context) error) error
 }
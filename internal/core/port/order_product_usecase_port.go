@@ -13,4 +13,5 @@ type OrderProductUseCase interface {
 	Get(ctx context.Context, input dto.GetOrderProductInput) (*entity.OrderProduct, error)
 	Update(ctx context.Context, input dto.UpdateOrderProductInput) (*entity.OrderProduct, error)
 	Delete(ctx context.Context, input dto.DeleteOrderProductInput) (*entity.OrderProduct, error)
+	Reprice(ctx context.Context, input dto.RepriceOrderProductsInput) (*entity.Order, error)
 }
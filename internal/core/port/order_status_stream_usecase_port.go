@@ -0,0 +1,13 @@
+package port
+
+import (
+	"context"
+
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/dto"
+)
+
+type OrderStatusStreamUseCase interface {
+	// Subscribe returns the status changes of the orders since the cursor, then the live ones, until ctx is done
+	Subscribe(ctx context.Context, input dto.SubscribeOrderStatusInput) (<-chan *entity.OrderStatusEvent, error)
+}
@@ -0,0 +1,14 @@
+package port
+
+import (
+	"context"
+
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
+)
+
+type OrderTimingUseCase interface {
+	// Track computes the time each order spent in each status from its histories, setting its Timing
+	Track(ctx context.Context, orders []*entity.Order) error
+	// ListLate returns the orders in the kitchen past their preparation target, oldest first
+	ListLate(ctx context.Context) ([]*entity.Order, error)
+}
@@ -12,5 +12,6 @@ type OrderUseCase interface {
 	Create(ctx context.Context, input dto.CreateOrderInput) (*entity.Order, error)
 	Get(ctx context.Context, input dto.GetOrderInput) (*entity.Order, error)
 	Update(ctx context.Context, input dto.UpdateOrderInput) (*entity.Order, error)
+	AttachCustomer(ctx context.Context, input dto.AttachOrderCustomerInput) (*entity.Order, error)
 	Delete(ctx context.Context, input dto.DeleteOrderInput) (*entity.Order, error)
 }
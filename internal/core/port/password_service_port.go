@@ -0,0 +1,10 @@
+package port
+
+// PasswordService provides password hashing and verification methods
+type PasswordService interface {
+	// Hash returns a one-way hash of the given password
+	Hash(password string) (string, error)
+
+	// Compare returns true if the password matches the hash
+	Compare(hash, password string) bool
+}
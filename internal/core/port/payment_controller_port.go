@@ -10,4 +10,6 @@ type PaymentController interface {
 	Create(ctx context.Context, presenter Presenter, input dto.CreatePaymentInput) ([]byte, error)
 	Update(ctx context.Context, p Presenter, i dto.UpdatePaymentInput) ([]byte, error)
 	Get(ctx context.Context, p Presenter, i dto.GetPaymentInput) ([]byte, error)
+	Refund(ctx context.Context, p Presenter, i dto.CreateRefundInput) ([]byte, error)
+	ConfirmCash(ctx context.Context, p Presenter, i dto.ConfirmCashPaymentInput) ([]byte, error)
 }
@@ -2,6 +2,7 @@ package port
 
 import (
 	"context"
+	"time"
 
 	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
 	valueobject "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/value_object"
@@ -13,4 +14,12 @@ type PaymentDataSource interface {
 	GetByOrderIDAndStatusProcessing(ctx context.Context, orderID uint64) (*entity.Payment, error)
 	UpdateStatus(ctx context.Context, status valueobject.PaymentStatus, externalPaymentID string) error
 	GetByExternalPaymentID(ctx context.Context, externalPaymentID string) (*entity.Payment, error)
+	CountByOrderIDAndStatus(ctx context.Context, orderID uint64, statuses []valueobject.PaymentStatus) (int64, error)
+	GetExpiredProcessing(ctx context.Context, now time.Time, limit int) ([]*entity.Payment, error)
+	UpdateStatusIfProcessing(ctx context.Context, id uint64, status valueobject.PaymentStatus) (bool, error)
+	GetLastByOrderIDAndStatus(ctx context.Context, orderID uint64, statuses []valueobject.PaymentStatus) (*entity.Payment, error)
+	CreatePendingRefund(ctx context.Context, refund *entity.PaymentRefund, refundedAmount valueobject.Money) (bool, error)
+	CompleteRefund(ctx context.Context, refund *entity.PaymentRefund, status valueobject.PaymentStatus) error
+	ConfirmCash(ctx context.Context, receipt *entity.CashReceipt) (bool, error)
+	GetProcessing(ctx context.Context, afterID uint64, limit int) ([]*entity.Payment, error)
 }
@@ -4,8 +4,11 @@ import (
 	"context"
 
 	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
+	valueobject "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/value_object"
 )
 
 type PaymentExternalDatasource interface {
 	Create(context context.Context, payment *entity.CreatePaymentExternalInput) (*entity.CreatePaymentExternalOutput, error)
+	GetStatus(ctx context.Context, payment *entity.Payment) (valueobject.PaymentStatus, error)
+	Refund(ctx context.Context, refund *entity.RefundPaymentExternalInput) (*entity.RefundPaymentExternalOutput, error)
 }
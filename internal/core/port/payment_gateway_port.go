@@ -2,6 +2,7 @@ package port
 
 import (
 	"context"
+	"time"
 
 	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
 	valueobject "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/value_object"
@@ -14,4 +15,14 @@ type PaymentGateway interface {
 	FindByOrderIDAndStatusProcessing(ctx context.Context, orderID uint64) (*entity.Payment, error) // TODO: Unify with FindByExternalPaymentID into FindOne
 	FindByExternalPaymentID(ctx context.Context, resource string) (*entity.Payment, error)         // TODO: Unify with FindByExternalPaymentID into FindOne
 	Update(ctx context.Context, status valueobject.PaymentStatus, resource string) error
+	CountByOrderIDAndStatus(ctx context.Context, orderID uint64, statuses ...valueobject.PaymentStatus) (int64, error)
+	FindExternalStatus(ctx context.Context, payment *entity.Payment) (valueobject.PaymentStatus, error)
+	FindExpiredProcessing(ctx context.Context, now time.Time, limit int) ([]*entity.Payment, error)
+	UpdateIfProcessing(ctx context.Context, id uint64, status valueobject.PaymentStatus) (bool, error)
+	FindByOrderIDAndStatus(ctx context.Context, orderID uint64, statuses ...valueobject.PaymentStatus) (*entity.Payment, error)
+	RefundExternal(ctx context.Context, refund *entity.RefundPaymentExternalInput) (*entity.RefundPaymentExternalOutput, error)
+	CreatePendingRefund(ctx context.Context, refund *entity.PaymentRefund, refundedAmount valueobject.Money) (bool, error)
+	CompleteRefund(ctx context.Context, refund *entity.PaymentRefund, status valueobject.PaymentStatus) error
+	ConfirmCash(ctx context.Context, receipt *entity.CashReceipt) (bool, error)
+	FindProcessing(ctx context.Context, afterID uint64, limit int) ([]*entity.Payment, error)
 }
@@ -0,0 +1,19 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
+)
+
+type PaymentNotificationGateway interface {
+	FindByEventID(ctx context.Context, eventID string) (*entity.PaymentNotification, error)
+	// FindByEventIDForUpdate returns the notification locked until the unit of work of ctx commits
+	FindByEventIDForUpdate(ctx context.Context, eventID string) (*entity.PaymentNotification, error)
+	// Create stores the notification, returning false if a notification with the same event ID already exists
+	Create(ctx context.Context, notification *entity.PaymentNotification) (bool, error)
+	MarkProcessed(ctx context.Context, id uint64, processedAt time.Time) error
+	// FindUnprocessed returns the notifications received before the given time and never applied, after the given ID
+	FindUnprocessed(ctx context.Context, receivedBefore time.Time, afterID uint64, limit int) ([]*entity.PaymentNotification, error)
+}
@@ -0,0 +1,9 @@
+package port
+
+import valueobject "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/value_object"
+
+// PaymentProviderRegistry holds the payment providers available, keyed by name
+type PaymentProviderRegistry interface {
+	// Get returns the provider with the given name, or the default provider when the name is empty
+	Get(provider valueobject.PaymentProvider) (valueobject.PaymentProvider, PaymentExternalDatasource, error)
+}
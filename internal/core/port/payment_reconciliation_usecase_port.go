@@ -0,0 +1,11 @@
+package port
+
+import (
+	"context"
+
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
+)
+
+type PaymentReconciliationUseCase interface {
+	Reconcile(ctx context.Context) (*entity.PaymentReconciliationReport, error)
+}
@@ -0,0 +1,14 @@
+package port
+
+import (
+	"context"
+
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
+	valueobject "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/value_object"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/dto"
+)
+
+type PaymentRefundUseCase interface {
+	Create(ctx context.Context, input dto.CreateRefundInput) (*entity.Payment, error)
+	RefundOrder(ctx context.Context, input dto.CreateRefundInput) (valueobject.PaymentStatus, error)
+}
@@ -11,4 +11,6 @@ type PaymentUseCase interface {
 	Create(ctx context.Context, input dto.CreatePaymentInput) (*entity.Payment, error)
 	Update(ctx context.Context, payment dto.UpdatePaymentInput) (*entity.Payment, error)
 	Get(ctx context.Context, payment dto.GetPaymentInput) (*entity.Payment, error)
+	ExpirePayments(ctx context.Context) (int, error)
+	ConfirmCash(ctx context.Context, input dto.ConfirmCashPaymentInput) (*entity.Payment, error)
 }
@@ -0,0 +1,17 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
+)
+
+type RefreshTokenGateway interface {
+	FindByHash(ctx context.Context, hash string) (*entity.RefreshToken, error)
+	FindByFamilyID(ctx context.Context, familyID string) ([]*entity.RefreshToken, error)
+	Create(ctx context.Context, token *entity.RefreshToken) error
+	// MarkUsed flags an unused token as used, returning false when it was already used or revoked
+	MarkUsed(ctx context.Context, id uint64, usedAt time.Time) (bool, error)
+	RevokeFamily(ctx context.Context, familyID string, revokedAt time.Time) error
+}
@@ -0,0 +1,12 @@
+package port
+
+import "time"
+
+// RefreshTokenService provides opaque refresh token generation and hashing methods
+type RefreshTokenService interface {
+	// Generate creates a new random refresh token, returning the token, its hash and expiration time
+	Generate() (token string, hash string, expiresAt time.Time, err error)
+
+	// Hash returns the hash under which a refresh token is stored
+	Hash(token string) string
+}
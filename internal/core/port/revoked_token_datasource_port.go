@@ -0,0 +1,12 @@
+package port
+
+import (
+	"context"
+
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
+)
+
+type RevokedTokenDataSource interface {
+	ExistsByJTI(ctx context.Context, jti string) (bool, error)
+	Create(ctx context.Context, token *entity.RevokedToken) error
+}
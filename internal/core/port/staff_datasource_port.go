@@ -12,5 +12,4 @@ type StaffDataSource interface {
 	Create(ctx context.Context, staff *entity.Staff) error
 	Update(ctx context.Context, staff *entity.Staff) error
 	Delete(ctx context.Context, id uint64) error
-	Transaction(ctx context.Context, fn func(ctx context.Context) error) error
 }
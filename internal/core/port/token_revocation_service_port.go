@@ -0,0 +1,15 @@
+package port
+
+import (
+	"context"
+	"time"
+)
+
+// TokenRevocationService keeps the list of revoked access tokens
+type TokenRevocationService interface {
+	// Revoke adds the token ID (jti) to the revocation list until the token expires
+	Revoke(ctx context.Context, tokenID string, expiresAt time.Time) error
+
+	// IsRevoked returns true if the token ID (jti) is on the revocation list
+	IsRevoked(ctx context.Context, tokenID string) (bool, error)
+}
@@ -0,0 +1,13 @@
+package port
+
+import "context"
+
+// UnitOfWork runs use case steps that must be saved together, across gateways
+type UnitOfWork interface {
+	// Do calls fn in a transaction, committed when fn returns nil and rolled back otherwise. The gateways called with
+	// the context given to fn take part in the transaction, a nested Do joins it.
+	Do(ctx context.Context, fn func(ctx context.Context) error) error
+	// AfterCommit calls fn once the outermost transaction of ctx commits, or at once outside of one. fn is dropped
+	// when the transaction rolls back, and its error is only logged, the work it follows being already saved.
+	AfterCommit(ctx context.Context, fn func(ctx context.Context) error)
+}
@@ -0,0 +1,12 @@
+package port
+
+import "time"
+
+// WebhookSignatureService signs and verifies the notifications of the payment provider
+type WebhookSignatureService interface {
+	// Sign returns the x-signature header of a notification about dataID, sent with the x-request-id requestID
+	Sign(dataID, requestID string, timestamp time.Time) string
+
+	// Verify checks the x-signature header of a notification, and that it was sent within the tolerance window
+	Verify(signature, dataID, requestID string) error
+}
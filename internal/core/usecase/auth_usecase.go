@@ -2,37 +2,250 @@ package usecase
 
 import (
 	"context"
+	"errors"
+	"time"
 
+	"github.com/google/uuid"
+
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
+	valueobject "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/value_object"
 	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/dto"
 	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/port"
 )
 
 type authUseCase struct {
-	customerUseCase port.CustomerUseCase
-	jwtService      port.JWTService
+	customerUseCase     port.CustomerUseCase
+	staffUseCase        port.StaffUseCase
+	jwtService          port.JWTService
+	passwordService     port.PasswordService
+	refreshTokenService port.RefreshTokenService
+	refreshTokenGateway port.RefreshTokenGateway
+	revocationService   port.TokenRevocationService
 }
 
 // NewAuthUseCase creates a new auth use case instance
-func NewAuthUseCase(customerUseCase port.CustomerUseCase, jwtService port.JWTService) port.AuthUseCase {
+func NewAuthUseCase(
+	customerUseCase port.CustomerUseCase,
+	staffUseCase port.StaffUseCase,
+	jwtService port.JWTService,
+	passwordService port.PasswordService,
+	refreshTokenService port.RefreshTokenService,
+	refreshTokenGateway port.RefreshTokenGateway,
+	revocationService port.TokenRevocationService,
+) port.AuthUseCase {
 	return &authUseCase{
-		customerUseCase: customerUseCase,
-		jwtService:      jwtService,
+		customerUseCase:     customerUseCase,
+		staffUseCase:        staffUseCase,
+		jwtService:          jwtService,
+		passwordService:     passwordService,
+		refreshTokenService: refreshTokenService,
+		refreshTokenGateway: refreshTokenGateway,
+		revocationService:   revocationService,
 	}
 }
 
-// Authenticate authenticates a customer by CPF and returns the customer entity, token and expiration time
-func (u *authUseCase) Authenticate(ctx context.Context, input dto.AuthenticateInput) (string, error) {
+// Authenticate authenticates a customer by CPF and returns the access and refresh tokens
+func (u *authUseCase) Authenticate(ctx context.Context, input dto.AuthenticateInput) (*entity.AuthToken, error) {
 	// Find customer by CPF
 	customer, err := u.customerUseCase.FindByCPF(ctx, dto.FindCustomerByCPFInput(input))
 	if err != nil {
-		return "", err
+		return nil, err
+	}
+
+	// Start a new token family - let the services handle the token durations
+	return u.issueTokens(ctx, &entity.Principal{
+		SubjectType: valueobject.CUSTOMER,
+		ID:          customer.ID,
+	}, uuid.NewString())
+}
+
+// AuthenticateStaff authenticates a staff by ID and password and returns the access and refresh tokens
+func (u *authUseCase) AuthenticateStaff(ctx context.Context, input dto.AuthenticateStaffInput) (*entity.AuthToken, error) {
+	staff, err := u.staffUseCase.Get(ctx, dto.GetStaffInput{ID: input.StaffID})
+	if err != nil {
+		var notFoundErr *domain.NotFoundError
+		if errors.As(err, &notFoundErr) {
+			return nil, domain.NewUnauthorizedError(domain.ErrInvalidCredentials)
+		}
+		return nil, err
+	}
+
+	if staff.PasswordHash == "" || !u.passwordService.Compare(staff.PasswordHash, input.Password) {
+		return nil, domain.NewUnauthorizedError(domain.ErrInvalidCredentials)
+	}
+
+	return u.issueTokens(ctx, &entity.Principal{
+		SubjectType: valueobject.STAFF,
+		ID:          staff.ID,
+		Role:        staff.Role,
+	}, uuid.NewString())
+}
+
+// Refresh rotates the refresh token. Presenting a token that was already used revokes the whole family,
+// since either the client or an attacker holds a stolen copy.
+func (u *authUseCase) Refresh(ctx context.Context, input dto.RefreshTokenInput) (*entity.AuthToken, error) {
+	current, err := u.refreshTokenGateway.FindByHash(ctx, u.refreshTokenService.Hash(input.RefreshToken))
+	if err != nil {
+		return nil, domain.NewInternalError(err)
+	}
+
+	if current == nil || current.IsRevoked() || current.IsExpired(time.Now()) {
+		return nil, domain.NewUnauthorizedError(domain.ErrInvalidRefreshToken)
+	}
+
+	if current.IsUsed() {
+		return nil, u.revokeReusedFamily(ctx, current.FamilyID)
+	}
+
+	// The subject is read again, a removed customer or staff, or a staff whose role changed, must log in again
+	principal, err := u.currentPrincipal(ctx, current)
+	if err != nil {
+		return nil, err
+	}
+
+	if principal == nil {
+		if err := u.revokeFamily(ctx, current.FamilyID); err != nil {
+			return nil, domain.NewInternalError(err)
+		}
+		return nil, domain.NewUnauthorizedError(domain.ErrInvalidRefreshToken)
+	}
+
+	marked, err := u.refreshTokenGateway.MarkUsed(ctx, current.ID, time.Now())
+	if err != nil {
+		return nil, domain.NewInternalError(err)
+	}
+
+	// Lost the race against another request with the same token
+	if !marked {
+		return nil, u.revokeReusedFamily(ctx, current.FamilyID)
 	}
 
-	// Generate JWT token - let the service handle the token duration
-	token, err := u.jwtService.GenerateToken(customer.ID)
+	return u.issueTokens(ctx, principal, current.FamilyID)
+}
+
+// Logout revokes the access token of the caller, and the family of the refresh token when it is given
+func (u *authUseCase) Logout(ctx context.Context, input dto.LogoutInput) error {
+	principal, ok := entity.PrincipalFromContext(ctx)
+	if !ok || principal.TokenID == "" {
+		return domain.NewUnauthorizedError(domain.ErrUnauthorized)
+	}
+
+	if err := u.revocationService.Revoke(ctx, principal.TokenID, principal.TokenExpiresAt); err != nil {
+		return domain.NewInternalError(err)
+	}
+
+	if input.RefreshToken == "" {
+		return nil
+	}
+
+	refreshToken, err := u.refreshTokenGateway.FindByHash(ctx, u.refreshTokenService.Hash(input.RefreshToken))
 	if err != nil {
-		return "", err
+		return domain.NewInternalError(err)
+	}
+
+	// Nothing to revoke, or a token of someone else
+	if refreshToken == nil || !refreshToken.BelongsTo(principal) {
+		return nil
+	}
+
+	if err := u.revokeFamily(ctx, refreshToken.FamilyID); err != nil {
+		return domain.NewInternalError(err)
+	}
+
+	return nil
+}
+
+// currentPrincipal returns the principal of the refresh token as it is now, nil when its subject was removed or the
+// role of the staff changed since the token family was started
+func (u *authUseCase) currentPrincipal(ctx context.Context, token *entity.RefreshToken) (*entity.Principal, error) {
+	var err error
+	switch token.SubjectType {
+	case valueobject.CUSTOMER:
+		_, err = u.customerUseCase.Get(ctx, dto.GetCustomerInput{ID: token.SubjectID})
+	case valueobject.STAFF:
+		var staff *entity.Staff
+		staff, err = u.staffUseCase.Get(ctx, dto.GetStaffInput{ID: token.SubjectID})
+		if err == nil && staff.Role != token.Role {
+			return nil, nil
+		}
+	default:
+		return nil, nil
+	}
+
+	var notFoundErr *domain.NotFoundError
+	if errors.As(err, &notFoundErr) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return token.Principal(), nil
+}
+
+// issueTokens generates an access token and a refresh token of the given family
+func (u *authUseCase) issueTokens(ctx context.Context, principal *entity.Principal, familyID string) (*entity.AuthToken, error) {
+	accessToken, err := u.jwtService.GenerateToken(principal)
+	if err != nil {
+		return nil, err
+	}
+
+	token, hash, expiresAt, err := u.refreshTokenService.Generate()
+	if err != nil {
+		return nil, domain.NewInternalError(err)
+	}
+
+	refreshToken := &entity.RefreshToken{
+		FamilyID:             familyID,
+		TokenHash:            hash,
+		SubjectType:          principal.SubjectType,
+		SubjectID:            principal.ID,
+		Role:                 principal.Role,
+		AccessTokenID:        accessToken.ID,
+		AccessTokenExpiresAt: accessToken.ExpiresAt,
+		ExpiresAt:            expiresAt,
+	}
+
+	if err := u.refreshTokenGateway.Create(ctx, refreshToken); err != nil {
+		return nil, domain.NewInternalError(err)
+	}
+
+	return &entity.AuthToken{
+		AccessToken:  accessToken.Token,
+		RefreshToken: token,
+		ExpiresAt:    accessToken.ExpiresAt,
+	}, nil
+}
+
+// revokeReusedFamily revokes the family of a reused refresh token and returns the error for the caller
+func (u *authUseCase) revokeReusedFamily(ctx context.Context, familyID string) error {
+	if err := u.revokeFamily(ctx, familyID); err != nil {
+		return domain.NewInternalError(err)
+	}
+	return domain.NewUnauthorizedError(domain.ErrInvalidRefreshToken)
+}
+
+// revokeFamily revokes every refresh token of the family and the access tokens issued with them
+func (u *authUseCase) revokeFamily(ctx context.Context, familyID string) error {
+	tokens, err := u.refreshTokenGateway.FindByFamilyID(ctx, familyID)
+	if err != nil {
+		return err
+	}
+
+	if err := u.refreshTokenGateway.RevokeFamily(ctx, familyID, time.Now()); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, token := range tokens {
+		if token.AccessTokenID == "" || !now.Before(token.AccessTokenExpiresAt) {
+			continue
+		}
+		if err := u.revocationService.Revoke(ctx, token.AccessTokenID, token.AccessTokenExpiresAt); err != nil {
+			return err
+		}
 	}
 
-	return token, nil
+	return nil
 }
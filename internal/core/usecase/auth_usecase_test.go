@@ -4,61 +4,113 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain"
 	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
+	valueobject "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/value_object"
 	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/dto"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/port"
 	mockport "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/port/mocks"
 	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/usecase"
 )
 
+type authUseCaseMocks struct {
+	customerUseCase     *mockport.MockCustomerUseCase
+	staffUseCase        *mockport.MockStaffUseCase
+	jwtService          *mockport.MockJWTService
+	passwordService     *mockport.MockPasswordService
+	refreshTokenService *mockport.MockRefreshTokenService
+	refreshTokenGateway *mockport.MockRefreshTokenGateway
+	revocationService   *mockport.MockTokenRevocationService
+}
+
+func newAuthUseCase(ctrl *gomock.Controller) (port.AuthUseCase, *authUseCaseMocks) {
+	m := &authUseCaseMocks{
+		customerUseCase:     mockport.NewMockCustomerUseCase(ctrl),
+		staffUseCase:        mockport.NewMockStaffUseCase(ctrl),
+		jwtService:          mockport.NewMockJWTService(ctrl),
+		passwordService:     mockport.NewMockPasswordService(ctrl),
+		refreshTokenService: mockport.NewMockRefreshTokenService(ctrl),
+		refreshTokenGateway: mockport.NewMockRefreshTokenGateway(ctrl),
+		revocationService:   mockport.NewMockTokenRevocationService(ctrl),
+	}
+	useCase := usecase.NewAuthUseCase(
+		m.customerUseCase,
+		m.staffUseCase,
+		m.jwtService,
+		m.passwordService,
+		m.refreshTokenService,
+		m.refreshTokenGateway,
+		m.revocationService,
+	)
+	return useCase, m
+}
+
+// expectIssueTokens sets up the expectations of a successful token pair generation
+func (m *authUseCaseMocks) expectIssueTokens(principal *entity.Principal, accessToken *entity.AccessToken) {
+	m.jwtService.EXPECT().
+		GenerateToken(principal).
+		Return(accessToken, nil)
+
+	m.refreshTokenService.EXPECT().
+		Generate().
+		Return("test-refresh-token", "test-refresh-token-hash", time.Now().Add(time.Hour), nil)
+
+	m.refreshTokenGateway.EXPECT().
+		Create(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, token *entity.RefreshToken) error {
+			if token.TokenHash != "test-refresh-token-hash" || token.AccessTokenID != accessToken.ID || !token.BelongsTo(principal) {
+				return errors.New("unexpected refresh token")
+			}
+			return nil
+		})
+}
+
 func TestAuthUseCase_Authenticate(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
+	mockCustomer := &entity.Customer{
+		ID:    1,
+		Name:  "Test Customer",
+		Email: "test@example.com",
+		CPF:   "12345678901",
+	}
+	mockAccessToken := &entity.AccessToken{Token: "test-jwt-token", ID: "test-jti", ExpiresAt: time.Now().Add(time.Hour)}
+
 	t.Run("success", func(t *testing.T) {
 		// Arrange
-		mockCustomerUseCase := mockport.NewMockCustomerUseCase(ctrl)
-		mockJWTService := mockport.NewMockJWTService(ctrl)
-		useCase := usecase.NewAuthUseCase(mockCustomerUseCase, mockJWTService)
+		useCase, m := newAuthUseCase(ctrl)
 
 		ctx := context.Background()
 		input := dto.AuthenticateInput{
 			CPF: "12345678901",
 		}
 
-		mockCustomer := &entity.Customer{
-			ID:    1,
-			Name:  "Test Customer",
-			Email: "test@example.com",
-			CPF:   "12345678901",
-		}
-		mockToken := "test-jwt-token"
-
 		// Set up expectations
-		mockCustomerUseCase.EXPECT().
+		m.customerUseCase.EXPECT().
 			FindByCPF(ctx, dto.FindCustomerByCPFInput(input)).
 			Return(mockCustomer, nil)
 
-		mockJWTService.EXPECT().
-			GenerateToken(mockCustomer.ID).
-			Return(mockToken, nil)
+		m.expectIssueTokens(&entity.Principal{SubjectType: valueobject.CUSTOMER, ID: mockCustomer.ID}, mockAccessToken)
 
 		// Act
 		token, err := useCase.Authenticate(ctx, input)
 
 		// Assert
 		assert.NoError(t, err)
-		assert.Equal(t, mockToken, token)
+		assert.Equal(t, mockAccessToken.Token, token.AccessToken)
+		assert.Equal(t, "test-refresh-token", token.RefreshToken)
+		assert.Equal(t, mockAccessToken.ExpiresAt, token.ExpiresAt)
 	})
 
 	t.Run("customer_not_found", func(t *testing.T) {
 		// Arrange
-		mockCustomerUseCase := mockport.NewMockCustomerUseCase(ctrl)
-		mockJWTService := mockport.NewMockJWTService(ctrl)
-		useCase := usecase.NewAuthUseCase(mockCustomerUseCase, mockJWTService)
+		useCase, m := newAuthUseCase(ctrl)
 
 		ctx := context.Background()
 		input := dto.AuthenticateInput{
@@ -68,7 +120,7 @@ func TestAuthUseCase_Authenticate(t *testing.T) {
 		expectedErr := errors.New("customer not found")
 
 		// Set up expectations
-		mockCustomerUseCase.EXPECT().
+		m.customerUseCase.EXPECT().
 			FindByCPF(ctx, dto.FindCustomerByCPFInput(input)).
 			Return(nil, expectedErr)
 
@@ -78,36 +130,28 @@ func TestAuthUseCase_Authenticate(t *testing.T) {
 		// Assert
 		assert.Error(t, err)
 		assert.Equal(t, expectedErr, err)
-		assert.Empty(t, token)
+		assert.Nil(t, token)
 	})
 
 	t.Run("token_generation_error", func(t *testing.T) {
 		// Arrange
-		mockCustomerUseCase := mockport.NewMockCustomerUseCase(ctrl)
-		mockJWTService := mockport.NewMockJWTService(ctrl)
-		useCase := usecase.NewAuthUseCase(mockCustomerUseCase, mockJWTService)
+		useCase, m := newAuthUseCase(ctrl)
 
 		ctx := context.Background()
 		input := dto.AuthenticateInput{
 			CPF: "12345678901",
 		}
 
-		mockCustomer := &entity.Customer{
-			ID:    1,
-			Name:  "Test Customer",
-			Email: "test@example.com",
-			CPF:   "12345678901",
-		}
 		expectedErr := errors.New("token generation error")
 
 		// Set up expectations
-		mockCustomerUseCase.EXPECT().
+		m.customerUseCase.EXPECT().
 			FindByCPF(ctx, dto.FindCustomerByCPFInput(input)).
 			Return(mockCustomer, nil)
 
-		mockJWTService.EXPECT().
-			GenerateToken(mockCustomer.ID).
-			Return("", expectedErr)
+		m.jwtService.EXPECT().
+			GenerateToken(&entity.Principal{SubjectType: valueobject.CUSTOMER, ID: mockCustomer.ID}).
+			Return(nil, expectedErr)
 
 		// Act
 		token, err := useCase.Authenticate(ctx, input)
@@ -115,6 +159,461 @@ func TestAuthUseCase_Authenticate(t *testing.T) {
 		// Assert
 		assert.Error(t, err)
 		assert.Equal(t, expectedErr, err)
-		assert.Empty(t, token)
+		assert.Nil(t, token)
+	})
+
+	t.Run("refresh_token_store_error", func(t *testing.T) {
+		// Arrange
+		useCase, m := newAuthUseCase(ctrl)
+
+		ctx := context.Background()
+		input := dto.AuthenticateInput{
+			CPF: "12345678901",
+		}
+
+		// Set up expectations
+		m.customerUseCase.EXPECT().
+			FindByCPF(ctx, dto.FindCustomerByCPFInput(input)).
+			Return(mockCustomer, nil)
+
+		m.jwtService.EXPECT().
+			GenerateToken(gomock.Any()).
+			Return(mockAccessToken, nil)
+
+		m.refreshTokenService.EXPECT().
+			Generate().
+			Return("test-refresh-token", "test-refresh-token-hash", time.Now().Add(time.Hour), nil)
+
+		m.refreshTokenGateway.EXPECT().
+			Create(ctx, gomock.Any()).
+			Return(assert.AnError)
+
+		// Act
+		token, err := useCase.Authenticate(ctx, input)
+
+		// Assert
+		assert.Error(t, err)
+		assert.IsType(t, &domain.InternalError{}, err)
+		assert.Nil(t, token)
+	})
+}
+
+func TestAuthUseCase_AuthenticateStaff(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStaff := &entity.Staff{
+		ID:           1,
+		Name:         "Test Staff",
+		Role:         valueobject.COOK,
+		PasswordHash: "hashed-password",
+	}
+	mockAccessToken := &entity.AccessToken{Token: "test-jwt-token", ID: "test-jti", ExpiresAt: time.Now().Add(time.Hour)}
+
+	t.Run("success", func(t *testing.T) {
+		// Arrange
+		useCase, m := newAuthUseCase(ctrl)
+
+		ctx := context.Background()
+		input := dto.AuthenticateStaffInput{StaffID: 1, Password: "123456"}
+
+		// Set up expectations
+		m.staffUseCase.EXPECT().
+			Get(ctx, dto.GetStaffInput{ID: input.StaffID}).
+			Return(mockStaff, nil)
+
+		m.passwordService.EXPECT().
+			Compare(mockStaff.PasswordHash, input.Password).
+			Return(true)
+
+		m.expectIssueTokens(&entity.Principal{SubjectType: valueobject.STAFF, ID: mockStaff.ID, Role: valueobject.COOK}, mockAccessToken)
+
+		// Act
+		token, err := useCase.AuthenticateStaff(ctx, input)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, mockAccessToken.Token, token.AccessToken)
+		assert.Equal(t, "test-refresh-token", token.RefreshToken)
+	})
+
+	t.Run("wrong_password", func(t *testing.T) {
+		// Arrange
+		useCase, m := newAuthUseCase(ctrl)
+
+		ctx := context.Background()
+		input := dto.AuthenticateStaffInput{StaffID: 1, Password: "wrong"}
+
+		// Set up expectations
+		m.staffUseCase.EXPECT().
+			Get(ctx, dto.GetStaffInput{ID: input.StaffID}).
+			Return(mockStaff, nil)
+
+		m.passwordService.EXPECT().
+			Compare(mockStaff.PasswordHash, input.Password).
+			Return(false)
+
+		// Act
+		token, err := useCase.AuthenticateStaff(ctx, input)
+
+		// Assert
+		assert.Error(t, err)
+		assert.IsType(t, &domain.UnauthorizedError{}, err)
+		assert.Nil(t, token)
+	})
+
+	t.Run("staff_not_found", func(t *testing.T) {
+		// Arrange
+		useCase, m := newAuthUseCase(ctrl)
+
+		ctx := context.Background()
+		input := dto.AuthenticateStaffInput{StaffID: 99, Password: "123456"}
+
+		// Set up expectations
+		m.staffUseCase.EXPECT().
+			Get(ctx, dto.GetStaffInput{ID: input.StaffID}).
+			Return(nil, domain.NewNotFoundError(domain.ErrNotFound))
+
+		// Act
+		token, err := useCase.AuthenticateStaff(ctx, input)
+
+		// Assert
+		assert.Error(t, err)
+		assert.IsType(t, &domain.UnauthorizedError{}, err)
+		assert.Nil(t, token)
+	})
+
+	t.Run("staff_without_password", func(t *testing.T) {
+		// Arrange
+		useCase, m := newAuthUseCase(ctrl)
+
+		ctx := context.Background()
+		input := dto.AuthenticateStaffInput{StaffID: 2, Password: "123456"}
+
+		// Set up expectations
+		m.staffUseCase.EXPECT().
+			Get(ctx, dto.GetStaffInput{ID: input.StaffID}).
+			Return(&entity.Staff{ID: 2, Role: valueobject.ATTENDANT}, nil)
+
+		// Act
+		token, err := useCase.AuthenticateStaff(ctx, input)
+
+		// Assert
+		assert.Error(t, err)
+		assert.IsType(t, &domain.UnauthorizedError{}, err)
+		assert.Nil(t, token)
+	})
+}
+
+func TestAuthUseCase_Refresh(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	input := dto.RefreshTokenInput{RefreshToken: "presented-refresh-token"}
+	mockAccessToken := &entity.AccessToken{Token: "new-jwt-token", ID: "new-jti", ExpiresAt: time.Now().Add(time.Hour)}
+	newStoredToken := func() *entity.RefreshToken {
+		return &entity.RefreshToken{
+			ID:                   10,
+			FamilyID:             "family-1",
+			TokenHash:            "presented-hash",
+			SubjectType:          valueobject.STAFF,
+			SubjectID:            1,
+			Role:                 valueobject.MANAGER,
+			AccessTokenID:        "old-jti",
+			AccessTokenExpiresAt: time.Now().Add(time.Hour),
+			ExpiresAt:            time.Now().Add(time.Hour),
+		}
+	}
+
+	t.Run("success", func(t *testing.T) {
+		// Arrange
+		useCase, m := newAuthUseCase(ctrl)
+		ctx := context.Background()
+		stored := newStoredToken()
+
+		// Set up expectations
+		m.refreshTokenService.EXPECT().Hash(input.RefreshToken).Return("presented-hash")
+		m.refreshTokenGateway.EXPECT().FindByHash(ctx, "presented-hash").Return(stored, nil)
+		m.staffUseCase.EXPECT().Get(ctx, dto.GetStaffInput{ID: 1}).Return(&entity.Staff{ID: 1, Role: valueobject.MANAGER}, nil)
+		m.refreshTokenGateway.EXPECT().MarkUsed(ctx, stored.ID, gomock.Any()).Return(true, nil)
+		m.jwtService.EXPECT().
+			GenerateToken(&entity.Principal{SubjectType: valueobject.STAFF, ID: 1, Role: valueobject.MANAGER}).
+			Return(mockAccessToken, nil)
+		m.refreshTokenService.EXPECT().
+			Generate().
+			Return("rotated-refresh-token", "rotated-hash", time.Now().Add(time.Hour), nil)
+		m.refreshTokenGateway.EXPECT().
+			Create(ctx, gomock.Any()).
+			DoAndReturn(func(_ context.Context, token *entity.RefreshToken) error {
+				assert.Equal(t, "family-1", token.FamilyID)
+				return nil
+			})
+
+		// Act
+		token, err := useCase.Refresh(ctx, input)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "new-jwt-token", token.AccessToken)
+		assert.Equal(t, "rotated-refresh-token", token.RefreshToken)
+	})
+
+	t.Run("success_customer", func(t *testing.T) {
+		// Arrange
+		useCase, m := newAuthUseCase(ctrl)
+		ctx := context.Background()
+		stored := newStoredToken()
+		stored.SubjectType = valueobject.CUSTOMER
+		stored.Role = ""
+		principal := &entity.Principal{SubjectType: valueobject.CUSTOMER, ID: 1}
+
+		// Set up expectations
+		m.refreshTokenService.EXPECT().Hash(input.RefreshToken).Return("presented-hash")
+		m.refreshTokenGateway.EXPECT().FindByHash(ctx, "presented-hash").Return(stored, nil)
+		m.customerUseCase.EXPECT().Get(ctx, dto.GetCustomerInput{ID: 1}).Return(&entity.Customer{ID: 1}, nil)
+		m.refreshTokenGateway.EXPECT().MarkUsed(ctx, stored.ID, gomock.Any()).Return(true, nil)
+		m.expectIssueTokens(principal, mockAccessToken)
+
+		// Act
+		token, err := useCase.Refresh(ctx, input)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "new-jwt-token", token.AccessToken)
+	})
+
+	t.Run("staff_role_changed_revokes_family", func(t *testing.T) {
+		// Arrange
+		useCase, m := newAuthUseCase(ctrl)
+		ctx := context.Background()
+		stored := newStoredToken()
+
+		// Set up expectations
+		m.refreshTokenService.EXPECT().Hash(input.RefreshToken).Return("presented-hash")
+		m.refreshTokenGateway.EXPECT().FindByHash(ctx, "presented-hash").Return(stored, nil)
+		m.staffUseCase.EXPECT().Get(ctx, dto.GetStaffInput{ID: 1}).Return(&entity.Staff{ID: 1, Role: valueobject.COOK}, nil)
+		m.refreshTokenGateway.EXPECT().FindByFamilyID(ctx, "family-1").Return([]*entity.RefreshToken{stored}, nil)
+		m.refreshTokenGateway.EXPECT().RevokeFamily(ctx, "family-1", gomock.Any()).Return(nil)
+		m.revocationService.EXPECT().Revoke(ctx, "old-jti", stored.AccessTokenExpiresAt).Return(nil)
+
+		// Act
+		token, err := useCase.Refresh(ctx, input)
+
+		// Assert
+		assert.IsType(t, &domain.UnauthorizedError{}, err)
+		assert.Nil(t, token)
+	})
+
+	t.Run("removed_customer_revokes_family", func(t *testing.T) {
+		// Arrange
+		useCase, m := newAuthUseCase(ctrl)
+		ctx := context.Background()
+		stored := newStoredToken()
+		stored.SubjectType = valueobject.CUSTOMER
+		stored.Role = ""
+
+		// Set up expectations
+		m.refreshTokenService.EXPECT().Hash(input.RefreshToken).Return("presented-hash")
+		m.refreshTokenGateway.EXPECT().FindByHash(ctx, "presented-hash").Return(stored, nil)
+		m.customerUseCase.EXPECT().Get(ctx, dto.GetCustomerInput{ID: 1}).Return(nil, domain.NewNotFoundError(domain.ErrNotFound))
+		m.refreshTokenGateway.EXPECT().FindByFamilyID(ctx, "family-1").Return([]*entity.RefreshToken{stored}, nil)
+		m.refreshTokenGateway.EXPECT().RevokeFamily(ctx, "family-1", gomock.Any()).Return(nil)
+		m.revocationService.EXPECT().Revoke(ctx, "old-jti", stored.AccessTokenExpiresAt).Return(nil)
+
+		// Act
+		token, err := useCase.Refresh(ctx, input)
+
+		// Assert
+		assert.IsType(t, &domain.UnauthorizedError{}, err)
+		assert.Nil(t, token)
+	})
+
+	t.Run("staff_lookup_error_keeps_token", func(t *testing.T) {
+		// Arrange
+		useCase, m := newAuthUseCase(ctrl)
+		ctx := context.Background()
+		stored := newStoredToken()
+
+		// Set up expectations, the token is not marked used so the client can retry
+		m.refreshTokenService.EXPECT().Hash(input.RefreshToken).Return("presented-hash")
+		m.refreshTokenGateway.EXPECT().FindByHash(ctx, "presented-hash").Return(stored, nil)
+		m.staffUseCase.EXPECT().Get(ctx, dto.GetStaffInput{ID: 1}).Return(nil, domain.NewInternalError(assert.AnError))
+
+		// Act
+		token, err := useCase.Refresh(ctx, input)
+
+		// Assert
+		assert.IsType(t, &domain.InternalError{}, err)
+		assert.Nil(t, token)
+	})
+
+	t.Run("unknown_token", func(t *testing.T) {
+		// Arrange
+		useCase, m := newAuthUseCase(ctrl)
+		ctx := context.Background()
+
+		// Set up expectations
+		m.refreshTokenService.EXPECT().Hash(input.RefreshToken).Return("presented-hash")
+		m.refreshTokenGateway.EXPECT().FindByHash(ctx, "presented-hash").Return(nil, nil)
+
+		// Act
+		token, err := useCase.Refresh(ctx, input)
+
+		// Assert
+		assert.IsType(t, &domain.UnauthorizedError{}, err)
+		assert.Nil(t, token)
+	})
+
+	t.Run("expired_token", func(t *testing.T) {
+		// Arrange
+		useCase, m := newAuthUseCase(ctrl)
+		ctx := context.Background()
+		stored := newStoredToken()
+		stored.ExpiresAt = time.Now().Add(-time.Minute)
+
+		// Set up expectations
+		m.refreshTokenService.EXPECT().Hash(input.RefreshToken).Return("presented-hash")
+		m.refreshTokenGateway.EXPECT().FindByHash(ctx, "presented-hash").Return(stored, nil)
+
+		// Act
+		token, err := useCase.Refresh(ctx, input)
+
+		// Assert
+		assert.IsType(t, &domain.UnauthorizedError{}, err)
+		assert.Nil(t, token)
+	})
+
+	t.Run("reused_token_revokes_family", func(t *testing.T) {
+		// Arrange
+		useCase, m := newAuthUseCase(ctrl)
+		ctx := context.Background()
+		usedAt := time.Now().Add(-time.Minute)
+		stored := newStoredToken()
+		stored.UsedAt = &usedAt
+		rotated := newStoredToken()
+		rotated.ID = 11
+		rotated.AccessTokenID = "rotated-jti"
+
+		// Set up expectations
+		m.refreshTokenService.EXPECT().Hash(input.RefreshToken).Return("presented-hash")
+		m.refreshTokenGateway.EXPECT().FindByHash(ctx, "presented-hash").Return(stored, nil)
+		m.refreshTokenGateway.EXPECT().FindByFamilyID(ctx, "family-1").Return([]*entity.RefreshToken{stored, rotated}, nil)
+		m.refreshTokenGateway.EXPECT().RevokeFamily(ctx, "family-1", gomock.Any()).Return(nil)
+		m.revocationService.EXPECT().Revoke(ctx, "old-jti", stored.AccessTokenExpiresAt).Return(nil)
+		m.revocationService.EXPECT().Revoke(ctx, "rotated-jti", rotated.AccessTokenExpiresAt).Return(nil)
+
+		// Act
+		token, err := useCase.Refresh(ctx, input)
+
+		// Assert
+		assert.IsType(t, &domain.UnauthorizedError{}, err)
+		assert.Nil(t, token)
+	})
+
+	t.Run("concurrent_use_revokes_family", func(t *testing.T) {
+		// Arrange
+		useCase, m := newAuthUseCase(ctrl)
+		ctx := context.Background()
+		stored := newStoredToken()
+
+		// Set up expectations
+		m.refreshTokenService.EXPECT().Hash(input.RefreshToken).Return("presented-hash")
+		m.refreshTokenGateway.EXPECT().FindByHash(ctx, "presented-hash").Return(stored, nil)
+		m.staffUseCase.EXPECT().Get(ctx, dto.GetStaffInput{ID: 1}).Return(&entity.Staff{ID: 1, Role: valueobject.MANAGER}, nil)
+		m.refreshTokenGateway.EXPECT().MarkUsed(ctx, stored.ID, gomock.Any()).Return(false, nil)
+		m.refreshTokenGateway.EXPECT().FindByFamilyID(ctx, "family-1").Return([]*entity.RefreshToken{stored}, nil)
+		m.refreshTokenGateway.EXPECT().RevokeFamily(ctx, "family-1", gomock.Any()).Return(nil)
+		m.revocationService.EXPECT().Revoke(ctx, "old-jti", stored.AccessTokenExpiresAt).Return(nil)
+
+		// Act
+		token, err := useCase.Refresh(ctx, input)
+
+		// Assert
+		assert.IsType(t, &domain.UnauthorizedError{}, err)
+		assert.Nil(t, token)
+	})
+}
+
+func TestAuthUseCase_Logout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	principal := &entity.Principal{
+		SubjectType:    valueobject.CUSTOMER,
+		ID:             1,
+		TokenID:        "current-jti",
+		TokenExpiresAt: time.Now().Add(time.Hour),
+	}
+
+	t.Run("revokes_access_token", func(t *testing.T) {
+		// Arrange
+		useCase, m := newAuthUseCase(ctrl)
+		ctx := entity.ContextWithPrincipal(context.Background(), principal)
+
+		// Set up expectations
+		m.revocationService.EXPECT().Revoke(ctx, "current-jti", principal.TokenExpiresAt).Return(nil)
+
+		// Act
+		err := useCase.Logout(ctx, dto.LogoutInput{})
+
+		// Assert
+		assert.NoError(t, err)
+	})
+
+	t.Run("revokes_refresh_token_family", func(t *testing.T) {
+		// Arrange
+		useCase, m := newAuthUseCase(ctrl)
+		ctx := entity.ContextWithPrincipal(context.Background(), principal)
+		stored := &entity.RefreshToken{
+			ID:                   10,
+			FamilyID:             "family-1",
+			SubjectType:          valueobject.CUSTOMER,
+			SubjectID:            1,
+			AccessTokenID:        "current-jti",
+			AccessTokenExpiresAt: principal.TokenExpiresAt,
+		}
+
+		// Set up expectations
+		m.revocationService.EXPECT().Revoke(ctx, "current-jti", principal.TokenExpiresAt).Return(nil).Times(2)
+		m.refreshTokenService.EXPECT().Hash("refresh-token").Return("refresh-hash")
+		m.refreshTokenGateway.EXPECT().FindByHash(ctx, "refresh-hash").Return(stored, nil)
+		m.refreshTokenGateway.EXPECT().FindByFamilyID(ctx, "family-1").Return([]*entity.RefreshToken{stored}, nil)
+		m.refreshTokenGateway.EXPECT().RevokeFamily(ctx, "family-1", gomock.Any()).Return(nil)
+
+		// Act
+		err := useCase.Logout(ctx, dto.LogoutInput{RefreshToken: "refresh-token"})
+
+		// Assert
+		assert.NoError(t, err)
+	})
+
+	t.Run("ignores_refresh_token_of_another_subject", func(t *testing.T) {
+		// Arrange
+		useCase, m := newAuthUseCase(ctrl)
+		ctx := entity.ContextWithPrincipal(context.Background(), principal)
+
+		// Set up expectations
+		m.revocationService.EXPECT().Revoke(ctx, "current-jti", principal.TokenExpiresAt).Return(nil)
+		m.refreshTokenService.EXPECT().Hash("refresh-token").Return("refresh-hash")
+		m.refreshTokenGateway.EXPECT().
+			FindByHash(ctx, "refresh-hash").
+			Return(&entity.RefreshToken{FamilyID: "family-2", SubjectType: valueobject.CUSTOMER, SubjectID: 2}, nil)
+
+		// Act
+		err := useCase.Logout(ctx, dto.LogoutInput{RefreshToken: "refresh-token"})
+
+		// Assert
+		assert.NoError(t, err)
+	})
+
+	t.Run("unauthenticated", func(t *testing.T) {
+		// Arrange
+		useCase, _ := newAuthUseCase(ctrl)
+
+		// Act
+		err := useCase.Logout(context.Background(), dto.LogoutInput{})
+
+		// Assert
+		assert.IsType(t, &domain.UnauthorizedError{}, err)
 	})
 }
@@ -63,7 +63,7 @@ func (uc *categoryUseCase) Update(ctx context.Context, i dto.UpdateCategoryInput
 		return nil, domain.NewNotFoundError(domain.ErrNotFound)
 	}
 
-	category.Update(i.Name)
+	category.Update(i.Name, i.Station, i.PrepTargetMinutes)
 
 	if err := uc.gateway.Update(ctx, category); err != nil {
 		return nil, domain.NewInternalError(err)
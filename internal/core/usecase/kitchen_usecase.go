@@ -0,0 +1,230 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
+	valueobject "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/value_object"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/dto"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/port"
+)
+
+const (
+	// kitchenQueueLimit is the maximum number of orders shown on the kitchen display
+	kitchenQueueLimit = 100
+	// kitchenCategoryLimit is the maximum number of categories routed to the stations, the others go to the default one
+	kitchenCategoryLimit = 100
+)
+
+// kitchenOrderStatuses are the statuses of the orders being prepared by the kitchen
+var kitchenOrderStatuses = []valueobject.OrderStatus{valueobject.RECEIVED, valueobject.PREPARING}
+
+type kitchenUseCase struct {
+	orderUseCase    port.OrderUseCase
+	categoryUseCase port.CategoryUseCase
+	bumpGateway     port.KitchenTicketBumpGateway
+	stateMachine    *entity.OrderStateMachine
+	unitOfWork      port.UnitOfWork
+}
+
+// NewKitchenUseCase creates a new KitchenUseCase, the bumps move the orders through the stateMachine
+func NewKitchenUseCase(
+	orderUseCase port.OrderUseCase,
+	categoryUseCase port.CategoryUseCase,
+	bumpGateway port.KitchenTicketBumpGateway,
+	stateMachine *entity.OrderStateMachine,
+	unitOfWork port.UnitOfWork,
+) port.KitchenUseCase {
+	return &kitchenUseCase{orderUseCase, categoryUseCase, bumpGateway, stateMachine, unitOfWork}
+}
+
+// ListTickets returns the pending tickets of the orders in the kitchen, oldest first
+func (uc *kitchenUseCase) ListTickets(ctx context.Context, i dto.ListKitchenTicketsInput) ([]*entity.KitchenTicket, error) {
+	orders, _, err := uc.orderUseCase.List(ctx, dto.ListOrdersInput{
+		Status: kitchenOrderStatuses,
+		Page:   1,
+		Limit:  kitchenQueueLimit,
+		Sort:   "created_at",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	stations, err := listCategoryStations(ctx, uc.categoryUseCase)
+	if err != nil {
+		return nil, err
+	}
+
+	orderIDs := make([]uint64, 0, len(orders))
+	for _, order := range orders {
+		orderIDs = append(orderIDs, order.ID)
+	}
+
+	bumps, err := uc.bumpGateway.FindByOrderIDs(ctx, orderIDs)
+	if err != nil {
+		return nil, domain.NewInternalError(err)
+	}
+
+	tickets := make([]*entity.KitchenTicket, 0)
+	for _, order := range orders {
+		for _, ticket := range splitBumpedKitchenTickets(order, stations, bumps) {
+			if ticket.IsBumped() || (i.Station != valueobject.UNDEFINED_STATION && ticket.Station != i.Station) {
+				continue
+			}
+			tickets = append(tickets, ticket)
+		}
+	}
+
+	return tickets, nil
+}
+
+// BumpTicket marks the ticket of the station as done, moving the order to READY once every ticket is bumped
+func (uc *kitchenUseCase) BumpTicket(ctx context.Context, i dto.BumpKitchenTicketInput) (*entity.KitchenTicket, error) {
+	if i.StaffID == 0 {
+		return nil, domain.NewInvalidInputError(domain.ErrStaffIdIsMandatory)
+	}
+
+	order, err := uc.orderUseCase.Get(ctx, dto.GetOrderInput{ID: i.OrderID})
+	if err != nil {
+		return nil, err
+	}
+
+	if order.Status != valueobject.RECEIVED && order.Status != valueobject.PREPARING {
+		return nil, domain.NewInvalidInputError(domain.ErrOrderIsNotInKitchen)
+	}
+
+	stations, err := listCategoryStations(ctx, uc.categoryUseCase)
+	if err != nil {
+		return nil, err
+	}
+
+	var ticket *entity.KitchenTicket
+	for _, t := range entity.SplitKitchenTickets(order, stations) {
+		if t.Station == i.Station {
+			ticket = t
+		}
+	}
+
+	if ticket == nil {
+		return nil, domain.NewNotFoundError(domain.ErrNotFound)
+	}
+
+	err = uc.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		bump := entity.NewKitchenTicketBump(order.ID, i.Station, i.StaffID)
+
+		created, err := uc.bumpGateway.Create(ctx, bump)
+		if err != nil {
+			return domain.NewInternalError(err)
+		}
+
+		if !created {
+			return domain.NewInvalidInputError(domain.ErrKitchenTicketAlreadyBumped)
+		}
+		ticket.Bump = bump
+
+		// Create holds the order row locked until commit, so a concurrent bump of another station
+		// either committed before it, and is seen by the reads below, or waits for this one to commit
+		locked, err := uc.orderUseCase.Get(ctx, dto.GetOrderInput{ID: order.ID})
+		if err != nil {
+			return err
+		}
+
+		bumps, err := uc.bumpGateway.FindByOrderIDs(ctx, []uint64{order.ID})
+		if err != nil {
+			return domain.NewInternalError(err)
+		}
+
+		allBumped := allKitchenTicketsBumped(splitBumpedKitchenTickets(order, stations, bumps))
+		for _, status := range uc.kitchenStatuses(ctx, locked, stations, allBumped) {
+			if err := uc.updateOrderStatus(ctx, ticket, status, i.StaffID); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return ticket, nil
+}
+
+// kitchenStatuses returns the statuses a bump moves the order through, as allowed by the state machine: the first bump
+// starts the preparation and the last one makes the order READY, straight from RECEIVED when a transition allows it
+// (ex: an order of drinks only)
+func (uc *kitchenUseCase) kitchenStatuses(
+	ctx context.Context,
+	order *entity.Order,
+	stations map[uint64]valueobject.KitchenStation,
+	allBumped bool,
+) []valueobject.OrderStatus {
+	if allBumped {
+		principal, _ := entity.PrincipalFromContext(ctx)
+		if transition, ok := uc.stateMachine.Transition(order.Status, valueobject.READY); ok && transition.AllowsRole(principal) && transition.GuardsPass(order, stations) {
+			return []valueobject.OrderStatus{valueobject.READY}
+		}
+	}
+
+	if order.Status != valueobject.RECEIVED || !uc.stateMachine.CanTransition(valueobject.RECEIVED, valueobject.PREPARING) {
+		return nil
+	}
+
+	if allBumped && uc.stateMachine.CanTransition(valueobject.PREPARING, valueobject.READY) {
+		return []valueobject.OrderStatus{valueobject.PREPARING, valueobject.READY}
+	}
+
+	return []valueobject.OrderStatus{valueobject.PREPARING}
+}
+
+// updateOrderStatus moves the order of the ticket to the status, as changed by the cook
+func (uc *kitchenUseCase) updateOrderStatus(ctx context.Context, ticket *entity.KitchenTicket, status valueobject.OrderStatus, staffID uint64) error {
+	order, err := uc.orderUseCase.Update(ctx, dto.UpdateOrderInput{
+		ID:      ticket.OrderID,
+		Status:  status,
+		StaffID: staffID,
+	})
+	if err != nil {
+		return err
+	}
+
+	ticket.OrderStatus = order.Status
+	return nil
+}
+
+// listCategoryStations returns the station of each category
+func listCategoryStations(ctx context.Context, categoryUseCase port.CategoryUseCase) (map[uint64]valueobject.KitchenStation, error) {
+	categories, _, err := categoryUseCase.List(ctx, dto.ListCategoriesInput{Page: 1, Limit: kitchenCategoryLimit})
+	if err != nil {
+		return nil, err
+	}
+
+	stations := make(map[uint64]valueobject.KitchenStation, len(categories))
+	for _, category := range categories {
+		stations[category.ID] = category.Station
+	}
+	return stations, nil
+}
+
+// splitBumpedKitchenTickets splits the order into its tickets, with the bumps of the order attached
+func splitBumpedKitchenTickets(order *entity.Order, stations map[uint64]valueobject.KitchenStation, bumps []*entity.KitchenTicketBump) []*entity.KitchenTicket {
+	tickets := entity.SplitKitchenTickets(order, stations)
+	for _, ticket := range tickets {
+		for _, bump := range bumps {
+			if bump.OrderID == ticket.OrderID && bump.Station == ticket.Station {
+				ticket.Bump = bump
+			}
+		}
+	}
+	return tickets
+}
+
+func allKitchenTicketsBumped(tickets []*entity.KitchenTicket) bool {
+	for _, ticket := range tickets {
+		if !ticket.IsBumped() {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,69 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
+	valueobject "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/value_object"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/port"
+	mockport "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/port/mocks"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/usecase"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type KitchenUsecaseSuiteTest struct {
+	suite.Suite
+	mockCategories      []*entity.Category
+	mockOrderUseCase    *mockport.MockOrderUseCase
+	mockCategoryUseCase *mockport.MockCategoryUseCase
+	mockBumpGateway     *mockport.MockKitchenTicketBumpGateway
+	mockUnitOfWork      *mockport.MockUnitOfWork
+	useCase             port.KitchenUseCase
+	ctx                 context.Context
+}
+
+func (s *KitchenUsecaseSuiteTest) SetupTest() {
+	ctrl := gomock.NewController(s.T())
+	defer ctrl.Finish()
+	s.mockOrderUseCase = mockport.NewMockOrderUseCase(ctrl)
+	s.mockCategoryUseCase = mockport.NewMockCategoryUseCase(ctrl)
+	s.mockBumpGateway = mockport.NewMockKitchenTicketBumpGateway(ctrl)
+	s.mockUnitOfWork = mockport.NewMockUnitOfWork(ctrl)
+	s.mockUnitOfWork.EXPECT().
+		Do(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) }).
+		AnyTimes()
+	s.useCase = s.newUseCase(newOrderStateMachine(s.T()))
+	s.ctx = context.Background()
+	s.mockCategories = []*entity.Category{
+		{ID: 1, Name: "Foods", Station: valueobject.GRILL},
+		{ID: 2, Name: "Beverages", Station: valueobject.DRINKS},
+	}
+}
+
+// newUseCase creates the use case with the mocks of the suite and the given state machine
+func (s *KitchenUsecaseSuiteTest) newUseCase(stateMachine *entity.OrderStateMachine) port.KitchenUseCase {
+	return usecase.NewKitchenUseCase(s.mockOrderUseCase, s.mockCategoryUseCase, s.mockBumpGateway, stateMachine, s.mockUnitOfWork)
+}
+
+// newKitchenOrder returns an order with a product of each of the given categories
+func newKitchenOrder(id uint64, status valueobject.OrderStatus, categoryIDs ...uint64) *entity.Order {
+	order := &entity.Order{ID: id, Status: status, CreatedAt: time.Now()}
+	for i, categoryID := range categoryIDs {
+		order.OrderProducts = append(order.OrderProducts, entity.OrderProduct{
+			OrderID:     id,
+			ProductID:   uint64(i + 1),
+			Quantity:    1,
+			ProductName: "Product",
+			CategoryID:  categoryID,
+		})
+	}
+	return order
+}
+
+func TestKitchenUsecaseSuiteTest(t *testing.T) {
+	suite.Run(t, new(KitchenUsecaseSuiteTest))
+}
@@ -0,0 +1,408 @@
+package usecase_test
+
+import (
+	"testing"
+
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
+	valueobject "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/value_object"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/dto"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func (s *KitchenUsecaseSuiteTest) TestKitchenUseCase_ListTickets() {
+	orders := []*entity.Order{
+		newKitchenOrder(1, valueobject.RECEIVED, 1, 2),
+		newKitchenOrder(2, valueobject.PREPARING, 2, 3), // category 3 has no station
+	}
+
+	tests := []struct {
+		name        string
+		input       dto.ListKitchenTicketsInput
+		setupMocks  func()
+		checkResult func(*testing.T, []*entity.KitchenTicket, error)
+	}{
+		{
+			name:  "should split the orders by station without the bumped tickets",
+			input: dto.ListKitchenTicketsInput{},
+			setupMocks: func() {
+				s.mockOrderUseCase.EXPECT().
+					List(s.ctx, gomock.Any()).
+					Return(orders, int64(2), nil)
+				s.mockCategoryUseCase.EXPECT().
+					List(s.ctx, gomock.Any()).
+					Return(s.mockCategories, int64(2), nil)
+				s.mockBumpGateway.EXPECT().
+					FindByOrderIDs(s.ctx, []uint64{1, 2}).
+					Return([]*entity.KitchenTicketBump{{OrderID: 2, Station: valueobject.DRINKS, StaffID: 1}}, nil)
+			},
+			checkResult: func(t *testing.T, tickets []*entity.KitchenTicket, err error) {
+				assert.NoError(t, err)
+				assert.Len(t, tickets, 3)
+				assert.Equal(t, uint64(1), tickets[0].OrderID)
+				assert.Equal(t, valueobject.GRILL, tickets[0].Station)
+				assert.Equal(t, uint64(1), tickets[1].OrderID)
+				assert.Equal(t, valueobject.DRINKS, tickets[1].Station)
+				assert.Equal(t, uint64(2), tickets[2].OrderID)
+				assert.Equal(t, valueobject.GRILL, tickets[2].Station)
+				assert.Equal(t, uint64(3), tickets[2].Items[0].CategoryID)
+			},
+		},
+		{
+			name:  "should only list the tickets of the station",
+			input: dto.ListKitchenTicketsInput{Station: valueobject.DRINKS},
+			setupMocks: func() {
+				s.mockOrderUseCase.EXPECT().
+					List(s.ctx, gomock.Any()).
+					Return(orders, int64(2), nil)
+				s.mockCategoryUseCase.EXPECT().
+					List(s.ctx, gomock.Any()).
+					Return(s.mockCategories, int64(2), nil)
+				s.mockBumpGateway.EXPECT().
+					FindByOrderIDs(s.ctx, []uint64{1, 2}).
+					Return(nil, nil)
+			},
+			checkResult: func(t *testing.T, tickets []*entity.KitchenTicket, err error) {
+				assert.NoError(t, err)
+				assert.Len(t, tickets, 2)
+				for _, ticket := range tickets {
+					assert.Equal(t, valueobject.DRINKS, ticket.Station)
+				}
+			},
+		},
+		{
+			name:  "should return error when the order use case fails",
+			input: dto.ListKitchenTicketsInput{},
+			setupMocks: func() {
+				s.mockOrderUseCase.EXPECT().
+					List(s.ctx, gomock.Any()).
+					Return(nil, int64(0), domain.NewInternalError(assert.AnError))
+			},
+			checkResult: func(t *testing.T, tickets []*entity.KitchenTicket, err error) {
+				assert.Error(t, err)
+				assert.Nil(t, tickets)
+			},
+		},
+		{
+			name:  "should return internal error when the bump gateway fails",
+			input: dto.ListKitchenTicketsInput{},
+			setupMocks: func() {
+				s.mockOrderUseCase.EXPECT().
+					List(s.ctx, gomock.Any()).
+					Return(orders, int64(2), nil)
+				s.mockCategoryUseCase.EXPECT().
+					List(s.ctx, gomock.Any()).
+					Return(s.mockCategories, int64(2), nil)
+				s.mockBumpGateway.EXPECT().
+					FindByOrderIDs(s.ctx, gomock.Any()).
+					Return(nil, assert.AnError)
+			},
+			checkResult: func(t *testing.T, tickets []*entity.KitchenTicket, err error) {
+				assert.Error(t, err)
+				assert.Nil(t, tickets)
+				assert.IsType(t, &domain.InternalError{}, err)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.T().Run(tt.name, func(t *testing.T) {
+			tt.setupMocks()
+			tickets, err := s.useCase.ListTickets(s.ctx, tt.input)
+			tt.checkResult(t, tickets, err)
+		})
+	}
+}
+
+func (s *KitchenUsecaseSuiteTest) TestKitchenUseCase_BumpTicket() {
+	drinksStraightToReady := newOrderStateMachine(s.T(), entity.OrderTransition{
+		From:   valueobject.RECEIVED,
+		To:     valueobject.READY,
+		Staff:  true,
+		Guards: []valueobject.OrderGuard{valueobject.DRINKS_ONLY_GUARD},
+	})
+
+	tests := []struct {
+		name         string
+		stateMachine *entity.OrderStateMachine
+		input        dto.BumpKitchenTicketInput
+		setupMocks   func()
+		checkResult  func(*testing.T, *entity.KitchenTicket, error)
+	}{
+		{
+			name:  "should start the preparation on the first bump",
+			input: dto.BumpKitchenTicketInput{OrderID: 1, Station: valueobject.GRILL, StaffID: 3},
+			setupMocks: func() {
+				s.mockOrderUseCase.EXPECT().
+					Get(s.ctx, dto.GetOrderInput{ID: 1}).
+					Return(newKitchenOrder(1, valueobject.RECEIVED, 1, 2), nil).
+					Times(2)
+				s.mockCategoryUseCase.EXPECT().
+					List(s.ctx, gomock.Any()).
+					Return(s.mockCategories, int64(2), nil)
+				s.mockBumpGateway.EXPECT().
+					Create(s.ctx, gomock.Any()).
+					Return(true, nil)
+				s.mockBumpGateway.EXPECT().
+					FindByOrderIDs(s.ctx, []uint64{1}).
+					Return([]*entity.KitchenTicketBump{{OrderID: 1, Station: valueobject.GRILL, StaffID: 3}}, nil)
+				s.mockOrderUseCase.EXPECT().
+					Update(s.ctx, dto.UpdateOrderInput{ID: 1, Status: valueobject.PREPARING, StaffID: 3}).
+					Return(&entity.Order{ID: 1, Status: valueobject.PREPARING}, nil)
+			},
+			checkResult: func(t *testing.T, ticket *entity.KitchenTicket, err error) {
+				assert.NoError(t, err)
+				assert.True(t, ticket.IsBumped())
+				assert.Equal(t, uint64(3), ticket.Bump.StaffID)
+				assert.Equal(t, valueobject.PREPARING, ticket.OrderStatus)
+			},
+		},
+		{
+			name:  "should move the order to ready on the last bump",
+			input: dto.BumpKitchenTicketInput{OrderID: 1, Station: valueobject.DRINKS, StaffID: 3},
+			setupMocks: func() {
+				s.mockOrderUseCase.EXPECT().
+					Get(s.ctx, dto.GetOrderInput{ID: 1}).
+					Return(newKitchenOrder(1, valueobject.PREPARING, 1, 2), nil).
+					Times(2)
+				s.mockCategoryUseCase.EXPECT().
+					List(s.ctx, gomock.Any()).
+					Return(s.mockCategories, int64(2), nil)
+				s.mockBumpGateway.EXPECT().
+					Create(s.ctx, gomock.Any()).
+					Return(true, nil)
+				s.mockBumpGateway.EXPECT().
+					FindByOrderIDs(s.ctx, []uint64{1}).
+					Return([]*entity.KitchenTicketBump{
+						{OrderID: 1, Station: valueobject.GRILL, StaffID: 4},
+						{OrderID: 1, Station: valueobject.DRINKS, StaffID: 3},
+					}, nil)
+				s.mockOrderUseCase.EXPECT().
+					Update(s.ctx, dto.UpdateOrderInput{ID: 1, Status: valueobject.READY, StaffID: 3}).
+					Return(&entity.Order{ID: 1, Status: valueobject.READY}, nil)
+			},
+			checkResult: func(t *testing.T, ticket *entity.KitchenTicket, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, valueobject.DRINKS, ticket.Station)
+				assert.Equal(t, valueobject.READY, ticket.OrderStatus)
+			},
+		},
+		{
+			name:  "should prepare and finish an order of a single station at once",
+			input: dto.BumpKitchenTicketInput{OrderID: 1, Station: valueobject.GRILL, StaffID: 3},
+			setupMocks: func() {
+				s.mockOrderUseCase.EXPECT().
+					Get(s.ctx, dto.GetOrderInput{ID: 1}).
+					Return(newKitchenOrder(1, valueobject.RECEIVED, 1), nil).
+					Times(2)
+				s.mockCategoryUseCase.EXPECT().
+					List(s.ctx, gomock.Any()).
+					Return(s.mockCategories, int64(2), nil)
+				s.mockBumpGateway.EXPECT().
+					Create(s.ctx, gomock.Any()).
+					Return(true, nil)
+				s.mockBumpGateway.EXPECT().
+					FindByOrderIDs(s.ctx, []uint64{1}).
+					Return([]*entity.KitchenTicketBump{{OrderID: 1, Station: valueobject.GRILL, StaffID: 3}}, nil)
+				gomock.InOrder(
+					s.mockOrderUseCase.EXPECT().
+						Update(s.ctx, dto.UpdateOrderInput{ID: 1, Status: valueobject.PREPARING, StaffID: 3}).
+						Return(&entity.Order{ID: 1, Status: valueobject.PREPARING}, nil),
+					s.mockOrderUseCase.EXPECT().
+						Update(s.ctx, dto.UpdateOrderInput{ID: 1, Status: valueobject.READY, StaffID: 3}).
+						Return(&entity.Order{ID: 1, Status: valueobject.READY}, nil),
+				)
+			},
+			checkResult: func(t *testing.T, ticket *entity.KitchenTicket, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, valueobject.READY, ticket.OrderStatus)
+			},
+		},
+		{
+			name:         "should move an order of drinks only straight to ready when the state machine allows it",
+			stateMachine: drinksStraightToReady,
+			input:        dto.BumpKitchenTicketInput{OrderID: 1, Station: valueobject.DRINKS, StaffID: 3},
+			setupMocks: func() {
+				s.mockOrderUseCase.EXPECT().
+					Get(s.ctx, dto.GetOrderInput{ID: 1}).
+					Return(newKitchenOrder(1, valueobject.RECEIVED, 2), nil).
+					Times(2)
+				s.mockCategoryUseCase.EXPECT().
+					List(s.ctx, gomock.Any()).
+					Return(s.mockCategories, int64(2), nil)
+				s.mockBumpGateway.EXPECT().
+					Create(s.ctx, gomock.Any()).
+					Return(true, nil)
+				s.mockBumpGateway.EXPECT().
+					FindByOrderIDs(s.ctx, []uint64{1}).
+					Return([]*entity.KitchenTicketBump{{OrderID: 1, Station: valueobject.DRINKS, StaffID: 3}}, nil)
+				s.mockOrderUseCase.EXPECT().
+					Update(s.ctx, dto.UpdateOrderInput{ID: 1, Status: valueobject.READY, StaffID: 3}).
+					Return(&entity.Order{ID: 1, Status: valueobject.READY}, nil)
+			},
+			checkResult: func(t *testing.T, ticket *entity.KitchenTicket, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, valueobject.READY, ticket.OrderStatus)
+			},
+		},
+		{
+			name:         "should prepare an order of another station before making it ready",
+			stateMachine: drinksStraightToReady,
+			input:        dto.BumpKitchenTicketInput{OrderID: 1, Station: valueobject.GRILL, StaffID: 3},
+			setupMocks: func() {
+				s.mockOrderUseCase.EXPECT().
+					Get(s.ctx, dto.GetOrderInput{ID: 1}).
+					Return(newKitchenOrder(1, valueobject.RECEIVED, 1), nil).
+					Times(2)
+				s.mockCategoryUseCase.EXPECT().
+					List(s.ctx, gomock.Any()).
+					Return(s.mockCategories, int64(2), nil)
+				s.mockBumpGateway.EXPECT().
+					Create(s.ctx, gomock.Any()).
+					Return(true, nil)
+				s.mockBumpGateway.EXPECT().
+					FindByOrderIDs(s.ctx, []uint64{1}).
+					Return([]*entity.KitchenTicketBump{{OrderID: 1, Station: valueobject.GRILL, StaffID: 3}}, nil)
+				gomock.InOrder(
+					s.mockOrderUseCase.EXPECT().
+						Update(s.ctx, dto.UpdateOrderInput{ID: 1, Status: valueobject.PREPARING, StaffID: 3}).
+						Return(&entity.Order{ID: 1, Status: valueobject.PREPARING}, nil),
+					s.mockOrderUseCase.EXPECT().
+						Update(s.ctx, dto.UpdateOrderInput{ID: 1, Status: valueobject.READY, StaffID: 3}).
+						Return(&entity.Order{ID: 1, Status: valueobject.READY}, nil),
+				)
+			},
+			checkResult: func(t *testing.T, ticket *entity.KitchenTicket, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, valueobject.READY, ticket.OrderStatus)
+			},
+		},
+		{
+			name:  "should only move the order to ready when a concurrent bump started the preparation",
+			input: dto.BumpKitchenTicketInput{OrderID: 1, Station: valueobject.DRINKS, StaffID: 3},
+			setupMocks: func() {
+				s.mockCategoryUseCase.EXPECT().
+					List(s.ctx, gomock.Any()).
+					Return(s.mockCategories, int64(2), nil)
+				// The grill bump commits while this one waits for the order lock taken by Create
+				gomock.InOrder(
+					s.mockOrderUseCase.EXPECT().
+						Get(s.ctx, dto.GetOrderInput{ID: 1}).
+						Return(newKitchenOrder(1, valueobject.RECEIVED, 1, 2), nil),
+					s.mockBumpGateway.EXPECT().
+						Create(s.ctx, gomock.Any()).
+						Return(true, nil),
+					s.mockOrderUseCase.EXPECT().
+						Get(s.ctx, dto.GetOrderInput{ID: 1}).
+						Return(newKitchenOrder(1, valueobject.PREPARING, 1, 2), nil),
+					s.mockBumpGateway.EXPECT().
+						FindByOrderIDs(s.ctx, []uint64{1}).
+						Return([]*entity.KitchenTicketBump{
+							{OrderID: 1, Station: valueobject.GRILL, StaffID: 4},
+							{OrderID: 1, Station: valueobject.DRINKS, StaffID: 3},
+						}, nil),
+					s.mockOrderUseCase.EXPECT().
+						Update(s.ctx, dto.UpdateOrderInput{ID: 1, Status: valueobject.READY, StaffID: 3}).
+						Return(&entity.Order{ID: 1, Status: valueobject.READY}, nil),
+				)
+			},
+			checkResult: func(t *testing.T, ticket *entity.KitchenTicket, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, valueobject.READY, ticket.OrderStatus)
+			},
+		},
+		{
+			name:  "should return invalid input error when the ticket was already bumped",
+			input: dto.BumpKitchenTicketInput{OrderID: 1, Station: valueobject.GRILL, StaffID: 3},
+			setupMocks: func() {
+				s.mockOrderUseCase.EXPECT().
+					Get(s.ctx, dto.GetOrderInput{ID: 1}).
+					Return(newKitchenOrder(1, valueobject.PREPARING, 1, 2), nil)
+				s.mockCategoryUseCase.EXPECT().
+					List(s.ctx, gomock.Any()).
+					Return(s.mockCategories, int64(2), nil)
+				s.mockBumpGateway.EXPECT().
+					Create(s.ctx, gomock.Any()).
+					Return(false, nil)
+			},
+			checkResult: func(t *testing.T, ticket *entity.KitchenTicket, err error) {
+				assert.Nil(t, ticket)
+				assert.IsType(t, &domain.InvalidInputError{}, err)
+				assert.Equal(t, domain.ErrKitchenTicketAlreadyBumped, err.Error())
+			},
+		},
+		{
+			name:  "should return invalid input error when the order is not in the kitchen",
+			input: dto.BumpKitchenTicketInput{OrderID: 1, Station: valueobject.GRILL, StaffID: 3},
+			setupMocks: func() {
+				s.mockOrderUseCase.EXPECT().
+					Get(s.ctx, dto.GetOrderInput{ID: 1}).
+					Return(newKitchenOrder(1, valueobject.READY, 1), nil)
+			},
+			checkResult: func(t *testing.T, ticket *entity.KitchenTicket, err error) {
+				assert.Nil(t, ticket)
+				assert.IsType(t, &domain.InvalidInputError{}, err)
+				assert.Equal(t, domain.ErrOrderIsNotInKitchen, err.Error())
+			},
+		},
+		{
+			name:  "should return not found error when the order has no ticket for the station",
+			input: dto.BumpKitchenTicketInput{OrderID: 1, Station: valueobject.DESSERTS, StaffID: 3},
+			setupMocks: func() {
+				s.mockOrderUseCase.EXPECT().
+					Get(s.ctx, dto.GetOrderInput{ID: 1}).
+					Return(newKitchenOrder(1, valueobject.RECEIVED, 1, 2), nil)
+				s.mockCategoryUseCase.EXPECT().
+					List(s.ctx, gomock.Any()).
+					Return(s.mockCategories, int64(2), nil)
+			},
+			checkResult: func(t *testing.T, ticket *entity.KitchenTicket, err error) {
+				assert.Nil(t, ticket)
+				assert.IsType(t, &domain.NotFoundError{}, err)
+			},
+		},
+		{
+			name:       "should return invalid input error without a staff",
+			input:      dto.BumpKitchenTicketInput{OrderID: 1, Station: valueobject.GRILL},
+			setupMocks: func() {},
+			checkResult: func(t *testing.T, ticket *entity.KitchenTicket, err error) {
+				assert.Nil(t, ticket)
+				assert.IsType(t, &domain.InvalidInputError{}, err)
+				assert.Equal(t, domain.ErrStaffIdIsMandatory, err.Error())
+			},
+		},
+		{
+			name:  "should return internal error when the bump gateway fails",
+			input: dto.BumpKitchenTicketInput{OrderID: 1, Station: valueobject.GRILL, StaffID: 3},
+			setupMocks: func() {
+				s.mockOrderUseCase.EXPECT().
+					Get(s.ctx, dto.GetOrderInput{ID: 1}).
+					Return(newKitchenOrder(1, valueobject.RECEIVED, 1), nil)
+				s.mockCategoryUseCase.EXPECT().
+					List(s.ctx, gomock.Any()).
+					Return(s.mockCategories, int64(2), nil)
+				s.mockBumpGateway.EXPECT().
+					Create(s.ctx, gomock.Any()).
+					Return(false, assert.AnError)
+			},
+			checkResult: func(t *testing.T, ticket *entity.KitchenTicket, err error) {
+				assert.Nil(t, ticket)
+				assert.IsType(t, &domain.InternalError{}, err)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.T().Run(tt.name, func(t *testing.T) {
+			useCase := s.useCase
+			if tt.stateMachine != nil {
+				useCase = s.newUseCase(tt.stateMachine)
+			}
+
+			tt.setupMocks()
+			ticket, err := useCase.BumpTicket(s.ctx, tt.input)
+			tt.checkResult(t, ticket, err)
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/port"
+)
+
+const (
+	// prepTimeSampleSize is the number of last orders the historical preparation time is averaged from
+	prepTimeSampleSize = 50
+	// estimateTolerance is the change below which an estimate is not saved again
+	estimateTolerance = time.Minute
+)
+
+type orderEstimateUseCase struct {
+	orderGateway    port.OrderGateway
+	historyGateway  port.OrderHistoryGateway
+	kitchenCapacity int
+	defaultPrepTime time.Duration
+}
+
+// NewOrderEstimateUseCase creates a new OrderEstimateUseCase. The kitchen prepares kitchenCapacity orders at a time,
+// taking defaultPrepTime for each until orders were prepared.
+func NewOrderEstimateUseCase(
+	orderGateway port.OrderGateway,
+	historyGateway port.OrderHistoryGateway,
+	kitchenCapacity int,
+	defaultPrepTime time.Duration,
+) port.OrderEstimateUseCase {
+	return &orderEstimateUseCase{orderGateway, historyGateway, kitchenCapacity, defaultPrepTime}
+}
+
+// EstimateQueue estimates when each order in the kitchen gets READY, saving the estimates that changed
+func (uc *orderEstimateUseCase) EstimateQueue(ctx context.Context) (map[uint64]time.Time, error) {
+	orders, _, err := uc.orderGateway.FindAll(ctx, 0, kitchenOrderStatuses, nil, 1, kitchenQueueLimit, "created_at")
+	if err != nil {
+		return nil, domain.NewInternalError(err)
+	}
+
+	if len(orders) == 0 {
+		return map[uint64]time.Time{}, nil
+	}
+
+	orderIDs := make([]uint64, 0, len(orders))
+	for _, order := range orders {
+		orderIDs = append(orderIDs, order.ID)
+	}
+
+	histories, err := uc.historyGateway.FindByOrderIDs(ctx, orderIDs)
+	if err != nil {
+		return nil, domain.NewInternalError(err)
+	}
+
+	averagePrepTime, err := uc.historyGateway.AveragePrepTime(ctx, prepTimeSampleSize)
+	if err != nil {
+		return nil, domain.NewInternalError(err)
+	}
+
+	// The time already spent preparing is discounted from the estimate
+	now := time.Now()
+	historiesByOrder := make(map[uint64][]*entity.OrderHistory, len(orders))
+	for _, history := range histories {
+		historiesByOrder[history.OrderID] = append(historiesByOrder[history.OrderID], history)
+	}
+	for _, order := range orders {
+		order.Timing = entity.NewOrderTiming(order, historiesByOrder[order.ID], 0, now)
+	}
+
+	queue := entity.KitchenQueue{
+		Capacity:        uc.kitchenCapacity,
+		AveragePrepTime: averagePrepTime,
+		DefaultPrepTime: uc.defaultPrepTime,
+	}
+	estimates := queue.Estimate(orders, now)
+
+	for _, order := range orders {
+		estimate := estimates[order.ID]
+		if order.EstimatedReadyAt != nil && estimate.Sub(*order.EstimatedReadyAt).Abs() < estimateTolerance {
+			estimates[order.ID] = *order.EstimatedReadyAt
+			continue
+		}
+
+		if err := uc.orderGateway.UpdateEstimatedReadyAt(ctx, order.ID, estimate); err != nil {
+			return nil, domain.NewInternalError(err)
+		}
+	}
+
+	return estimates, nil
+}
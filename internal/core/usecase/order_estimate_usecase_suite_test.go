@@ -0,0 +1,46 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/port"
+	mockport "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/port/mocks"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/usecase"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+const (
+	// orderEstimateKitchenCapacity is the number of orders the kitchen prepares at the same time
+	orderEstimateKitchenCapacity = 2
+	// orderEstimateDefaultPrepTime is the time to prepare an order without history
+	orderEstimateDefaultPrepTime = 10 * time.Minute
+)
+
+type OrderEstimateUsecaseSuiteTest struct {
+	suite.Suite
+	mockOrderGateway   *mockport.MockOrderGateway
+	mockHistoryGateway *mockport.MockOrderHistoryGateway
+	useCase            port.OrderEstimateUseCase
+	ctx                context.Context
+}
+
+func (s *OrderEstimateUsecaseSuiteTest) SetupTest() {
+	ctrl := gomock.NewController(s.T())
+	defer ctrl.Finish()
+	s.mockOrderGateway = mockport.NewMockOrderGateway(ctrl)
+	s.mockHistoryGateway = mockport.NewMockOrderHistoryGateway(ctrl)
+	s.useCase = usecase.NewOrderEstimateUseCase(
+		s.mockOrderGateway,
+		s.mockHistoryGateway,
+		orderEstimateKitchenCapacity,
+		orderEstimateDefaultPrepTime,
+	)
+	s.ctx = context.Background()
+}
+
+func TestOrderEstimateUsecaseSuiteTest(t *testing.T) {
+	suite.Run(t, new(OrderEstimateUsecaseSuiteTest))
+}
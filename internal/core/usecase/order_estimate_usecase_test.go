@@ -0,0 +1,146 @@
+package usecase_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
+	valueobject "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/value_object"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+// assertEstimate checks the estimate is the given time from now
+func assertEstimate(t *testing.T, expected time.Duration, estimate time.Time) {
+	assert.WithinDuration(t, time.Now().Add(expected), estimate, 5*time.Second)
+}
+
+func (s *OrderEstimateUsecaseSuiteTest) TestOrderEstimateUseCase_EstimateQueue() {
+	tests := []struct {
+		name        string
+		setupMocks  func()
+		checkResult func(*testing.T, map[uint64]time.Time, error)
+	}{
+		{
+			name: "should queue the paid orders behind the ones being prepared",
+			setupMocks: func() {
+				slow := newKitchenOrder(3, valueobject.RECEIVED, 1)
+				slow.OrderProducts[0].Product.PrepMinutes = 20
+
+				s.mockOrderGateway.EXPECT().
+					FindAll(s.ctx, uint64(0), []valueobject.OrderStatus{valueobject.RECEIVED, valueobject.PREPARING}, nil, 1, gomock.Any(), "created_at").
+					Return([]*entity.Order{
+						newKitchenOrder(1, valueobject.RECEIVED, 1),
+						newKitchenOrder(2, valueobject.PREPARING, 1),
+						slow,
+						newKitchenOrder(4, valueobject.PREPARING, 1),
+					}, int64(4), nil)
+				histories := newOrderHistories(2, map[valueobject.OrderStatus]time.Duration{valueobject.PREPARING: 6 * time.Minute})
+				histories = append(histories, newOrderHistories(4, map[valueobject.OrderStatus]time.Duration{valueobject.PREPARING: 2 * time.Minute})...)
+				s.mockHistoryGateway.EXPECT().
+					FindByOrderIDs(s.ctx, []uint64{1, 2, 3, 4}).
+					Return(histories, nil)
+				s.mockHistoryGateway.EXPECT().
+					AveragePrepTime(s.ctx, gomock.Any()).
+					Return(8*time.Minute, nil)
+				s.mockOrderGateway.EXPECT().
+					UpdateEstimatedReadyAt(s.ctx, gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(4)
+			},
+			checkResult: func(t *testing.T, estimates map[uint64]time.Time, err error) {
+				assert.NoError(t, err)
+				assert.Len(t, estimates, 4)
+				assertEstimate(t, 2*time.Minute, estimates[2])  // 8 minutes, 6 already spent
+				assertEstimate(t, 6*time.Minute, estimates[4])  // 8 minutes, 2 already spent
+				assertEstimate(t, 10*time.Minute, estimates[1]) // after order 2
+				assertEstimate(t, 26*time.Minute, estimates[3]) // its product takes 20 minutes, after order 4
+			},
+		},
+		{
+			name: "should use the default preparation time without history",
+			setupMocks: func() {
+				s.mockOrderGateway.EXPECT().
+					FindAll(s.ctx, uint64(0), gomock.Any(), nil, 1, gomock.Any(), "created_at").
+					Return([]*entity.Order{newKitchenOrder(1, valueobject.RECEIVED, 1)}, int64(1), nil)
+				s.mockHistoryGateway.EXPECT().
+					FindByOrderIDs(s.ctx, []uint64{1}).
+					Return(nil, nil)
+				s.mockHistoryGateway.EXPECT().
+					AveragePrepTime(s.ctx, gomock.Any()).
+					Return(time.Duration(0), nil)
+				s.mockOrderGateway.EXPECT().
+					UpdateEstimatedReadyAt(s.ctx, uint64(1), gomock.Any()).
+					Return(nil)
+			},
+			checkResult: func(t *testing.T, estimates map[uint64]time.Time, err error) {
+				assert.NoError(t, err)
+				assertEstimate(t, orderEstimateDefaultPrepTime, estimates[1])
+			},
+		},
+		{
+			name: "should keep the estimates that barely changed",
+			setupMocks: func() {
+				order := newKitchenOrder(1, valueobject.RECEIVED, 1)
+				saved := time.Now().Add(orderEstimateDefaultPrepTime + 20*time.Second)
+				order.EstimatedReadyAt = &saved
+
+				s.mockOrderGateway.EXPECT().
+					FindAll(s.ctx, uint64(0), gomock.Any(), nil, 1, gomock.Any(), "created_at").
+					Return([]*entity.Order{order}, int64(1), nil)
+				s.mockHistoryGateway.EXPECT().
+					FindByOrderIDs(s.ctx, []uint64{1}).
+					Return(nil, nil)
+				s.mockHistoryGateway.EXPECT().
+					AveragePrepTime(s.ctx, gomock.Any()).
+					Return(time.Duration(0), nil)
+			},
+			checkResult: func(t *testing.T, estimates map[uint64]time.Time, err error) {
+				assert.NoError(t, err)
+				assertEstimate(t, orderEstimateDefaultPrepTime+20*time.Second, estimates[1])
+			},
+		},
+		{
+			name: "should return internal error when the order gateway fails",
+			setupMocks: func() {
+				s.mockOrderGateway.EXPECT().
+					FindAll(s.ctx, uint64(0), gomock.Any(), nil, 1, gomock.Any(), "created_at").
+					Return(nil, int64(0), assert.AnError)
+			},
+			checkResult: func(t *testing.T, estimates map[uint64]time.Time, err error) {
+				assert.Nil(t, estimates)
+				assert.IsType(t, &domain.InternalError{}, err)
+			},
+		},
+		{
+			name: "should return internal error when the estimate cannot be saved",
+			setupMocks: func() {
+				s.mockOrderGateway.EXPECT().
+					FindAll(s.ctx, uint64(0), gomock.Any(), nil, 1, gomock.Any(), "created_at").
+					Return([]*entity.Order{newKitchenOrder(1, valueobject.RECEIVED, 1)}, int64(1), nil)
+				s.mockHistoryGateway.EXPECT().
+					FindByOrderIDs(s.ctx, []uint64{1}).
+					Return(nil, nil)
+				s.mockHistoryGateway.EXPECT().
+					AveragePrepTime(s.ctx, gomock.Any()).
+					Return(5*time.Minute, nil)
+				s.mockOrderGateway.EXPECT().
+					UpdateEstimatedReadyAt(s.ctx, uint64(1), gomock.Any()).
+					Return(assert.AnError)
+			},
+			checkResult: func(t *testing.T, estimates map[uint64]time.Time, err error) {
+				assert.Nil(t, estimates)
+				assert.IsType(t, &domain.InternalError{}, err)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.T().Run(tt.name, func(t *testing.T) {
+			tt.setupMocks()
+			estimates, err := s.useCase.EstimateQueue(s.ctx)
+			tt.checkResult(t, estimates, err)
+		})
+	}
+}
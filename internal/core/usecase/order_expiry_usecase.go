@@ -0,0 +1,131 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain"
+	valueobject "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/value_object"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/dto"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/port"
+)
+
+// expiredOrdersBatchSize limits the orders of each status changed by each ExpireOrders call
+const expiredOrdersBatchSize = 100
+
+type orderExpiryUseCase struct {
+	orderGateway       port.OrderGateway
+	orderUseCase       port.OrderUseCase
+	unitOfWork         port.UnitOfWork
+	readyTimeout       time.Duration
+	readyTimeoutStatus valueobject.OrderStatus
+	openTimeout        time.Duration
+}
+
+// NewOrderExpiryUseCase creates a new OrderExpiryUseCase. The READY orders not collected within readyTimeout are moved
+// to readyTimeoutStatus (COMPLETED or ABANDONED), the OPEN orders without activity within openTimeout are cancelled.
+// A zero timeout disables it.
+func NewOrderExpiryUseCase(
+	orderGateway port.OrderGateway,
+	orderUseCase port.OrderUseCase,
+	unitOfWork port.UnitOfWork,
+	readyTimeout time.Duration,
+	readyTimeoutStatus valueobject.OrderStatus,
+	openTimeout time.Duration,
+) port.OrderExpiryUseCase {
+	return &orderExpiryUseCase{orderGateway, orderUseCase, unitOfWork, readyTimeout, readyTimeoutStatus, openTimeout}
+}
+
+// ExpireOrders closes the READY orders never collected and cancels the OPEN orders left idle, returning how many
+// orders were changed
+func (uc *orderExpiryUseCase) ExpireOrders(ctx context.Context) (int, error) {
+	readyExpired, readyErr := uc.expire(ctx, valueobject.READY, uc.readyTimeout, uc.readyTimeoutStatus)
+	openExpired, openErr := uc.expire(ctx, valueobject.OPEN, uc.openTimeout, valueobject.CANCELLED)
+
+	if err := errors.Join(readyErr, openErr); err != nil {
+		return readyExpired + openExpired, domain.NewInternalError(err)
+	}
+
+	return readyExpired + openExpired, nil
+}
+
+// expire moves the orders idle on the status for longer than the timeout to the new status, recorded with the
+// expiry as actor
+func (uc *orderExpiryUseCase) expire(
+	ctx context.Context,
+	status valueobject.OrderStatus,
+	timeout time.Duration,
+	newStatus valueobject.OrderStatus,
+) (int, error) {
+	if timeout <= 0 {
+		return 0, nil
+	}
+
+	idleSince := time.Now().Add(-timeout)
+	orders, err := uc.orderGateway.FindIdle(ctx, status, idleSince, expiredOrdersBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	var expired int
+	var errs []error
+	for _, order := range orders {
+		changed, err := uc.expireOrder(ctx, order.ID, status, idleSince, newStatus)
+		if err != nil {
+			// Changed meanwhile (ex: collected at the counter), so it is no longer idle
+			var preconditionErr *domain.PreconditionFailedError
+			var conflictErr *domain.ConflictError
+			var notFoundErr *domain.NotFoundError
+			if errors.As(err, &preconditionErr) || errors.As(err, &conflictErr) || errors.As(err, &notFoundErr) {
+				continue
+			}
+
+			errs = append(errs, err)
+			continue
+		}
+
+		if changed {
+			expired++
+		}
+	}
+
+	return expired, errors.Join(errs...)
+}
+
+// expireOrder moves the order to the new status if it is still idle on the status, returning false otherwise. The
+// order is locked while checked, so a product added to it (which locks the order as well) either committed before and
+// is seen here, or waits for the expiry and finds the order no longer OPEN.
+func (uc *orderExpiryUseCase) expireOrder(
+	ctx context.Context,
+	orderID uint64,
+	status valueobject.OrderStatus,
+	idleSince time.Time,
+	newStatus valueobject.OrderStatus,
+) (bool, error) {
+	var expired bool
+	err := uc.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		order, err := uc.orderUseCase.Get(ctx, dto.GetOrderInput{ID: orderID, ForUpdate: true})
+		if err != nil {
+			return err
+		}
+
+		if order.Status != status || !order.IsIdleSince(idleSince) {
+			return nil
+		}
+
+		if _, err := uc.orderUseCase.Update(ctx, dto.UpdateOrderInput{
+			ID:          order.ID,
+			Status:      newStatus,
+			Version:     &order.Version,
+			SystemActor: valueobject.ORDER_EXPIRY_ACTOR,
+		}); err != nil {
+			return err
+		}
+
+		expired = true
+		return nil
+	})
+
+	return expired, err
+}
@@ -0,0 +1,55 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	valueobject "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/value_object"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/port"
+	mockport "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/port/mocks"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/usecase"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+const (
+	// orderExpiryReadyTimeout is the time a READY order waits to be collected
+	orderExpiryReadyTimeout = 30 * time.Minute
+	// orderExpiryOpenTimeout is the time an OPEN order waits without activity
+	orderExpiryOpenTimeout = 2 * time.Hour
+)
+
+type OrderExpiryUsecaseSuiteTest struct {
+	suite.Suite
+	mockOrderGateway *mockport.MockOrderGateway
+	mockOrderUseCase *mockport.MockOrderUseCase
+	mockUnitOfWork   *mockport.MockUnitOfWork
+	useCase          port.OrderExpiryUseCase
+	ctx              context.Context
+}
+
+func (s *OrderExpiryUsecaseSuiteTest) SetupTest() {
+	ctrl := gomock.NewController(s.T())
+	defer ctrl.Finish()
+	s.mockOrderGateway = mockport.NewMockOrderGateway(ctrl)
+	s.mockOrderUseCase = mockport.NewMockOrderUseCase(ctrl)
+	s.mockUnitOfWork = mockport.NewMockUnitOfWork(ctrl)
+	s.mockUnitOfWork.EXPECT().
+		Do(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) }).
+		AnyTimes()
+	s.useCase = usecase.NewOrderExpiryUseCase(
+		s.mockOrderGateway,
+		s.mockOrderUseCase,
+		s.mockUnitOfWork,
+		orderExpiryReadyTimeout,
+		valueobject.ABANDONED,
+		orderExpiryOpenTimeout,
+	)
+	s.ctx = context.Background()
+}
+
+func TestOrderExpiryUsecaseSuiteTest(t *testing.T) {
+	suite.Run(t, new(OrderExpiryUsecaseSuiteTest))
+}
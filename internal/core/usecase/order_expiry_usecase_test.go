@@ -0,0 +1,158 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
+	valueobject "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/value_object"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/dto"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+// idleSinceMatcher matches the time an order is idle since, given its timeout
+func idleSinceMatcher(timeout time.Duration) gomock.Matcher {
+	return gomock.Cond(func(x any) bool {
+		idleSince, ok := x.(time.Time)
+		return ok && idleSince.Sub(time.Now().Add(-timeout)).Abs() < 5*time.Second
+	})
+}
+
+func (s *OrderExpiryUsecaseSuiteTest) TestOrderExpiryUseCase_ExpireOrders() {
+	idle := time.Now().Add(-3 * time.Hour)
+	// expectLockedOrder expects the order to be locked and read again before it is expired
+	expectLockedOrder := func(order *entity.Order) {
+		s.mockOrderUseCase.EXPECT().
+			Get(s.ctx, dto.GetOrderInput{ID: order.ID, ForUpdate: true}).
+			Return(order, nil)
+	}
+
+	tests := []struct {
+		name        string
+		setupMocks  func()
+		checkResult func(*testing.T, int, error)
+	}{
+		{
+			name: "should abandon the ready orders and cancel the idle open orders",
+			setupMocks: func() {
+				s.mockOrderGateway.EXPECT().
+					FindIdle(s.ctx, valueobject.READY, idleSinceMatcher(orderExpiryReadyTimeout), gomock.Any()).
+					Return([]*entity.Order{{ID: 1, Status: valueobject.READY, Version: 4}}, nil)
+				s.mockOrderGateway.EXPECT().
+					FindIdle(s.ctx, valueobject.OPEN, idleSinceMatcher(orderExpiryOpenTimeout), gomock.Any()).
+					Return([]*entity.Order{{ID: 2, Status: valueobject.OPEN, Version: 1}}, nil)
+				expectLockedOrder(&entity.Order{ID: 1, Status: valueobject.READY, Version: 4, UpdatedAt: idle})
+				expectLockedOrder(&entity.Order{ID: 2, Status: valueobject.OPEN, Version: 1, UpdatedAt: idle})
+
+				s.mockOrderUseCase.EXPECT().
+					Update(s.ctx, gomock.Any()).
+					DoAndReturn(func(_ context.Context, i dto.UpdateOrderInput) (*entity.Order, error) {
+						assert.Equal(s.T(), uint64(1), i.ID)
+						assert.Equal(s.T(), valueobject.ABANDONED, i.Status)
+						assert.Equal(s.T(), uint64(4), *i.Version)
+						assert.Equal(s.T(), valueobject.ORDER_EXPIRY_ACTOR, i.SystemActor)
+						assert.Zero(s.T(), i.StaffID)
+						return &entity.Order{ID: 1, Status: i.Status}, nil
+					})
+				s.mockOrderUseCase.EXPECT().
+					Update(s.ctx, gomock.Any()).
+					DoAndReturn(func(_ context.Context, i dto.UpdateOrderInput) (*entity.Order, error) {
+						assert.Equal(s.T(), uint64(2), i.ID)
+						assert.Equal(s.T(), valueobject.CANCELLED, i.Status)
+						assert.Equal(s.T(), valueobject.ORDER_EXPIRY_ACTOR, i.SystemActor)
+						return &entity.Order{ID: 2, Status: i.Status}, nil
+					})
+			},
+			checkResult: func(t *testing.T, expired int, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, 2, expired)
+			},
+		},
+		{
+			name: "should skip the orders changed meanwhile",
+			setupMocks: func() {
+				s.mockOrderGateway.EXPECT().
+					FindIdle(s.ctx, valueobject.READY, gomock.Any(), gomock.Any()).
+					Return([]*entity.Order{{ID: 1, Status: valueobject.READY}, {ID: 2, Status: valueobject.READY}}, nil)
+				s.mockOrderGateway.EXPECT().
+					FindIdle(s.ctx, valueobject.OPEN, gomock.Any(), gomock.Any()).
+					Return(nil, nil)
+				expectLockedOrder(&entity.Order{ID: 1, Status: valueobject.READY, UpdatedAt: idle})
+				expectLockedOrder(&entity.Order{ID: 2, Status: valueobject.READY, UpdatedAt: idle})
+
+				s.mockOrderUseCase.EXPECT().
+					Update(s.ctx, gomock.Any()).
+					Return(nil, domain.NewPreconditionFailedError(domain.ErrVersionMismatch))
+				s.mockOrderUseCase.EXPECT().
+					Update(s.ctx, gomock.Any()).
+					Return(nil, domain.NewConflictError(domain.ErrConcurrentUpdate))
+			},
+			checkResult: func(t *testing.T, expired int, err error) {
+				assert.NoError(t, err)
+				assert.Zero(t, expired)
+			},
+		},
+		{
+			name: "should skip the orders that are no longer idle once locked",
+			setupMocks: func() {
+				s.mockOrderGateway.EXPECT().
+					FindIdle(s.ctx, valueobject.READY, gomock.Any(), gomock.Any()).
+					Return([]*entity.Order{{ID: 1, Status: valueobject.READY}}, nil)
+				s.mockOrderGateway.EXPECT().
+					FindIdle(s.ctx, valueobject.OPEN, gomock.Any(), gomock.Any()).
+					Return([]*entity.Order{{ID: 2, Status: valueobject.OPEN}, {ID: 3, Status: valueobject.OPEN}}, nil)
+
+				// collected at the counter, given a new product and deleted after they were found
+				expectLockedOrder(&entity.Order{ID: 1, Status: valueobject.COMPLETED, UpdatedAt: time.Now()})
+				expectLockedOrder(&entity.Order{
+					ID:            2,
+					Status:        valueobject.OPEN,
+					UpdatedAt:     idle,
+					OrderProducts: []entity.OrderProduct{{OrderID: 2, ProductID: 1, UpdatedAt: time.Now()}},
+				})
+				s.mockOrderUseCase.EXPECT().
+					Get(s.ctx, dto.GetOrderInput{ID: 3, ForUpdate: true}).
+					Return(nil, domain.NewNotFoundError(domain.ErrNotFound))
+			},
+			checkResult: func(t *testing.T, expired int, err error) {
+				assert.NoError(t, err)
+				assert.Zero(t, expired)
+			},
+		},
+		{
+			name: "should keep expiring the other orders when one fails",
+			setupMocks: func() {
+				s.mockOrderGateway.EXPECT().
+					FindIdle(s.ctx, valueobject.READY, gomock.Any(), gomock.Any()).
+					Return(nil, assert.AnError)
+				s.mockOrderGateway.EXPECT().
+					FindIdle(s.ctx, valueobject.OPEN, gomock.Any(), gomock.Any()).
+					Return([]*entity.Order{{ID: 2, Status: valueobject.OPEN}, {ID: 3, Status: valueobject.OPEN}}, nil)
+				expectLockedOrder(&entity.Order{ID: 2, Status: valueobject.OPEN, UpdatedAt: idle})
+				expectLockedOrder(&entity.Order{ID: 3, Status: valueobject.OPEN, UpdatedAt: idle})
+
+				s.mockOrderUseCase.EXPECT().
+					Update(s.ctx, gomock.Any()).
+					Return(nil, domain.NewInternalError(assert.AnError))
+				s.mockOrderUseCase.EXPECT().
+					Update(s.ctx, gomock.Any()).
+					Return(&entity.Order{ID: 3, Status: valueobject.CANCELLED}, nil)
+			},
+			checkResult: func(t *testing.T, expired int, err error) {
+				assert.Equal(t, 1, expired)
+				assert.IsType(t, &domain.InternalError{}, err)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.T().Run(tt.name, func(t *testing.T) {
+			tt.setupMocks()
+			expired, err := s.useCase.ExpireOrders(s.ctx)
+			tt.checkResult(t, expired, err)
+		})
+	}
+}
@@ -31,6 +31,8 @@ func (uc *orderHistoryUseCase) List(ctx context.Context, input dto.ListOrderHist
 // Create creates a new orderHistory
 func (uc *orderHistoryUseCase) Create(ctx context.Context, input dto.CreateOrderHistoryInput) (*entity.OrderHistory, error) {
 	orderHistory := entity.NewOrderHistory(input.OrderID, input.Status, input.StaffID)
+	orderHistory.PaymentStatus = input.PaymentStatus
+	orderHistory.SystemActor = input.SystemActor
 
 	if err := uc.gateway.Create(ctx, orderHistory); err != nil {
 		return nil, domain.NewInternalError(err)
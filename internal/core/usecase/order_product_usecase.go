@@ -5,21 +5,34 @@ import (
 
 	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain"
 	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
+	valueobject "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/value_object"
 	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/dto"
 	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/port"
 )
 
 type orderProductUseCase struct {
-	gateway port.OrderProductGateway
+	gateway        port.OrderProductGateway
+	orderUseCase   port.OrderUseCase
+	productUseCase port.ProductUseCase
+	unitOfWork     port.UnitOfWork
 }
 
 // NewOrderProductUseCase creates a new ListOrderProductsUseCase
-func NewOrderProductUseCase(gateway port.OrderProductGateway) port.OrderProductUseCase {
-	return &orderProductUseCase{gateway}
+func NewOrderProductUseCase(
+	gateway port.OrderProductGateway,
+	orderUseCase port.OrderUseCase,
+	productUseCase port.ProductUseCase,
+	unitOfWork port.UnitOfWork,
+) port.OrderProductUseCase {
+	return &orderProductUseCase{gateway, orderUseCase, productUseCase, unitOfWork}
 }
 
 // List lists all orderProducts
 func (uc *orderProductUseCase) List(ctx context.Context, i dto.ListOrderProductsInput) ([]*entity.OrderProduct, int64, error) {
+	if err := uc.authorizeOrder(ctx, i.OrderID); err != nil {
+		return nil, 0, err
+	}
+
 	orderProducts, total, err := uc.gateway.FindAll(ctx, i.OrderID, i.ProductID, i.Page, i.Limit)
 	if err != nil {
 		return nil, 0, domain.NewInternalError(err)
@@ -27,12 +40,27 @@ func (uc *orderProductUseCase) List(ctx context.Context, i dto.ListOrderProducts
 	return orderProducts, total, nil
 }
 
-// Create creates a new orderProduct
+// Create creates a new orderProduct, keeping the price, name and category the product has now
 func (uc *orderProductUseCase) Create(ctx context.Context, i dto.CreateOrderProductInput) (*entity.OrderProduct, error) {
 	orderProduct := i.ToEntity()
-
-	if err := uc.gateway.Create(ctx, orderProduct); err != nil {
-		return nil, domain.NewInternalError(err)
+	err := uc.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		if _, err := uc.openOrder(ctx, i.OrderID); err != nil {
+			return err
+		}
+
+		product, err := uc.productUseCase.Get(ctx, dto.GetProductInput{ID: i.ProductID})
+		if err != nil {
+			return err
+		}
+		orderProduct.Snapshot(*product)
+
+		if err := uc.gateway.Create(ctx, orderProduct); err != nil {
+			return domain.NewInternalError(err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return orderProduct, nil
@@ -40,6 +68,10 @@ func (uc *orderProductUseCase) Create(ctx context.Context, i dto.CreateOrderProd
 
 // Get returns a orderProduct by ID
 func (uc *orderProductUseCase) Get(ctx context.Context, i dto.GetOrderProductInput) (*entity.OrderProduct, error) {
+	if err := uc.authorizeOrder(ctx, i.OrderID); err != nil {
+		return nil, err
+	}
+
 	orderProduct, err := uc.gateway.FindByID(ctx, i.OrderID, i.ProductID)
 	if err != nil {
 		return nil, domain.NewInternalError(err)
@@ -53,40 +85,125 @@ func (uc *orderProductUseCase) Get(ctx context.Context, i dto.GetOrderProductInp
 }
 
 func (uc *orderProductUseCase) Update(ctx context.Context, i dto.UpdateOrderProductInput) (*entity.OrderProduct, error) {
-	orderProduct, err := uc.gateway.FindByID(ctx, i.OrderID, i.ProductID)
+	var orderProduct *entity.OrderProduct
+	err := uc.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		if _, err := uc.openOrder(ctx, i.OrderID); err != nil {
+			return err
+		}
+
+		var err error
+		orderProduct, err = uc.gateway.FindByID(ctx, i.OrderID, i.ProductID)
+		if err != nil {
+			return domain.NewInternalError(err)
+		}
+
+		if orderProduct == nil {
+			return domain.NewNotFoundError(domain.ErrNotFound)
+		}
+
+		order := orderProduct.Order
+		product := orderProduct.Product
+		orderProduct.Update(i.Quantity)
+
+		if err := uc.gateway.Update(ctx, orderProduct); err != nil {
+			return domain.NewInternalError(err)
+		}
+
+		orderProduct.Order = order
+		orderProduct.Product = product
+		return nil
+	})
 	if err != nil {
-		return nil, domain.NewInternalError(err)
+		return nil, err
 	}
 
-	if orderProduct == nil {
-		return nil, domain.NewNotFoundError(domain.ErrNotFound)
+	return orderProduct, nil
+}
+
+func (uc *orderProductUseCase) Delete(ctx context.Context, i dto.DeleteOrderProductInput) (*entity.OrderProduct, error) {
+	var orderProduct *entity.OrderProduct
+	err := uc.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		if _, err := uc.openOrder(ctx, i.OrderID); err != nil {
+			return err
+		}
+
+		var err error
+		orderProduct, err = uc.gateway.FindByID(ctx, i.OrderID, i.ProductID)
+		if err != nil {
+			return domain.NewInternalError(err)
+		}
+		if orderProduct == nil {
+			return domain.NewNotFoundError(domain.ErrNotFound)
+		}
+
+		if err := uc.gateway.Delete(ctx, i.OrderID, i.ProductID); err != nil {
+			return domain.NewInternalError(err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	order := orderProduct.Order
-	product := orderProduct.Product
-	orderProduct.Update(i.Quantity)
+	return orderProduct, nil
+}
 
-	if err := uc.gateway.Update(ctx, orderProduct); err != nil {
-		return nil, domain.NewInternalError(err)
+// Reprice refreshes the snapshot of every product of an OPEN order with the current price, name and category
+// of the product, the orders that left OPEN keep the prices they were charged
+func (uc *orderProductUseCase) Reprice(ctx context.Context, i dto.RepriceOrderProductsInput) (*entity.Order, error) {
+	// Every product of the order is repriced, or none
+	var order *entity.Order
+	err := uc.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		var err error
+		if order, err = uc.openOrder(ctx, i.OrderID); err != nil {
+			return err
+		}
+
+		for idx := range order.OrderProducts {
+			orderProduct := &order.OrderProducts[idx]
+			product := orderProduct.Product
+			orderProduct.Reprice(product)
+
+			if err := uc.gateway.Update(ctx, orderProduct); err != nil {
+				return domain.NewInternalError(err)
+			}
+
+			orderProduct.Product = product
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	orderProduct.Order = order
-	orderProduct.Product = product
+	return order, nil
+}
 
-	return orderProduct, nil
+// authorizeOrder checks that a customer or guest caller owns the order, staff callers are not restricted
+func (uc *orderProductUseCase) authorizeOrder(ctx context.Context, orderID uint64) error {
+	if !isRestrictedCaller(ctx) {
+		return nil
+	}
+
+	if orderID == 0 {
+		return domain.NewForbiddenError(domain.ErrPermissionDenied)
+	}
+
+	_, err := uc.orderUseCase.Get(ctx, dto.GetOrderInput{ID: orderID})
+	return err
 }
 
-func (uc *orderProductUseCase) Delete(ctx context.Context, i dto.DeleteOrderProductInput) (*entity.OrderProduct, error) {
-	order, err := uc.gateway.FindByID(ctx, i.OrderID, i.ProductID)
+// openOrder returns the order when its products may still be changed, which is only while it is OPEN: once the
+// checkout begins the payment is created for the products the order has. Called in a unit of work, the order stays
+// locked until it commits, so the checkout waits for the change or the change sees the order left OPEN.
+func (uc *orderProductUseCase) openOrder(ctx context.Context, orderID uint64) (*entity.Order, error) {
+	order, err := uc.orderUseCase.Get(ctx, dto.GetOrderInput{ID: orderID, ForUpdate: true})
 	if err != nil {
-		return nil, domain.NewInternalError(err)
-	}
-	if order == nil {
-		return nil, domain.NewNotFoundError(domain.ErrNotFound)
+		return nil, err
 	}
 
-	if err := uc.gateway.Delete(ctx, i.OrderID, i.ProductID); err != nil {
-		return nil, domain.NewInternalError(err)
+	if order.Status != valueobject.OPEN {
+		return nil, domain.NewInvalidInputError(domain.ErrOrderIsNotOpen)
 	}
 
 	return order, nil
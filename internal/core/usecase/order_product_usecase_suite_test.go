@@ -6,6 +6,8 @@ import (
 	"time"
 
 	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
+	valueobject "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/value_object"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/dto"
 	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/port"
 	mockport "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/port/mocks"
 	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/usecase"
@@ -17,6 +19,9 @@ type OrderProductUsecaseSuiteTest struct {
 	suite.Suite
 	mockOrderProducts []*entity.OrderProduct
 	mockGateway       *mockport.MockOrderProductGateway
+	mockOrderUseCase  *mockport.MockOrderUseCase
+	mockProductUC     *mockport.MockProductUseCase
+	mockUnitOfWork    *mockport.MockUnitOfWork
 	useCase           port.OrderProductUseCase
 	ctx               context.Context
 }
@@ -25,7 +30,14 @@ func (s *OrderProductUsecaseSuiteTest) SetupTest() {
 	ctrl := gomock.NewController(s.T())
 	defer ctrl.Finish()
 	s.mockGateway = mockport.NewMockOrderProductGateway(ctrl)
-	s.useCase = usecase.NewOrderProductUseCase(s.mockGateway)
+	s.mockOrderUseCase = mockport.NewMockOrderUseCase(ctrl)
+	s.mockProductUC = mockport.NewMockProductUseCase(ctrl)
+	s.mockUnitOfWork = mockport.NewMockUnitOfWork(ctrl)
+	s.mockUnitOfWork.EXPECT().
+		Do(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) }).
+		AnyTimes()
+	s.useCase = usecase.NewOrderProductUseCase(s.mockGateway, s.mockOrderUseCase, s.mockProductUC, s.mockUnitOfWork)
 	s.ctx = context.Background()
 	currentTime := time.Now()
 	s.mockOrderProducts = []*entity.OrderProduct{
@@ -46,6 +58,13 @@ func (s *OrderProductUsecaseSuiteTest) SetupTest() {
 	}
 }
 
+// expectOrderStatus makes the order use case return the order with the given status
+func (s *OrderProductUsecaseSuiteTest) expectOrderStatus(orderID uint64, status valueobject.OrderStatus) {
+	s.mockOrderUseCase.EXPECT().
+		Get(gomock.Any(), dto.GetOrderInput{ID: orderID, ForUpdate: true}).
+		Return(&entity.Order{ID: orderID, Status: status}, nil)
+}
+
 func TestOrderProductUsecaseSuiteTest(t *testing.T) {
 	suite.Run(t, new(OrderProductUsecaseSuiteTest))
 }
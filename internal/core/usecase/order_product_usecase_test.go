@@ -7,8 +7,11 @@ import (
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain"
 	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
+	valueobject "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/value_object"
 	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/dto"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/util"
 )
 
 func (s *OrderProductUsecaseSuiteTest) TestOrderProductsUseCase_List() {
@@ -116,8 +119,13 @@ func (s *OrderProductUsecaseSuiteTest) TestOrderProductUseCase_Create() {
 			input: dto.CreateOrderProductInput{
 				OrderID:   1,
 				ProductID: 1,
+				Quantity:  2,
 			},
 			setupMocks: func() {
+				s.expectOrderStatus(1, valueobject.OPEN)
+				s.mockProductUC.EXPECT().
+					Get(s.ctx, dto.GetProductInput{ID: 1}).
+					Return(&entity.Product{ID: 1, Name: "Burger", Price: valueobject.NewMoney(1990), CategoryID: 3}, nil)
 				s.mockGateway.EXPECT().
 					Create(s.ctx, gomock.Any()).
 					Return(nil)
@@ -129,6 +137,50 @@ func (s *OrderProductUsecaseSuiteTest) TestOrderProductUseCase_Create() {
 				assert.Equal(t, uint64(1), orderProduct.ProductID)
 			},
 		},
+		{
+			name: "should snapshot the price, name and category of the product",
+			input: dto.CreateOrderProductInput{
+				OrderID:   1,
+				ProductID: 1,
+				Quantity:  2,
+			},
+			setupMocks: func() {
+				s.expectOrderStatus(1, valueobject.OPEN)
+				s.mockProductUC.EXPECT().
+					Get(s.ctx, dto.GetProductInput{ID: 1}).
+					Return(&entity.Product{ID: 1, Name: "Burger", Price: valueobject.NewMoney(1990), CategoryID: 3}, nil)
+				s.mockGateway.EXPECT().
+					Create(s.ctx, gomock.Any()).
+					DoAndReturn(func(_ context.Context, orderProduct *entity.OrderProduct) error {
+						assert.Equal(s.T(), valueobject.NewMoney(1990), orderProduct.UnitPrice)
+						assert.Equal(s.T(), "Burger", orderProduct.ProductName)
+						assert.Equal(s.T(), uint64(3), orderProduct.CategoryID)
+						return nil
+					})
+			},
+			checkResult: func(t *testing.T, orderProduct *entity.OrderProduct, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, valueobject.NewMoney(3980), orderProduct.Subtotal())
+			},
+		},
+		{
+			name: "should return not found error when product doesn't exist",
+			input: dto.CreateOrderProductInput{
+				OrderID:   1,
+				ProductID: 9,
+			},
+			setupMocks: func() {
+				s.expectOrderStatus(1, valueobject.OPEN)
+				s.mockProductUC.EXPECT().
+					Get(s.ctx, dto.GetProductInput{ID: 9}).
+					Return(nil, domain.NewNotFoundError(domain.ErrNotFound))
+			},
+			checkResult: func(t *testing.T, orderProduct *entity.OrderProduct, err error) {
+				assert.Error(t, err)
+				assert.Nil(t, orderProduct)
+				assert.IsType(t, &domain.NotFoundError{}, err)
+			},
+		},
 		{
 			name: "should return error when gateway fails",
 			input: dto.CreateOrderProductInput{
@@ -136,6 +188,10 @@ func (s *OrderProductUsecaseSuiteTest) TestOrderProductUseCase_Create() {
 				ProductID: 1,
 			},
 			setupMocks: func() {
+				s.expectOrderStatus(1, valueobject.OPEN)
+				s.mockProductUC.EXPECT().
+					Get(s.ctx, dto.GetProductInput{ID: 1}).
+					Return(&entity.Product{ID: 1}, nil)
 				s.mockGateway.EXPECT().
 					Create(s.ctx, gomock.Any()).
 					Return(assert.AnError)
@@ -145,6 +201,18 @@ func (s *OrderProductUsecaseSuiteTest) TestOrderProductUseCase_Create() {
 				assert.Nil(t, orderProduct)
 			},
 		},
+		{
+			name:  "should not add products to an order that is not open",
+			input: dto.CreateOrderProductInput{OrderID: 1, ProductID: 1, Quantity: 1},
+			setupMocks: func() {
+				s.expectOrderStatus(1, valueobject.PENDING)
+			},
+			checkResult: func(t *testing.T, orderProduct *entity.OrderProduct, err error) {
+				assert.Nil(t, orderProduct)
+				assert.IsType(t, &domain.InvalidInputError{}, err)
+				assert.EqualError(t, err, domain.ErrOrderIsNotOpen)
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -240,6 +308,7 @@ func (s *OrderProductUsecaseSuiteTest) TestOrderProductUseCase_Update() {
 				Quantity:  1,
 			},
 			setupMocks: func() {
+				s.expectOrderStatus(1, valueobject.OPEN)
 				s.mockGateway.EXPECT().
 					FindByID(s.ctx, uint64(1), uint64(1)).
 					Return(s.mockOrderProducts[0], nil)
@@ -269,6 +338,7 @@ func (s *OrderProductUsecaseSuiteTest) TestOrderProductUseCase_Update() {
 				Quantity:  1,
 			},
 			setupMocks: func() {
+				s.expectOrderStatus(1, valueobject.OPEN)
 				s.mockGateway.EXPECT().
 					FindByID(s.ctx, uint64(1), uint64(1)).
 					Return(nil, nil)
@@ -286,6 +356,7 @@ func (s *OrderProductUsecaseSuiteTest) TestOrderProductUseCase_Update() {
 				Quantity:  1,
 			},
 			setupMocks: func() {
+				s.expectOrderStatus(1, valueobject.OPEN)
 				s.mockGateway.EXPECT().
 					FindByID(s.ctx, uint64(1), uint64(1)).
 					Return(nil, assert.AnError)
@@ -303,6 +374,7 @@ func (s *OrderProductUsecaseSuiteTest) TestOrderProductUseCase_Update() {
 				Quantity:  1,
 			},
 			setupMocks: func() {
+				s.expectOrderStatus(1, valueobject.OPEN)
 				s.mockGateway.EXPECT().
 					FindByID(s.ctx, uint64(1), uint64(1)).
 					Return(s.mockOrderProducts[0], nil)
@@ -316,6 +388,18 @@ func (s *OrderProductUsecaseSuiteTest) TestOrderProductUseCase_Update() {
 				assert.Nil(t, orderProduct)
 			},
 		},
+		{
+			name:  "should not change products of an order that is not open",
+			input: dto.UpdateOrderProductInput{OrderID: 1, ProductID: 1, Quantity: 2},
+			setupMocks: func() {
+				s.expectOrderStatus(1, valueobject.PENDING)
+			},
+			checkResult: func(t *testing.T, orderProduct *entity.OrderProduct, err error) {
+				assert.Nil(t, orderProduct)
+				assert.IsType(t, &domain.InvalidInputError{}, err)
+				assert.EqualError(t, err, domain.ErrOrderIsNotOpen)
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -343,6 +427,7 @@ func (s *OrderProductUsecaseSuiteTest) TestOrderProductUseCase_Delete() {
 			name:  "should delete orderProduct successfully",
 			input: dto.DeleteOrderProductInput{OrderID: 1, ProductID: 1},
 			setupMocks: func() {
+				s.expectOrderStatus(1, valueobject.OPEN)
 				s.mockGateway.EXPECT().
 					FindByID(s.ctx, uint64(1), uint64(1)).
 					Return(&entity.OrderProduct{OrderID: 1, ProductID: 1}, nil)
@@ -362,6 +447,7 @@ func (s *OrderProductUsecaseSuiteTest) TestOrderProductUseCase_Delete() {
 			name:  "should return not found error when orderProduct doesn't exist",
 			input: dto.DeleteOrderProductInput{OrderID: 1, ProductID: 1},
 			setupMocks: func() {
+				s.expectOrderStatus(1, valueobject.OPEN)
 				s.mockGateway.EXPECT().
 					FindByID(s.ctx, uint64(1), uint64(1)).
 					Return(nil, nil)
@@ -375,6 +461,7 @@ func (s *OrderProductUsecaseSuiteTest) TestOrderProductUseCase_Delete() {
 			name:  "should return error when gateway fails on find",
 			input: dto.DeleteOrderProductInput{OrderID: 1, ProductID: 1},
 			setupMocks: func() {
+				s.expectOrderStatus(1, valueobject.OPEN)
 				s.mockGateway.EXPECT().
 					FindByID(s.ctx, uint64(1), uint64(1)).
 					Return(nil, assert.AnError)
@@ -388,6 +475,7 @@ func (s *OrderProductUsecaseSuiteTest) TestOrderProductUseCase_Delete() {
 			name:  "should return error when gateway fails on delete",
 			input: dto.DeleteOrderProductInput{OrderID: 1, ProductID: 1},
 			setupMocks: func() {
+				s.expectOrderStatus(1, valueobject.OPEN)
 				s.mockGateway.EXPECT().
 					FindByID(s.ctx, uint64(1), uint64(1)).
 					Return(&entity.OrderProduct{}, nil)
@@ -401,6 +489,18 @@ func (s *OrderProductUsecaseSuiteTest) TestOrderProductUseCase_Delete() {
 				assert.Nil(t, orderProduct)
 			},
 		},
+		{
+			name:  "should not remove products from an order that is not open",
+			input: dto.DeleteOrderProductInput{OrderID: 1, ProductID: 1},
+			setupMocks: func() {
+				s.expectOrderStatus(1, valueobject.PENDING)
+			},
+			checkResult: func(t *testing.T, orderProduct *entity.OrderProduct, err error) {
+				assert.Nil(t, orderProduct)
+				assert.IsType(t, &domain.InvalidInputError{}, err)
+				assert.EqualError(t, err, domain.ErrOrderIsNotOpen)
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -416,3 +516,177 @@ func (s *OrderProductUsecaseSuiteTest) TestOrderProductUseCase_Delete() {
 		})
 	}
 }
+
+func (s *OrderProductUsecaseSuiteTest) TestOrderProductUseCase_Reprice() {
+	openOrder := func() *entity.Order {
+		return &entity.Order{
+			ID:     1,
+			Status: valueobject.OPEN,
+			OrderProducts: []entity.OrderProduct{
+				{
+					OrderID:     1,
+					ProductID:   1,
+					Quantity:    2,
+					UnitPrice:   valueobject.NewMoney(1000),
+					ProductName: "Burger",
+					CategoryID:  1,
+					Product:     entity.Product{ID: 1, Name: "Cheeseburger", Price: valueobject.NewMoney(1250), CategoryID: 1},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name        string
+		input       dto.RepriceOrderProductsInput
+		setupMocks  func()
+		checkResult func(*testing.T, *entity.Order, error)
+	}{
+		{
+			name:  "should reprice the products of an open order",
+			input: dto.RepriceOrderProductsInput{OrderID: 1},
+			setupMocks: func() {
+				s.mockOrderUseCase.EXPECT().
+					Get(s.ctx, dto.GetOrderInput{ID: 1, ForUpdate: true}).
+					Return(openOrder(), nil)
+				s.mockGateway.EXPECT().
+					Update(s.ctx, gomock.Any()).
+					Return(nil)
+			},
+			checkResult: func(t *testing.T, order *entity.Order, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, valueobject.NewMoney(1250), order.OrderProducts[0].UnitPrice)
+				assert.Equal(t, "Cheeseburger", order.OrderProducts[0].ProductName)
+				assert.Equal(t, uint64(1), order.OrderProducts[0].Product.ID)
+				assert.Equal(t, valueobject.NewMoney(2500), order.TotalAmount())
+			},
+		},
+		{
+			name:  "should not reprice an order that is not open",
+			input: dto.RepriceOrderProductsInput{OrderID: 1},
+			setupMocks: func() {
+				order := openOrder()
+				order.Status = valueobject.PENDING
+				s.mockOrderUseCase.EXPECT().
+					Get(s.ctx, dto.GetOrderInput{ID: 1, ForUpdate: true}).
+					Return(order, nil)
+			},
+			checkResult: func(t *testing.T, order *entity.Order, err error) {
+				assert.Nil(t, order)
+				assert.IsType(t, &domain.InvalidInputError{}, err)
+				assert.EqualError(t, err, domain.ErrOrderIsNotOpen)
+			},
+		},
+		{
+			name:  "should return not found error when order doesn't exist",
+			input: dto.RepriceOrderProductsInput{OrderID: 1},
+			setupMocks: func() {
+				s.mockOrderUseCase.EXPECT().
+					Get(s.ctx, dto.GetOrderInput{ID: 1, ForUpdate: true}).
+					Return(nil, domain.NewNotFoundError(domain.ErrNotFound))
+			},
+			checkResult: func(t *testing.T, order *entity.Order, err error) {
+				assert.Nil(t, order)
+				assert.IsType(t, &domain.NotFoundError{}, err)
+			},
+		},
+		{
+			name:  "should return error when gateway fails",
+			input: dto.RepriceOrderProductsInput{OrderID: 1},
+			setupMocks: func() {
+				s.mockOrderUseCase.EXPECT().
+					Get(s.ctx, dto.GetOrderInput{ID: 1, ForUpdate: true}).
+					Return(openOrder(), nil)
+				s.mockGateway.EXPECT().
+					Update(s.ctx, gomock.Any()).
+					Return(assert.AnError)
+			},
+			checkResult: func(t *testing.T, order *entity.Order, err error) {
+				assert.Nil(t, order)
+				assert.IsType(t, &domain.InternalError{}, err)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.T().Run(tt.name, func(t *testing.T) {
+			// Arrange
+			tt.setupMocks()
+
+			// Act
+			order, err := s.useCase.Reprice(s.ctx, tt.input)
+
+			// Assert
+			tt.checkResult(t, order, err)
+		})
+	}
+}
+
+func (s *OrderProductUsecaseSuiteTest) TestOrderProductUseCase_CustomerOwnership() {
+	customerCtx := entity.ContextWithPrincipal(s.ctx, &entity.Principal{SubjectType: valueobject.CUSTOMER, ID: 1})
+
+	tests := []struct {
+		name        string
+		run         func() error
+		setupMocks  func()
+		checkResult func(*testing.T, error)
+	}{
+		{
+			name: "should refuse listing without an order filter",
+			run: func() error {
+				_, _, err := s.useCase.List(customerCtx, dto.ListOrderProductsInput{Page: 1, Limit: 10})
+				return err
+			},
+			setupMocks: func() {},
+			checkResult: func(t *testing.T, err error) {
+				assert.IsType(t, &domain.ForbiddenError{}, err)
+			},
+		},
+		{
+			name: "should list products of own order",
+			run: func() error {
+				_, _, err := s.useCase.List(customerCtx, dto.ListOrderProductsInput{OrderID: 1, Page: 1, Limit: 10})
+				return err
+			},
+			setupMocks: func() {
+				s.mockOrderUseCase.EXPECT().
+					Get(customerCtx, dto.GetOrderInput{ID: 1}).
+					Return(&entity.Order{ID: 1, CustomerID: util.Ptr(uint64(1))}, nil)
+				s.mockGateway.EXPECT().
+					FindAll(customerCtx, uint64(1), uint64(0), 1, 10).
+					Return(s.mockOrderProducts, int64(2), nil)
+			},
+			checkResult: func(t *testing.T, err error) {
+				assert.NoError(t, err)
+			},
+		},
+		{
+			name: "should refuse adding products to an order of another customer",
+			run: func() error {
+				_, err := s.useCase.Create(customerCtx, dto.CreateOrderProductInput{OrderID: 2, ProductID: 1, Quantity: 1})
+				return err
+			},
+			setupMocks: func() {
+				s.mockOrderUseCase.EXPECT().
+					Get(customerCtx, dto.GetOrderInput{ID: 2, ForUpdate: true}).
+					Return(nil, domain.NewForbiddenError(domain.ErrPermissionDenied))
+			},
+			checkResult: func(t *testing.T, err error) {
+				assert.IsType(t, &domain.ForbiddenError{}, err)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.T().Run(tt.name, func(t *testing.T) {
+			// Arrange
+			tt.setupMocks()
+
+			// Act
+			err := tt.run()
+
+			// Assert
+			tt.checkResult(t, err)
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/dto"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/port"
+)
+
+// orderStatusReplayPageSize is the number of missed events read at a time, a client far behind reads several pages
+const orderStatusReplayPageSize = 500
+
+type orderStatusStreamUseCase struct {
+	historyGateway port.OrderHistoryGateway
+	eventBus       port.OrderEventBus
+}
+
+// NewOrderStatusStreamUseCase creates a new OrderStatusStreamUseCase
+func NewOrderStatusStreamUseCase(historyGateway port.OrderHistoryGateway, eventBus port.OrderEventBus) port.OrderStatusStreamUseCase {
+	return &orderStatusStreamUseCase{historyGateway, eventBus}
+}
+
+// Subscribe returns the status changes of the orders since the cursor, then the live ones, until ctx is done
+func (uc *orderStatusStreamUseCase) Subscribe(ctx context.Context, i dto.SubscribeOrderStatusInput) (<-chan *entity.OrderStatusEvent, error) {
+	if principal, ok := entity.PrincipalFromContext(ctx); ok && principal.IsGuest() {
+		return nil, domain.NewForbiddenError(domain.ErrPermissionDenied)
+	}
+
+	// customers only follow their own orders
+	if customerID, ok := customerFromContext(ctx); ok {
+		if i.CustomerID != 0 && i.CustomerID != customerID {
+			return nil, domain.NewForbiddenError(domain.ErrPermissionDenied)
+		}
+		i.CustomerID = customerID
+	}
+
+	filter := entity.OrderStatusEventFilter{Status: i.Status, CustomerID: i.CustomerID}
+
+	// Subscribe before reading the cursor, so no event is lost in between
+	live, unsubscribe := uc.eventBus.Subscribe()
+
+	// Without a cursor only the changes committed from now on are sent
+	cursor := i.AfterID
+	if cursor == 0 {
+		lastID, err := uc.historyGateway.FindLastID(ctx)
+		if err != nil {
+			unsubscribe()
+			return nil, domain.NewInternalError(err)
+		}
+		cursor = lastID
+	}
+
+	// The first page is read before streaming, so a failure is reported to the client
+	missed, err := uc.historyGateway.FindAfter(ctx, cursor, i.Status, i.CustomerID, orderStatusReplayPageSize)
+	if err != nil {
+		unsubscribe()
+		return nil, domain.NewInternalError(err)
+	}
+
+	events := make(chan *entity.OrderStatusEvent)
+
+	go func() {
+		defer close(events)
+		defer unsubscribe()
+
+		// sendAfterCursor sends the histories after the cursor, page by page. The histories are only read once every
+		// one with a lower ID is committed, so the ones read are never followed by one with a lower ID.
+		sendAfterCursor := func(histories []*entity.OrderHistory) bool {
+			for {
+				for _, history := range histories {
+					select {
+					case events <- entity.NewOrderStatusEvent(history, history.Order.CustomerID):
+						cursor = history.ID
+					case <-ctx.Done():
+						return false
+					}
+				}
+
+				if len(histories) < orderStatusReplayPageSize {
+					return true
+				}
+
+				var err error
+				histories, err = uc.historyGateway.FindAfter(ctx, cursor, i.Status, i.CustomerID, orderStatusReplayPageSize)
+				// the client reconnects from the last event sent
+				if err != nil {
+					return false
+				}
+			}
+		}
+
+		if !sendAfterCursor(missed) {
+			return
+		}
+
+		// A live event only tells there are new histories, they are read from the cursor, so an event published
+		// out of order or while the missed ones were read is neither lost nor sent twice
+		for {
+			select {
+			case event, ok := <-live:
+				if !ok {
+					return
+				}
+				if event.ID <= cursor || !filter.Matches(event) {
+					continue
+				}
+
+				histories, err := uc.historyGateway.FindAfter(ctx, cursor, i.Status, i.CustomerID, orderStatusReplayPageSize)
+				if err != nil || !sendAfterCursor(histories) {
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return events, nil
+}
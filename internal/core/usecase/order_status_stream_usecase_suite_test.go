@@ -0,0 +1,45 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/port"
+	mockport "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/port/mocks"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/usecase"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type OrderStatusStreamUsecaseSuiteTest struct {
+	suite.Suite
+	mockHistoryGateway *mockport.MockOrderHistoryGateway
+	mockEventBus       *mockport.MockOrderEventBus
+	live               chan *entity.OrderStatusEvent // events published on the mock bus
+	unsubscribed       bool
+	useCase            port.OrderStatusStreamUseCase
+	ctx                context.Context
+}
+
+func (s *OrderStatusStreamUsecaseSuiteTest) SetupTest() {
+	ctrl := gomock.NewController(s.T())
+	defer ctrl.Finish()
+	s.mockHistoryGateway = mockport.NewMockOrderHistoryGateway(ctrl)
+	s.mockEventBus = mockport.NewMockOrderEventBus(ctrl)
+	s.useCase = usecase.NewOrderStatusStreamUseCase(s.mockHistoryGateway, s.mockEventBus)
+	s.ctx = context.Background()
+}
+
+// expectSubscribe makes the mock bus return a new live channel
+func (s *OrderStatusStreamUsecaseSuiteTest) expectSubscribe() {
+	s.live = make(chan *entity.OrderStatusEvent, 10)
+	s.unsubscribed = false
+	s.mockEventBus.EXPECT().
+		Subscribe().
+		Return((<-chan *entity.OrderStatusEvent)(s.live), func() { s.unsubscribed = true })
+}
+
+func TestOrderStatusStreamUsecaseSuiteTest(t *testing.T) {
+	suite.Run(t, new(OrderStatusStreamUsecaseSuiteTest))
+}
@@ -0,0 +1,244 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
+	valueobject "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/value_object"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/dto"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/util"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func (s *OrderStatusStreamUsecaseSuiteTest) TestOrderStatusStreamUseCase_Subscribe() {
+	customerCtx := entity.ContextWithPrincipal(s.ctx, &entity.Principal{SubjectType: valueobject.CUSTOMER, ID: 1})
+	guestCtx := entity.ContextWithPrincipal(s.ctx, &entity.Principal{SubjectType: valueobject.GUEST})
+
+	history := func(id uint64, status valueobject.OrderStatus, customerID *uint64) *entity.OrderHistory {
+		return &entity.OrderHistory{ID: id, OrderID: 1, Status: status, Order: entity.Order{CustomerID: customerID}}
+	}
+
+	// a full page of missed histories, after the ID 10
+	fullPage := make([]*entity.OrderHistory, 0, 500)
+	for id := uint64(11); id <= 510; id++ {
+		fullPage = append(fullPage, history(id, valueobject.RECEIVED, nil))
+	}
+
+	tests := []struct {
+		name        string
+		ctx         context.Context
+		input       dto.SubscribeOrderStatusInput
+		setupMocks  func()
+		publish     []*entity.OrderStatusEvent
+		checkResult func(*testing.T, []*entity.OrderStatusEvent, error)
+	}{
+		{
+			name:  "should send the missed events, then the live ones once",
+			ctx:   s.ctx,
+			input: dto.SubscribeOrderStatusInput{AfterID: 10},
+			setupMocks: func() {
+				s.expectSubscribe()
+				gomock.InOrder(
+					s.mockHistoryGateway.EXPECT().
+						FindAfter(gomock.Any(), uint64(10), nil, uint64(0), gomock.Any()).
+						Return([]*entity.OrderHistory{history(11, valueobject.RECEIVED, util.Ptr(uint64(1)))}, nil),
+					s.mockHistoryGateway.EXPECT().
+						FindAfter(gomock.Any(), uint64(11), nil, uint64(0), gomock.Any()).
+						Return([]*entity.OrderHistory{history(12, valueobject.PREPARING, util.Ptr(uint64(1)))}, nil),
+				)
+			},
+			publish: []*entity.OrderStatusEvent{
+				{ID: 11, OrderID: 1, Status: valueobject.RECEIVED}, // published while the missed ones were read
+				{ID: 12, OrderID: 1, Status: valueobject.PREPARING},
+			},
+			checkResult: func(t *testing.T, events []*entity.OrderStatusEvent, err error) {
+				assert.NoError(t, err)
+				assert.Len(t, events, 2)
+				assert.Equal(t, uint64(11), events[0].ID)
+				assert.Equal(t, uint64(1), *events[0].CustomerID)
+				assert.Equal(t, uint64(12), events[1].ID)
+			},
+		},
+		{
+			name:  "should send the events published out of order in the order they were committed",
+			ctx:   s.ctx,
+			input: dto.SubscribeOrderStatusInput{AfterID: 10},
+			setupMocks: func() {
+				s.expectSubscribe()
+				gomock.InOrder(
+					s.mockHistoryGateway.EXPECT().
+						FindAfter(gomock.Any(), uint64(10), nil, uint64(0), gomock.Any()).
+						Return(nil, nil),
+					s.mockHistoryGateway.EXPECT().
+						FindAfter(gomock.Any(), uint64(10), nil, uint64(0), gomock.Any()).
+						Return([]*entity.OrderHistory{
+							history(11, valueobject.RECEIVED, nil),
+							history(12, valueobject.PREPARING, nil),
+						}, nil),
+				)
+			},
+			publish: []*entity.OrderStatusEvent{
+				{ID: 12, OrderID: 1, Status: valueobject.PREPARING},
+				{ID: 11, OrderID: 1, Status: valueobject.RECEIVED},
+			},
+			checkResult: func(t *testing.T, events []*entity.OrderStatusEvent, err error) {
+				assert.NoError(t, err)
+				assert.Len(t, events, 2)
+				assert.Equal(t, uint64(11), events[0].ID)
+				assert.Equal(t, uint64(12), events[1].ID)
+			},
+		},
+		{
+			name:  "should send every missed event, a page at a time",
+			ctx:   s.ctx,
+			input: dto.SubscribeOrderStatusInput{AfterID: 10},
+			setupMocks: func() {
+				s.expectSubscribe()
+				gomock.InOrder(
+					s.mockHistoryGateway.EXPECT().
+						FindAfter(gomock.Any(), uint64(10), nil, uint64(0), 500).
+						Return(fullPage, nil),
+					s.mockHistoryGateway.EXPECT().
+						FindAfter(gomock.Any(), uint64(510), nil, uint64(0), 500).
+						Return([]*entity.OrderHistory{history(511, valueobject.PREPARING, nil)}, nil),
+				)
+			},
+			checkResult: func(t *testing.T, events []*entity.OrderStatusEvent, err error) {
+				assert.NoError(t, err)
+				assert.Len(t, events, 501)
+				assert.Equal(t, uint64(511), events[500].ID)
+			},
+		},
+		{
+			name:  "should only send the events of the filtered status",
+			ctx:   s.ctx,
+			input: dto.SubscribeOrderStatusInput{Status: []valueobject.OrderStatus{valueobject.READY}},
+			setupMocks: func() {
+				s.expectSubscribe()
+				s.mockHistoryGateway.EXPECT().FindLastID(gomock.Any()).Return(uint64(5), nil)
+				gomock.InOrder(
+					s.mockHistoryGateway.EXPECT().
+						FindAfter(gomock.Any(), uint64(5), []valueobject.OrderStatus{valueobject.READY}, uint64(0), gomock.Any()).
+						Return(nil, nil),
+					s.mockHistoryGateway.EXPECT().
+						FindAfter(gomock.Any(), uint64(5), []valueobject.OrderStatus{valueobject.READY}, uint64(0), gomock.Any()).
+						Return([]*entity.OrderHistory{history(7, valueobject.READY, nil)}, nil),
+				)
+			},
+			publish: []*entity.OrderStatusEvent{
+				{ID: 6, OrderID: 1, Status: valueobject.PREPARING},
+				{ID: 7, OrderID: 1, Status: valueobject.READY},
+			},
+			checkResult: func(t *testing.T, events []*entity.OrderStatusEvent, err error) {
+				assert.NoError(t, err)
+				assert.Len(t, events, 1)
+				assert.Equal(t, valueobject.READY, events[0].Status)
+			},
+		},
+		{
+			name:  "should only send the orders of the authenticated customer",
+			ctx:   customerCtx,
+			input: dto.SubscribeOrderStatusInput{},
+			setupMocks: func() {
+				s.expectSubscribe()
+				s.mockHistoryGateway.EXPECT().FindLastID(gomock.Any()).Return(uint64(0), nil)
+				gomock.InOrder(
+					s.mockHistoryGateway.EXPECT().
+						FindAfter(gomock.Any(), uint64(0), nil, uint64(1), gomock.Any()).
+						Return(nil, nil),
+					s.mockHistoryGateway.EXPECT().
+						FindAfter(gomock.Any(), uint64(0), nil, uint64(1), gomock.Any()).
+						Return([]*entity.OrderHistory{{ID: 3, OrderID: 3, Status: valueobject.READY, Order: entity.Order{CustomerID: util.Ptr(uint64(1))}}}, nil),
+				)
+			},
+			publish: []*entity.OrderStatusEvent{
+				{ID: 1, OrderID: 1, CustomerID: util.Ptr(uint64(2)), Status: valueobject.READY},
+				{ID: 2, OrderID: 2, Status: valueobject.READY},
+				{ID: 3, OrderID: 3, CustomerID: util.Ptr(uint64(1)), Status: valueobject.READY},
+			},
+			checkResult: func(t *testing.T, events []*entity.OrderStatusEvent, err error) {
+				assert.NoError(t, err)
+				assert.Len(t, events, 1)
+				assert.Equal(t, uint64(3), events[0].OrderID)
+			},
+		},
+		{
+			name:       "should refuse the orders of another customer",
+			ctx:        customerCtx,
+			input:      dto.SubscribeOrderStatusInput{CustomerID: 2},
+			setupMocks: func() {},
+			checkResult: func(t *testing.T, events []*entity.OrderStatusEvent, err error) {
+				assert.IsType(t, &domain.ForbiddenError{}, err)
+			},
+		},
+		{
+			name:       "should refuse guests",
+			ctx:        guestCtx,
+			input:      dto.SubscribeOrderStatusInput{},
+			setupMocks: func() {},
+			checkResult: func(t *testing.T, events []*entity.OrderStatusEvent, err error) {
+				assert.IsType(t, &domain.ForbiddenError{}, err)
+			},
+		},
+		{
+			name:  "should unsubscribe when the last event cannot be read",
+			ctx:   s.ctx,
+			input: dto.SubscribeOrderStatusInput{},
+			setupMocks: func() {
+				s.expectSubscribe()
+				s.mockHistoryGateway.EXPECT().FindLastID(gomock.Any()).Return(uint64(0), assert.AnError)
+			},
+			checkResult: func(t *testing.T, events []*entity.OrderStatusEvent, err error) {
+				assert.IsType(t, &domain.InternalError{}, err)
+				assert.True(t, s.unsubscribed)
+			},
+		},
+		{
+			name:  "should unsubscribe when the missed events cannot be read",
+			ctx:   s.ctx,
+			input: dto.SubscribeOrderStatusInput{AfterID: 10},
+			setupMocks: func() {
+				s.expectSubscribe()
+				s.mockHistoryGateway.EXPECT().
+					FindAfter(gomock.Any(), uint64(10), nil, uint64(0), gomock.Any()).
+					Return(nil, assert.AnError)
+			},
+			checkResult: func(t *testing.T, events []*entity.OrderStatusEvent, err error) {
+				assert.IsType(t, &domain.InternalError{}, err)
+				assert.True(t, s.unsubscribed)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.T().Run(tt.name, func(t *testing.T) {
+			// Arrange
+			tt.setupMocks()
+			for _, event := range tt.publish {
+				s.live <- event
+			}
+			if s.live != nil {
+				// the subscriber is dropped once every event was delivered
+				close(s.live)
+			}
+			ctx, cancel := context.WithCancel(tt.ctx)
+			defer cancel()
+
+			// Act
+			stream, err := s.useCase.Subscribe(ctx, tt.input)
+
+			// Assert
+			var events []*entity.OrderStatusEvent
+			if err == nil {
+				for event := range stream {
+					events = append(events, event)
+				}
+			}
+			tt.checkResult(t, events, err)
+			s.live = nil
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/dto"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/port"
+)
+
+type orderTimingUseCase struct {
+	orderUseCase    port.OrderUseCase
+	historyGateway  port.OrderHistoryGateway
+	categoryUseCase port.CategoryUseCase
+	defaultTarget   time.Duration
+}
+
+// NewOrderTimingUseCase creates a new OrderTimingUseCase, defaultTarget is the preparation target of the categories
+// without one
+func NewOrderTimingUseCase(
+	orderUseCase port.OrderUseCase,
+	historyGateway port.OrderHistoryGateway,
+	categoryUseCase port.CategoryUseCase,
+	defaultTarget time.Duration,
+) port.OrderTimingUseCase {
+	return &orderTimingUseCase{orderUseCase, historyGateway, categoryUseCase, defaultTarget}
+}
+
+// Track computes the time each order spent in each status from its histories, setting its Timing
+func (uc *orderTimingUseCase) Track(ctx context.Context, orders []*entity.Order) error {
+	if len(orders) == 0 {
+		return nil
+	}
+
+	orderIDs := make([]uint64, 0, len(orders))
+	for _, order := range orders {
+		orderIDs = append(orderIDs, order.ID)
+	}
+
+	histories, err := uc.historyGateway.FindByOrderIDs(ctx, orderIDs)
+	if err != nil {
+		return domain.NewInternalError(err)
+	}
+
+	historiesByOrder := make(map[uint64][]*entity.OrderHistory, len(orders))
+	for _, history := range histories {
+		historiesByOrder[history.OrderID] = append(historiesByOrder[history.OrderID], history)
+	}
+
+	targets, err := uc.categoryPrepTargets(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, order := range orders {
+		order.Timing = entity.NewOrderTiming(order, historiesByOrder[order.ID], uc.orderPrepTarget(order, targets), now)
+	}
+
+	return nil
+}
+
+// ListLate returns the orders in the kitchen past their preparation target, oldest first
+func (uc *orderTimingUseCase) ListLate(ctx context.Context) ([]*entity.Order, error) {
+	orders, _, err := uc.orderUseCase.List(ctx, dto.ListOrdersInput{
+		Status: kitchenOrderStatuses,
+		Page:   1,
+		Limit:  kitchenQueueLimit,
+		Sort:   "created_at",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.Track(ctx, orders); err != nil {
+		return nil, err
+	}
+
+	late := make([]*entity.Order, 0)
+	for _, order := range orders {
+		if order.Timing.Late {
+			late = append(late, order)
+		}
+	}
+
+	return late, nil
+}
+
+// categoryPrepTargets returns the preparation target of each category that has one
+func (uc *orderTimingUseCase) categoryPrepTargets(ctx context.Context) (map[uint64]time.Duration, error) {
+	categories, _, err := uc.categoryUseCase.List(ctx, dto.ListCategoriesInput{Page: 1, Limit: kitchenCategoryLimit})
+	if err != nil {
+		return nil, err
+	}
+
+	targets := make(map[uint64]time.Duration, len(categories))
+	for _, category := range categories {
+		if category.PrepTargetMinutes > 0 {
+			targets[category.ID] = time.Duration(category.PrepTargetMinutes) * time.Minute
+		}
+	}
+	return targets, nil
+}
+
+// orderPrepTarget returns the target of the slowest category of the order, as its products are ready together
+func (uc *orderTimingUseCase) orderPrepTarget(order *entity.Order, targets map[uint64]time.Duration) time.Duration {
+	if len(order.OrderProducts) == 0 {
+		return uc.defaultTarget
+	}
+
+	var target time.Duration
+	for _, item := range order.OrderProducts {
+		categoryTarget, ok := targets[item.CategoryID]
+		if !ok {
+			categoryTarget = uc.defaultTarget
+		}
+		target = max(target, categoryTarget)
+	}
+	return target
+}
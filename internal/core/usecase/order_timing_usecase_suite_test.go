@@ -0,0 +1,46 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/port"
+	mockport "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/port/mocks"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/usecase"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+// orderTimingDefaultTarget is the preparation target of the categories without one
+const orderTimingDefaultTarget = 15 * time.Minute
+
+type OrderTimingUsecaseSuiteTest struct {
+	suite.Suite
+	mockCategories      []*entity.Category
+	mockOrderUseCase    *mockport.MockOrderUseCase
+	mockHistoryGateway  *mockport.MockOrderHistoryGateway
+	mockCategoryUseCase *mockport.MockCategoryUseCase
+	useCase             port.OrderTimingUseCase
+	ctx                 context.Context
+}
+
+func (s *OrderTimingUsecaseSuiteTest) SetupTest() {
+	ctrl := gomock.NewController(s.T())
+	defer ctrl.Finish()
+	s.mockOrderUseCase = mockport.NewMockOrderUseCase(ctrl)
+	s.mockHistoryGateway = mockport.NewMockOrderHistoryGateway(ctrl)
+	s.mockCategoryUseCase = mockport.NewMockCategoryUseCase(ctrl)
+	s.useCase = usecase.NewOrderTimingUseCase(s.mockOrderUseCase, s.mockHistoryGateway, s.mockCategoryUseCase, orderTimingDefaultTarget)
+	s.ctx = context.Background()
+	s.mockCategories = []*entity.Category{
+		{ID: 1, Name: "Foods", PrepTargetMinutes: 20},
+		{ID: 2, Name: "Beverages", PrepTargetMinutes: 5},
+		{ID: 3, Name: "Desserts"}, // default target
+	}
+}
+
+func TestOrderTimingUsecaseSuiteTest(t *testing.T) {
+	suite.Run(t, new(OrderTimingUsecaseSuiteTest))
+}
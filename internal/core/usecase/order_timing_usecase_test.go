@@ -0,0 +1,187 @@
+package usecase_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
+	valueobject "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/value_object"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+// newOrderHistories returns the histories of the order, each status reached the given time ago
+func newOrderHistories(orderID uint64, reached map[valueobject.OrderStatus]time.Duration) []*entity.OrderHistory {
+	histories := make([]*entity.OrderHistory, 0, len(reached))
+	for status, ago := range reached {
+		histories = append(histories, &entity.OrderHistory{OrderID: orderID, Status: status, CreatedAt: time.Now().Add(-ago)})
+	}
+	return histories
+}
+
+func (s *OrderTimingUsecaseSuiteTest) TestOrderTimingUseCase_Track() {
+	tests := []struct {
+		name        string
+		orders      []*entity.Order
+		setupMocks  func()
+		checkResult func(*testing.T, []*entity.Order, error)
+	}{
+		{
+			name:   "should compute the time in each status of a completed order",
+			orders: []*entity.Order{newKitchenOrder(1, valueobject.COMPLETED, 1)},
+			setupMocks: func() {
+				s.mockHistoryGateway.EXPECT().
+					FindByOrderIDs(s.ctx, []uint64{1}).
+					Return(newOrderHistories(1, map[valueobject.OrderStatus]time.Duration{
+						valueobject.RECEIVED:  30 * time.Minute,
+						valueobject.PREPARING: 28 * time.Minute,
+						valueobject.READY:     18 * time.Minute,
+						valueobject.COMPLETED: 15 * time.Minute,
+					}), nil)
+				s.mockCategoryUseCase.EXPECT().
+					List(s.ctx, gomock.Any()).
+					Return(s.mockCategories, int64(3), nil)
+			},
+			checkResult: func(t *testing.T, orders []*entity.Order, err error) {
+				assert.NoError(t, err)
+				timing := orders[0].Timing
+				assert.Equal(t, 2*time.Minute, timing.QueueWait.Round(time.Second))
+				assert.Equal(t, 10*time.Minute, timing.PrepTime.Round(time.Second))
+				assert.Equal(t, 3*time.Minute, timing.TimeToPickup.Round(time.Second))
+				assert.Equal(t, 20*time.Minute, timing.Target)
+				assert.False(t, timing.Late)
+			},
+		},
+		{
+			name: "should flag the orders in the kitchen past the target of their slowest category",
+			orders: []*entity.Order{
+				newKitchenOrder(1, valueobject.PREPARING, 1, 2),
+				newKitchenOrder(2, valueobject.RECEIVED, 2),
+				newKitchenOrder(3, valueobject.RECEIVED, 3),
+			},
+			setupMocks: func() {
+				histories := newOrderHistories(1, map[valueobject.OrderStatus]time.Duration{
+					valueobject.RECEIVED:  12 * time.Minute,
+					valueobject.PREPARING: 10 * time.Minute,
+				})
+				histories = append(histories, newOrderHistories(2, map[valueobject.OrderStatus]time.Duration{
+					valueobject.RECEIVED: 6 * time.Minute,
+				})...)
+				histories = append(histories, newOrderHistories(3, map[valueobject.OrderStatus]time.Duration{
+					valueobject.RECEIVED: 6 * time.Minute,
+				})...)
+				s.mockHistoryGateway.EXPECT().
+					FindByOrderIDs(s.ctx, []uint64{1, 2, 3}).
+					Return(histories, nil)
+				s.mockCategoryUseCase.EXPECT().
+					List(s.ctx, gomock.Any()).
+					Return(s.mockCategories, int64(3), nil)
+			},
+			checkResult: func(t *testing.T, orders []*entity.Order, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, 20*time.Minute, orders[0].Timing.Target)
+				assert.False(t, orders[0].Timing.Late)
+				assert.Equal(t, 5*time.Minute, orders[1].Timing.Target)
+				assert.True(t, orders[1].Timing.Late)
+				assert.Equal(t, orderTimingDefaultTarget, orders[2].Timing.Target)
+				assert.False(t, orders[2].Timing.Late)
+			},
+		},
+		{
+			name:   "should not flag a ready order",
+			orders: []*entity.Order{newKitchenOrder(1, valueobject.READY, 2)},
+			setupMocks: func() {
+				s.mockHistoryGateway.EXPECT().
+					FindByOrderIDs(s.ctx, []uint64{1}).
+					Return(newOrderHistories(1, map[valueobject.OrderStatus]time.Duration{
+						valueobject.RECEIVED:  30 * time.Minute,
+						valueobject.PREPARING: 25 * time.Minute,
+						valueobject.READY:     10 * time.Minute,
+					}), nil)
+				s.mockCategoryUseCase.EXPECT().
+					List(s.ctx, gomock.Any()).
+					Return(s.mockCategories, int64(3), nil)
+			},
+			checkResult: func(t *testing.T, orders []*entity.Order, err error) {
+				assert.NoError(t, err)
+				assert.False(t, orders[0].Timing.Late)
+				assert.Equal(t, 10*time.Minute, orders[0].Timing.TimeToPickup.Round(time.Minute))
+			},
+		},
+		{
+			name:   "should return internal error when the history gateway fails",
+			orders: []*entity.Order{newKitchenOrder(1, valueobject.RECEIVED, 1)},
+			setupMocks: func() {
+				s.mockHistoryGateway.EXPECT().
+					FindByOrderIDs(s.ctx, []uint64{1}).
+					Return(nil, assert.AnError)
+			},
+			checkResult: func(t *testing.T, orders []*entity.Order, err error) {
+				assert.IsType(t, &domain.InternalError{}, err)
+				assert.Nil(t, orders[0].Timing)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.T().Run(tt.name, func(t *testing.T) {
+			tt.setupMocks()
+			err := s.useCase.Track(s.ctx, tt.orders)
+			tt.checkResult(t, tt.orders, err)
+		})
+	}
+}
+
+func (s *OrderTimingUsecaseSuiteTest) TestOrderTimingUseCase_ListLate() {
+	tests := []struct {
+		name        string
+		setupMocks  func()
+		checkResult func(*testing.T, []*entity.Order, error)
+	}{
+		{
+			name: "should return only the late orders",
+			setupMocks: func() {
+				s.mockOrderUseCase.EXPECT().
+					List(s.ctx, gomock.Any()).
+					Return([]*entity.Order{
+						newKitchenOrder(1, valueobject.RECEIVED, 2),
+						newKitchenOrder(2, valueobject.RECEIVED, 2),
+					}, int64(2), nil)
+				histories := newOrderHistories(1, map[valueobject.OrderStatus]time.Duration{valueobject.RECEIVED: 8 * time.Minute})
+				histories = append(histories, newOrderHistories(2, map[valueobject.OrderStatus]time.Duration{valueobject.RECEIVED: time.Minute})...)
+				s.mockHistoryGateway.EXPECT().
+					FindByOrderIDs(s.ctx, []uint64{1, 2}).
+					Return(histories, nil)
+				s.mockCategoryUseCase.EXPECT().
+					List(s.ctx, gomock.Any()).
+					Return(s.mockCategories, int64(3), nil)
+			},
+			checkResult: func(t *testing.T, orders []*entity.Order, err error) {
+				assert.NoError(t, err)
+				assert.Len(t, orders, 1)
+				assert.Equal(t, uint64(1), orders[0].ID)
+			},
+		},
+		{
+			name: "should return error when the order use case fails",
+			setupMocks: func() {
+				s.mockOrderUseCase.EXPECT().
+					List(s.ctx, gomock.Any()).
+					Return(nil, int64(0), domain.NewInternalError(assert.AnError))
+			},
+			checkResult: func(t *testing.T, orders []*entity.Order, err error) {
+				assert.Error(t, err)
+				assert.Nil(t, orders)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.T().Run(tt.name, func(t *testing.T) {
+			tt.setupMocks()
+			orders, err := s.useCase.ListLate(s.ctx)
+			tt.checkResult(t, orders, err)
+		})
+	}
+}
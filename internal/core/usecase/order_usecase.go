@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"slices"
 
 	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain"
 	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
@@ -13,15 +14,62 @@ import (
 type orderUseCase struct {
 	gateway             port.OrderGateway
 	orderHistoryUseCase port.OrderHistoryUseCase
+	customerUseCase     port.CustomerUseCase
+	refundUseCase       port.PaymentRefundUseCase
+	paymentGateway      port.PaymentGateway
+	unitOfWork          port.UnitOfWork
+	eventBus            port.OrderEventBus
+	estimateUseCase     port.OrderEstimateUseCase
+	categoryUseCase     port.CategoryUseCase
+	guestTokenService   port.GuestTokenService
+	stateMachine        *entity.OrderStateMachine
 }
 
-// NewOrderUseCase creates a new OrdersUseCase
-func NewOrderUseCase(gateway port.OrderGateway, orderHistoryUseCase port.OrderHistoryUseCase) port.OrderUseCase {
-	return &orderUseCase{gateway, orderHistoryUseCase}
+// orderCancelledRefundReason is the reason of the refunds started by the cancellation of a paid order
+const orderCancelledRefundReason = "order cancelled"
+
+// NewOrderUseCase creates a new OrdersUseCase, the changes of status are checked against the stateMachine
+func NewOrderUseCase(
+	gateway port.OrderGateway,
+	orderHistoryUseCase port.OrderHistoryUseCase,
+	customerUseCase port.CustomerUseCase,
+	refundUseCase port.PaymentRefundUseCase,
+	paymentGateway port.PaymentGateway,
+	unitOfWork port.UnitOfWork,
+	eventBus port.OrderEventBus,
+	estimateUseCase port.OrderEstimateUseCase,
+	categoryUseCase port.CategoryUseCase,
+	guestTokenService port.GuestTokenService,
+	stateMachine *entity.OrderStateMachine,
+) port.OrderUseCase {
+	return &orderUseCase{
+		gateway,
+		orderHistoryUseCase,
+		customerUseCase,
+		refundUseCase,
+		paymentGateway,
+		unitOfWork,
+		eventBus,
+		estimateUseCase,
+		categoryUseCase,
+		guestTokenService,
+		stateMachine,
+	}
 }
 
 // List returns a list of Orders
 func (uc *orderUseCase) List(ctx context.Context, i dto.ListOrdersInput) ([]*entity.Order, int64, error) {
+	if principal, ok := entity.PrincipalFromContext(ctx); ok && principal.IsGuest() {
+		return nil, 0, domain.NewForbiddenError(domain.ErrPermissionDenied)
+	}
+
+	if customerID, ok := customerFromContext(ctx); ok {
+		if i.CustomerID != 0 && i.CustomerID != customerID {
+			return nil, 0, domain.NewForbiddenError(domain.ErrPermissionDenied)
+		}
+		i.CustomerID = customerID
+	}
+
 	orders, total, err := uc.gateway.FindAll(ctx, i.CustomerID, i.Status, i.StatusExclude, i.Page, i.Limit, i.Sort)
 	if err != nil {
 		return nil, 0, domain.NewInternalError(err)
@@ -32,19 +80,52 @@ func (uc *orderUseCase) List(ctx context.Context, i dto.ListOrdersInput) ([]*ent
 
 // Create creates a new Order
 func (uc *orderUseCase) Create(ctx context.Context, i dto.CreateOrderInput) (*entity.Order, error) {
-	order := &entity.Order{CustomerID: i.CustomerID, Status: valueobject.OPEN}
+	if principal, ok := entity.PrincipalFromContext(ctx); ok && principal.IsGuest() && i.CustomerID != 0 {
+		return nil, domain.NewForbiddenError(domain.ErrPermissionDenied)
+	}
 
-	if err := uc.gateway.Create(ctx, order); err != nil {
-		return nil, domain.NewInternalError(err)
+	if customerID, ok := customerFromContext(ctx); ok {
+		if i.CustomerID != 0 && i.CustomerID != customerID {
+			return nil, domain.NewForbiddenError(domain.ErrPermissionDenied)
+		}
+		i.CustomerID = customerID
+	}
+
+	order := &entity.Order{Status: valueobject.OPEN}
+	if i.CustomerID != 0 {
+		order.CustomerID = &i.CustomerID
+	} else {
+		// without a customer the order is placed as a guest, who reaches it with the guest token
+		token, hash, err := uc.guestTokenService.Generate()
+		if err != nil {
+			return nil, domain.NewInternalError(err)
+		}
+		order.GuestToken = token
+		order.GuestTokenHash = hash
 	}
 
-	_, err := uc.orderHistoryUseCase.Create(ctx, dto.CreateOrderHistoryInput{
-		OrderID: order.ID,
-		Status:  valueobject.OPEN,
-		StaffID: nil,
+	var history *entity.OrderHistory
+	err := uc.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		if err := uc.gateway.Create(ctx, order); err != nil {
+			return domain.NewInternalError(err)
+		}
+
+		var err error
+		history, err = uc.orderHistoryUseCase.Create(ctx, dto.CreateOrderHistoryInput{
+			OrderID: order.ID,
+			Status:  valueobject.OPEN,
+			StaffID: nil,
+		})
+		if err != nil {
+			return domain.NewInternalError(err)
+		}
+
+		// Published once committed, the subscribers read the order back
+		uc.publishAfterCommit(ctx, history, order.CustomerID)
+		return nil
 	})
 	if err != nil {
-		return nil, domain.NewInternalError(err)
+		return nil, err
 	}
 
 	return order, nil
@@ -52,7 +133,12 @@ func (uc *orderUseCase) Create(ctx context.Context, i dto.CreateOrderInput) (*en
 
 // Get returns a Order by ID
 func (uc *orderUseCase) Get(ctx context.Context, i dto.GetOrderInput) (*entity.Order, error) {
-	order, err := uc.gateway.FindByID(ctx, i.ID)
+	find := uc.gateway.FindByID
+	if i.ForUpdate {
+		find = uc.gateway.FindByIDForUpdate
+	}
+
+	order, err := find(ctx, i.ID)
 	if err != nil {
 		return nil, domain.NewInternalError(err)
 	}
@@ -61,6 +147,10 @@ func (uc *orderUseCase) Get(ctx context.Context, i dto.GetOrderInput) (*entity.O
 		return nil, domain.NewNotFoundError(domain.ErrNotFound)
 	}
 
+	if err := uc.authorizeOrderOwner(ctx, order); err != nil {
+		return nil, err
+	}
+
 	return order, nil
 }
 
@@ -75,42 +165,191 @@ func (uc *orderUseCase) Update(ctx context.Context, i dto.UpdateOrderInput) (*en
 		return nil, domain.NewNotFoundError(domain.ErrNotFound)
 	}
 
-	if i.CustomerID != 0 && order.CustomerID != i.CustomerID {
+	if err := uc.authorizeOrderOwner(ctx, order); err != nil {
+		return nil, err
+	}
+
+	if i.CustomerID != 0 && !order.IsOwnedBy(i.CustomerID) {
 		return nil, domain.NewInvalidInputError(domain.ErrInvalidBody)
 	}
 
+	if i.Version != nil && *i.Version != order.Version {
+		return nil, domain.NewPreconditionFailedError(domain.ErrVersionMismatch)
+	}
+
 	statusHasChanged := order.Status != i.Status
 	if i.Status != "" && statusHasChanged {
-		if !valueobject.StatusCanTransitionTo(order.Status, i.Status) {
-			return nil, domain.NewInvalidInputError(domain.ErrInvalidBody)
-		}
-
-		if valueobject.StatusTransitionNeedsStaffID(i.Status) && i.StaffID == 0 {
-			return nil, domain.NewInvalidInputError(domain.ErrStaffIdIsMandatory)
+		if err := uc.checkTransition(ctx, order, i); err != nil {
+			return nil, err
 		}
 	}
 
 	orderProducts := order.OrderProducts
-	order.Update(i.CustomerID, i.Status)
+	previousStatus := order.Status
 
-	if err := uc.gateway.Update(ctx, order); err != nil {
-		return nil, domain.NewInternalError(err)
+	// The order, its history and its payment are saved together
+	var history *entity.OrderHistory
+	err = uc.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		// A reopened order may have its products changed, so the payment started for the old ones can no longer be paid
+		if i.Status == valueobject.OPEN && statusHasChanged {
+			aborted, err := uc.abortProcessingPayment(ctx, order.ID)
+			if err != nil {
+				return err
+			}
+
+			if aborted && i.PaymentStatus == valueobject.UNDEFINDED_P {
+				i.PaymentStatus = valueobject.ABORTED
+			}
+		}
+
+		order.Update(i.CustomerID, i.Status)
+
+		updated, err := uc.gateway.Update(ctx, order)
+		if err != nil {
+			return domain.NewInternalError(err)
+		}
+
+		// Changed by another request since it was read
+		if !updated {
+			return domain.NewConflictError(domain.ErrConcurrentUpdate)
+		}
+
+		// A paid order gives the money back when cancelled, on behalf of the staff cancelling it. The refund is saved
+		// with the cancellation and given back once committed, so an order left as it was is never refunded.
+		if i.Status == valueobject.CANCELLED && statusHasChanged {
+			paymentStatus, err := uc.refundUseCase.RefundOrder(ctx, dto.CreateRefundInput{
+				OrderID: order.ID,
+				Reason:  orderCancelledRefundReason,
+				StaffID: i.StaffID,
+			})
+			if err != nil {
+				return err
+			}
+
+			if paymentStatus != valueobject.UNDEFINDED_P {
+				i.PaymentStatus = paymentStatus
+			}
+		}
+
+		// if status has changed, create a new order history
+		if i.Status != "" && statusHasChanged {
+			historyInput := dto.CreateOrderHistoryInput{
+				OrderID: order.ID,
+				Status:  i.Status,
+				StaffID: &i.StaffID,
+			}
+			if i.PaymentStatus != valueobject.UNDEFINDED_P {
+				historyInput.PaymentStatus = &i.PaymentStatus
+			}
+			if i.SystemActor != valueobject.UNDEFINED_ACTOR {
+				historyInput.SystemActor = &i.SystemActor
+			}
+
+			history, err = uc.orderHistoryUseCase.Create(ctx, historyInput)
+			if err != nil {
+				return domain.NewInternalError(err)
+			}
+		}
+
+		// The kitchen queue changed, ex: a paid order joined it, so the orders in it are estimated again. Reading the
+		// whole queue holds no locks once committed, and a failed estimate does not undo the status change.
+		if history != nil && (isKitchenStatus(previousStatus) || isKitchenStatus(i.Status)) {
+			uc.unitOfWork.AfterCommit(ctx, func(ctx context.Context) error {
+				estimates, err := uc.estimateUseCase.EstimateQueue(ctx)
+				if err != nil {
+					return err
+				}
+
+				if estimate, ok := estimates[order.ID]; ok {
+					order.EstimatedReadyAt = &estimate
+				}
+				return nil
+			})
+		}
+
+		// Published once committed, after the estimate, the subscribers read the order back
+		if history != nil {
+			uc.publishAfterCommit(ctx, history, order.CustomerID)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	// Restore order products, to calculate total bill in the presenter
 	order.OrderProducts = orderProducts // TODO: Remove relations from entities
 
-	// if status has changed, create a new order history
-	if i.Status != "" && statusHasChanged {
-		if _, err := uc.orderHistoryUseCase.Create(ctx, dto.CreateOrderHistoryInput{
-			OrderID: order.ID,
-			Status:  i.Status,
-			StaffID: &i.StaffID,
-		}); err != nil {
-			return nil, domain.NewInternalError(err)
+	return order, nil
+}
+
+// publishAfterCommit publishes the status change once the outermost transaction commits, so the event is never seen
+// before its history and is dropped when rolled back
+func (uc *orderUseCase) publishAfterCommit(ctx context.Context, history *entity.OrderHistory, customerID *uint64) {
+	uc.unitOfWork.AfterCommit(ctx, func(context.Context) error {
+		uc.eventBus.Publish(entity.NewOrderStatusEvent(history, customerID))
+		return nil
+	})
+}
+
+// AttachCustomer identifies the customer of a guest order that is still OPEN or PENDING. A customer claiming the
+// order must present its guest token.
+func (uc *orderUseCase) AttachCustomer(ctx context.Context, i dto.AttachOrderCustomerInput) (*entity.Order, error) {
+	// customers can only identify themselves
+	if customerID, ok := customerFromContext(ctx); ok {
+		if i.CustomerID != 0 && i.CustomerID != customerID {
+			return nil, domain.NewForbiddenError(domain.ErrPermissionDenied)
 		}
+		i.CustomerID = customerID
 	}
 
+	if i.CustomerID == 0 {
+		return nil, domain.NewInvalidInputError(domain.ErrCustomerIsMandatory)
+	}
+
+	order, err := uc.gateway.FindByID(ctx, i.ID)
+	if err != nil {
+		return nil, domain.NewInternalError(err)
+	}
+
+	if order == nil {
+		return nil, domain.NewNotFoundError(domain.ErrNotFound)
+	}
+
+	if !order.IsGuest() {
+		return nil, domain.NewInvalidInputError(domain.ErrOrderAlreadyHasCustomer)
+	}
+
+	// customers can only claim the guest orders they placed, proven by the guest token
+	if _, ok := customerFromContext(ctx); ok && !order.IsGuestOwnedBy(uc.guestTokenService.Hash(i.GuestToken)) {
+		return nil, domain.NewForbiddenError(domain.ErrPermissionDenied)
+	}
+
+	if order.Status != valueobject.OPEN && order.Status != valueobject.PENDING {
+		return nil, domain.NewInvalidInputError(domain.ErrOrderCustomerCannotBeChanged)
+	}
+
+	customer, err := uc.customerUseCase.Get(ctx, dto.GetCustomerInput{ID: i.CustomerID})
+	if err != nil {
+		return nil, err
+	}
+
+	orderProducts := order.OrderProducts
+	order.AttachCustomer(customer.ID)
+
+	updated, err := uc.gateway.Update(ctx, order)
+	if err != nil {
+		return nil, domain.NewInternalError(err)
+	}
+
+	if !updated {
+		return nil, domain.NewConflictError(domain.ErrConcurrentUpdate)
+	}
+
+	order.OrderProducts = orderProducts
+	order.Customer = *customer
+
 	return order, nil
 }
 
@@ -124,9 +363,104 @@ func (uc *orderUseCase) Delete(ctx context.Context, i dto.DeleteOrderInput) (*en
 		return nil, domain.NewNotFoundError(domain.ErrNotFound)
 	}
 
+	if err := uc.authorizeOrderOwner(ctx, order); err != nil {
+		return nil, err
+	}
+
 	if err := uc.gateway.Delete(ctx, i.ID); err != nil {
 		return nil, domain.NewInternalError(err)
 	}
 
 	return order, nil
 }
+
+// checkTransition enforces the state machine on the change of status of the order: the transition must be allowed,
+// made by a staff or an automatic process when needed, by one of its roles, and the order must meet its guards
+func (uc *orderUseCase) checkTransition(ctx context.Context, order *entity.Order, i dto.UpdateOrderInput) error {
+	transition, ok := uc.stateMachine.Transition(order.Status, i.Status)
+	if !ok {
+		return domain.NewInvalidInputError(domain.ErrOrderInvalidStatusTransition)
+	}
+
+	// An automatic process acts on its own, without a staff
+	if transition.Staff && i.StaffID == 0 && i.SystemActor == valueobject.UNDEFINED_ACTOR {
+		return domain.NewInvalidInputError(domain.ErrStaffIdIsMandatory)
+	}
+
+	principal, _ := entity.PrincipalFromContext(ctx)
+	if !transition.AllowsRole(principal) {
+		return domain.NewForbiddenError(domain.ErrPermissionDenied)
+	}
+
+	var stations map[uint64]valueobject.KitchenStation
+	if transition.NeedsStations() {
+		var err error
+		if stations, err = listCategoryStations(ctx, uc.categoryUseCase); err != nil {
+			return err
+		}
+	}
+
+	if !transition.GuardsPass(order, stations) {
+		return domain.NewInvalidInputError(domain.ErrOrderTransitionGuardFailed)
+	}
+
+	return nil
+}
+
+// abortProcessingPayment aborts the payment of the order that is still awaiting the customer, if any
+func (uc *orderUseCase) abortProcessingPayment(ctx context.Context, orderID uint64) (bool, error) {
+	payment, err := uc.paymentGateway.FindByOrderIDAndStatusProcessing(ctx, orderID)
+	if err != nil {
+		return false, domain.NewInternalError(err)
+	}
+
+	if payment == nil || payment.ID == 0 {
+		return false, nil
+	}
+
+	aborted, err := uc.paymentGateway.UpdateIfProcessing(ctx, payment.ID, valueobject.ABORTED)
+	if err != nil {
+		return false, domain.NewInternalError(err)
+	}
+
+	return aborted, nil
+}
+
+// isKitchenStatus returns true for the statuses of the orders in the kitchen queue
+func isKitchenStatus(status valueobject.OrderStatus) bool {
+	return slices.Contains(kitchenOrderStatuses, status)
+}
+
+// customerFromContext returns the customer ID when the caller is an authenticated customer
+func customerFromContext(ctx context.Context) (uint64, bool) {
+	principal, ok := entity.PrincipalFromContext(ctx)
+	if !ok || !principal.IsCustomer() {
+		return 0, false
+	}
+	return principal.ID, true
+}
+
+// isRestrictedCaller returns true when the caller is a customer or a guest, whose access is limited to their own orders
+func isRestrictedCaller(ctx context.Context) bool {
+	principal, ok := entity.PrincipalFromContext(ctx)
+	return ok && (principal.IsCustomer() || principal.IsGuest())
+}
+
+// authorizeOrderOwner refuses access when the caller is a customer other than the order owner,
+// or a guest without the guest token of the order
+func (uc *orderUseCase) authorizeOrderOwner(ctx context.Context, order *entity.Order) error {
+	principal, ok := entity.PrincipalFromContext(ctx)
+	if !ok || principal.IsStaff() {
+		return nil
+	}
+
+	if principal.IsCustomer() && order.IsOwnedBy(principal.ID) {
+		return nil
+	}
+
+	if principal.IsGuest() && principal.GuestToken != "" && order.IsGuestOwnedBy(uc.guestTokenService.Hash(principal.GuestToken)) {
+		return nil
+	}
+
+	return domain.NewForbiddenError(domain.ErrPermissionDenied)
+}
@@ -2,6 +2,7 @@ package usecase_test
 
 import (
 	"context"
+	"slices"
 	"testing"
 	"time"
 
@@ -10,14 +11,47 @@ import (
 	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/port"
 	mockport "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/port/mocks"
 	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/usecase"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/util"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
 	"go.uber.org/mock/gomock"
 )
 
+// defaultOrderTransitions are the transitions of the built-in order state machine
+var defaultOrderTransitions = []entity.OrderTransition{
+	{From: valueobject.OPEN, To: valueobject.PENDING},
+	{From: valueobject.OPEN, To: valueobject.CANCELLED},
+	{From: valueobject.PENDING, To: valueobject.OPEN},
+	{From: valueobject.PENDING, To: valueobject.RECEIVED},
+	{From: valueobject.PENDING, To: valueobject.CANCELLED},
+	{From: valueobject.RECEIVED, To: valueobject.PREPARING, Staff: true},
+	{From: valueobject.RECEIVED, To: valueobject.CANCELLED},
+	{From: valueobject.PREPARING, To: valueobject.READY, Staff: true},
+	{From: valueobject.PREPARING, To: valueobject.CANCELLED},
+	{From: valueobject.READY, To: valueobject.COMPLETED, Staff: true},
+	{From: valueobject.READY, To: valueobject.ABANDONED, Staff: true},
+}
+
+// newOrderStateMachine creates the order state machine with the default transitions and the given ones
+func newOrderStateMachine(t *testing.T, transitions ...entity.OrderTransition) *entity.OrderStateMachine {
+	stateMachine, err := entity.NewOrderStateMachine(append(slices.Clone(defaultOrderTransitions), transitions...))
+	assert.NoError(t, err)
+	return stateMachine
+}
+
 type OrderUsecaseSuiteTest struct {
 	suite.Suite
 	mockOrders              []*entity.Order
 	mockOrderHistoryUseCase *mockport.MockOrderHistoryUseCase
+	mockCustomerUseCase     *mockport.MockCustomerUseCase
+	mockRefundUseCase       *mockport.MockPaymentRefundUseCase
+	mockPaymentGateway      *mockport.MockPaymentGateway
+	mockUnitOfWork          *mockport.MockUnitOfWork
+	afterCommit             []func(context.Context) error
+	mockEventBus            *mockport.MockOrderEventBus
+	mockEstimateUseCase     *mockport.MockOrderEstimateUseCase
+	mockCategoryUseCase     *mockport.MockCategoryUseCase
+	mockGuestTokenService   *mockport.MockGuestTokenService
 	mockGateway             *mockport.MockOrderGateway
 	useCase                 port.OrderUseCase
 	ctx                     context.Context
@@ -28,20 +62,52 @@ func (s *OrderUsecaseSuiteTest) SetupTest() {
 	defer ctrl.Finish()
 	s.mockOrderHistoryUseCase = mockport.NewMockOrderHistoryUseCase(ctrl)
 	s.mockGateway = mockport.NewMockOrderGateway(ctrl)
-	s.useCase = usecase.NewOrderUseCase(s.mockGateway, s.mockOrderHistoryUseCase)
+	s.mockCustomerUseCase = mockport.NewMockCustomerUseCase(ctrl)
+	s.mockRefundUseCase = mockport.NewMockPaymentRefundUseCase(ctrl)
+	s.mockPaymentGateway = mockport.NewMockPaymentGateway(ctrl)
+	s.mockUnitOfWork = mockport.NewMockUnitOfWork(ctrl)
+	s.mockUnitOfWork.EXPECT().
+		Do(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+			err := fn(ctx)
+			hooks := s.afterCommit
+			s.afterCommit = nil
+			if err != nil {
+				return err
+			}
+			// Run once committed, their errors are only logged
+			for _, hook := range hooks {
+				_ = hook(ctx)
+			}
+			return nil
+		}).
+		AnyTimes()
+	s.mockUnitOfWork.EXPECT().
+		AfterCommit(gomock.Any(), gomock.Any()).
+		Do(func(_ context.Context, fn func(context.Context) error) { s.afterCommit = append(s.afterCommit, fn) }).
+		AnyTimes()
+	s.mockEventBus = mockport.NewMockOrderEventBus(ctrl)
+	s.mockEstimateUseCase = mockport.NewMockOrderEstimateUseCase(ctrl)
+	s.mockCategoryUseCase = mockport.NewMockCategoryUseCase(ctrl)
+	s.mockGuestTokenService = mockport.NewMockGuestTokenService(ctrl)
+	s.mockGuestTokenService.EXPECT().
+		Hash(gomock.Any()).
+		DoAndReturn(func(token string) string { return "hash-" + token }).
+		AnyTimes()
+	s.useCase = s.newUseCase(newOrderStateMachine(s.T()))
 	s.ctx = context.Background()
 	currentTime := time.Now()
 	s.mockOrders = []*entity.Order{
 		{
 			ID:         1,
-			CustomerID: uint64(1),
+			CustomerID: util.Ptr(uint64(1)),
 			Status:     valueobject.PENDING,
 			CreatedAt:  currentTime,
 			UpdatedAt:  currentTime,
 		},
 		{
 			ID:         2,
-			CustomerID: uint64(2),
+			CustomerID: util.Ptr(uint64(2)),
 			Status:     valueobject.RECEIVED,
 			CreatedAt:  currentTime,
 			UpdatedAt:  currentTime,
@@ -49,6 +115,23 @@ func (s *OrderUsecaseSuiteTest) SetupTest() {
 	}
 }
 
+// newUseCase creates the use case with the mocks of the suite and the state machine
+func (s *OrderUsecaseSuiteTest) newUseCase(stateMachine *entity.OrderStateMachine) port.OrderUseCase {
+	return usecase.NewOrderUseCase(
+		s.mockGateway,
+		s.mockOrderHistoryUseCase,
+		s.mockCustomerUseCase,
+		s.mockRefundUseCase,
+		s.mockPaymentGateway,
+		s.mockUnitOfWork,
+		s.mockEventBus,
+		s.mockEstimateUseCase,
+		s.mockCategoryUseCase,
+		s.mockGuestTokenService,
+		stateMachine,
+	)
+}
+
 func TestOrderUsecaseSuiteTest(t *testing.T) {
 	suite.Run(t, new(OrderUsecaseSuiteTest))
 }
@@ -3,6 +3,7 @@ package usecase_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
@@ -11,6 +12,7 @@ import (
 	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
 	valueobject "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/value_object"
 	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/dto"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/util"
 )
 
 func (s *OrderUsecaseSuiteTest) TestOrdersUseCase_List() {
@@ -125,11 +127,53 @@ func (s *OrderUsecaseSuiteTest) TestOrderUseCase_Create() {
 				s.mockOrderHistoryUseCase.EXPECT().
 					Create(s.ctx, gomock.Any()).
 					Return(&entity.OrderHistory{OrderID: 1, Status: valueobject.OPEN}, nil)
+
+				s.mockEventBus.EXPECT().
+					Publish(gomock.Any())
 			},
 			checkResult: func(t *testing.T, order *entity.Order, err error) {
 				assert.NoError(t, err)
 				assert.NotNil(t, order)
-				assert.Equal(t, uint64(1), order.CustomerID)
+				assert.True(t, order.IsOwnedBy(1))
+			},
+		},
+		{
+			name:  "should create guest order without customer",
+			input: dto.CreateOrderInput{},
+			setupMocks: func() {
+				s.mockGuestTokenService.EXPECT().
+					Generate().
+					Return("secret", "hash-secret", nil)
+				s.mockGateway.EXPECT().
+					Create(s.ctx, gomock.Cond(func(order *entity.Order) bool {
+						return order.GuestTokenHash == "hash-secret"
+					})).
+					Return(nil)
+				s.mockOrderHistoryUseCase.EXPECT().
+					Create(s.ctx, gomock.Any()).
+					Return(&entity.OrderHistory{OrderID: 1, Status: valueobject.OPEN}, nil)
+
+				s.mockEventBus.EXPECT().
+					Publish(gomock.Any())
+			},
+			checkResult: func(t *testing.T, order *entity.Order, err error) {
+				assert.NoError(t, err)
+				assert.NotNil(t, order)
+				assert.True(t, order.IsGuest())
+				assert.Equal(t, "secret", order.GuestToken)
+			},
+		},
+		{
+			name:  "should return error when guest token generation fails",
+			input: dto.CreateOrderInput{},
+			setupMocks: func() {
+				s.mockGuestTokenService.EXPECT().
+					Generate().
+					Return("", "", assert.AnError)
+			},
+			checkResult: func(t *testing.T, order *entity.Order, err error) {
+				assert.Nil(t, order)
+				assert.IsType(t, &domain.InternalError{}, err)
 			},
 		},
 		{
@@ -250,6 +294,8 @@ func (s *OrderUsecaseSuiteTest) TestOrderUseCase_Get() {
 }
 
 func (s *OrderUsecaseSuiteTest) TestOrderUseCase_Update() {
+	staleVersion := uint64(3)
+	estimatedReadyAt := time.Now().Add(12 * time.Minute)
 	tests := []struct {
 		name        string
 		input       dto.UpdateOrderInput
@@ -270,19 +316,157 @@ func (s *OrderUsecaseSuiteTest) TestOrderUseCase_Update() {
 
 				s.mockGateway.EXPECT().
 					Update(s.ctx, gomock.Any()).
-					DoAndReturn(func(_ context.Context, p *entity.Order) error {
+					DoAndReturn(func(_ context.Context, p *entity.Order) (bool, error) {
 						assert.Equal(s.T(), uint64(1), p.ID)
-						return nil
+						return true, nil
 					})
 
 				s.mockOrderHistoryUseCase.EXPECT().
 					Create(s.ctx, gomock.Any()).
 					Return(&entity.OrderHistory{OrderID: 1, Status: valueobject.RECEIVED}, nil)
+
+				s.mockEstimateUseCase.EXPECT().
+					EstimateQueue(s.ctx).
+					Return(map[uint64]time.Time{1: estimatedReadyAt}, nil)
+
+				s.mockEventBus.EXPECT().
+					Publish(gomock.Any()).
+					Do(func(event *entity.OrderStatusEvent) {
+						assert.Equal(s.T(), uint64(1), event.OrderID)
+						assert.Equal(s.T(), valueobject.RECEIVED, event.Status)
+						assert.Equal(s.T(), uint64(1), *event.CustomerID)
+					})
 			},
 			checkResult: func(t *testing.T, order *entity.Order, err error) {
 				assert.NoError(t, err)
 				assert.NotNil(t, order)
 				assert.Equal(t, valueobject.RECEIVED, order.Status)
+				assert.Equal(t, estimatedReadyAt, *order.EstimatedReadyAt)
+			},
+		},
+		{
+			name: "should keep the update when the kitchen queue cannot be estimated",
+			input: dto.UpdateOrderInput{
+				ID:      1,
+				Status:  valueobject.PREPARING,
+				StaffID: 1,
+			},
+			setupMocks: func() {
+				s.mockGateway.EXPECT().
+					FindByID(s.ctx, uint64(1)).
+					Return(&entity.Order{ID: 1, Status: valueobject.RECEIVED}, nil)
+
+				s.mockGateway.EXPECT().
+					Update(s.ctx, gomock.Any()).
+					Return(true, nil)
+
+				s.mockOrderHistoryUseCase.EXPECT().
+					Create(s.ctx, gomock.Any()).
+					Return(&entity.OrderHistory{OrderID: 1, Status: valueobject.PREPARING}, nil)
+
+				s.mockEstimateUseCase.EXPECT().
+					EstimateQueue(s.ctx).
+					Return(nil, domain.NewInternalError(assert.AnError))
+
+				s.mockEventBus.EXPECT().Publish(gomock.Any())
+			},
+			checkResult: func(t *testing.T, order *entity.Order, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, valueobject.PREPARING, order.Status)
+				assert.Nil(t, order.EstimatedReadyAt)
+			},
+		},
+		{
+			name: "should abort the processing payment when the order is reopened",
+			input: dto.UpdateOrderInput{
+				ID:     1,
+				Status: valueobject.OPEN,
+			},
+			setupMocks: func() {
+				s.mockGateway.EXPECT().
+					FindByID(s.ctx, uint64(1)).
+					Return(&entity.Order{ID: 1, Status: valueobject.PENDING}, nil)
+
+				s.mockPaymentGateway.EXPECT().
+					FindByOrderIDAndStatusProcessing(s.ctx, uint64(1)).
+					Return(&entity.Payment{ID: 7, OrderID: 1, Status: valueobject.PROCESSING}, nil)
+
+				s.mockPaymentGateway.EXPECT().
+					UpdateIfProcessing(s.ctx, uint64(7), valueobject.ABORTED).
+					Return(true, nil)
+
+				s.mockGateway.EXPECT().
+					Update(s.ctx, gomock.Any()).
+					Return(true, nil)
+
+				s.mockOrderHistoryUseCase.EXPECT().
+					Create(s.ctx, gomock.Any()).
+					DoAndReturn(func(_ context.Context, i dto.CreateOrderHistoryInput) (*entity.OrderHistory, error) {
+						assert.Equal(s.T(), valueobject.OPEN, i.Status)
+						assert.Equal(s.T(), valueobject.ABORTED, *i.PaymentStatus)
+						return &entity.OrderHistory{OrderID: 1, Status: valueobject.OPEN}, nil
+					})
+
+				s.mockEventBus.EXPECT().
+					Publish(gomock.Any())
+			},
+			checkResult: func(t *testing.T, order *entity.Order, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, valueobject.OPEN, order.Status)
+			},
+		},
+		{
+			name: "should reopen the order without a processing payment",
+			input: dto.UpdateOrderInput{
+				ID:     1,
+				Status: valueobject.OPEN,
+			},
+			setupMocks: func() {
+				s.mockGateway.EXPECT().
+					FindByID(s.ctx, uint64(1)).
+					Return(&entity.Order{ID: 1, Status: valueobject.PENDING}, nil)
+
+				s.mockPaymentGateway.EXPECT().
+					FindByOrderIDAndStatusProcessing(s.ctx, uint64(1)).
+					Return(&entity.Payment{}, nil)
+
+				s.mockGateway.EXPECT().
+					Update(s.ctx, gomock.Any()).
+					Return(true, nil)
+
+				s.mockOrderHistoryUseCase.EXPECT().
+					Create(s.ctx, gomock.Any()).
+					DoAndReturn(func(_ context.Context, i dto.CreateOrderHistoryInput) (*entity.OrderHistory, error) {
+						assert.Nil(s.T(), i.PaymentStatus)
+						return &entity.OrderHistory{OrderID: 1, Status: valueobject.OPEN}, nil
+					})
+
+				s.mockEventBus.EXPECT().
+					Publish(gomock.Any())
+			},
+			checkResult: func(t *testing.T, order *entity.Order, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, valueobject.OPEN, order.Status)
+			},
+		},
+		{
+			name: "should not reopen the order when the payment cannot be aborted",
+			input: dto.UpdateOrderInput{
+				ID:     1,
+				Status: valueobject.OPEN,
+			},
+			setupMocks: func() {
+				s.mockGateway.EXPECT().
+					FindByID(s.ctx, uint64(1)).
+					Return(&entity.Order{ID: 1, Status: valueobject.PENDING}, nil)
+
+				s.mockPaymentGateway.EXPECT().
+					FindByOrderIDAndStatusProcessing(s.ctx, uint64(1)).
+					Return(nil, assert.AnError)
+			},
+			checkResult: func(t *testing.T, order *entity.Order, err error) {
+				assert.Nil(t, order)
+				assert.IsType(t, &domain.InternalError{}, err)
 			},
 		},
 		{
@@ -375,6 +559,37 @@ func (s *OrderUsecaseSuiteTest) TestOrderUseCase_Update() {
 				assert.IsType(t, &domain.InvalidInputError{}, err)
 			},
 		},
+		{
+			name: "should abandon a ready order without staff when changed by the system",
+			input: dto.UpdateOrderInput{
+				ID:          1,
+				Status:      valueobject.ABANDONED,
+				SystemActor: valueobject.ORDER_EXPIRY_ACTOR,
+			},
+			setupMocks: func() {
+				s.mockGateway.EXPECT().
+					FindByID(s.ctx, uint64(1)).
+					Return(&entity.Order{ID: 1, Status: valueobject.READY}, nil)
+
+				s.mockGateway.EXPECT().
+					Update(s.ctx, gomock.Any()).
+					Return(true, nil)
+
+				s.mockOrderHistoryUseCase.EXPECT().
+					Create(s.ctx, gomock.Any()).
+					DoAndReturn(func(_ context.Context, i dto.CreateOrderHistoryInput) (*entity.OrderHistory, error) {
+						assert.Equal(s.T(), valueobject.ABANDONED, i.Status)
+						assert.Equal(s.T(), valueobject.ORDER_EXPIRY_ACTOR, *i.SystemActor)
+						return &entity.OrderHistory{OrderID: 1, Status: i.Status, SystemActor: i.SystemActor}, nil
+					})
+
+				s.mockEventBus.EXPECT().Publish(gomock.Any())
+			},
+			checkResult: func(t *testing.T, order *entity.Order, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, valueobject.ABANDONED, order.Status)
+			},
+		},
 		{
 			name: "should return error when gateway update fails",
 			input: dto.UpdateOrderInput{
@@ -389,7 +604,7 @@ func (s *OrderUsecaseSuiteTest) TestOrderUseCase_Update() {
 
 				s.mockGateway.EXPECT().
 					Update(s.ctx, gomock.Any()).
-					Return(assert.AnError)
+					Return(false, assert.AnError)
 			},
 			checkResult: func(t *testing.T, order *entity.Order, err error) {
 				assert.Error(t, err)
@@ -398,11 +613,29 @@ func (s *OrderUsecaseSuiteTest) TestOrderUseCase_Update() {
 			},
 		},
 		{
-			name: "should return error when status is different and order history use case create fails",
+			name: "should return precondition failed when version does not match",
 			input: dto.UpdateOrderInput{
 				ID:         1,
 				CustomerID: 1,
-				Status:     valueobject.CANCELLED,
+				Status:     valueobject.RECEIVED,
+				Version:    &staleVersion,
+			},
+			setupMocks: func() {
+				s.mockGateway.EXPECT().
+					FindByID(s.ctx, uint64(1)).
+					Return(s.mockOrders[0], nil)
+			},
+			checkResult: func(t *testing.T, order *entity.Order, err error) {
+				assert.Nil(t, order)
+				assert.IsType(t, &domain.PreconditionFailedError{}, err)
+			},
+		},
+		{
+			name: "should return conflict when order was changed concurrently",
+			input: dto.UpdateOrderInput{
+				ID:         1,
+				CustomerID: 1,
+				Status:     valueobject.RECEIVED,
 			},
 			setupMocks: func() {
 				s.mockGateway.EXPECT().
@@ -411,7 +644,33 @@ func (s *OrderUsecaseSuiteTest) TestOrderUseCase_Update() {
 
 				s.mockGateway.EXPECT().
 					Update(s.ctx, gomock.Any()).
-					Return(nil)
+					Return(false, nil)
+			},
+			checkResult: func(t *testing.T, order *entity.Order, err error) {
+				assert.Nil(t, order)
+				assert.IsType(t, &domain.ConflictError{}, err)
+			},
+		},
+		{
+			name: "should return error when status is different and order history use case create fails",
+			input: dto.UpdateOrderInput{
+				ID:         1,
+				CustomerID: 1,
+				Status:     valueobject.CANCELLED,
+			},
+			setupMocks: func() {
+				s.mockGateway.EXPECT().
+					FindByID(s.ctx, uint64(1)).
+					Return(s.mockOrders[0], nil)
+
+				gomock.InOrder(
+					s.mockGateway.EXPECT().
+						Update(s.ctx, gomock.Any()).
+						Return(true, nil),
+					s.mockRefundUseCase.EXPECT().
+						RefundOrder(s.ctx, gomock.Any()).
+						Return(valueobject.UNDEFINDED_P, nil),
+				)
 
 				s.mockOrderHistoryUseCase.EXPECT().
 					Create(s.ctx, gomock.Any()).
@@ -423,6 +682,97 @@ func (s *OrderUsecaseSuiteTest) TestOrderUseCase_Update() {
 				assert.IsType(t, &domain.InternalError{}, err)
 			},
 		},
+		{
+			name: "should refund a paid order when it is cancelled",
+			input: dto.UpdateOrderInput{
+				ID:      3,
+				Status:  valueobject.CANCELLED,
+				StaffID: 1,
+			},
+			setupMocks: func() {
+				s.mockGateway.EXPECT().
+					FindByID(s.ctx, uint64(3)).
+					Return(&entity.Order{ID: 3, Status: valueobject.PREPARING}, nil)
+
+				// the refund is only started once the cancellation is saved
+				gomock.InOrder(
+					s.mockGateway.EXPECT().
+						Update(s.ctx, gomock.Any()).
+						Return(true, nil),
+					s.mockRefundUseCase.EXPECT().
+						RefundOrder(s.ctx, dto.CreateRefundInput{OrderID: 3, Reason: "order cancelled", StaffID: 1}).
+						Return(valueobject.REFUNDED, nil),
+				)
+
+				refunded := valueobject.REFUNDED
+				s.mockOrderHistoryUseCase.EXPECT().
+					Create(s.ctx, dto.CreateOrderHistoryInput{
+						OrderID:       3,
+						Status:        valueobject.CANCELLED,
+						StaffID:       util.Ptr(uint64(1)),
+						PaymentStatus: &refunded,
+					}).
+					Return(&entity.OrderHistory{}, nil)
+
+				// the cancelled order leaves the kitchen queue
+				s.mockEstimateUseCase.EXPECT().
+					EstimateQueue(s.ctx).
+					Return(map[uint64]time.Time{}, nil)
+
+				s.mockEventBus.EXPECT().
+					Publish(gomock.Any())
+			},
+			checkResult: func(t *testing.T, order *entity.Order, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, valueobject.CANCELLED, order.Status)
+			},
+		},
+		{
+			name: "should keep the order when the refund of the cancellation fails",
+			input: dto.UpdateOrderInput{
+				ID:      3,
+				Status:  valueobject.CANCELLED,
+				StaffID: 1,
+			},
+			setupMocks: func() {
+				s.mockGateway.EXPECT().
+					FindByID(s.ctx, uint64(3)).
+					Return(&entity.Order{ID: 3, Status: valueobject.RECEIVED}, nil)
+
+				s.mockGateway.EXPECT().
+					Update(s.ctx, gomock.Any()).
+					Return(true, nil)
+
+				s.mockRefundUseCase.EXPECT().
+					RefundOrder(s.ctx, gomock.Any()).
+					Return(valueobject.UNDEFINDED_P, domain.NewConflictError(domain.ErrRefundInProgress))
+			},
+			checkResult: func(t *testing.T, order *entity.Order, err error) {
+				assert.Nil(t, order)
+				assert.IsType(t, &domain.ConflictError{}, err)
+			},
+		},
+		{
+			name: "should not refund the order when the cancellation conflicts",
+			input: dto.UpdateOrderInput{
+				ID:      3,
+				Status:  valueobject.CANCELLED,
+				StaffID: 1,
+			},
+			setupMocks: func() {
+				s.mockGateway.EXPECT().
+					FindByID(s.ctx, uint64(3)).
+					Return(&entity.Order{ID: 3, Status: valueobject.RECEIVED}, nil)
+
+				s.mockGateway.EXPECT().
+					Update(s.ctx, gomock.Any()).
+					Return(false, nil)
+			},
+			checkResult: func(t *testing.T, order *entity.Order, err error) {
+				assert.Nil(t, order)
+				assert.IsType(t, &domain.ConflictError{}, err)
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -524,3 +874,474 @@ func (s *OrderUsecaseSuiteTest) TestOrderUseCase_Delete() {
 		})
 	}
 }
+
+func (s *OrderUsecaseSuiteTest) TestOrderUseCase_CustomerOwnership() {
+	customerCtx := entity.ContextWithPrincipal(s.ctx, &entity.Principal{SubjectType: valueobject.CUSTOMER, ID: 1})
+
+	tests := []struct {
+		name        string
+		run         func() error
+		setupMocks  func()
+		checkResult func(*testing.T, error)
+	}{
+		{
+			name: "should scope list to the authenticated customer",
+			run: func() error {
+				_, _, err := s.useCase.List(customerCtx, dto.ListOrdersInput{Page: 1, Limit: 10})
+				return err
+			},
+			setupMocks: func() {
+				s.mockGateway.EXPECT().
+					FindAll(customerCtx, uint64(1), nil, nil, 1, 10, "").
+					Return(s.mockOrders[:1], int64(1), nil)
+			},
+			checkResult: func(t *testing.T, err error) {
+				assert.NoError(t, err)
+			},
+		},
+		{
+			name: "should refuse listing orders of another customer",
+			run: func() error {
+				_, _, err := s.useCase.List(customerCtx, dto.ListOrdersInput{CustomerID: 2, Page: 1, Limit: 10})
+				return err
+			},
+			setupMocks: func() {},
+			checkResult: func(t *testing.T, err error) {
+				assert.IsType(t, &domain.ForbiddenError{}, err)
+			},
+		},
+		{
+			name: "should refuse creating an order for another customer",
+			run: func() error {
+				_, err := s.useCase.Create(customerCtx, dto.CreateOrderInput{CustomerID: 2})
+				return err
+			},
+			setupMocks: func() {},
+			checkResult: func(t *testing.T, err error) {
+				assert.IsType(t, &domain.ForbiddenError{}, err)
+			},
+		},
+		{
+			name: "should get own order",
+			run: func() error {
+				_, err := s.useCase.Get(customerCtx, dto.GetOrderInput{ID: 1})
+				return err
+			},
+			setupMocks: func() {
+				s.mockGateway.EXPECT().
+					FindByID(customerCtx, uint64(1)).
+					Return(s.mockOrders[0], nil)
+			},
+			checkResult: func(t *testing.T, err error) {
+				assert.NoError(t, err)
+			},
+		},
+		{
+			name: "should refuse getting an order of another customer",
+			run: func() error {
+				_, err := s.useCase.Get(customerCtx, dto.GetOrderInput{ID: 2})
+				return err
+			},
+			setupMocks: func() {
+				s.mockGateway.EXPECT().
+					FindByID(customerCtx, uint64(2)).
+					Return(s.mockOrders[1], nil)
+			},
+			checkResult: func(t *testing.T, err error) {
+				assert.IsType(t, &domain.ForbiddenError{}, err)
+			},
+		},
+		{
+			name: "should refuse updating an order of another customer",
+			run: func() error {
+				_, err := s.useCase.Update(customerCtx, dto.UpdateOrderInput{ID: 2, Status: valueobject.CANCELLED})
+				return err
+			},
+			setupMocks: func() {
+				s.mockGateway.EXPECT().
+					FindByID(customerCtx, uint64(2)).
+					Return(s.mockOrders[1], nil)
+			},
+			checkResult: func(t *testing.T, err error) {
+				assert.IsType(t, &domain.ForbiddenError{}, err)
+			},
+		},
+		{
+			name: "should allow staff to get any order",
+			run: func() error {
+				staffCtx := entity.ContextWithPrincipal(s.ctx, &entity.Principal{SubjectType: valueobject.STAFF, ID: 1, Role: valueobject.COOK})
+				_, err := s.useCase.Get(staffCtx, dto.GetOrderInput{ID: 2})
+				return err
+			},
+			setupMocks: func() {
+				s.mockGateway.EXPECT().
+					FindByID(gomock.Any(), uint64(2)).
+					Return(s.mockOrders[1], nil)
+			},
+			checkResult: func(t *testing.T, err error) {
+				assert.NoError(t, err)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.T().Run(tt.name, func(t *testing.T) {
+			// Arrange
+			tt.setupMocks()
+
+			// Act
+			err := tt.run()
+
+			// Assert
+			tt.checkResult(t, err)
+		})
+	}
+}
+
+func (s *OrderUsecaseSuiteTest) TestOrderUseCase_AttachCustomer() {
+	guestOrder := func(status valueobject.OrderStatus) *entity.Order {
+		return &entity.Order{ID: 3, Status: status, GuestTokenHash: "hash-secret"}
+	}
+
+	tests := []struct {
+		name        string
+		ctx         context.Context
+		input       dto.AttachOrderCustomerInput
+		setupMocks  func()
+		checkResult func(*testing.T, *entity.Order, error)
+	}{
+		{
+			name:  "should attach customer to guest order",
+			ctx:   s.ctx,
+			input: dto.AttachOrderCustomerInput{ID: 3, CustomerID: 1},
+			setupMocks: func() {
+				s.mockGateway.EXPECT().
+					FindByID(s.ctx, uint64(3)).
+					Return(guestOrder(valueobject.PENDING), nil)
+				s.mockCustomerUseCase.EXPECT().
+					Get(s.ctx, dto.GetCustomerInput{ID: 1}).
+					Return(&entity.Customer{ID: 1, Name: "Test Customer"}, nil)
+				s.mockGateway.EXPECT().
+					Update(s.ctx, gomock.Any()).
+					Return(true, nil)
+			},
+			checkResult: func(t *testing.T, order *entity.Order, err error) {
+				assert.NoError(t, err)
+				assert.True(t, order.IsOwnedBy(1))
+				assert.Equal(t, uint64(1), order.Customer.ID)
+				assert.Empty(t, order.GuestTokenHash)
+			},
+		},
+		{
+			name:  "should identify the authenticated customer",
+			ctx:   entity.ContextWithPrincipal(s.ctx, &entity.Principal{SubjectType: valueobject.CUSTOMER, ID: 2}),
+			input: dto.AttachOrderCustomerInput{ID: 3, GuestToken: "secret"},
+			setupMocks: func() {
+				s.mockGateway.EXPECT().
+					FindByID(gomock.Any(), uint64(3)).
+					Return(guestOrder(valueobject.OPEN), nil)
+				s.mockCustomerUseCase.EXPECT().
+					Get(gomock.Any(), dto.GetCustomerInput{ID: 2}).
+					Return(&entity.Customer{ID: 2}, nil)
+				s.mockGateway.EXPECT().
+					Update(gomock.Any(), gomock.Any()).
+					Return(true, nil)
+			},
+			checkResult: func(t *testing.T, order *entity.Order, err error) {
+				assert.NoError(t, err)
+				assert.True(t, order.IsOwnedBy(2))
+			},
+		},
+		{
+			name:  "should refuse customer claiming a guest order without its guest token",
+			ctx:   entity.ContextWithPrincipal(s.ctx, &entity.Principal{SubjectType: valueobject.CUSTOMER, ID: 2}),
+			input: dto.AttachOrderCustomerInput{ID: 3, GuestToken: "another"},
+			setupMocks: func() {
+				s.mockGateway.EXPECT().
+					FindByID(gomock.Any(), uint64(3)).
+					Return(guestOrder(valueobject.OPEN), nil)
+			},
+			checkResult: func(t *testing.T, order *entity.Order, err error) {
+				assert.Nil(t, order)
+				assert.IsType(t, &domain.ForbiddenError{}, err)
+			},
+		},
+		{
+			name:       "should refuse attaching another customer",
+			ctx:        entity.ContextWithPrincipal(s.ctx, &entity.Principal{SubjectType: valueobject.CUSTOMER, ID: 2}),
+			input:      dto.AttachOrderCustomerInput{ID: 3, CustomerID: 1},
+			setupMocks: func() {},
+			checkResult: func(t *testing.T, order *entity.Order, err error) {
+				assert.Nil(t, order)
+				assert.IsType(t, &domain.ForbiddenError{}, err)
+			},
+		},
+		{
+			name:       "should return invalid input when customer is missing",
+			ctx:        s.ctx,
+			input:      dto.AttachOrderCustomerInput{ID: 3},
+			setupMocks: func() {},
+			checkResult: func(t *testing.T, order *entity.Order, err error) {
+				assert.Nil(t, order)
+				assert.IsType(t, &domain.InvalidInputError{}, err)
+			},
+		},
+		{
+			name:  "should return invalid input when order already has a customer",
+			ctx:   s.ctx,
+			input: dto.AttachOrderCustomerInput{ID: 1, CustomerID: 2},
+			setupMocks: func() {
+				s.mockGateway.EXPECT().
+					FindByID(s.ctx, uint64(1)).
+					Return(s.mockOrders[0], nil)
+			},
+			checkResult: func(t *testing.T, order *entity.Order, err error) {
+				assert.Nil(t, order)
+				assert.IsType(t, &domain.InvalidInputError{}, err)
+			},
+		},
+		{
+			name:  "should return invalid input when order is already paid",
+			ctx:   s.ctx,
+			input: dto.AttachOrderCustomerInput{ID: 3, CustomerID: 1},
+			setupMocks: func() {
+				s.mockGateway.EXPECT().
+					FindByID(s.ctx, uint64(3)).
+					Return(guestOrder(valueobject.RECEIVED), nil)
+			},
+			checkResult: func(t *testing.T, order *entity.Order, err error) {
+				assert.Nil(t, order)
+				assert.IsType(t, &domain.InvalidInputError{}, err)
+			},
+		},
+		{
+			name:  "should return not found when customer doesn't exist",
+			ctx:   s.ctx,
+			input: dto.AttachOrderCustomerInput{ID: 3, CustomerID: 9},
+			setupMocks: func() {
+				s.mockGateway.EXPECT().
+					FindByID(s.ctx, uint64(3)).
+					Return(guestOrder(valueobject.OPEN), nil)
+				s.mockCustomerUseCase.EXPECT().
+					Get(s.ctx, dto.GetCustomerInput{ID: 9}).
+					Return(nil, domain.NewNotFoundError(domain.ErrNotFound))
+			},
+			checkResult: func(t *testing.T, order *entity.Order, err error) {
+				assert.Nil(t, order)
+				assert.IsType(t, &domain.NotFoundError{}, err)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.T().Run(tt.name, func(t *testing.T) {
+			// Arrange
+			tt.setupMocks()
+
+			// Act
+			order, err := s.useCase.AttachCustomer(tt.ctx, tt.input)
+
+			// Assert
+			tt.checkResult(t, order, err)
+		})
+	}
+}
+
+func (s *OrderUsecaseSuiteTest) TestOrderUseCase_GuestAccess() {
+	guestCtx := entity.ContextWithPrincipal(s.ctx, &entity.Principal{SubjectType: valueobject.GUEST, GuestToken: "secret"})
+	guestOrder := &entity.Order{ID: 3, Status: valueobject.OPEN, GuestTokenHash: "hash-secret"}
+
+	s.T().Run("should get guest order with its guest token", func(t *testing.T) {
+		s.mockGateway.EXPECT().
+			FindByID(guestCtx, uint64(3)).
+			Return(guestOrder, nil)
+
+		order, err := s.useCase.Get(guestCtx, dto.GetOrderInput{ID: 3})
+
+		assert.NoError(t, err)
+		assert.NotNil(t, order)
+	})
+
+	s.T().Run("should refuse guest order with another guest token", func(t *testing.T) {
+		ctx := entity.ContextWithPrincipal(s.ctx, &entity.Principal{SubjectType: valueobject.GUEST, GuestToken: "another"})
+		s.mockGateway.EXPECT().
+			FindByID(ctx, uint64(3)).
+			Return(guestOrder, nil)
+
+		order, err := s.useCase.Get(ctx, dto.GetOrderInput{ID: 3})
+
+		assert.Nil(t, order)
+		assert.IsType(t, &domain.ForbiddenError{}, err)
+	})
+
+	s.T().Run("should refuse guest order without a guest token", func(t *testing.T) {
+		ctx := entity.ContextWithPrincipal(s.ctx, &entity.Principal{SubjectType: valueobject.GUEST})
+		s.mockGateway.EXPECT().
+			FindByID(ctx, uint64(3)).
+			Return(guestOrder, nil)
+
+		order, err := s.useCase.Get(ctx, dto.GetOrderInput{ID: 3})
+
+		assert.Nil(t, order)
+		assert.IsType(t, &domain.ForbiddenError{}, err)
+	})
+
+	s.T().Run("should refuse order of an identified customer", func(t *testing.T) {
+		s.mockGateway.EXPECT().
+			FindByID(guestCtx, uint64(1)).
+			Return(s.mockOrders[0], nil)
+
+		order, err := s.useCase.Get(guestCtx, dto.GetOrderInput{ID: 1})
+
+		assert.Nil(t, order)
+		assert.IsType(t, &domain.ForbiddenError{}, err)
+	})
+
+	s.T().Run("should refuse listing orders", func(t *testing.T) {
+		orders, _, err := s.useCase.List(guestCtx, dto.ListOrdersInput{Page: 1, Limit: 10})
+
+		assert.Nil(t, orders)
+		assert.IsType(t, &domain.ForbiddenError{}, err)
+	})
+}
+
+func (s *OrderUsecaseSuiteTest) TestOrderUseCase_StateMachine() {
+	// A franchise flow: drinks are handed over without being prepared, and managers may reopen cancelled orders
+	useCase := s.newUseCase(newOrderStateMachine(s.T(),
+		entity.OrderTransition{
+			From:   valueobject.RECEIVED,
+			To:     valueobject.READY,
+			Staff:  true,
+			Roles:  []valueobject.StaffRole{valueobject.ATTENDANT, valueobject.MANAGER},
+			Guards: []valueobject.OrderGuard{valueobject.DRINKS_ONLY_GUARD},
+		},
+		entity.OrderTransition{
+			From:  valueobject.CANCELLED,
+			To:    valueobject.OPEN,
+			Roles: []valueobject.StaffRole{valueobject.MANAGER},
+		},
+	))
+	attendantCtx := entity.ContextWithPrincipal(s.ctx, &entity.Principal{SubjectType: valueobject.STAFF, ID: 1, Role: valueobject.ATTENDANT})
+	managerCtx := entity.ContextWithPrincipal(s.ctx, &entity.Principal{SubjectType: valueobject.STAFF, ID: 2, Role: valueobject.MANAGER})
+	categories := []*entity.Category{
+		{ID: 1, Name: "Foods", Station: valueobject.GRILL},
+		{ID: 2, Name: "Beverages", Station: valueobject.DRINKS},
+	}
+
+	tests := []struct {
+		name        string
+		ctx         context.Context
+		input       dto.UpdateOrderInput
+		setupMocks  func(ctx context.Context)
+		checkResult func(*testing.T, *entity.Order, error)
+	}{
+		{
+			name:  "should skip preparing a drinks only order",
+			ctx:   attendantCtx,
+			input: dto.UpdateOrderInput{ID: 1, Status: valueobject.READY, StaffID: 1},
+			setupMocks: func(ctx context.Context) {
+				s.mockGateway.EXPECT().
+					FindByID(ctx, uint64(1)).
+					Return(&entity.Order{ID: 1, Status: valueobject.RECEIVED, OrderProducts: []entity.OrderProduct{{OrderID: 1, CategoryID: 2}}}, nil)
+				s.mockCategoryUseCase.EXPECT().
+					List(ctx, gomock.Any()).
+					Return(categories, int64(len(categories)), nil)
+				s.mockGateway.EXPECT().
+					Update(ctx, gomock.Any()).
+					Return(true, nil)
+				s.mockOrderHistoryUseCase.EXPECT().
+					Create(ctx, gomock.Any()).
+					Return(&entity.OrderHistory{OrderID: 1, Status: valueobject.READY}, nil)
+				s.mockEstimateUseCase.EXPECT().
+					EstimateQueue(ctx).
+					Return(map[uint64]time.Time{}, nil)
+				s.mockEventBus.EXPECT().Publish(gomock.Any())
+			},
+			checkResult: func(t *testing.T, order *entity.Order, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, valueobject.READY, order.Status)
+			},
+		},
+		{
+			name:  "should refuse skipping preparation when the order has food",
+			ctx:   attendantCtx,
+			input: dto.UpdateOrderInput{ID: 1, Status: valueobject.READY, StaffID: 1},
+			setupMocks: func(ctx context.Context) {
+				s.mockGateway.EXPECT().
+					FindByID(ctx, uint64(1)).
+					Return(&entity.Order{ID: 1, Status: valueobject.RECEIVED, OrderProducts: []entity.OrderProduct{
+						{OrderID: 1, CategoryID: 1},
+						{OrderID: 1, CategoryID: 2},
+					}}, nil)
+				s.mockCategoryUseCase.EXPECT().
+					List(ctx, gomock.Any()).
+					Return(categories, int64(len(categories)), nil)
+			},
+			checkResult: func(t *testing.T, order *entity.Order, err error) {
+				assert.Nil(t, order)
+				assert.IsType(t, &domain.InvalidInputError{}, err)
+				assert.EqualError(t, err, domain.ErrOrderTransitionGuardFailed)
+			},
+		},
+		{
+			name:  "should refuse a transition to a role not allowed",
+			ctx:   attendantCtx,
+			input: dto.UpdateOrderInput{ID: 1, Status: valueobject.OPEN, StaffID: 1},
+			setupMocks: func(ctx context.Context) {
+				s.mockGateway.EXPECT().
+					FindByID(ctx, uint64(1)).
+					Return(&entity.Order{ID: 1, Status: valueobject.CANCELLED}, nil)
+			},
+			checkResult: func(t *testing.T, order *entity.Order, err error) {
+				assert.Nil(t, order)
+				assert.IsType(t, &domain.ForbiddenError{}, err)
+			},
+		},
+		{
+			name:  "should let a manager reopen a cancelled order",
+			ctx:   managerCtx,
+			input: dto.UpdateOrderInput{ID: 1, Status: valueobject.OPEN, StaffID: 2},
+			setupMocks: func(ctx context.Context) {
+				s.mockGateway.EXPECT().
+					FindByID(ctx, uint64(1)).
+					Return(&entity.Order{ID: 1, Status: valueobject.CANCELLED}, nil)
+				s.mockPaymentGateway.EXPECT().
+					FindByOrderIDAndStatusProcessing(ctx, uint64(1)).
+					Return(nil, nil)
+				s.mockGateway.EXPECT().
+					Update(ctx, gomock.Any()).
+					Return(true, nil)
+				s.mockOrderHistoryUseCase.EXPECT().
+					Create(ctx, gomock.Any()).
+					Return(&entity.OrderHistory{OrderID: 1, Status: valueobject.OPEN}, nil)
+				s.mockEventBus.EXPECT().Publish(gomock.Any())
+			},
+			checkResult: func(t *testing.T, order *entity.Order, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, valueobject.OPEN, order.Status)
+			},
+		},
+		{
+			name:  "should refuse a transition missing from the state machine",
+			ctx:   managerCtx,
+			input: dto.UpdateOrderInput{ID: 1, Status: valueobject.PREPARING, StaffID: 2},
+			setupMocks: func(ctx context.Context) {
+				s.mockGateway.EXPECT().
+					FindByID(ctx, uint64(1)).
+					Return(&entity.Order{ID: 1, Status: valueobject.CANCELLED}, nil)
+			},
+			checkResult: func(t *testing.T, order *entity.Order, err error) {
+				assert.Nil(t, order)
+				assert.EqualError(t, err, domain.ErrOrderInvalidStatusTransition)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.T().Run(tt.name, func(t *testing.T) {
+			tt.setupMocks(tt.ctx)
+			order, err := useCase.Update(tt.ctx, tt.input)
+			tt.checkResult(t, order, err)
+		})
+	}
+}
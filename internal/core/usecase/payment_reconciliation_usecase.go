@@ -0,0 +1,193 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
+	valueobject "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/value_object"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/dto"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/port"
+)
+
+const (
+	// reconciliationBatchSize limits the payments and notifications loaded at once
+	reconciliationBatchSize = 100
+	// reconciliationTopic identifies the notifications created by the reconciliation
+	reconciliationTopic = "reconciliation"
+	// unprocessedNotificationGrace leaves alone the notifications that may still be processed by their delivery
+	unprocessedNotificationGrace = time.Minute
+)
+
+type paymentReconciliationUseCase struct {
+	paymentGateway      port.PaymentGateway
+	notificationGateway port.PaymentNotificationGateway
+	paymentUseCase      port.PaymentUseCase
+}
+
+// NewPaymentReconciliationUseCase create a new payment reconciliation use case
+func NewPaymentReconciliationUseCase(
+	paymentGateway port.PaymentGateway,
+	notificationGateway port.PaymentNotificationGateway,
+	paymentUseCase port.PaymentUseCase,
+) port.PaymentReconciliationUseCase {
+	return &paymentReconciliationUseCase{paymentGateway, notificationGateway, paymentUseCase}
+}
+
+// Reconcile checks every PROCESSING payment against its provider and applies the notifications that were never
+// processed (ex: lost when the payment was not found). The drifts are fixed through the payment use case, as if
+// the provider had notified them. The report lists every payment checked, even when an error is returned.
+func (uc *paymentReconciliationUseCase) Reconcile(ctx context.Context) (*entity.PaymentReconciliationReport, error) {
+	report := &entity.PaymentReconciliationReport{StartedAt: time.Now()}
+	var errs []error
+
+	var afterID uint64
+	for {
+		payments, err := uc.paymentGateway.FindProcessing(ctx, afterID, reconciliationBatchSize)
+		if err != nil {
+			return nil, domain.NewInternalError(err)
+		}
+
+		for _, payment := range payments {
+			if err := uc.reconcilePayment(ctx, report, payment); err != nil {
+				errs = append(errs, err)
+			}
+			afterID = payment.ID
+		}
+
+		if len(payments) < reconciliationBatchSize {
+			break
+		}
+	}
+
+	receivedBefore := report.StartedAt.Add(-unprocessedNotificationGrace)
+	afterID = 0
+	for {
+		notifications, err := uc.notificationGateway.FindUnprocessed(ctx, receivedBefore, afterID, reconciliationBatchSize)
+		if err != nil {
+			return nil, domain.NewInternalError(err)
+		}
+
+		for _, notification := range notifications {
+			if err := uc.reconcileNotification(ctx, report, notification); err != nil {
+				errs = append(errs, err)
+			}
+			afterID = notification.ID
+		}
+
+		if len(notifications) < reconciliationBatchSize {
+			break
+		}
+	}
+
+	report.FinishedAt = time.Now()
+
+	if len(errs) > 0 {
+		return report, domain.NewInternalError(errors.Join(errs...))
+	}
+
+	return report, nil
+}
+
+// reconcilePayment asks the provider for the status of a PROCESSING payment and settles it when the provider did
+func (uc *paymentReconciliationUseCase) reconcilePayment(ctx context.Context, report *entity.PaymentReconciliationReport, payment *entity.Payment) error {
+	entry := entity.PaymentReconciliationEntry{
+		PaymentID:   payment.ID,
+		OrderID:     payment.OrderID,
+		Resource:    payment.ExternalPaymentID,
+		Provider:    payment.Provider,
+		LocalStatus: payment.Status,
+	}
+
+	status, err := uc.paymentGateway.FindExternalStatus(ctx, payment)
+	if err != nil {
+		var notFoundErr *domain.NotFoundError
+		switch {
+		case errors.As(err, &notFoundErr):
+			entry.Result = valueobject.RECONCILIATION_ORPHANED
+			entry.Reason = domain.ErrPaymentNotFoundExternal
+		case errors.Is(err, domain.ErrPaymentStatusUnavailable):
+			entry.Result = valueobject.RECONCILIATION_SKIPPED
+			entry.Reason = domain.ErrPaymentStatusNotNotified
+		default:
+			entry.Result = valueobject.RECONCILIATION_FAILED
+			entry.Reason = err.Error()
+		}
+		report.Add(entry)
+
+		if entry.Result == valueobject.RECONCILIATION_FAILED {
+			return fmt.Errorf("error reconciling payment %d: %w", payment.ID, err)
+		}
+		return nil
+	}
+	entry.ProviderStatus = status
+
+	if !status.IsSettled() || !status.Overrides(payment.Status) {
+		entry.Result = valueobject.RECONCILIATION_MATCHED
+		report.Add(entry)
+		return nil
+	}
+
+	// The event ID makes a repeated reconciliation of the same drift a duplicate notification
+	input := dto.UpdatePaymentInput{
+		EventID:  fmt.Sprintf("%s-%d-%s", reconciliationTopic, payment.ID, status),
+		Resource: payment.ExternalPaymentID,
+		Topic:    reconciliationTopic,
+		Status:   status,
+	}
+
+	if _, err := uc.paymentUseCase.Update(ctx, input); err != nil {
+		entry.Result = valueobject.RECONCILIATION_FAILED
+		entry.Reason = err.Error()
+		report.Add(entry)
+		return fmt.Errorf("error reconciling payment %d: %w", payment.ID, err)
+	}
+
+	entry.Result = valueobject.RECONCILIATION_FIXED
+	report.Add(entry)
+	return nil
+}
+
+// reconcileNotification applies a notification that was never processed, it is orphaned while there is no local
+// payment for the notified resource
+func (uc *paymentReconciliationUseCase) reconcileNotification(ctx context.Context, report *entity.PaymentReconciliationReport, notification *entity.PaymentNotification) error {
+	entry := entity.PaymentReconciliationEntry{
+		Resource:       notification.Resource,
+		ProviderStatus: notification.Status,
+	}
+
+	input := dto.UpdatePaymentInput{
+		EventID:  notification.EventID,
+		Resource: notification.Resource,
+		Topic:    notification.Topic,
+		Status:   notification.Status,
+	}
+
+	payment, err := uc.paymentUseCase.Update(ctx, input)
+	if err != nil {
+		var notFoundErr *domain.NotFoundError
+		if errors.As(err, &notFoundErr) {
+			entry.Result = valueobject.RECONCILIATION_ORPHANED
+			entry.Reason = "no local payment for the notified resource"
+			report.Add(entry)
+			return nil
+		}
+
+		entry.Result = valueobject.RECONCILIATION_FAILED
+		entry.Reason = err.Error()
+		report.Add(entry)
+		return fmt.Errorf("error reconciling payment notification %s: %w", notification.EventID, err)
+	}
+
+	entry.PaymentID = payment.ID
+	entry.OrderID = payment.OrderID
+	entry.Provider = payment.Provider
+	entry.LocalStatus = payment.Status
+	entry.Result = valueobject.RECONCILIATION_FIXED
+	entry.Reason = "notification applied"
+	report.Add(entry)
+	return nil
+}
@@ -0,0 +1,35 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/port"
+	mockport "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/port/mocks"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/usecase"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type PaymentReconciliationUsecaseSuiteTest struct {
+	suite.Suite
+	mockGateway             *mockport.MockPaymentGateway
+	mockNotificationGateway *mockport.MockPaymentNotificationGateway
+	mockPaymentUseCase      *mockport.MockPaymentUseCase
+	useCase                 port.PaymentReconciliationUseCase
+	ctx                     context.Context
+}
+
+func (s *PaymentReconciliationUsecaseSuiteTest) SetupTest() {
+	ctrl := gomock.NewController(s.T())
+	defer ctrl.Finish()
+	s.mockGateway = mockport.NewMockPaymentGateway(ctrl)
+	s.mockNotificationGateway = mockport.NewMockPaymentNotificationGateway(ctrl)
+	s.mockPaymentUseCase = mockport.NewMockPaymentUseCase(ctrl)
+	s.useCase = usecase.NewPaymentReconciliationUseCase(s.mockGateway, s.mockNotificationGateway, s.mockPaymentUseCase)
+	s.ctx = context.Background()
+}
+
+func TestPaymentReconciliationUsecaseSuiteTest(t *testing.T) {
+	suite.Run(t, new(PaymentReconciliationUsecaseSuiteTest))
+}
@@ -0,0 +1,139 @@
+package usecase_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
+	valueobject "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/value_object"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/dto"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func (s *PaymentReconciliationUsecaseSuiteTest) Test_paymentReconciliationUseCase_Reconcile() {
+	processing := func(id uint64) *entity.Payment {
+		return &entity.Payment{
+			ID:                id,
+			OrderID:           id,
+			ExternalPaymentID: "resource",
+			Provider:          valueobject.MERCADO_PAGO_PROVIDER,
+			Status:            valueobject.PROCESSING,
+		}
+	}
+
+	tests := []struct {
+		name        string
+		setupMocks  func()
+		checkResult func(*testing.T, *entity.PaymentReconciliationReport, error)
+	}{
+		{
+			name: "should fix a payment settled by the provider and match the others",
+			setupMocks: func() {
+				s.mockGateway.EXPECT().
+					FindProcessing(s.ctx, uint64(0), gomock.Any()).
+					Return([]*entity.Payment{processing(1), processing(2)}, nil)
+				s.mockGateway.EXPECT().FindExternalStatus(s.ctx, processing(1)).Return(valueobject.CONFIRMED, nil)
+				s.mockPaymentUseCase.EXPECT().
+					Update(s.ctx, dto.UpdatePaymentInput{
+						EventID:  "reconciliation-1-CONFIRMED",
+						Resource: "resource",
+						Topic:    "reconciliation",
+						Status:   valueobject.CONFIRMED,
+					}).
+					Return(&entity.Payment{ID: 1, Status: valueobject.CONFIRMED}, nil)
+				s.mockGateway.EXPECT().FindExternalStatus(s.ctx, processing(2)).Return(valueobject.PROCESSING, nil)
+				s.mockNotificationGateway.EXPECT().FindUnprocessed(s.ctx, gomock.Any(), uint64(0), gomock.Any()).Return(nil, nil)
+			},
+			checkResult: func(t *testing.T, report *entity.PaymentReconciliationReport, err error) {
+				assert.NoError(t, err)
+				assert.Len(t, report.Entries, 2)
+				assert.Equal(t, 1, report.Count(valueobject.RECONCILIATION_FIXED))
+				assert.Equal(t, 1, report.Count(valueobject.RECONCILIATION_MATCHED))
+				assert.Equal(t, valueobject.PROCESSING, report.Entries[0].LocalStatus)
+				assert.Equal(t, valueobject.CONFIRMED, report.Entries[0].ProviderStatus)
+			},
+		},
+		{
+			name: "should report orphaned and skipped payments",
+			setupMocks: func() {
+				s.mockGateway.EXPECT().
+					FindProcessing(s.ctx, uint64(0), gomock.Any()).
+					Return([]*entity.Payment{processing(1), processing(2)}, nil)
+				s.mockGateway.EXPECT().
+					FindExternalStatus(s.ctx, processing(1)).
+					Return(valueobject.UNDEFINDED_P, domain.NewNotFoundError(domain.ErrPaymentNotFoundExternal))
+				s.mockGateway.EXPECT().
+					FindExternalStatus(s.ctx, processing(2)).
+					Return(valueobject.UNDEFINDED_P, fmt.Errorf("error getting status: %w", domain.ErrPaymentStatusUnavailable))
+				s.mockNotificationGateway.EXPECT().FindUnprocessed(s.ctx, gomock.Any(), uint64(0), gomock.Any()).Return(nil, nil)
+			},
+			checkResult: func(t *testing.T, report *entity.PaymentReconciliationReport, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, 1, report.Count(valueobject.RECONCILIATION_ORPHANED))
+				assert.Equal(t, 1, report.Count(valueobject.RECONCILIATION_SKIPPED))
+			},
+		},
+		{
+			name: "should apply the unprocessed notifications and report the ones without payment as orphaned",
+			setupMocks: func() {
+				s.mockGateway.EXPECT().FindProcessing(s.ctx, uint64(0), gomock.Any()).Return(nil, nil)
+				s.mockNotificationGateway.EXPECT().
+					FindUnprocessed(s.ctx, gomock.Any(), uint64(0), gomock.Any()).
+					Return([]*entity.PaymentNotification{
+						{ID: 1, EventID: "event-1", Resource: "resource-1", Topic: "payment", Status: valueobject.CONFIRMED},
+						{ID: 2, EventID: "event-2", Resource: "resource-2", Topic: "payment", Status: valueobject.CONFIRMED},
+					}, nil)
+				s.mockPaymentUseCase.EXPECT().
+					Update(s.ctx, dto.UpdatePaymentInput{EventID: "event-1", Resource: "resource-1", Topic: "payment", Status: valueobject.CONFIRMED}).
+					Return(&entity.Payment{ID: 1, OrderID: 1, Status: valueobject.CONFIRMED}, nil)
+				s.mockPaymentUseCase.EXPECT().
+					Update(s.ctx, dto.UpdatePaymentInput{EventID: "event-2", Resource: "resource-2", Topic: "payment", Status: valueobject.CONFIRMED}).
+					Return(nil, domain.NewNotFoundError(domain.ErrNotFound))
+			},
+			checkResult: func(t *testing.T, report *entity.PaymentReconciliationReport, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, 1, report.Count(valueobject.RECONCILIATION_FIXED))
+				assert.Equal(t, 1, report.Count(valueobject.RECONCILIATION_ORPHANED))
+				assert.Equal(t, uint64(1), report.Entries[0].PaymentID)
+			},
+		},
+		{
+			name: "should keep reconciling when a payment fails and return the report with the error",
+			setupMocks: func() {
+				s.mockGateway.EXPECT().
+					FindProcessing(s.ctx, uint64(0), gomock.Any()).
+					Return([]*entity.Payment{processing(1), processing(2)}, nil)
+				s.mockGateway.EXPECT().FindExternalStatus(s.ctx, processing(1)).Return(valueobject.UNDEFINDED_P, assert.AnError)
+				s.mockGateway.EXPECT().FindExternalStatus(s.ctx, processing(2)).Return(valueobject.FAILED, nil)
+				s.mockPaymentUseCase.EXPECT().Update(s.ctx, gomock.Any()).Return(&entity.Payment{ID: 2, Status: valueobject.FAILED}, nil)
+				s.mockNotificationGateway.EXPECT().FindUnprocessed(s.ctx, gomock.Any(), uint64(0), gomock.Any()).Return(nil, nil)
+			},
+			checkResult: func(t *testing.T, report *entity.PaymentReconciliationReport, err error) {
+				assert.IsType(t, &domain.InternalError{}, err)
+				assert.Equal(t, 1, report.Count(valueobject.RECONCILIATION_FAILED))
+				assert.Equal(t, 1, report.Count(valueobject.RECONCILIATION_FIXED))
+			},
+		},
+		{
+			name: "should return error when FindProcessing from gateway fails",
+			setupMocks: func() {
+				s.mockGateway.EXPECT().FindProcessing(s.ctx, uint64(0), gomock.Any()).Return(nil, assert.AnError)
+			},
+			checkResult: func(t *testing.T, report *entity.PaymentReconciliationReport, err error) {
+				assert.Nil(t, report)
+				assert.IsType(t, &domain.InternalError{}, err)
+			},
+		},
+	}
+	for _, tt := range tests {
+		s.T().Run(tt.name, func(t *testing.T) {
+			tt.setupMocks()
+
+			report, err := s.useCase.Reconcile(s.ctx)
+
+			tt.checkResult(t, report, err)
+		})
+	}
+}
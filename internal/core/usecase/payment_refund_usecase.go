@@ -0,0 +1,162 @@
+package usecase
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
+	valueobject "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/value_object"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/dto"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/port"
+)
+
+type paymentRefundUseCase struct {
+	paymentGateway port.PaymentGateway
+	unitOfWork     port.UnitOfWork
+}
+
+// NewPaymentRefundUseCase create a new payment refund use case
+func NewPaymentRefundUseCase(paymentGateway port.PaymentGateway, unitOfWork port.UnitOfWork) port.PaymentRefundUseCase {
+	return &paymentRefundUseCase{paymentGateway, unitOfWork}
+}
+
+// Create refunds the paid payment of an order, partially when an amount is given. Only managers may authorize refunds.
+// A refund left pending by a failed attempt, or by the cancellation of the order, is resumed by asking for it again.
+func (uc *paymentRefundUseCase) Create(ctx context.Context, i dto.CreateRefundInput) (*entity.Payment, error) {
+	if principal, ok := entity.PrincipalFromContext(ctx); ok && !principal.HasAnyRole(valueobject.MANAGER) {
+		return nil, domain.NewForbiddenError(domain.ErrPermissionDenied)
+	}
+
+	if i.StaffID == 0 {
+		return nil, domain.NewInvalidInputError(domain.ErrStaffIdIsMandatory)
+	}
+
+	payment, err := uc.findPaidPayment(ctx, i.OrderID)
+	if err != nil {
+		return nil, err
+	}
+
+	if payment == nil {
+		return nil, domain.NewInvalidInputError(domain.ErrPaymentNotRefundable)
+	}
+
+	refund, status, err := uc.startRefund(ctx, payment, i)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.giveBack(ctx, payment, refund, status); err != nil {
+		return nil, err
+	}
+
+	return payment, nil
+}
+
+// RefundOrder records the refund of what is left of the paid payment of an order that is being cancelled, on behalf
+// of the staff cancelling it or of the automatic process. The money is given back once the unit of work of ctx
+// commits, so a cancellation rolled back refunds nothing; a refund that fails then stays pending. It returns the
+// status the payment will have once refunded, or UNDEFINDED_P when the order was not paid.
+func (uc *paymentRefundUseCase) RefundOrder(ctx context.Context, i dto.CreateRefundInput) (valueobject.PaymentStatus, error) {
+	payment, err := uc.findPaidPayment(ctx, i.OrderID)
+	if err != nil {
+		return valueobject.UNDEFINDED_P, err
+	}
+
+	if payment == nil {
+		return valueobject.UNDEFINDED_P, nil
+	}
+
+	i.Amount = valueobject.Money{}
+	refund, status, err := uc.startRefund(ctx, payment, i)
+	if err != nil {
+		return valueobject.UNDEFINDED_P, err
+	}
+
+	uc.unitOfWork.AfterCommit(ctx, func(ctx context.Context) error {
+		return uc.giveBack(ctx, payment, refund, status)
+	})
+
+	return status, nil
+}
+
+// findPaidPayment returns the last payment of the order that was paid and not fully refunded yet
+func (uc *paymentRefundUseCase) findPaidPayment(ctx context.Context, orderID uint64) (*entity.Payment, error) {
+	payment, err := uc.paymentGateway.FindByOrderIDAndStatus(ctx, orderID, valueobject.CONFIRMED, valueobject.PARTIALLY_REFUNDED)
+	if err != nil {
+		return nil, domain.NewInternalError(err)
+	}
+
+	return payment, nil
+}
+
+// startRefund records the refund as pending, before the provider gives the money back, and returns the status the
+// payment will have once refunded
+func (uc *paymentRefundUseCase) startRefund(ctx context.Context, payment *entity.Payment, i dto.CreateRefundInput) (*entity.PaymentRefund, valueobject.PaymentStatus, error) {
+	refundable := payment.RefundableAmount()
+	amount := i.Amount
+	if amount.IsZero() {
+		amount = refundable
+	}
+
+	if !amount.IsPositive() || amount.GreaterThan(refundable) {
+		return nil, valueobject.UNDEFINDED_P, domain.NewInvalidInputError(domain.ErrInvalidRefundAmount)
+	}
+
+	// The automatic processes (ex: order expiry) refund without a staff
+	var staffID *uint64
+	if i.StaffID != 0 {
+		staffID = &i.StaffID
+	}
+
+	// The refund is recorded before the provider gives the money back, so a failure after it is never lost: the retry
+	// finds the pending refund and sends the same idempotency key, and the provider refunds once
+	refund := &entity.PaymentRefund{
+		PaymentID:      payment.ID,
+		Status:         valueobject.REFUND_PENDING,
+		IdempotencyKey: payment.RefundIdempotencyKey(amount),
+		Amount:         amount,
+		Reason:         i.Reason,
+		StaffID:        staffID,
+	}
+
+	created, err := uc.paymentGateway.CreatePendingRefund(ctx, refund, payment.RefundedAmount)
+	if err != nil {
+		return nil, valueobject.UNDEFINDED_P, domain.NewInternalError(err)
+	}
+
+	if !created {
+		return nil, valueobject.UNDEFINDED_P, domain.NewConflictError(domain.ErrRefundInProgress)
+	}
+
+	status := valueobject.REFUNDED
+	if amount.LessThan(refundable) {
+		status = valueobject.PARTIALLY_REFUNDED
+	}
+
+	return refund, status, nil
+}
+
+// giveBack asks the provider to give the money of the pending refund back, then completes it
+func (uc *paymentRefundUseCase) giveBack(ctx context.Context, payment *entity.Payment, refund *entity.PaymentRefund, status valueobject.PaymentStatus) error {
+	extRefund, err := uc.paymentGateway.RefundExternal(ctx, &entity.RefundPaymentExternalInput{
+		Provider:          payment.Provider,
+		ExternalPaymentID: payment.ExternalPaymentID,
+		ExternalReference: strconv.FormatUint(payment.OrderID, 10),
+		Amount:            refund.Amount,
+		IdempotencyKey:    refund.IdempotencyKey,
+	})
+	if err != nil {
+		return domain.NewInternalError(err)
+	}
+
+	refund.ExternalRefundID = extRefund.ExternalRefundID
+	if err := uc.paymentGateway.CompleteRefund(ctx, refund, status); err != nil {
+		return domain.NewInternalError(err)
+	}
+
+	payment.Status = status
+	payment.RefundedAmount = payment.RefundedAmount.Add(refund.Amount)
+
+	return nil
+}
@@ -0,0 +1,39 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/port"
+	mockport "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/port/mocks"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/usecase"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type PaymentRefundUsecaseSuiteTest struct {
+	suite.Suite
+	mockGateway    *mockport.MockPaymentGateway
+	mockUnitOfWork *mockport.MockUnitOfWork
+	afterCommit    []func(context.Context) error
+	useCase        port.PaymentRefundUseCase
+	ctx            context.Context
+}
+
+func (s *PaymentRefundUsecaseSuiteTest) SetupTest() {
+	ctrl := gomock.NewController(s.T())
+	defer ctrl.Finish()
+	s.mockGateway = mockport.NewMockPaymentGateway(ctrl)
+	s.mockUnitOfWork = mockport.NewMockUnitOfWork(ctrl)
+	s.afterCommit = nil
+	s.mockUnitOfWork.EXPECT().
+		AfterCommit(gomock.Any(), gomock.Any()).
+		Do(func(_ context.Context, fn func(context.Context) error) { s.afterCommit = append(s.afterCommit, fn) }).
+		AnyTimes()
+	s.useCase = usecase.NewPaymentRefundUseCase(s.mockGateway, s.mockUnitOfWork)
+	s.ctx = context.Background()
+}
+
+func TestPaymentRefundUsecaseSuiteTest(t *testing.T) {
+	suite.Run(t, new(PaymentRefundUsecaseSuiteTest))
+}
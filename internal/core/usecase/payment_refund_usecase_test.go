@@ -0,0 +1,417 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
+	valueobject "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/value_object"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/dto"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/util"
+)
+
+func (s *PaymentRefundUsecaseSuiteTest) Test_paymentRefundUseCase_Create() {
+	managerCtx := entity.ContextWithPrincipal(s.ctx, &entity.Principal{SubjectType: valueobject.STAFF, ID: 1, Role: valueobject.MANAGER})
+	attendantCtx := entity.ContextWithPrincipal(s.ctx, &entity.Principal{SubjectType: valueobject.STAFF, ID: 2, Role: valueobject.ATTENDANT})
+	paidPayment := func() *entity.Payment {
+		return &entity.Payment{ID: 1, OrderID: 1, ExternalPaymentID: "ext-1", Amount: valueobject.NewMoney(3000), Status: valueobject.CONFIRMED}
+	}
+	expectPaidPayment := func(ctx context.Context, payment *entity.Payment) {
+		s.mockGateway.EXPECT().
+			FindByOrderIDAndStatus(ctx, uint64(1), valueobject.CONFIRMED, valueobject.PARTIALLY_REFUNDED).
+			Return(payment, nil)
+	}
+
+	tests := []struct {
+		name        string
+		ctx         context.Context
+		input       dto.CreateRefundInput
+		setupMocks  func()
+		checkResult func(*testing.T, *entity.Payment, error)
+	}{
+		{
+			name:  "should refund the whole payment",
+			ctx:   managerCtx,
+			input: dto.CreateRefundInput{OrderID: 1, Reason: "Customer complaint", StaffID: 1},
+			setupMocks: func() {
+				expectPaidPayment(managerCtx, paidPayment())
+				s.mockGateway.EXPECT().
+					CreatePendingRefund(managerCtx, &entity.PaymentRefund{PaymentID: 1, Status: valueobject.REFUND_PENDING, IdempotencyKey: "refund-1-0.00-30.00", Amount: valueobject.NewMoney(3000), Reason: "Customer complaint", StaffID: util.Ptr(uint64(1))}, valueobject.NewMoney(0)).
+					Return(true, nil)
+				s.mockGateway.EXPECT().
+					RefundExternal(managerCtx, &entity.RefundPaymentExternalInput{ExternalPaymentID: "ext-1", ExternalReference: "1", Amount: valueobject.NewMoney(3000), IdempotencyKey: "refund-1-0.00-30.00"}).
+					Return(&entity.RefundPaymentExternalOutput{ExternalRefundID: "refund-1"}, nil)
+				s.mockGateway.EXPECT().
+					CompleteRefund(managerCtx, &entity.PaymentRefund{PaymentID: 1, Status: valueobject.REFUND_PENDING, IdempotencyKey: "refund-1-0.00-30.00", ExternalRefundID: "refund-1", Amount: valueobject.NewMoney(3000), Reason: "Customer complaint", StaffID: util.Ptr(uint64(1))}, valueobject.REFUNDED).
+					Return(nil)
+			},
+			checkResult: func(t *testing.T, payment *entity.Payment, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, valueobject.REFUNDED, payment.Status)
+				assert.Equal(t, valueobject.NewMoney(3000), payment.RefundedAmount)
+			},
+		},
+		{
+			name:  "should refund part of the payment",
+			ctx:   managerCtx,
+			input: dto.CreateRefundInput{OrderID: 1, Amount: valueobject.NewMoney(1050), Reason: "Missing item", StaffID: 1},
+			setupMocks: func() {
+				expectPaidPayment(managerCtx, paidPayment())
+				s.mockGateway.EXPECT().
+					CreatePendingRefund(managerCtx, gomock.Any(), valueobject.NewMoney(0)).
+					Return(true, nil)
+				s.mockGateway.EXPECT().
+					RefundExternal(managerCtx, &entity.RefundPaymentExternalInput{ExternalPaymentID: "ext-1", ExternalReference: "1", Amount: valueobject.NewMoney(1050), IdempotencyKey: "refund-1-0.00-10.50"}).
+					Return(&entity.RefundPaymentExternalOutput{ExternalRefundID: "refund-1"}, nil)
+				s.mockGateway.EXPECT().
+					CompleteRefund(managerCtx, &entity.PaymentRefund{PaymentID: 1, Status: valueobject.REFUND_PENDING, IdempotencyKey: "refund-1-0.00-10.50", ExternalRefundID: "refund-1", Amount: valueobject.NewMoney(1050), Reason: "Missing item", StaffID: util.Ptr(uint64(1))}, valueobject.PARTIALLY_REFUNDED).
+					Return(nil)
+			},
+			checkResult: func(t *testing.T, payment *entity.Payment, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, valueobject.PARTIALLY_REFUNDED, payment.Status)
+				assert.Equal(t, valueobject.NewMoney(1050), payment.RefundedAmount)
+			},
+		},
+		{
+			name:  "should refund what is left of a partially refunded payment",
+			ctx:   managerCtx,
+			input: dto.CreateRefundInput{OrderID: 1, Amount: valueobject.NewMoney(1950), Reason: "Order returned", StaffID: 1},
+			setupMocks: func() {
+				payment := paidPayment()
+				payment.Status = valueobject.PARTIALLY_REFUNDED
+				payment.RefundedAmount = valueobject.NewMoney(1050)
+				expectPaidPayment(managerCtx, payment)
+				s.mockGateway.EXPECT().
+					CreatePendingRefund(managerCtx, gomock.Any(), valueobject.NewMoney(1050)).
+					Return(true, nil)
+				s.mockGateway.EXPECT().
+					RefundExternal(managerCtx, &entity.RefundPaymentExternalInput{ExternalPaymentID: "ext-1", ExternalReference: "1", Amount: valueobject.NewMoney(1950), IdempotencyKey: "refund-1-10.50-19.50"}).
+					Return(&entity.RefundPaymentExternalOutput{ExternalRefundID: "refund-2"}, nil)
+				s.mockGateway.EXPECT().
+					CompleteRefund(managerCtx, &entity.PaymentRefund{PaymentID: 1, Status: valueobject.REFUND_PENDING, IdempotencyKey: "refund-1-10.50-19.50", ExternalRefundID: "refund-2", Amount: valueobject.NewMoney(1950), Reason: "Order returned", StaffID: util.Ptr(uint64(1))}, valueobject.REFUNDED).
+					Return(nil)
+			},
+			checkResult: func(t *testing.T, payment *entity.Payment, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, valueobject.REFUNDED, payment.Status)
+				assert.Equal(t, valueobject.NewMoney(3000), payment.RefundedAmount)
+			},
+		},
+		{
+			name:  "should refuse an amount greater than the refundable amount",
+			ctx:   managerCtx,
+			input: dto.CreateRefundInput{OrderID: 1, Amount: valueobject.NewMoney(3001), Reason: "Customer complaint", StaffID: 1},
+			setupMocks: func() {
+				expectPaidPayment(managerCtx, paidPayment())
+			},
+			checkResult: func(t *testing.T, payment *entity.Payment, err error) {
+				assert.Nil(t, payment)
+				assert.IsType(t, &domain.InvalidInputError{}, err)
+			},
+		},
+		{
+			name:  "should return error when the order was not paid",
+			ctx:   managerCtx,
+			input: dto.CreateRefundInput{OrderID: 1, Reason: "Customer complaint", StaffID: 1},
+			setupMocks: func() {
+				expectPaidPayment(managerCtx, nil)
+			},
+			checkResult: func(t *testing.T, payment *entity.Payment, err error) {
+				assert.Nil(t, payment)
+				assert.IsType(t, &domain.InvalidInputError{}, err)
+			},
+		},
+		{
+			name:  "should refuse a refund authorized by a staff other than a manager",
+			ctx:   attendantCtx,
+			input: dto.CreateRefundInput{OrderID: 1, Reason: "Customer complaint", StaffID: 2},
+			setupMocks: func() {
+				expectPaidPayment(attendantCtx, paidPayment())
+			},
+			checkResult: func(t *testing.T, payment *entity.Payment, err error) {
+				assert.Nil(t, payment)
+				assert.IsType(t, &domain.ForbiddenError{}, err)
+			},
+		},
+		{
+			name:  "should return error when the staff is missing",
+			ctx:   managerCtx,
+			input: dto.CreateRefundInput{OrderID: 1, Reason: "Customer complaint"},
+			setupMocks: func() {
+				expectPaidPayment(managerCtx, paidPayment())
+			},
+			checkResult: func(t *testing.T, payment *entity.Payment, err error) {
+				assert.Nil(t, payment)
+				assert.IsType(t, &domain.InvalidInputError{}, err)
+			},
+		},
+		{
+			name:  "should retry a pending refund with the same idempotency key",
+			ctx:   managerCtx,
+			input: dto.CreateRefundInput{OrderID: 1, Reason: "Customer complaint", StaffID: 1},
+			setupMocks: func() {
+				expectPaidPayment(managerCtx, paidPayment())
+				// The refund recorded by the failed attempt is loaded, with its reason
+				s.mockGateway.EXPECT().
+					CreatePendingRefund(managerCtx, gomock.Any(), valueobject.NewMoney(0)).
+					DoAndReturn(func(_ context.Context, refund *entity.PaymentRefund, _ valueobject.Money) (bool, error) {
+						refund.ID = 7
+						refund.Reason = "First attempt"
+						return true, nil
+					})
+				s.mockGateway.EXPECT().
+					RefundExternal(managerCtx, &entity.RefundPaymentExternalInput{ExternalPaymentID: "ext-1", ExternalReference: "1", Amount: valueobject.NewMoney(3000), IdempotencyKey: "refund-1-0.00-30.00"}).
+					Return(&entity.RefundPaymentExternalOutput{ExternalRefundID: "refund-1"}, nil)
+				s.mockGateway.EXPECT().
+					CompleteRefund(managerCtx, gomock.Cond(func(refund *entity.PaymentRefund) bool {
+						return refund.ID == 7 && refund.ExternalRefundID == "refund-1"
+					}), valueobject.REFUNDED).
+					Return(nil)
+			},
+			checkResult: func(t *testing.T, payment *entity.Payment, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, valueobject.REFUNDED, payment.Status)
+			},
+		},
+		{
+			name:  "should return conflict when the payment was refunded meanwhile or has another refund in progress",
+			ctx:   managerCtx,
+			input: dto.CreateRefundInput{OrderID: 1, Reason: "Customer complaint", StaffID: 1},
+			setupMocks: func() {
+				expectPaidPayment(managerCtx, paidPayment())
+				s.mockGateway.EXPECT().
+					CreatePendingRefund(managerCtx, gomock.Any(), valueobject.NewMoney(0)).
+					Return(false, nil)
+			},
+			checkResult: func(t *testing.T, payment *entity.Payment, err error) {
+				assert.Nil(t, payment)
+				assert.IsType(t, &domain.ConflictError{}, err)
+			},
+		},
+		{
+			name:  "should return error when CreatePendingRefund from gateway fails",
+			ctx:   managerCtx,
+			input: dto.CreateRefundInput{OrderID: 1, Reason: "Customer complaint", StaffID: 1},
+			setupMocks: func() {
+				expectPaidPayment(managerCtx, paidPayment())
+				s.mockGateway.EXPECT().
+					CreatePendingRefund(managerCtx, gomock.Any(), valueobject.NewMoney(0)).
+					Return(false, assert.AnError)
+			},
+			checkResult: func(t *testing.T, payment *entity.Payment, err error) {
+				assert.Nil(t, payment)
+				assert.IsType(t, &domain.InternalError{}, err)
+			},
+		},
+		{
+			name:  "should leave the refund pending when RefundExternal from gateway fails",
+			ctx:   managerCtx,
+			input: dto.CreateRefundInput{OrderID: 1, Reason: "Customer complaint", StaffID: 1},
+			setupMocks: func() {
+				expectPaidPayment(managerCtx, paidPayment())
+				s.mockGateway.EXPECT().
+					CreatePendingRefund(managerCtx, gomock.Any(), valueobject.NewMoney(0)).
+					Return(true, nil)
+				s.mockGateway.EXPECT().RefundExternal(managerCtx, gomock.Any()).Return(nil, assert.AnError)
+			},
+			checkResult: func(t *testing.T, payment *entity.Payment, err error) {
+				assert.Nil(t, payment)
+				assert.IsType(t, &domain.InternalError{}, err)
+			},
+		},
+		{
+			name:  "should return error when CompleteRefund from gateway fails",
+			ctx:   managerCtx,
+			input: dto.CreateRefundInput{OrderID: 1, Reason: "Customer complaint", StaffID: 1},
+			setupMocks: func() {
+				expectPaidPayment(managerCtx, paidPayment())
+				s.mockGateway.EXPECT().
+					CreatePendingRefund(managerCtx, gomock.Any(), valueobject.NewMoney(0)).
+					Return(true, nil)
+				s.mockGateway.EXPECT().
+					RefundExternal(managerCtx, gomock.Any()).
+					Return(&entity.RefundPaymentExternalOutput{ExternalRefundID: "refund-1"}, nil)
+				s.mockGateway.EXPECT().
+					CompleteRefund(managerCtx, gomock.Any(), valueobject.REFUNDED).
+					Return(assert.AnError)
+			},
+			checkResult: func(t *testing.T, payment *entity.Payment, err error) {
+				assert.Nil(t, payment)
+				assert.IsType(t, &domain.InternalError{}, err)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.T().Run(tt.name, func(t *testing.T) {
+			tt.setupMocks()
+
+			payment, err := s.useCase.Create(tt.ctx, tt.input)
+
+			tt.checkResult(t, payment, err)
+		})
+	}
+}
+
+func (s *PaymentRefundUsecaseSuiteTest) Test_paymentRefundUseCase_RefundOrder() {
+	cookCtx := entity.ContextWithPrincipal(s.ctx, &entity.Principal{SubjectType: valueobject.STAFF, ID: 3, Role: valueobject.COOK})
+	partiallyRefunded := func() *entity.Payment {
+		return &entity.Payment{ID: 1, OrderID: 1, Amount: valueobject.NewMoney(3000), RefundedAmount: valueobject.NewMoney(1000), Status: valueobject.PARTIALLY_REFUNDED}
+	}
+	pendingRefund := func(staffID *uint64) *entity.PaymentRefund {
+		return &entity.PaymentRefund{PaymentID: 1, Status: valueobject.REFUND_PENDING, IdempotencyKey: "refund-1-10.00-20.00", Amount: valueobject.NewMoney(2000), Reason: "order cancelled", StaffID: staffID}
+	}
+
+	tests := []struct {
+		name          string
+		ctx           context.Context
+		input         dto.CreateRefundInput
+		setupMocks    func()
+		checkResult   func(*testing.T, valueobject.PaymentStatus, error)
+		wantRefund    bool
+		wantCommitErr bool
+	}{
+		{
+			name:  "should record the refund of what is left of a paid order and give it back after the commit",
+			ctx:   cookCtx,
+			input: dto.CreateRefundInput{OrderID: 1, Amount: valueobject.NewMoney(500), Reason: "order cancelled", StaffID: 3},
+			setupMocks: func() {
+				gomock.InOrder(
+					s.mockGateway.EXPECT().
+						FindByOrderIDAndStatus(cookCtx, uint64(1), valueobject.CONFIRMED, valueobject.PARTIALLY_REFUNDED).
+						Return(partiallyRefunded(), nil),
+					s.mockGateway.EXPECT().
+						CreatePendingRefund(cookCtx, pendingRefund(util.Ptr(uint64(3))), valueobject.NewMoney(1000)).
+						Return(true, nil),
+					s.mockGateway.EXPECT().
+						RefundExternal(cookCtx, &entity.RefundPaymentExternalInput{ExternalReference: "1", Amount: valueobject.NewMoney(2000), IdempotencyKey: "refund-1-10.00-20.00"}).
+						Return(&entity.RefundPaymentExternalOutput{ExternalRefundID: "refund-2"}, nil),
+					s.mockGateway.EXPECT().
+						CompleteRefund(cookCtx, gomock.Cond(func(r *entity.PaymentRefund) bool { return r.ExternalRefundID == "refund-2" }), valueobject.REFUNDED).
+						Return(nil),
+				)
+			},
+			checkResult: func(t *testing.T, status valueobject.PaymentStatus, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, valueobject.REFUNDED, status)
+			},
+			wantRefund: true,
+		},
+		{
+			name:  "should refund on behalf of the automatic process",
+			ctx:   s.ctx,
+			input: dto.CreateRefundInput{OrderID: 1, Reason: "order cancelled"},
+			setupMocks: func() {
+				s.mockGateway.EXPECT().
+					FindByOrderIDAndStatus(s.ctx, uint64(1), valueobject.CONFIRMED, valueobject.PARTIALLY_REFUNDED).
+					Return(partiallyRefunded(), nil)
+				s.mockGateway.EXPECT().
+					CreatePendingRefund(s.ctx, pendingRefund(nil), valueobject.NewMoney(1000)).
+					Return(true, nil)
+				s.mockGateway.EXPECT().
+					RefundExternal(s.ctx, gomock.Any()).
+					Return(&entity.RefundPaymentExternalOutput{ExternalRefundID: "refund-2"}, nil)
+				s.mockGateway.EXPECT().CompleteRefund(s.ctx, gomock.Any(), valueobject.REFUNDED).Return(nil)
+			},
+			checkResult: func(t *testing.T, status valueobject.PaymentStatus, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, valueobject.REFUNDED, status)
+			},
+			wantRefund: true,
+		},
+		{
+			name:  "should keep the refund pending when the provider fails after the commit",
+			ctx:   s.ctx,
+			input: dto.CreateRefundInput{OrderID: 1, Reason: "order cancelled"},
+			setupMocks: func() {
+				s.mockGateway.EXPECT().
+					FindByOrderIDAndStatus(s.ctx, uint64(1), valueobject.CONFIRMED, valueobject.PARTIALLY_REFUNDED).
+					Return(partiallyRefunded(), nil)
+				s.mockGateway.EXPECT().
+					CreatePendingRefund(s.ctx, pendingRefund(nil), valueobject.NewMoney(1000)).
+					Return(true, nil)
+				s.mockGateway.EXPECT().
+					RefundExternal(s.ctx, gomock.Any()).
+					Return(nil, assert.AnError)
+			},
+			checkResult: func(t *testing.T, status valueobject.PaymentStatus, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, valueobject.REFUNDED, status)
+			},
+			wantRefund:    true,
+			wantCommitErr: true,
+		},
+		{
+			name:  "should do nothing when the order was not paid",
+			ctx:   s.ctx,
+			input: dto.CreateRefundInput{OrderID: 1, Reason: "order cancelled"},
+			setupMocks: func() {
+				s.mockGateway.EXPECT().
+					FindByOrderIDAndStatus(s.ctx, uint64(1), valueobject.CONFIRMED, valueobject.PARTIALLY_REFUNDED).
+					Return(nil, nil)
+			},
+			checkResult: func(t *testing.T, status valueobject.PaymentStatus, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, valueobject.UNDEFINDED_P, status)
+			},
+		},
+		{
+			name:  "should return conflict error when a refund of the payment is in progress",
+			ctx:   s.ctx,
+			input: dto.CreateRefundInput{OrderID: 1, Reason: "order cancelled"},
+			setupMocks: func() {
+				s.mockGateway.EXPECT().
+					FindByOrderIDAndStatus(s.ctx, uint64(1), valueobject.CONFIRMED, valueobject.PARTIALLY_REFUNDED).
+					Return(partiallyRefunded(), nil)
+				s.mockGateway.EXPECT().
+					CreatePendingRefund(s.ctx, gomock.Any(), valueobject.NewMoney(1000)).
+					Return(false, nil)
+			},
+			checkResult: func(t *testing.T, status valueobject.PaymentStatus, err error) {
+				assert.Equal(t, valueobject.UNDEFINDED_P, status)
+				assert.IsType(t, &domain.ConflictError{}, err)
+			},
+		},
+		{
+			name:  "should return error when FindByOrderIDAndStatus from gateway fails",
+			ctx:   s.ctx,
+			input: dto.CreateRefundInput{OrderID: 1, Reason: "order cancelled", StaffID: 1},
+			setupMocks: func() {
+				s.mockGateway.EXPECT().
+					FindByOrderIDAndStatus(s.ctx, uint64(1), valueobject.CONFIRMED, valueobject.PARTIALLY_REFUNDED).
+					Return(nil, assert.AnError)
+			},
+			checkResult: func(t *testing.T, status valueobject.PaymentStatus, err error) {
+				assert.Equal(t, valueobject.UNDEFINDED_P, status)
+				assert.IsType(t, &domain.InternalError{}, err)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.T().Run(tt.name, func(t *testing.T) {
+			s.afterCommit = nil
+			tt.setupMocks()
+
+			status, err := s.useCase.RefundOrder(tt.ctx, tt.input)
+
+			tt.checkResult(t, status, err)
+
+			// The provider is only called once the cancellation commits
+			if tt.wantRefund {
+				assert.Len(t, s.afterCommit, 1)
+			} else {
+				assert.Empty(t, s.afterCommit)
+			}
+			for _, hook := range s.afterCommit {
+				commitErr := hook(tt.ctx)
+				assert.Equal(t, tt.wantCommitErr, commitErr != nil)
+			}
+		})
+	}
+}
@@ -2,7 +2,11 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"strconv"
+	"time"
+
+	"github.com/google/uuid"
 
 	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain"
 	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
@@ -12,119 +16,494 @@ import (
 )
 
 type paymentUseCase struct {
-	paymentGateway port.PaymentGateway
-	orderUseCase   port.OrderUseCase
+	paymentGateway      port.PaymentGateway
+	notificationGateway port.PaymentNotificationGateway
+	orderUseCase        port.OrderUseCase
+	stateMachine        *entity.OrderStateMachine
+	unitOfWork          port.UnitOfWork
+	maxAttempts         int
+	expiration          time.Duration
 }
 
-// NewPaymentUseCase create a new payment use case
+// expiredPaymentsBatchSize limits the payments aborted by each ExpirePayments call
+const expiredPaymentsBatchSize = 100
+
+// NewPaymentUseCase create a new payment use case. The order is cancelled after maxAttempts failed or
+// aborted payments, zero means no limit. A payment not settled after expiration is aborted, zero means
+// it never expires.
 func NewPaymentUseCase(
 	paymentGateway port.PaymentGateway,
+	notificationGateway port.PaymentNotificationGateway,
 	orderUseCase port.OrderUseCase,
+	stateMachine *entity.OrderStateMachine,
+	unitOfWork port.UnitOfWork,
+	maxAttempts int,
+	expiration time.Duration,
 ) port.PaymentUseCase {
-	return &paymentUseCase{paymentGateway, orderUseCase}
+	return &paymentUseCase{paymentGateway, notificationGateway, orderUseCase, stateMachine, unitOfWork, maxAttempts, expiration}
 }
 
-// Create create a new payment
+// Create create a new payment. The order is checked and moved to PENDING while locked before the payment provider is
+// called, so its products can no longer change and a cancelled or paid order is never charged again. The provider is
+// not part of the transaction: the payment is saved afterwards, once the order is locked again and still PENDING.
 func (uc *paymentUseCase) Create(ctx context.Context, i dto.CreatePaymentInput) (*entity.Payment, error) {
-	existentPedingPayment, err := uc.paymentGateway.FindByOrderIDAndStatusProcessing(ctx, i.OrderID)
+	now := time.Now()
+	order, reusable, started, err := uc.startCheckout(ctx, i, now)
 	if err != nil {
-		return nil, domain.NewInternalError(err)
+		return nil, err
 	}
 
-	if existentPedingPayment.ID != 0 {
-		return existentPedingPayment, nil
+	if reusable != nil {
+		return reusable, nil
 	}
 
-	order, err := uc.orderUseCase.Get(ctx, dto.GetOrderInput{ID: i.OrderID})
-	if err != nil {
-		return nil, domain.NewNotFoundError(domain.ErrOrderIsMandatory)
-	}
+	paymentPayload := uc.createPaymentPayload(order)
+	paymentPayload.Provider = i.Provider
 
-	if len(order.OrderProducts) == 0 {
-		return nil, domain.NewNotFoundError(domain.ErrOrderWithoutProducts)
+	var expiresAt *time.Time
+	if uc.expiration > 0 {
+		expiration := now.Add(uc.expiration)
+		expiresAt = &expiration
+		paymentPayload.ExpirationDate = expiresAt
 	}
 
-	paymentPayload := uc.createPaymentPayload(order)
-
 	extPayment, err := uc.paymentGateway.CreateExternal(ctx, paymentPayload)
 	if err != nil {
+		// The checkout begun here is undone, so the customer may change the order again
+		if started {
+			if reopenErr := uc.reopenOrderAfterProviderFailure(ctx, i.OrderID); reopenErr != nil {
+				err = errors.Join(err, reopenErr)
+			}
+		}
 		return nil, domain.NewInternalError(err)
 	}
 
 	iPayment := &entity.Payment{
 		ExternalPaymentID: extPayment.InStoreOrderID,
 		OrderID:           i.OrderID,
+		Provider:          extPayment.Provider,
 		QrData:            extPayment.QrData,
+		Amount:            order.TotalAmount(),
 		Status:            valueobject.PROCESSING,
+		ExpiresAt:         expiresAt,
 	}
 
-	payment, err := uc.paymentGateway.Create(ctx, iPayment)
-	if err != nil {
-		return nil, domain.NewInternalError(err)
-	}
+	var payment *entity.Payment
+	err = uc.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		locked, err := uc.orderUseCase.Get(ctx, dto.GetOrderInput{ID: i.OrderID, ForUpdate: true})
+		if err != nil {
+			return err
+		}
 
-	orderInput := dto.UpdateOrderInput{
-		ID:         order.ID,
-		Status:     valueobject.PENDING,
-		CustomerID: order.CustomerID,
-	}
+		// Cancelled or paid during the provider call: the QR code just issued is left to expire
+		if locked.Status != valueobject.PENDING {
+			return domain.NewConflictError(domain.ErrConcurrentUpdate)
+		}
 
-	if _, err := uc.orderUseCase.Update(ctx, orderInput); err != nil {
-		return nil, domain.NewInternalError(err)
+		pending, err := uc.paymentGateway.FindByOrderIDAndStatusProcessing(ctx, i.OrderID)
+		if err != nil {
+			return domain.NewInternalError(err)
+		}
+
+		if pending.ID != 0 {
+			// The QR code can no longer be paid, or the customer chose another provider: it is replaced by a new one
+			if _, err := uc.paymentGateway.UpdateIfProcessing(ctx, pending.ID, valueobject.ABORTED); err != nil {
+				return domain.NewInternalError(err)
+			}
+		}
+
+		if payment, err = uc.paymentGateway.Create(ctx, iPayment); err != nil {
+			return domain.NewInternalError(err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return payment, nil
 }
 
+// startCheckout locks the order and checks it may be paid: it returns the pending payment when it can still be
+// used, otherwise the order, moved to PENDING when it was OPEN, in which case started is true
+func (uc *paymentUseCase) startCheckout(ctx context.Context, i dto.CreatePaymentInput, now time.Time) (order *entity.Order, reusable *entity.Payment, started bool, err error) {
+	err = uc.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		var err error
+		order, err = uc.orderUseCase.Get(ctx, dto.GetOrderInput{ID: i.OrderID, ForUpdate: true})
+		if err != nil {
+			var forbiddenErr *domain.ForbiddenError
+			if errors.As(err, &forbiddenErr) {
+				return err
+			}
+			return domain.NewNotFoundError(domain.ErrOrderIsMandatory)
+		}
+
+		pending, err := uc.paymentGateway.FindByOrderIDAndStatusProcessing(ctx, i.OrderID)
+		if err != nil {
+			return domain.NewInternalError(err)
+		}
+
+		if pending.ID != 0 {
+			sameProvider := i.Provider == valueobject.UNDEFINED_PROVIDER || i.Provider == pending.Provider
+			if sameProvider && !pending.IsExpired(now) {
+				reusable = pending
+				return nil
+			}
+		}
+
+		// PENDING is a checkout already begun, whose payment expired or whose provider is being changed
+		if order.Status != valueobject.OPEN && order.Status != valueobject.PENDING {
+			return domain.NewInvalidInputError(domain.ErrOrderIsNotOpen)
+		}
+
+		if len(order.OrderProducts) == 0 {
+			return domain.NewNotFoundError(domain.ErrOrderWithoutProducts)
+		}
+
+		if order.Status == valueobject.PENDING {
+			return nil
+		}
+
+		if _, err := uc.orderUseCase.Update(ctx, dto.UpdateOrderInput{ID: order.ID, Status: valueobject.PENDING}); err != nil {
+			return err
+		}
+		started = true
+
+		return nil
+	})
+
+	return order, reusable, started, err
+}
+
+// reopenOrderAfterProviderFailure moves the order back to OPEN after the payment provider failed, unless it was moved
+// on meanwhile or another checkout got a payment for it
+func (uc *paymentUseCase) reopenOrderAfterProviderFailure(ctx context.Context, orderID uint64) error {
+	return uc.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		order, err := uc.orderUseCase.Get(ctx, dto.GetOrderInput{ID: orderID, ForUpdate: true})
+		if err != nil {
+			return err
+		}
+
+		if order.Status != valueobject.PENDING {
+			return nil
+		}
+
+		pending, err := uc.paymentGateway.FindByOrderIDAndStatusProcessing(ctx, orderID)
+		if err != nil {
+			return err
+		}
+
+		if pending.ID != 0 {
+			return nil
+		}
+
+		_, err = uc.orderUseCase.Update(ctx, dto.UpdateOrderInput{ID: orderID, Status: valueobject.OPEN})
+		return err
+	})
+}
+
+// Update applies the outcome notified by the payment provider (webhook). Every notification is kept in an inbox
+// keyed by its event ID, so a redelivered event is skipped. A confirmed payment moves the order to RECEIVED, a
+// failed or aborted one moves it back to OPEN, or to CANCELLED once the attempts run out. Events arriving out of
+// order follow the precedence of valueobject.PaymentStatus.Overrides.
 func (uc *paymentUseCase) Update(ctx context.Context, p dto.UpdatePaymentInput) (*entity.Payment, error) {
-	if err := uc.paymentGateway.Update(ctx, valueobject.CONFIRMED, p.Resource); err != nil {
+	notification, err := uc.receiveNotification(ctx, p)
+	if err != nil {
 		return nil, err
 	}
 
-	paymentOUT, err := uc.paymentGateway.FindByExternalPaymentID(ctx, p.Resource)
+	payment, err := uc.paymentGateway.FindByExternalPaymentID(ctx, p.Resource)
+	if err != nil {
+		return nil, domain.NewNotFoundError(domain.ErrNotFound)
+	}
+
+	// Already processed, the provider is retrying. Checked again under lock below, this only spares the provider call
+	if notification.IsProcessed() {
+		return payment, nil
+	}
+
+	status := p.Status
+	if status == valueobject.UNDEFINDED_P {
+		if status, err = uc.paymentGateway.FindExternalStatus(ctx, payment); err != nil {
+			return nil, domain.NewInternalError(err)
+		}
+	}
+
+	// The payment, its order and the notification are saved together, so a failure leaves the notification to be
+	// processed again
+	err = uc.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		// Locked, so a concurrent delivery of the same event waits for this one and then finds it processed
+		locked, err := uc.notificationGateway.FindByEventIDForUpdate(ctx, notification.EventID)
+		if err != nil {
+			return domain.NewInternalError(err)
+		}
+		if locked == nil {
+			return domain.NewInternalError(errors.New("payment notification not found"))
+		}
+		if locked.IsProcessed() {
+			return nil
+		}
+
+		if status.IsSettled() && status.Overrides(payment.Status) {
+			if err := uc.paymentGateway.Update(ctx, status, p.Resource); err != nil {
+				return domain.NewInternalError(err)
+			}
+			payment.Status = status
+
+			if err := uc.updateOrderAfterPayment(ctx, payment, 0); err != nil {
+				return err
+			}
+		}
+
+		if err := uc.notificationGateway.MarkProcessed(ctx, notification.ID, time.Now()); err != nil {
+			return domain.NewInternalError(err)
+		}
+
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	order, err := uc.orderUseCase.Get(ctx, dto.GetOrderInput{ID: paymentOUT.OrderID})
+	return payment, nil
+}
+
+// ConfirmCash confirms the payment of an order once an attendant took the money at the counter, or checked the static
+// PIX transfer was received, the order then moves on as if the payment provider had notified it
+func (uc *paymentUseCase) ConfirmCash(ctx context.Context, i dto.ConfirmCashPaymentInput) (*entity.Payment, error) {
+	if principal, ok := entity.PrincipalFromContext(ctx); ok && !principal.HasAnyRole(valueobject.ATTENDANT, valueobject.MANAGER) {
+		return nil, domain.NewForbiddenError(domain.ErrPermissionDenied)
+	}
+
+	if i.StaffID == 0 {
+		return nil, domain.NewInvalidInputError(domain.ErrStaffIdIsMandatory)
+	}
+
+	payment, err := uc.paymentGateway.FindByOrderIDAndStatusProcessing(ctx, i.OrderID)
+	if err != nil {
+		return nil, domain.NewInternalError(err)
+	}
+
+	if payment.ID == 0 || !payment.Provider.IsConfirmedAtCounter() {
+		return nil, domain.NewInvalidInputError(domain.ErrCashPaymentNotFound)
+	}
+
+	if i.AmountReceived.LessThan(payment.Amount) {
+		return nil, domain.NewInvalidInputError(domain.ErrCashAmountNotEnough)
+	}
+
+	if !i.ChangeGiven.Equal(i.AmountReceived.Sub(payment.Amount)) {
+		return nil, domain.NewInvalidInputError(domain.ErrCashChangeMismatch)
+	}
+
+	receipt := &entity.CashReceipt{
+		PaymentID:      payment.ID,
+		AmountReceived: i.AmountReceived,
+		ChangeGiven:    i.ChangeGiven,
+		StaffID:        i.StaffID,
+	}
+
+	err = uc.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		confirmed, err := uc.paymentGateway.ConfirmCash(ctx, receipt)
+		if err != nil {
+			return domain.NewInternalError(err)
+		}
+
+		// Aborted meanwhile (ex: expired or replaced by another checkout)
+		if !confirmed {
+			return domain.NewInvalidInputError(domain.ErrCashPaymentNotFound)
+		}
+		payment.Status = valueobject.CONFIRMED
+
+		return uc.updateOrderAfterPayment(ctx, payment, i.StaffID)
+	})
 	if err != nil {
 		return nil, err
 	}
 
+	return payment, nil
+}
+
+// ExpirePayments aborts the PROCESSING payments whose QR code expired and moves their orders back to OPEN, so the
+// customer may pay again. It returns how many payments were aborted.
+func (uc *paymentUseCase) ExpirePayments(ctx context.Context) (int, error) {
+	payments, err := uc.paymentGateway.FindExpiredProcessing(ctx, time.Now(), expiredPaymentsBatchSize)
+	if err != nil {
+		return 0, domain.NewInternalError(err)
+	}
+
+	var expired int
+	var errs []error
+	for _, payment := range payments {
+		var aborted bool
+		err := uc.unitOfWork.Do(ctx, func(ctx context.Context) error {
+			var err error
+			if aborted, err = uc.paymentGateway.UpdateIfProcessing(ctx, payment.ID, valueobject.ABORTED); err != nil {
+				return err
+			}
+
+			// Settled by a notification meanwhile
+			if !aborted {
+				return nil
+			}
+
+			return uc.reopenOrderAfterExpiry(ctx, payment.OrderID)
+		})
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		if aborted {
+			expired++
+		}
+	}
+
+	if len(errs) > 0 {
+		return expired, domain.NewInternalError(errors.Join(errs...))
+	}
+
+	return expired, nil
+}
+
+// reopenOrderAfterExpiry moves the order back to OPEN, unless it was moved on meanwhile (ex: cancelled)
+func (uc *paymentUseCase) reopenOrderAfterExpiry(ctx context.Context, orderID uint64) error {
+	order, err := uc.orderUseCase.Get(ctx, dto.GetOrderInput{ID: orderID})
+	if err != nil {
+		return err
+	}
+
+	if !uc.stateMachine.CanTransition(order.Status, valueobject.OPEN) {
+		return nil
+	}
+
 	orderInput := dto.UpdateOrderInput{
-		ID:         order.ID,
-		Status:     valueobject.RECEIVED,
-		CustomerID: order.CustomerID,
+		ID:            order.ID,
+		Status:        valueobject.OPEN,
+		PaymentStatus: valueobject.ABORTED,
 	}
 
 	if _, err := uc.orderUseCase.Update(ctx, orderInput); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// receiveNotification stores the notification in the inbox, or returns the one stored by a previous delivery
+func (uc *paymentUseCase) receiveNotification(ctx context.Context, p dto.UpdatePaymentInput) (*entity.PaymentNotification, error) {
+	notification := &entity.PaymentNotification{
+		EventID:  p.EventID,
+		Resource: p.Resource,
+		Topic:    p.Topic,
+		Status:   p.Status,
+	}
+
+	// Without an event ID the notification cannot be deduplicated
+	if notification.EventID == "" {
+		notification.EventID = uuid.NewString()
+	}
+
+	created, err := uc.notificationGateway.Create(ctx, notification)
+	if err != nil {
 		return nil, domain.NewInternalError(err)
 	}
 
-	return paymentOUT, nil
+	if created {
+		return notification, nil
+	}
+
+	existing, err := uc.notificationGateway.FindByEventID(ctx, notification.EventID)
+	if err != nil {
+		return nil, domain.NewInternalError(err)
+	}
+
+	if existing == nil {
+		return nil, domain.NewInternalError(errors.New("payment notification not found"))
+	}
+
+	return existing, nil
+}
+
+// updateOrderAfterPayment moves the order of a payment that was just settled, on behalf of the staff who settled it
+// when there is one. An order that cannot make the transition anymore (ex: cancelled meanwhile) is left as it is.
+func (uc *paymentUseCase) updateOrderAfterPayment(ctx context.Context, payment *entity.Payment, staffID uint64) error {
+	order, err := uc.orderUseCase.Get(ctx, dto.GetOrderInput{ID: payment.OrderID})
+	if err != nil {
+		return err
+	}
+
+	statuses, err := uc.orderStatusesAfterPayment(ctx, payment, order)
+	if err != nil {
+		return err
+	}
+
+	current := order.Status
+	for _, status := range statuses {
+		if !uc.stateMachine.CanTransition(current, status) {
+			return nil
+		}
+		current = status
+	}
+
+	for _, status := range statuses {
+		orderInput := dto.UpdateOrderInput{
+			ID:            order.ID,
+			Status:        status,
+			PaymentStatus: payment.Status,
+			StaffID:       staffID,
+		}
+
+		if _, err := uc.orderUseCase.Update(ctx, orderInput); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// orderStatusesAfterPayment returns the statuses the order of a settled payment goes through
+func (uc *paymentUseCase) orderStatusesAfterPayment(ctx context.Context, payment *entity.Payment, order *entity.Order) ([]valueobject.OrderStatus, error) {
+	if payment.Status == valueobject.CONFIRMED {
+		// A confirmation notified after a failure finds the order back to OPEN
+		if order.Status == valueobject.OPEN {
+			return []valueobject.OrderStatus{valueobject.PENDING, valueobject.RECEIVED}, nil
+		}
+		return []valueobject.OrderStatus{valueobject.RECEIVED}, nil
+	}
+
+	attempts, err := uc.paymentGateway.CountByOrderIDAndStatus(ctx, payment.OrderID, valueobject.FAILED, valueobject.ABORTED)
+	if err != nil {
+		return nil, domain.NewInternalError(err)
+	}
+
+	if uc.maxAttempts > 0 && attempts >= int64(uc.maxAttempts) {
+		return []valueobject.OrderStatus{valueobject.CANCELLED}, nil
+	}
+
+	return []valueobject.OrderStatus{valueobject.OPEN}, nil
 }
 
 func (uc *paymentUseCase) createPaymentPayload(o *entity.Order) *entity.CreatePaymentExternalInput {
-	var totalAmount float32
 	var items []entity.PaymentExternalItemsInput
 	externalReference := strconv.FormatUint(o.ID, 10)
 
 	for _, v := range o.OrderProducts {
 		items = append(items, entity.PaymentExternalItemsInput{
-			Title:       v.Product.Name,
+			Title:       v.ProductName,
 			Description: v.Product.Description,
-			UnitPrice:   float32(v.Product.Price),
+			UnitPrice:   v.UnitPrice,
 			Category:    "marketplace",
 			UnitMeasure: "unit",
 			Quantity:    uint64(v.Quantity),
-			TotalAmount: float32(v.Product.Price) * float32(v.Quantity),
+			TotalAmount: v.Subtotal(),
 		})
-		totalAmount += float32(v.Product.Price) * float32(v.Quantity)
 	}
 
 	return &entity.CreatePaymentExternalInput{
 		ExternalReference: externalReference,
-		TotalAmount:       totalAmount,
+		TotalAmount:       o.TotalAmount(),
 		Items:             items,
 		Title:             "FIAP Tech Challenge - Product Order",
 		Description:       "Purchases made at the FIAP Tech Challenge store",
@@ -132,6 +511,13 @@ func (uc *paymentUseCase) createPaymentPayload(o *entity.Order) *entity.CreatePa
 }
 
 func (uc *paymentUseCase) Get(ctx context.Context, input dto.GetPaymentInput) (*entity.Payment, error) {
+	// Customers and guests may only see payments of their own orders
+	if isRestrictedCaller(ctx) {
+		if _, err := uc.orderUseCase.Get(ctx, dto.GetOrderInput{ID: input.OrderID}); err != nil {
+			return nil, err
+		}
+	}
+
 	payment, err := uc.paymentGateway.FindByOrderID(ctx, input.OrderID)
 	if err != nil {
 		return nil, domain.NewInternalError(err)
@@ -3,6 +3,7 @@ package usecase_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/port"
 	mockport "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/port/mocks"
@@ -13,18 +14,26 @@ import (
 
 type PaymentUsecaseSuiteTest struct {
 	suite.Suite
-	mockGateway      *mockport.MockPaymentGateway
-	mockOrderUseCase *mockport.MockOrderUseCase
-	useCase          port.PaymentUseCase
-	ctx              context.Context
+	mockGateway             *mockport.MockPaymentGateway
+	mockNotificationGateway *mockport.MockPaymentNotificationGateway
+	mockOrderUseCase        *mockport.MockOrderUseCase
+	mockUnitOfWork          *mockport.MockUnitOfWork
+	useCase                 port.PaymentUseCase
+	ctx                     context.Context
 }
 
 func (s *PaymentUsecaseSuiteTest) SetupTest() {
 	ctrl := gomock.NewController(s.T())
 	defer ctrl.Finish()
 	s.mockGateway = mockport.NewMockPaymentGateway(ctrl)
+	s.mockNotificationGateway = mockport.NewMockPaymentNotificationGateway(ctrl)
 	s.mockOrderUseCase = mockport.NewMockOrderUseCase(ctrl)
-	s.useCase = usecase.NewPaymentUseCase(s.mockGateway, s.mockOrderUseCase)
+	s.mockUnitOfWork = mockport.NewMockUnitOfWork(ctrl)
+	s.mockUnitOfWork.EXPECT().
+		Do(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) }).
+		AnyTimes()
+	s.useCase = usecase.NewPaymentUseCase(s.mockGateway, s.mockNotificationGateway, s.mockOrderUseCase, newOrderStateMachine(s.T()), s.mockUnitOfWork, 3, 15*time.Minute)
 	s.ctx = context.Background()
 }
 
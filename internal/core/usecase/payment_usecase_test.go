@@ -1,16 +1,45 @@
 package usecase_test
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain"
 	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
+	valueobject "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/value_object"
 	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/dto"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 )
 
 func (s *PaymentUsecaseSuiteTest) Test_paymentUseCase_Create() {
+	lockOrder := dto.GetOrderInput{ID: 1, ForUpdate: true}
+	newOrder := func(status valueobject.OrderStatus, orderProducts ...entity.OrderProduct) *entity.Order {
+		if len(orderProducts) == 0 {
+			orderProducts = []entity.OrderProduct{{OrderID: 1, ProductID: 1}}
+		}
+		return &entity.Order{ID: 1, Status: status, OrderProducts: orderProducts}
+	}
+	// expectCheckout expects the open order to be locked and moved to PENDING before the provider is called
+	expectCheckout := func(order *entity.Order) {
+		gomock.InOrder(
+			s.mockOrderUseCase.EXPECT().Get(s.ctx, lockOrder).Return(order, nil),
+			s.mockGateway.EXPECT().FindByOrderIDAndStatusProcessing(s.ctx, uint64(1)).Return(&entity.Payment{}, nil),
+			s.mockOrderUseCase.EXPECT().Update(s.ctx, dto.UpdateOrderInput{ID: 1, Status: valueobject.PENDING}).Return(&entity.Order{ID: 1}, nil),
+		)
+	}
+	// expectSave expects the order to be locked again, still PENDING, when the payment is saved
+	expectSave := func(pending *entity.Payment) {
+		gomock.InOrder(
+			s.mockOrderUseCase.EXPECT().Get(s.ctx, lockOrder).Return(newOrder(valueobject.PENDING), nil),
+			s.mockGateway.EXPECT().FindByOrderIDAndStatusProcessing(s.ctx, uint64(1)).Return(pending, nil),
+		)
+	}
+	returnPayment := func(_ context.Context, p *entity.Payment) (*entity.Payment, error) {
+		return p, nil
+	}
+
 	tests := []struct {
 		name        string
 		input       dto.CreatePaymentInput
@@ -21,11 +50,10 @@ func (s *PaymentUsecaseSuiteTest) Test_paymentUseCase_Create() {
 			name:  "should create payment successfully",
 			input: dto.CreatePaymentInput{OrderID: uint64(1)},
 			setupMocks: func() {
-				s.mockGateway.EXPECT().FindByOrderIDAndStatusProcessing(s.ctx, gomock.Any()).Return(&entity.Payment{}, nil)
-				s.mockOrderUseCase.EXPECT().Get(s.ctx, gomock.Any()).Return(&entity.Order{ID: uint64(1), OrderProducts: []entity.OrderProduct{{OrderID: 1, ProductID: 1}}}, nil)
+				expectCheckout(newOrder(valueobject.OPEN))
 				s.mockGateway.EXPECT().CreateExternal(s.ctx, gomock.Any()).Return(&entity.CreatePaymentExternalOutput{}, nil)
+				expectSave(&entity.Payment{})
 				s.mockGateway.EXPECT().Create(s.ctx, gomock.Any()).Return(&entity.Payment{}, nil)
-				s.mockOrderUseCase.EXPECT().Update(s.ctx, gomock.Any()).Return(&entity.Order{ID: 1}, nil)
 			},
 			checkResult: func(t *testing.T, payment *entity.Payment, err error) {
 				assert.NoError(t, err)
@@ -33,44 +61,189 @@ func (s *PaymentUsecaseSuiteTest) Test_paymentUseCase_Create() {
 			},
 		},
 		{
-			name:  "should return error when update from order use case fails",
+			name:  "should charge exactly the total of the order",
 			input: dto.CreatePaymentInput{OrderID: uint64(1)},
 			setupMocks: func() {
-				s.mockGateway.EXPECT().FindByOrderIDAndStatusProcessing(s.ctx, gomock.Any()).Return(&entity.Payment{}, nil)
-				s.mockOrderUseCase.EXPECT().Get(s.ctx, gomock.Any()).Return(&entity.Order{ID: uint64(1), OrderProducts: []entity.OrderProduct{{OrderID: 1, ProductID: 1}}}, nil)
-				s.mockGateway.EXPECT().CreateExternal(s.ctx, gomock.Any()).Return(&entity.CreatePaymentExternalOutput{}, nil)
-				s.mockGateway.EXPECT().Create(s.ctx, gomock.Any()).Return(&entity.Payment{}, nil)
-				s.mockOrderUseCase.EXPECT().Update(s.ctx, gomock.Any()).Return(nil, &domain.InternalError{})
+				expectCheckout(newOrder(valueobject.OPEN,
+					entity.OrderProduct{OrderID: 1, ProductID: 1, Quantity: 999, UnitPrice: valueobject.NewMoney(1999)},
+					entity.OrderProduct{OrderID: 1, ProductID: 2, Quantity: 3, UnitPrice: valueobject.NewMoney(10)},
+				))
+				s.mockGateway.EXPECT().
+					CreateExternal(s.ctx, gomock.Any()).
+					DoAndReturn(func(_ context.Context, p *entity.CreatePaymentExternalInput) (*entity.CreatePaymentExternalOutput, error) {
+						assert.Equal(s.T(), valueobject.NewMoney(1997031), p.TotalAmount)
+						assert.Equal(s.T(), valueobject.NewMoney(1997001), p.Items[0].TotalAmount)
+						return &entity.CreatePaymentExternalOutput{InStoreOrderID: "1"}, nil
+					})
+				expectSave(&entity.Payment{})
+				s.mockGateway.EXPECT().Create(s.ctx, gomock.Any()).DoAndReturn(returnPayment)
+			},
+			checkResult: func(t *testing.T, payment *entity.Payment, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, "19970.31", payment.Amount.String())
+			},
+		},
+		{
+			name:  "should charge the prices the products had when they were added",
+			input: dto.CreatePaymentInput{OrderID: uint64(1)},
+			setupMocks: func() {
+				expectCheckout(newOrder(valueobject.OPEN,
+					entity.OrderProduct{OrderID: 1, ProductID: 1, Quantity: 2, UnitPrice: valueobject.NewMoney(1500), ProductName: "Burger", Product: entity.Product{Name: "Cheeseburger", Price: valueobject.NewMoney(2000)}},
+				))
+				s.mockGateway.EXPECT().
+					CreateExternal(s.ctx, gomock.Any()).
+					DoAndReturn(func(_ context.Context, p *entity.CreatePaymentExternalInput) (*entity.CreatePaymentExternalOutput, error) {
+						assert.Equal(s.T(), "Burger", p.Items[0].Title)
+						assert.Equal(s.T(), valueobject.NewMoney(1500), p.Items[0].UnitPrice)
+						return &entity.CreatePaymentExternalOutput{InStoreOrderID: "1"}, nil
+					})
+				expectSave(&entity.Payment{})
+				s.mockGateway.EXPECT().Create(s.ctx, gomock.Any()).DoAndReturn(returnPayment)
+			},
+			checkResult: func(t *testing.T, payment *entity.Payment, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, valueobject.NewMoney(3000), payment.Amount)
+			},
+		},
+		{
+			name:  "should return the pending payment while it is not expired",
+			input: dto.CreatePaymentInput{OrderID: uint64(1)},
+			setupMocks: func() {
+				expiresAt := time.Now().Add(time.Minute)
+				s.mockOrderUseCase.EXPECT().Get(s.ctx, lockOrder).Return(newOrder(valueobject.PENDING), nil)
+				s.mockGateway.EXPECT().
+					FindByOrderIDAndStatusProcessing(s.ctx, uint64(1)).
+					Return(&entity.Payment{ID: 1, OrderID: 1, QrData: "qr-1", Status: valueobject.PROCESSING, ExpiresAt: &expiresAt}, nil)
+			},
+			checkResult: func(t *testing.T, payment *entity.Payment, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, "qr-1", payment.QrData)
+			},
+		},
+		{
+			name:  "should abort the expired pending payment and issue a new qr code",
+			input: dto.CreatePaymentInput{OrderID: uint64(1)},
+			setupMocks: func() {
+				expiresAt := time.Now().Add(-time.Minute)
+				expired := &entity.Payment{ID: 1, OrderID: 1, QrData: "qr-1", Status: valueobject.PROCESSING, ExpiresAt: &expiresAt}
+				s.mockOrderUseCase.EXPECT().Get(s.ctx, lockOrder).Return(newOrder(valueobject.PENDING), nil)
+				s.mockGateway.EXPECT().FindByOrderIDAndStatusProcessing(s.ctx, uint64(1)).Return(expired, nil)
+				s.mockGateway.EXPECT().
+					CreateExternal(s.ctx, gomock.Any()).
+					DoAndReturn(func(_ context.Context, p *entity.CreatePaymentExternalInput) (*entity.CreatePaymentExternalOutput, error) {
+						assert.NotNil(s.T(), p.ExpirationDate)
+						return &entity.CreatePaymentExternalOutput{InStoreOrderID: "1", QrData: "qr-2"}, nil
+					})
+				expectSave(expired)
+				s.mockGateway.EXPECT().UpdateIfProcessing(s.ctx, uint64(1), valueobject.ABORTED).Return(true, nil)
+				s.mockGateway.EXPECT().Create(s.ctx, gomock.Any()).DoAndReturn(returnPayment)
+			},
+			checkResult: func(t *testing.T, payment *entity.Payment, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, "qr-2", payment.QrData)
+				assert.NotNil(t, payment.ExpiresAt)
+			},
+		},
+		{
+			name:  "should replace the pending payment when another provider is chosen",
+			input: dto.CreatePaymentInput{OrderID: uint64(1), Provider: valueobject.CASH_PROVIDER},
+			setupMocks: func() {
+				expiresAt := time.Now().Add(time.Minute)
+				pending := &entity.Payment{ID: 1, OrderID: 1, Provider: valueobject.MERCADO_PAGO_PROVIDER, Status: valueobject.PROCESSING, ExpiresAt: &expiresAt}
+				s.mockOrderUseCase.EXPECT().Get(s.ctx, lockOrder).Return(newOrder(valueobject.PENDING), nil)
+				s.mockGateway.EXPECT().FindByOrderIDAndStatusProcessing(s.ctx, uint64(1)).Return(pending, nil)
+				s.mockGateway.EXPECT().
+					CreateExternal(s.ctx, gomock.Any()).
+					DoAndReturn(func(_ context.Context, p *entity.CreatePaymentExternalInput) (*entity.CreatePaymentExternalOutput, error) {
+						assert.Equal(s.T(), valueobject.CASH_PROVIDER, p.Provider)
+						return &entity.CreatePaymentExternalOutput{Provider: valueobject.CASH_PROVIDER, InStoreOrderID: "cash-1"}, nil
+					})
+				expectSave(pending)
+				s.mockGateway.EXPECT().UpdateIfProcessing(s.ctx, uint64(1), valueobject.ABORTED).Return(true, nil)
+				s.mockGateway.EXPECT().Create(s.ctx, gomock.Any()).DoAndReturn(returnPayment)
+			},
+			checkResult: func(t *testing.T, payment *entity.Payment, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, valueobject.CASH_PROVIDER, payment.Provider)
+				assert.Equal(t, "cash-1", payment.ExternalPaymentID)
+			},
+		},
+		{
+			name:  "should refuse an order that is not open before calling the provider",
+			input: dto.CreatePaymentInput{OrderID: uint64(1)},
+			setupMocks: func() {
+				s.mockOrderUseCase.EXPECT().Get(s.ctx, lockOrder).Return(newOrder(valueobject.CANCELLED), nil)
+				s.mockGateway.EXPECT().FindByOrderIDAndStatusProcessing(s.ctx, uint64(1)).Return(&entity.Payment{}, nil)
 			},
 			checkResult: func(t *testing.T, payment *entity.Payment, err error) {
-				assert.Error(t, err)
 				assert.Nil(t, payment)
-				assert.IsType(t, &domain.InternalError{}, err)
+				assert.IsType(t, &domain.InvalidInputError{}, err)
+				assert.EqualError(t, err, domain.ErrOrderIsNotOpen)
 			},
 		},
 		{
-			name:  "should return error when create from gateway fails",
+			name:  "should return conflict when the order is cancelled during the provider call",
 			input: dto.CreatePaymentInput{OrderID: uint64(1)},
 			setupMocks: func() {
-				s.mockGateway.EXPECT().FindByOrderIDAndStatusProcessing(s.ctx, gomock.Any()).Return(&entity.Payment{}, nil)
-				s.mockOrderUseCase.EXPECT().Get(s.ctx, gomock.Any()).Return(&entity.Order{ID: uint64(1), OrderProducts: []entity.OrderProduct{{OrderID: 1, ProductID: 1}}}, nil)
+				expectCheckout(newOrder(valueobject.OPEN))
 				s.mockGateway.EXPECT().CreateExternal(s.ctx, gomock.Any()).Return(&entity.CreatePaymentExternalOutput{}, nil)
-				s.mockGateway.EXPECT().Create(s.ctx, gomock.Any()).Return(&entity.Payment{}, &domain.InternalError{})
+				s.mockOrderUseCase.EXPECT().Get(s.ctx, lockOrder).Return(newOrder(valueobject.CANCELLED), nil)
+			},
+			checkResult: func(t *testing.T, payment *entity.Payment, err error) {
+				assert.Nil(t, payment)
+				assert.IsType(t, &domain.ConflictError{}, err)
+			},
+		},
+		{
+			name:  "should reopen the order when CreateExternal from gateway fails",
+			input: dto.CreatePaymentInput{OrderID: uint64(1)},
+			setupMocks: func() {
+				expectCheckout(newOrder(valueobject.OPEN))
+				s.mockGateway.EXPECT().CreateExternal(s.ctx, gomock.Any()).Return(&entity.CreatePaymentExternalOutput{}, assert.AnError)
+				expectSave(&entity.Payment{})
+				s.mockOrderUseCase.EXPECT().Update(s.ctx, dto.UpdateOrderInput{ID: 1, Status: valueobject.OPEN}).Return(&entity.Order{ID: 1}, nil)
 			},
 			checkResult: func(t *testing.T, payment *entity.Payment, err error) {
-				assert.Error(t, err)
 				assert.Nil(t, payment)
 				assert.IsType(t, &domain.InternalError{}, err)
 			},
 		},
 		{
-			name:  "should return error when CreateExternal from gateway fails",
+			name:  "should keep the order pending when CreateExternal fails on a checkout already begun",
 			input: dto.CreatePaymentInput{OrderID: uint64(1)},
 			setupMocks: func() {
-				s.mockGateway.EXPECT().FindByOrderIDAndStatusProcessing(s.ctx, gomock.Any()).Return(&entity.Payment{}, nil)
-				s.mockOrderUseCase.EXPECT().Get(s.ctx, gomock.Any()).Return(&entity.Order{ID: uint64(1), OrderProducts: []entity.OrderProduct{{OrderID: 1, ProductID: 1}}}, nil)
+				s.mockOrderUseCase.EXPECT().Get(s.ctx, lockOrder).Return(newOrder(valueobject.PENDING), nil)
+				s.mockGateway.EXPECT().FindByOrderIDAndStatusProcessing(s.ctx, uint64(1)).Return(&entity.Payment{}, nil)
 				s.mockGateway.EXPECT().CreateExternal(s.ctx, gomock.Any()).Return(&entity.CreatePaymentExternalOutput{}, assert.AnError)
 			},
+			checkResult: func(t *testing.T, payment *entity.Payment, err error) {
+				assert.Nil(t, payment)
+				assert.IsType(t, &domain.InternalError{}, err)
+			},
+		},
+		{
+			name:  "should return the error of the order use case when update fails",
+			input: dto.CreatePaymentInput{OrderID: uint64(1)},
+			setupMocks: func() {
+				s.mockOrderUseCase.EXPECT().Get(s.ctx, lockOrder).Return(newOrder(valueobject.OPEN), nil)
+				s.mockGateway.EXPECT().FindByOrderIDAndStatusProcessing(s.ctx, uint64(1)).Return(&entity.Payment{}, nil)
+				s.mockOrderUseCase.EXPECT().Update(s.ctx, gomock.Any()).Return(nil, domain.NewConflictError(domain.ErrConcurrentUpdate))
+			},
+			checkResult: func(t *testing.T, payment *entity.Payment, err error) {
+				assert.Error(t, err)
+				assert.Nil(t, payment)
+				assert.IsType(t, &domain.ConflictError{}, err)
+			},
+		},
+		{
+			name:  "should return error when create from gateway fails",
+			input: dto.CreatePaymentInput{OrderID: uint64(1)},
+			setupMocks: func() {
+				expectCheckout(newOrder(valueobject.OPEN))
+				s.mockGateway.EXPECT().CreateExternal(s.ctx, gomock.Any()).Return(&entity.CreatePaymentExternalOutput{}, nil)
+				expectSave(&entity.Payment{})
+				s.mockGateway.EXPECT().Create(s.ctx, gomock.Any()).Return(&entity.Payment{}, &domain.InternalError{})
+			},
 			checkResult: func(t *testing.T, payment *entity.Payment, err error) {
 				assert.Error(t, err)
 				assert.Nil(t, payment)
@@ -81,8 +254,8 @@ func (s *PaymentUsecaseSuiteTest) Test_paymentUseCase_Create() {
 			name:  "should return error when dont have order product",
 			input: dto.CreatePaymentInput{OrderID: uint64(1)},
 			setupMocks: func() {
-				s.mockGateway.EXPECT().FindByOrderIDAndStatusProcessing(s.ctx, gomock.Any()).Return(&entity.Payment{}, nil)
-				s.mockOrderUseCase.EXPECT().Get(s.ctx, gomock.Any()).Return(&entity.Order{ID: 1}, nil)
+				s.mockOrderUseCase.EXPECT().Get(s.ctx, lockOrder).Return(&entity.Order{ID: 1, Status: valueobject.OPEN}, nil)
+				s.mockGateway.EXPECT().FindByOrderIDAndStatusProcessing(s.ctx, uint64(1)).Return(&entity.Payment{}, nil)
 			},
 			checkResult: func(t *testing.T, payment *entity.Payment, err error) {
 				assert.Error(t, err)
@@ -94,8 +267,7 @@ func (s *PaymentUsecaseSuiteTest) Test_paymentUseCase_Create() {
 			name:  "should return error when get from order use case fails",
 			input: dto.CreatePaymentInput{OrderID: uint64(1)},
 			setupMocks: func() {
-				s.mockGateway.EXPECT().FindByOrderIDAndStatusProcessing(s.ctx, gomock.Any()).Return(&entity.Payment{}, nil)
-				s.mockOrderUseCase.EXPECT().Get(s.ctx, gomock.Any()).Return(&entity.Order{}, assert.AnError)
+				s.mockOrderUseCase.EXPECT().Get(s.ctx, lockOrder).Return(&entity.Order{}, assert.AnError)
 			},
 			checkResult: func(t *testing.T, payment *entity.Payment, err error) {
 				assert.Error(t, err)
@@ -103,11 +275,24 @@ func (s *PaymentUsecaseSuiteTest) Test_paymentUseCase_Create() {
 				assert.IsType(t, &domain.NotFoundError{}, err)
 			},
 		},
+		{
+			name:  "should return forbidden when order belongs to another customer",
+			input: dto.CreatePaymentInput{OrderID: uint64(1)},
+			setupMocks: func() {
+				s.mockOrderUseCase.EXPECT().Get(s.ctx, lockOrder).Return(nil, domain.NewForbiddenError(domain.ErrPermissionDenied))
+			},
+			checkResult: func(t *testing.T, payment *entity.Payment, err error) {
+				assert.Error(t, err)
+				assert.Nil(t, payment)
+				assert.IsType(t, &domain.ForbiddenError{}, err)
+			},
+		},
 		{
 			name:  "should return error when FindByOrderIDAndStatusProcessing from gateway fails",
 			input: dto.CreatePaymentInput{OrderID: uint64(1)},
 			setupMocks: func() {
-				s.mockGateway.EXPECT().FindByOrderIDAndStatusProcessing(s.ctx, gomock.Any()).Return(&entity.Payment{}, assert.AnError)
+				s.mockOrderUseCase.EXPECT().Get(s.ctx, lockOrder).Return(newOrder(valueobject.OPEN), nil)
+				s.mockGateway.EXPECT().FindByOrderIDAndStatusProcessing(s.ctx, uint64(1)).Return(&entity.Payment{}, assert.AnError)
 			},
 			checkResult: func(t *testing.T, payment *entity.Payment, err error) {
 				assert.Error(t, err)
@@ -119,7 +304,8 @@ func (s *PaymentUsecaseSuiteTest) Test_paymentUseCase_Create() {
 			name:  "should return the existing payment when already exists",
 			input: dto.CreatePaymentInput{OrderID: uint64(1)},
 			setupMocks: func() {
-				s.mockGateway.EXPECT().FindByOrderIDAndStatusProcessing(s.ctx, gomock.Any()).Return(&entity.Payment{ID: 1}, nil)
+				s.mockOrderUseCase.EXPECT().Get(s.ctx, lockOrder).Return(newOrder(valueobject.PENDING), nil)
+				s.mockGateway.EXPECT().FindByOrderIDAndStatusProcessing(s.ctx, uint64(1)).Return(&entity.Payment{ID: 1}, nil)
 			},
 			checkResult: func(t *testing.T, payment *entity.Payment, err error) {
 				assert.NoError(t, err)
@@ -186,6 +372,27 @@ func (s *PaymentUsecaseSuiteTest) Test_paymentUseCase_Get() {
 }
 
 func (s *PaymentUsecaseSuiteTest) Test_paymentUseCase_Update() {
+	resource := "389d873a-436b-4ef2-a47a-0abf9b3e9924"
+	processingPayment := func() *entity.Payment {
+		return &entity.Payment{ID: 1, OrderID: 1, ExternalPaymentID: resource, Status: valueobject.PROCESSING}
+	}
+	expectNewNotification := func() {
+		s.mockNotificationGateway.EXPECT().
+			Create(s.ctx, gomock.Any()).
+			DoAndReturn(func(_ context.Context, n *entity.PaymentNotification) (bool, error) {
+				n.ID = 10
+				return true, nil
+			})
+	}
+	expectLocked := func(eventID string) {
+		s.mockNotificationGateway.EXPECT().
+			FindByEventIDForUpdate(s.ctx, eventID).
+			Return(&entity.PaymentNotification{ID: 10, EventID: eventID}, nil)
+	}
+	expectProcessed := func() {
+		s.mockNotificationGateway.EXPECT().MarkProcessed(s.ctx, uint64(10), gomock.Any()).Return(nil)
+	}
+
 	tests := []struct {
 		name        string
 		input       dto.UpdatePaymentInput
@@ -193,16 +400,143 @@ func (s *PaymentUsecaseSuiteTest) Test_paymentUseCase_Update() {
 		checkResult func(*testing.T, *entity.Payment, error)
 	}{
 		{
-			name: "should update the payment",
+			name: "should confirm the payment and move the order to received",
+			input: dto.UpdatePaymentInput{
+				EventID:  "event-1",
+				Resource: resource,
+				Topic:    "payment",
+				Status:   valueobject.CONFIRMED,
+			},
+			setupMocks: func() {
+				expectNewNotification()
+				s.mockGateway.EXPECT().FindByExternalPaymentID(s.ctx, resource).Return(processingPayment(), nil)
+				expectLocked("event-1")
+				s.mockGateway.EXPECT().Update(s.ctx, valueobject.CONFIRMED, resource).Return(nil)
+				s.mockOrderUseCase.EXPECT().Get(s.ctx, dto.GetOrderInput{ID: 1}).Return(&entity.Order{ID: 1, Status: valueobject.PENDING}, nil)
+				s.mockOrderUseCase.EXPECT().
+					Update(s.ctx, dto.UpdateOrderInput{ID: 1, Status: valueobject.RECEIVED, PaymentStatus: valueobject.CONFIRMED}).
+					Return(&entity.Order{ID: 1}, nil)
+				expectProcessed()
+			},
+			checkResult: func(t *testing.T, payment *entity.Payment, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, valueobject.CONFIRMED, payment.Status)
+			},
+		},
+		{
+			name: "should move the order back to open when the payment fails",
 			input: dto.UpdatePaymentInput{
-				Resource: "389d873a-436b-4ef2-a47a-0abf9b3e9924",
+				EventID:  "event-1",
+				Resource: resource,
 				Topic:    "payment",
+				Status:   valueobject.FAILED,
 			},
 			setupMocks: func() {
-				s.mockGateway.EXPECT().Update(s.ctx, gomock.Any(), gomock.Any()).Return(nil)
-				s.mockGateway.EXPECT().FindByExternalPaymentID(s.ctx, gomock.Any()).Return(&entity.Payment{ID: 1}, nil)
-				s.mockOrderUseCase.EXPECT().Get(s.ctx, gomock.Any()).Return(&entity.Order{ID: 1}, nil)
+				expectNewNotification()
+				s.mockGateway.EXPECT().FindByExternalPaymentID(s.ctx, resource).Return(processingPayment(), nil)
+				expectLocked("event-1")
+				s.mockGateway.EXPECT().Update(s.ctx, valueobject.FAILED, resource).Return(nil)
+				s.mockOrderUseCase.EXPECT().Get(s.ctx, dto.GetOrderInput{ID: 1}).Return(&entity.Order{ID: 1, Status: valueobject.PENDING}, nil)
+				s.mockGateway.EXPECT().CountByOrderIDAndStatus(s.ctx, uint64(1), valueobject.FAILED, valueobject.ABORTED).Return(int64(1), nil)
+				s.mockOrderUseCase.EXPECT().
+					Update(s.ctx, dto.UpdateOrderInput{ID: 1, Status: valueobject.OPEN, PaymentStatus: valueobject.FAILED}).
+					Return(&entity.Order{ID: 1}, nil)
+				expectProcessed()
+			},
+			checkResult: func(t *testing.T, payment *entity.Payment, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, valueobject.FAILED, payment.Status)
+			},
+		},
+		{
+			name: "should cancel the order when the attempts run out",
+			input: dto.UpdatePaymentInput{
+				EventID:  "event-1",
+				Resource: resource,
+				Topic:    "payment",
+				Status:   valueobject.ABORTED,
+			},
+			setupMocks: func() {
+				expectNewNotification()
+				s.mockGateway.EXPECT().FindByExternalPaymentID(s.ctx, resource).Return(processingPayment(), nil)
+				expectLocked("event-1")
+				s.mockGateway.EXPECT().Update(s.ctx, valueobject.ABORTED, resource).Return(nil)
+				s.mockOrderUseCase.EXPECT().Get(s.ctx, dto.GetOrderInput{ID: 1}).Return(&entity.Order{ID: 1, Status: valueobject.PENDING}, nil)
+				s.mockGateway.EXPECT().CountByOrderIDAndStatus(s.ctx, uint64(1), valueobject.FAILED, valueobject.ABORTED).Return(int64(3), nil)
+				s.mockOrderUseCase.EXPECT().
+					Update(s.ctx, dto.UpdateOrderInput{ID: 1, Status: valueobject.CANCELLED, PaymentStatus: valueobject.ABORTED}).
+					Return(&entity.Order{ID: 1}, nil)
+				expectProcessed()
+			},
+			checkResult: func(t *testing.T, payment *entity.Payment, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, valueobject.ABORTED, payment.Status)
+			},
+		},
+		{
+			name: "should fetch the status from the provider when it is not notified",
+			input: dto.UpdatePaymentInput{
+				EventID:  "event-1",
+				Resource: resource,
+				Topic:    "payment",
+			},
+			setupMocks: func() {
+				payment := processingPayment()
+				expectNewNotification()
+				s.mockGateway.EXPECT().FindByExternalPaymentID(s.ctx, resource).Return(payment, nil)
+				expectLocked("event-1")
+				s.mockGateway.EXPECT().FindExternalStatus(s.ctx, payment).Return(valueobject.CONFIRMED, nil)
+				s.mockGateway.EXPECT().Update(s.ctx, valueobject.CONFIRMED, resource).Return(nil)
+				s.mockOrderUseCase.EXPECT().Get(s.ctx, dto.GetOrderInput{ID: 1}).Return(&entity.Order{ID: 1, Status: valueobject.PENDING}, nil)
 				s.mockOrderUseCase.EXPECT().Update(s.ctx, gomock.Any()).Return(&entity.Order{ID: 1}, nil)
+				expectProcessed()
+			},
+			checkResult: func(t *testing.T, payment *entity.Payment, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, valueobject.CONFIRMED, payment.Status)
+			},
+		},
+		{
+			name: "should skip a redelivered event",
+			input: dto.UpdatePaymentInput{
+				EventID:  "event-1",
+				Resource: resource,
+				Topic:    "payment",
+				Status:   valueobject.CONFIRMED,
+			},
+			setupMocks: func() {
+				processedAt := time.Now()
+				s.mockNotificationGateway.EXPECT().Create(s.ctx, gomock.Any()).Return(false, nil)
+				s.mockNotificationGateway.EXPECT().
+					FindByEventID(s.ctx, "event-1").
+					Return(&entity.PaymentNotification{ID: 10, EventID: "event-1", ProcessedAt: &processedAt}, nil)
+				s.mockGateway.EXPECT().
+					FindByExternalPaymentID(s.ctx, resource).
+					Return(&entity.Payment{ID: 1, OrderID: 1, Status: valueobject.CONFIRMED}, nil)
+			},
+			checkResult: func(t *testing.T, payment *entity.Payment, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, valueobject.CONFIRMED, payment.Status)
+			},
+		},
+		{
+			name: "should skip an event a concurrent delivery processed meanwhile",
+			input: dto.UpdatePaymentInput{
+				EventID:  "event-1",
+				Resource: resource,
+				Topic:    "payment",
+				Status:   valueobject.CONFIRMED,
+			},
+			setupMocks: func() {
+				processedAt := time.Now()
+				s.mockNotificationGateway.EXPECT().Create(s.ctx, gomock.Any()).Return(false, nil)
+				s.mockNotificationGateway.EXPECT().
+					FindByEventID(s.ctx, "event-1").
+					Return(&entity.PaymentNotification{ID: 10, EventID: "event-1"}, nil)
+				s.mockGateway.EXPECT().FindByExternalPaymentID(s.ctx, resource).Return(processingPayment(), nil)
+				s.mockNotificationGateway.EXPECT().
+					FindByEventIDForUpdate(s.ctx, "event-1").
+					Return(&entity.PaymentNotification{ID: 10, EventID: "event-1", ProcessedAt: &processedAt}, nil)
 			},
 			checkResult: func(t *testing.T, payment *entity.Payment, err error) {
 				assert.NoError(t, err)
@@ -210,33 +544,174 @@ func (s *PaymentUsecaseSuiteTest) Test_paymentUseCase_Update() {
 			},
 		},
 		{
-			name: "should return error when update from order use case fails",
+			name: "should process again an event whose previous delivery failed",
 			input: dto.UpdatePaymentInput{
-				Resource: "389d873a-436b-4ef2-a47a-0abf9b3e9924",
+				EventID:  "event-1",
+				Resource: resource,
 				Topic:    "payment",
+				Status:   valueobject.CONFIRMED,
 			},
 			setupMocks: func() {
-				s.mockGateway.EXPECT().Update(s.ctx, gomock.Any(), gomock.Any()).Return(nil)
-				s.mockGateway.EXPECT().FindByExternalPaymentID(s.ctx, gomock.Any()).Return(&entity.Payment{ID: 1}, nil)
-				s.mockOrderUseCase.EXPECT().Get(s.ctx, gomock.Any()).Return(&entity.Order{ID: 1}, nil)
-				s.mockOrderUseCase.EXPECT().Update(s.ctx, gomock.Any()).Return(nil, &domain.InternalError{})
+				s.mockNotificationGateway.EXPECT().Create(s.ctx, gomock.Any()).Return(false, nil)
+				s.mockNotificationGateway.EXPECT().
+					FindByEventID(s.ctx, "event-1").
+					Return(&entity.PaymentNotification{ID: 10, EventID: "event-1"}, nil)
+				s.mockGateway.EXPECT().FindByExternalPaymentID(s.ctx, resource).Return(processingPayment(), nil)
+				expectLocked("event-1")
+				s.mockGateway.EXPECT().Update(s.ctx, valueobject.CONFIRMED, resource).Return(nil)
+				s.mockOrderUseCase.EXPECT().Get(s.ctx, dto.GetOrderInput{ID: 1}).Return(&entity.Order{ID: 1, Status: valueobject.PENDING}, nil)
+				s.mockOrderUseCase.EXPECT().Update(s.ctx, gomock.Any()).Return(&entity.Order{ID: 1}, nil)
+				expectProcessed()
+			},
+			checkResult: func(t *testing.T, payment *entity.Payment, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, valueobject.CONFIRMED, payment.Status)
+			},
+		},
+		{
+			name: "should keep the payment when the provider is still processing it",
+			input: dto.UpdatePaymentInput{
+				EventID:  "event-1",
+				Resource: resource,
+				Topic:    "payment",
+				Status:   valueobject.PROCESSING,
+			},
+			setupMocks: func() {
+				expectNewNotification()
+				s.mockGateway.EXPECT().FindByExternalPaymentID(s.ctx, resource).Return(processingPayment(), nil)
+				expectLocked("event-1")
+				expectProcessed()
+			},
+			checkResult: func(t *testing.T, payment *entity.Payment, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, valueobject.PROCESSING, payment.Status)
+			},
+		},
+		{
+			name: "should ignore a failure notified after the confirmation",
+			input: dto.UpdatePaymentInput{
+				EventID:  "event-2",
+				Resource: resource,
+				Topic:    "payment",
+				Status:   valueobject.FAILED,
+			},
+			setupMocks: func() {
+				expectNewNotification()
+				s.mockGateway.EXPECT().
+					FindByExternalPaymentID(s.ctx, resource).
+					Return(&entity.Payment{ID: 1, OrderID: 1, Status: valueobject.CONFIRMED}, nil)
+				expectLocked("event-2")
+				expectProcessed()
+			},
+			checkResult: func(t *testing.T, payment *entity.Payment, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, valueobject.CONFIRMED, payment.Status)
+			},
+		},
+		{
+			name: "should apply a confirmation notified after the failure",
+			input: dto.UpdatePaymentInput{
+				EventID:  "event-2",
+				Resource: resource,
+				Topic:    "payment",
+				Status:   valueobject.CONFIRMED,
+			},
+			setupMocks: func() {
+				expectNewNotification()
+				s.mockGateway.EXPECT().
+					FindByExternalPaymentID(s.ctx, resource).
+					Return(&entity.Payment{ID: 1, OrderID: 1, Status: valueobject.FAILED}, nil)
+				expectLocked("event-2")
+				s.mockGateway.EXPECT().Update(s.ctx, valueobject.CONFIRMED, resource).Return(nil)
+				s.mockOrderUseCase.EXPECT().Get(s.ctx, dto.GetOrderInput{ID: 1}).Return(&entity.Order{ID: 1, Status: valueobject.OPEN}, nil)
+				gomock.InOrder(
+					s.mockOrderUseCase.EXPECT().
+						Update(s.ctx, dto.UpdateOrderInput{ID: 1, Status: valueobject.PENDING, PaymentStatus: valueobject.CONFIRMED}).
+						Return(&entity.Order{ID: 1}, nil),
+					s.mockOrderUseCase.EXPECT().
+						Update(s.ctx, dto.UpdateOrderInput{ID: 1, Status: valueobject.RECEIVED, PaymentStatus: valueobject.CONFIRMED}).
+						Return(&entity.Order{ID: 1}, nil),
+				)
+				expectProcessed()
+			},
+			checkResult: func(t *testing.T, payment *entity.Payment, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, valueobject.CONFIRMED, payment.Status)
+			},
+		},
+		{
+			name: "should keep the order when it cannot transition anymore",
+			input: dto.UpdatePaymentInput{
+				EventID:  "event-1",
+				Resource: resource,
+				Topic:    "payment",
+				Status:   valueobject.CONFIRMED,
+			},
+			setupMocks: func() {
+				expectNewNotification()
+				s.mockGateway.EXPECT().FindByExternalPaymentID(s.ctx, resource).Return(processingPayment(), nil)
+				expectLocked("event-1")
+				s.mockGateway.EXPECT().Update(s.ctx, valueobject.CONFIRMED, resource).Return(nil)
+				s.mockOrderUseCase.EXPECT().Get(s.ctx, dto.GetOrderInput{ID: 1}).Return(&entity.Order{ID: 1, Status: valueobject.CANCELLED}, nil)
+				expectProcessed()
+			},
+			checkResult: func(t *testing.T, payment *entity.Payment, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, valueobject.CONFIRMED, payment.Status)
+			},
+		},
+		{
+			name: "should return error when the notification cannot be stored",
+			input: dto.UpdatePaymentInput{
+				EventID:  "event-1",
+				Resource: resource,
+				Topic:    "payment",
+				Status:   valueobject.CONFIRMED,
+			},
+			setupMocks: func() {
+				s.mockNotificationGateway.EXPECT().Create(s.ctx, gomock.Any()).Return(false, assert.AnError)
 			},
 			checkResult: func(t *testing.T, payment *entity.Payment, err error) {
-				assert.Error(t, err)
 				assert.Nil(t, payment)
 				assert.IsType(t, &domain.InternalError{}, err)
 			},
 		},
+		{
+			name: "should return the error of the order use case when update fails",
+			input: dto.UpdatePaymentInput{
+				EventID:  "event-1",
+				Resource: resource,
+				Topic:    "payment",
+				Status:   valueobject.CONFIRMED,
+			},
+			setupMocks: func() {
+				expectNewNotification()
+				s.mockGateway.EXPECT().FindByExternalPaymentID(s.ctx, resource).Return(processingPayment(), nil)
+				expectLocked("event-1")
+				s.mockGateway.EXPECT().Update(s.ctx, valueobject.CONFIRMED, resource).Return(nil)
+				s.mockOrderUseCase.EXPECT().Get(s.ctx, gomock.Any()).Return(&entity.Order{ID: 1, Status: valueobject.PENDING}, nil)
+				s.mockOrderUseCase.EXPECT().Update(s.ctx, gomock.Any()).Return(nil, domain.NewConflictError(domain.ErrConcurrentUpdate))
+			},
+			checkResult: func(t *testing.T, payment *entity.Payment, err error) {
+				assert.Error(t, err)
+				assert.Nil(t, payment)
+				assert.IsType(t, &domain.ConflictError{}, err)
+			},
+		},
 		{
 			name: "should return error when get from order use case fails",
 			input: dto.UpdatePaymentInput{
-				Resource: "389d873a-436b-4ef2-a47a-0abf9b3e9924",
+				EventID:  "event-1",
+				Resource: resource,
 				Topic:    "payment",
+				Status:   valueobject.CONFIRMED,
 			},
 			setupMocks: func() {
-				s.mockGateway.EXPECT().Update(s.ctx, gomock.Any(), gomock.Any()).Return(nil)
-				s.mockGateway.EXPECT().FindByExternalPaymentID(s.ctx, gomock.Any()).Return(&entity.Payment{ID: 1}, nil)
-				s.mockOrderUseCase.EXPECT().Get(s.ctx, gomock.Any()).Return(&entity.Order{}, &domain.InternalError{})
+				expectNewNotification()
+				s.mockGateway.EXPECT().FindByExternalPaymentID(s.ctx, resource).Return(processingPayment(), nil)
+				expectLocked("event-1")
+				s.mockGateway.EXPECT().Update(s.ctx, valueobject.CONFIRMED, resource).Return(nil)
+				s.mockOrderUseCase.EXPECT().Get(s.ctx, gomock.Any()).Return(nil, &domain.InternalError{})
 			},
 			checkResult: func(t *testing.T, payment *entity.Payment, err error) {
 				assert.Error(t, err)
@@ -247,32 +722,39 @@ func (s *PaymentUsecaseSuiteTest) Test_paymentUseCase_Update() {
 		{
 			name: "should return error when FindByExternalPaymentID from gateway fails",
 			input: dto.UpdatePaymentInput{
-				Resource: "389d873a-436b-4ef2-a47a-0abf9b3e9924",
+				EventID:  "event-1",
+				Resource: resource,
 				Topic:    "payment",
+				Status:   valueobject.CONFIRMED,
 			},
 			setupMocks: func() {
-				s.mockGateway.EXPECT().Update(s.ctx, gomock.Any(), gomock.Any()).Return(nil)
-				s.mockGateway.EXPECT().FindByExternalPaymentID(s.ctx, gomock.Any()).Return(&entity.Payment{}, assert.AnError)
+				expectNewNotification()
+				s.mockGateway.EXPECT().FindByExternalPaymentID(s.ctx, resource).Return(nil, assert.AnError)
 			},
 			checkResult: func(t *testing.T, payment *entity.Payment, err error) {
 				assert.Error(t, err)
 				assert.Nil(t, payment)
-				assert.IsType(t, assert.AnError, err)
+				assert.IsType(t, &domain.NotFoundError{}, err)
 			},
 		},
 		{
 			name: "should return error when gateway fails",
 			input: dto.UpdatePaymentInput{
-				Resource: "389d873a-436b-4ef2-a47a-0abf9b3e9924",
+				EventID:  "event-1",
+				Resource: resource,
 				Topic:    "payment",
+				Status:   valueobject.CONFIRMED,
 			},
 			setupMocks: func() {
-				s.mockGateway.EXPECT().Update(s.ctx, gomock.Any(), gomock.Any()).Return(assert.AnError)
+				expectNewNotification()
+				s.mockGateway.EXPECT().FindByExternalPaymentID(s.ctx, resource).Return(processingPayment(), nil)
+				expectLocked("event-1")
+				s.mockGateway.EXPECT().Update(s.ctx, valueobject.CONFIRMED, resource).Return(assert.AnError)
 			},
 			checkResult: func(t *testing.T, payment *entity.Payment, err error) {
 				assert.Error(t, err)
 				assert.Nil(t, payment)
-				assert.IsType(t, assert.AnError, err)
+				assert.IsType(t, &domain.InternalError{}, err)
 			},
 		},
 	}
@@ -286,3 +768,210 @@ func (s *PaymentUsecaseSuiteTest) Test_paymentUseCase_Update() {
 		})
 	}
 }
+
+func (s *PaymentUsecaseSuiteTest) Test_paymentUseCase_ExpirePayments() {
+	tests := []struct {
+		name        string
+		setupMocks  func()
+		checkResult func(*testing.T, int, error)
+	}{
+		{
+			name: "should abort expired payments and reopen their orders",
+			setupMocks: func() {
+				s.mockGateway.EXPECT().
+					FindExpiredProcessing(s.ctx, gomock.Any(), gomock.Any()).
+					Return([]*entity.Payment{{ID: 1, OrderID: 1}, {ID: 2, OrderID: 2}}, nil)
+				s.mockGateway.EXPECT().UpdateIfProcessing(s.ctx, uint64(1), valueobject.ABORTED).Return(true, nil)
+				s.mockOrderUseCase.EXPECT().Get(s.ctx, dto.GetOrderInput{ID: 1}).Return(&entity.Order{ID: 1, Status: valueobject.PENDING}, nil)
+				s.mockOrderUseCase.EXPECT().
+					Update(s.ctx, dto.UpdateOrderInput{ID: 1, Status: valueobject.OPEN, PaymentStatus: valueobject.ABORTED}).
+					Return(&entity.Order{ID: 1}, nil)
+				s.mockGateway.EXPECT().UpdateIfProcessing(s.ctx, uint64(2), valueobject.ABORTED).Return(true, nil)
+				s.mockOrderUseCase.EXPECT().Get(s.ctx, dto.GetOrderInput{ID: 2}).Return(&entity.Order{ID: 2, Status: valueobject.CANCELLED}, nil)
+			},
+			checkResult: func(t *testing.T, expired int, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, 2, expired)
+			},
+		},
+		{
+			name: "should skip a payment settled meanwhile",
+			setupMocks: func() {
+				s.mockGateway.EXPECT().
+					FindExpiredProcessing(s.ctx, gomock.Any(), gomock.Any()).
+					Return([]*entity.Payment{{ID: 1, OrderID: 1}}, nil)
+				s.mockGateway.EXPECT().UpdateIfProcessing(s.ctx, uint64(1), valueobject.ABORTED).Return(false, nil)
+			},
+			checkResult: func(t *testing.T, expired int, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, 0, expired)
+			},
+		},
+		{
+			name: "should keep expiring payments when an order fails to reopen",
+			setupMocks: func() {
+				s.mockGateway.EXPECT().
+					FindExpiredProcessing(s.ctx, gomock.Any(), gomock.Any()).
+					Return([]*entity.Payment{{ID: 1, OrderID: 1}, {ID: 2, OrderID: 2}}, nil)
+				s.mockGateway.EXPECT().UpdateIfProcessing(s.ctx, uint64(1), valueobject.ABORTED).Return(true, nil)
+				s.mockOrderUseCase.EXPECT().Get(s.ctx, dto.GetOrderInput{ID: 1}).Return(nil, &domain.InternalError{})
+				s.mockGateway.EXPECT().UpdateIfProcessing(s.ctx, uint64(2), valueobject.ABORTED).Return(true, nil)
+				s.mockOrderUseCase.EXPECT().Get(s.ctx, dto.GetOrderInput{ID: 2}).Return(&entity.Order{ID: 2, Status: valueobject.PENDING}, nil)
+				s.mockOrderUseCase.EXPECT().Update(s.ctx, gomock.Any()).Return(&entity.Order{ID: 2}, nil)
+			},
+			checkResult: func(t *testing.T, expired int, err error) {
+				assert.IsType(t, &domain.InternalError{}, err)
+				// The payment of the order that failed to reopen is left PROCESSING, to be expired by the next run
+				assert.Equal(t, 1, expired)
+			},
+		},
+		{
+			name: "should return error when FindExpiredProcessing from gateway fails",
+			setupMocks: func() {
+				s.mockGateway.EXPECT().FindExpiredProcessing(s.ctx, gomock.Any(), gomock.Any()).Return(nil, assert.AnError)
+			},
+			checkResult: func(t *testing.T, expired int, err error) {
+				assert.IsType(t, &domain.InternalError{}, err)
+				assert.Equal(t, 0, expired)
+			},
+		},
+	}
+	for _, tt := range tests {
+		s.T().Run(tt.name, func(t *testing.T) {
+			tt.setupMocks()
+
+			expired, err := s.useCase.ExpirePayments(s.ctx)
+
+			tt.checkResult(t, expired, err)
+		})
+	}
+}
+
+func (s *PaymentUsecaseSuiteTest) Test_paymentUseCase_ConfirmCash() {
+	cashPayment := func() *entity.Payment {
+		return &entity.Payment{ID: 1, OrderID: 1, Provider: valueobject.CASH_PROVIDER, Amount: valueobject.NewMoney(4090), Status: valueobject.PROCESSING}
+	}
+
+	tests := []struct {
+		name        string
+		input       dto.ConfirmCashPaymentInput
+		setupMocks  func()
+		checkResult func(*testing.T, *entity.Payment, error)
+	}{
+		{
+			name:  "should confirm the cash payment and move the order to RECEIVED",
+			input: dto.ConfirmCashPaymentInput{OrderID: 1, AmountReceived: valueobject.NewMoney(5000), ChangeGiven: valueobject.NewMoney(910), StaffID: 2},
+			setupMocks: func() {
+				s.mockGateway.EXPECT().FindByOrderIDAndStatusProcessing(s.ctx, uint64(1)).Return(cashPayment(), nil)
+				s.mockGateway.EXPECT().
+					ConfirmCash(s.ctx, &entity.CashReceipt{PaymentID: 1, AmountReceived: valueobject.NewMoney(5000), ChangeGiven: valueobject.NewMoney(910), StaffID: 2}).
+					Return(true, nil)
+				s.mockOrderUseCase.EXPECT().Get(s.ctx, dto.GetOrderInput{ID: 1}).Return(&entity.Order{ID: 1, Status: valueobject.PENDING}, nil)
+				s.mockOrderUseCase.EXPECT().
+					Update(s.ctx, dto.UpdateOrderInput{ID: 1, Status: valueobject.RECEIVED, PaymentStatus: valueobject.CONFIRMED, StaffID: 2}).
+					Return(&entity.Order{ID: 1}, nil)
+			},
+			checkResult: func(t *testing.T, payment *entity.Payment, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, valueobject.CONFIRMED, payment.Status)
+			},
+		},
+		{
+			name:  "should confirm the static PIX payment once the transfer was received",
+			input: dto.ConfirmCashPaymentInput{OrderID: 1, AmountReceived: valueobject.NewMoney(4090), StaffID: 2},
+			setupMocks: func() {
+				pixPayment := cashPayment()
+				pixPayment.Provider = valueobject.PIX_STATIC_PROVIDER
+				s.mockGateway.EXPECT().FindByOrderIDAndStatusProcessing(s.ctx, uint64(1)).Return(pixPayment, nil)
+				s.mockGateway.EXPECT().
+					ConfirmCash(s.ctx, &entity.CashReceipt{PaymentID: 1, AmountReceived: valueobject.NewMoney(4090), StaffID: 2}).
+					Return(true, nil)
+				s.mockOrderUseCase.EXPECT().Get(s.ctx, dto.GetOrderInput{ID: 1}).Return(&entity.Order{ID: 1, Status: valueobject.PENDING}, nil)
+				s.mockOrderUseCase.EXPECT().
+					Update(s.ctx, dto.UpdateOrderInput{ID: 1, Status: valueobject.RECEIVED, PaymentStatus: valueobject.CONFIRMED, StaffID: 2}).
+					Return(&entity.Order{ID: 1}, nil)
+			},
+			checkResult: func(t *testing.T, payment *entity.Payment, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, valueobject.CONFIRMED, payment.Status)
+			},
+		},
+		{
+			name:  "should return error when the order has no cash payment awaiting confirmation",
+			input: dto.ConfirmCashPaymentInput{OrderID: 1, AmountReceived: valueobject.NewMoney(5000), ChangeGiven: valueobject.NewMoney(910), StaffID: 2},
+			setupMocks: func() {
+				s.mockGateway.EXPECT().
+					FindByOrderIDAndStatusProcessing(s.ctx, uint64(1)).
+					Return(&entity.Payment{ID: 1, OrderID: 1, Provider: valueobject.FAKE_PROVIDER, Amount: valueobject.NewMoney(4090)}, nil)
+			},
+			checkResult: func(t *testing.T, payment *entity.Payment, err error) {
+				assert.Nil(t, payment)
+				assert.IsType(t, &domain.InvalidInputError{}, err)
+			},
+		},
+		{
+			name:  "should return error when the amount received is not enough",
+			input: dto.ConfirmCashPaymentInput{OrderID: 1, AmountReceived: valueobject.NewMoney(4000), StaffID: 2},
+			setupMocks: func() {
+				s.mockGateway.EXPECT().FindByOrderIDAndStatusProcessing(s.ctx, uint64(1)).Return(cashPayment(), nil)
+			},
+			checkResult: func(t *testing.T, payment *entity.Payment, err error) {
+				assert.Nil(t, payment)
+				assert.IsType(t, &domain.InvalidInputError{}, err)
+			},
+		},
+		{
+			name:  "should return error when the change given does not match",
+			input: dto.ConfirmCashPaymentInput{OrderID: 1, AmountReceived: valueobject.NewMoney(5000), ChangeGiven: valueobject.NewMoney(1000), StaffID: 2},
+			setupMocks: func() {
+				s.mockGateway.EXPECT().FindByOrderIDAndStatusProcessing(s.ctx, uint64(1)).Return(cashPayment(), nil)
+			},
+			checkResult: func(t *testing.T, payment *entity.Payment, err error) {
+				assert.Nil(t, payment)
+				assert.IsType(t, &domain.InvalidInputError{}, err)
+			},
+		},
+		{
+			name:  "should return error when the payment was aborted meanwhile",
+			input: dto.ConfirmCashPaymentInput{OrderID: 1, AmountReceived: valueobject.NewMoney(4090), StaffID: 2},
+			setupMocks: func() {
+				s.mockGateway.EXPECT().FindByOrderIDAndStatusProcessing(s.ctx, uint64(1)).Return(cashPayment(), nil)
+				s.mockGateway.EXPECT().ConfirmCash(s.ctx, gomock.Any()).Return(false, nil)
+			},
+			checkResult: func(t *testing.T, payment *entity.Payment, err error) {
+				assert.Nil(t, payment)
+				assert.IsType(t, &domain.InvalidInputError{}, err)
+			},
+		},
+		{
+			name:       "should return error when the staff is not given",
+			input:      dto.ConfirmCashPaymentInput{OrderID: 1, AmountReceived: valueobject.NewMoney(5000), ChangeGiven: valueobject.NewMoney(910)},
+			setupMocks: func() {},
+			checkResult: func(t *testing.T, payment *entity.Payment, err error) {
+				assert.Nil(t, payment)
+				assert.IsType(t, &domain.InvalidInputError{}, err)
+			},
+		},
+		{
+			name:  "should return error when ConfirmCash from gateway fails",
+			input: dto.ConfirmCashPaymentInput{OrderID: 1, AmountReceived: valueobject.NewMoney(5000), ChangeGiven: valueobject.NewMoney(910), StaffID: 2},
+			setupMocks: func() {
+				s.mockGateway.EXPECT().FindByOrderIDAndStatusProcessing(s.ctx, uint64(1)).Return(cashPayment(), nil)
+				s.mockGateway.EXPECT().ConfirmCash(s.ctx, gomock.Any()).Return(false, assert.AnError)
+			},
+			checkResult: func(t *testing.T, payment *entity.Payment, err error) {
+				assert.Nil(t, payment)
+				assert.IsType(t, &domain.InternalError{}, err)
+			},
+		},
+	}
+	for _, tt := range tests {
+		s.T().Run(tt.name, func(t *testing.T) {
+			tt.setupMocks()
+
+			payment, err := s.useCase.ConfirmCash(s.ctx, tt.input)
+
+			tt.checkResult(t, payment, err)
+		})
+	}
+}
@@ -63,12 +63,22 @@ func (uc *productUseCase) Update(ctx context.Context, i dto.UpdateProductInput)
 		return nil, domain.NewNotFoundError(domain.ErrNotFound)
 	}
 
-	product.Update(i.Name, i.Description, i.Price, i.CategoryID)
+	if i.Version != nil && *i.Version != product.Version {
+		return nil, domain.NewPreconditionFailedError(domain.ErrVersionMismatch)
+	}
+
+	product.Update(i.Name, i.Description, i.Price, i.CategoryID, i.PrepMinutes)
 
-	if err := uc.gateway.Update(ctx, product); err != nil {
+	updated, err := uc.gateway.Update(ctx, product)
+	if err != nil {
 		return nil, domain.NewInternalError(err)
 	}
 
+	// Changed by another request since it was read
+	if !updated {
+		return nil, domain.NewConflictError(domain.ErrConcurrentUpdate)
+	}
+
 	return product, nil
 }
 
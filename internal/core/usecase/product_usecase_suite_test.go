@@ -2,6 +2,7 @@ package usecase_test
 
 import (
 	"context"
+	valueobject "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/value_object"
 	"testing"
 	"time"
 
@@ -33,7 +34,7 @@ func (s *ProductUsecaseSuiteTest) SetupTest() {
 			ID:          1,
 			Name:        "Test Product 1",
 			Description: "Description 1",
-			Price:       99.99,
+			Price:       valueobject.NewMoney(9999),
 			CategoryID:  1,
 			CreatedAt:   currentTime,
 			UpdatedAt:   currentTime,
@@ -42,7 +43,7 @@ func (s *ProductUsecaseSuiteTest) SetupTest() {
 			ID:          2,
 			Name:        "Test Product 2",
 			Description: "Description 2",
-			Price:       199.99,
+			Price:       valueobject.NewMoney(19999),
 			CategoryID:  1,
 			CreatedAt:   currentTime,
 			UpdatedAt:   currentTime,
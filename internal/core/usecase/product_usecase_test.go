@@ -1,6 +1,7 @@
 package usecase_test
 
 import (
+	valueobject "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/value_object"
 	"testing"
 
 	"go.uber.org/mock/gomock"
@@ -117,7 +118,7 @@ func (s *ProductUsecaseSuiteTest) TestProductUseCase_Create() {
 			input: dto.CreateProductInput{
 				Name:        "Test Product",
 				Description: "Test Description",
-				Price:       99.99,
+				Price:       valueobject.NewMoney(9999),
 				CategoryID:  1,
 			},
 			setupMocks: func() {
@@ -130,7 +131,7 @@ func (s *ProductUsecaseSuiteTest) TestProductUseCase_Create() {
 				assert.NotNil(t, product)
 				assert.Equal(t, "Test Product", product.Name)
 				assert.Equal(t, "Test Description", product.Description)
-				assert.Equal(t, 99.99, product.Price)
+				assert.Equal(t, valueobject.NewMoney(9999), product.Price)
 				assert.Equal(t, uint64(1), product.CategoryID)
 			},
 		},
@@ -139,7 +140,7 @@ func (s *ProductUsecaseSuiteTest) TestProductUseCase_Create() {
 			input: dto.CreateProductInput{
 				Name:        "Test Product",
 				Description: "Test Description",
-				Price:       99.99,
+				Price:       valueobject.NewMoney(9999),
 				CategoryID:  1,
 			},
 			setupMocks: func() {
@@ -235,6 +236,7 @@ func (s *ProductUsecaseSuiteTest) TestProductUseCase_Get() {
 }
 
 func (s *ProductUsecaseSuiteTest) TestProductUseCase_Update() {
+	staleVersion := uint64(3)
 	tests := []struct {
 		name        string
 		input       dto.UpdateProductInput
@@ -247,7 +249,7 @@ func (s *ProductUsecaseSuiteTest) TestProductUseCase_Update() {
 				ID:          1,
 				Name:        "New Name",
 				Description: "New Description",
-				Price:       20.0,
+				Price:       valueobject.NewMoney(2000),
 				CategoryID:  2,
 			},
 			setupMocks: func() {
@@ -257,14 +259,14 @@ func (s *ProductUsecaseSuiteTest) TestProductUseCase_Update() {
 
 				s.mockGateway.EXPECT().
 					Update(s.ctx, gomock.Any()).
-					Return(nil)
+					Return(true, nil)
 			},
 			checkResult: func(t *testing.T, product *entity.Product, err error) {
 				assert.NoError(t, err)
 				assert.NotNil(t, product)
 				assert.Equal(t, "New Name", product.Name)
 				assert.Equal(t, "New Description", product.Description)
-				assert.Equal(t, 20.0, product.Price)
+				assert.Equal(t, valueobject.NewMoney(2000), product.Price)
 				assert.Equal(t, uint64(2), product.CategoryID)
 			},
 		},
@@ -274,7 +276,7 @@ func (s *ProductUsecaseSuiteTest) TestProductUseCase_Update() {
 				ID:          1,
 				Name:        "New Name",
 				Description: "New Description",
-				Price:       20.0,
+				Price:       valueobject.NewMoney(2000),
 				CategoryID:  2,
 			},
 			setupMocks: func() {
@@ -294,7 +296,7 @@ func (s *ProductUsecaseSuiteTest) TestProductUseCase_Update() {
 				ID:          1,
 				Name:        "New Name",
 				Description: "New Description",
-				Price:       20.0,
+				Price:       valueobject.NewMoney(2000),
 				CategoryID:  2,
 			},
 			setupMocks: func() {
@@ -314,7 +316,7 @@ func (s *ProductUsecaseSuiteTest) TestProductUseCase_Update() {
 				ID:          1,
 				Name:        "New Name",
 				Description: "New Description",
-				Price:       20.0,
+				Price:       valueobject.NewMoney(2000),
 				CategoryID:  2,
 			},
 			setupMocks: func() {
@@ -324,7 +326,7 @@ func (s *ProductUsecaseSuiteTest) TestProductUseCase_Update() {
 
 				s.mockGateway.EXPECT().
 					Update(s.ctx, gomock.Any()).
-					Return(assert.AnError)
+					Return(false, assert.AnError)
 			},
 			checkResult: func(t *testing.T, product *entity.Product, err error) {
 				assert.Error(t, err)
@@ -332,6 +334,49 @@ func (s *ProductUsecaseSuiteTest) TestProductUseCase_Update() {
 				assert.IsType(t, &domain.InternalError{}, err)
 			},
 		},
+		{
+			name: "should return precondition failed when version does not match",
+			input: dto.UpdateProductInput{
+				ID:          1,
+				Name:        "New Name",
+				Description: "New Description",
+				Price:       valueobject.NewMoney(2000),
+				CategoryID:  2,
+				Version:     &staleVersion,
+			},
+			setupMocks: func() {
+				s.mockGateway.EXPECT().
+					FindByID(s.ctx, uint64(1)).
+					Return(s.mockProducts[0], nil)
+			},
+			checkResult: func(t *testing.T, product *entity.Product, err error) {
+				assert.Nil(t, product)
+				assert.IsType(t, &domain.PreconditionFailedError{}, err)
+			},
+		},
+		{
+			name: "should return conflict when product was changed concurrently",
+			input: dto.UpdateProductInput{
+				ID:          1,
+				Name:        "New Name",
+				Description: "New Description",
+				Price:       valueobject.NewMoney(2000),
+				CategoryID:  2,
+			},
+			setupMocks: func() {
+				s.mockGateway.EXPECT().
+					FindByID(s.ctx, uint64(1)).
+					Return(s.mockProducts[0], nil)
+
+				s.mockGateway.EXPECT().
+					Update(s.ctx, gomock.Any()).
+					Return(false, nil)
+			},
+			checkResult: func(t *testing.T, product *entity.Product, err error) {
+				assert.Nil(t, product)
+				assert.IsType(t, &domain.ConflictError{}, err)
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -10,12 +10,13 @@ import (
 )
 
 type staffUseCase struct {
-	gateway port.StaffGateway
+	gateway         port.StaffGateway
+	passwordService port.PasswordService
 }
 
 // NewStaffUseCase creates a new StaffUseCase
-func NewStaffUseCase(gateway port.StaffGateway) port.StaffUseCase {
-	return &staffUseCase{gateway: gateway}
+func NewStaffUseCase(gateway port.StaffGateway, passwordService port.PasswordService) port.StaffUseCase {
+	return &staffUseCase{gateway: gateway, passwordService: passwordService}
 }
 
 // List returns a list of staffs
@@ -32,6 +33,14 @@ func (uc *staffUseCase) List(ctx context.Context, i dto.ListStaffsInput) ([]*ent
 func (uc *staffUseCase) Create(ctx context.Context, i dto.CreateStaffInput) (*entity.Staff, error) {
 	staff := i.ToEntity()
 
+	if i.Password != "" {
+		passwordHash, err := uc.passwordService.Hash(i.Password)
+		if err != nil {
+			return nil, domain.NewInternalError(err)
+		}
+		staff.PasswordHash = passwordHash
+	}
+
 	if err := uc.gateway.Create(ctx, staff); err != nil {
 		return nil, domain.NewInternalError(err)
 	}
@@ -65,6 +74,14 @@ func (uc *staffUseCase) Update(ctx context.Context, i dto.UpdateStaffInput) (*en
 
 	staff.Update(i.Name, i.Role)
 
+	if i.Password != "" {
+		passwordHash, err := uc.passwordService.Hash(i.Password)
+		if err != nil {
+			return nil, domain.NewInternalError(err)
+		}
+		staff.UpdatePassword(passwordHash)
+	}
+
 	if err := uc.gateway.Update(ctx, staff); err != nil {
 		return nil, domain.NewInternalError(err)
 	}
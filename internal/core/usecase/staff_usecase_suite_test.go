@@ -15,17 +15,19 @@ import (
 
 type StaffUsecaseSuiteTest struct {
 	suite.Suite
-	mockStaffs  []*entity.Staff
-	mockGateway *mockport.MockStaffGateway
-	useCase     port.StaffUseCase
-	ctx         context.Context
+	mockStaffs          []*entity.Staff
+	mockGateway         *mockport.MockStaffGateway
+	mockPasswordService *mockport.MockPasswordService
+	useCase             port.StaffUseCase
+	ctx                 context.Context
 }
 
 func (s *StaffUsecaseSuiteTest) SetupTest() {
 	ctrl := gomock.NewController(s.T())
 	defer ctrl.Finish()
 	s.mockGateway = mockport.NewMockStaffGateway(ctrl)
-	s.useCase = usecase.NewStaffUseCase(s.mockGateway)
+	s.mockPasswordService = mockport.NewMockPasswordService(ctrl)
+	s.useCase = usecase.NewStaffUseCase(s.mockGateway, s.mockPasswordService)
 	s.ctx = context.Background()
 	currentTime := time.Now()
 	s.mockStaffs = []*entity.Staff{
@@ -133,6 +133,27 @@ func (s *StaffUsecaseSuiteTest) TestStaffUseCase_Create() {
 				assert.Equal(t, "COOK", string(staff.Role))
 			},
 		},
+		{
+			name: "should hash password when provided",
+			input: dto.CreateStaffInput{
+				Name:     "John Smith",
+				Role:     "COOK",
+				Password: "123456",
+			},
+			setupMocks: func() {
+				s.mockPasswordService.EXPECT().
+					Hash("123456").
+					Return("hashed-password", nil)
+				s.mockGateway.EXPECT().
+					Create(s.ctx, gomock.Any()).
+					Return(nil)
+			},
+			checkResult: func(t *testing.T, staff *entity.Staff, err error) {
+				assert.NoError(t, err)
+				assert.NotNil(t, staff)
+				assert.Equal(t, "hashed-password", staff.PasswordHash)
+			},
+		},
 		{
 			name: "should return error when gateway fails",
 			input: dto.CreateStaffInput{
@@ -4,6 +4,7 @@ import (
 	"log"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/joho/godotenv"
@@ -33,15 +34,62 @@ type Config struct {
 	// Mercado Pago
 	FakeMercadoPagoURL             string
 	FakeMercadoPagoNotificationURL string
+	FakeMercadoPagoRefundURL       string
+	FakeMercadoPagoStatusURL       string
 	MercadoPagoToken               string
 	MercadoPagoURL                 string
 	MercadoPagoTimeout             time.Duration
 	MercadoPagoRetryCount          int
 	MercadoPagoNotificationURL     string
+	MercadoPagoMerchantOrdersURL   string
+	MercadoPagoPaymentsURL         string
+	MercadoPagoWebhookSecret       string
+	MercadoPagoWebhookTolerance    time.Duration
+
+	// Payment settings
+	PaymentProvider                string
+	PaymentMaxAttempts             int
+	PaymentExpiration              time.Duration
+	PaymentExpirySweepInterval     time.Duration
+	PaymentReconciliationInterval  time.Duration
+	PaymentReconciliationReportDir string
+
+	// Order status stream
+	OrderStreamBufferSize int
+	OrderStreamOrigins    []string // origins of the pages allowed to open the WebSocket stream, besides the API own
+
+	// Order SLA
+	OrderPrepTarget       time.Duration
+	OrderSLACheckInterval time.Duration
+
+	// Order estimated ready time
+	KitchenCapacity   int
+	OrderPrepEstimate time.Duration
+
+	// Order expiry
+	ReadyOrderTimeout       time.Duration
+	ReadyOrderTimeoutStatus string
+	OpenOrderTimeout        time.Duration
+	OrderExpiryInterval     time.Duration
+
+	// Order state machine, the default one when empty
+	OrderStateMachineFile string
+
+	// Static PIX
+	PixKey          string
+	PixMerchantName string
+	PixMerchantCity string
 
 	// JWT Settings
-	JWTSecret     string
-	JWTExpiration time.Duration
+	JWTAlgorithm           string
+	JWTKeysDir             string
+	JWTPrivateKey          string
+	JWTKeyID               string
+	JWTSigningKeyID        string
+	JWTKeyRotationInterval time.Duration
+	JWTExpiration          time.Duration
+	JWTRefreshExpiration   time.Duration
+	JWTRevocationCacheTTL  time.Duration
 }
 
 func LoadConfig() *Config {
@@ -62,6 +110,31 @@ func LoadConfig() *Config {
 	mercadoPagoTimeout, _ := time.ParseDuration(getEnv("MERCADO_PAGO_TIMEOUT", "10s"))
 	mercadoPagoRetryCount, _ := strconv.Atoi(getEnv("MERCADO_PAGO_RETRY_COUNT", "2"))
 
+	mercadoPagoWebhookTolerance, _ := time.ParseDuration(getEnv("MERCADO_PAGO_WEBHOOK_TOLERANCE", "5m"))
+
+	paymentMaxAttempts, _ := strconv.Atoi(getEnv("PAYMENT_MAX_ATTEMPTS", "3"))
+	paymentExpiration, _ := time.ParseDuration(getEnv("PAYMENT_EXPIRATION", "15m"))
+	paymentExpirySweepInterval, _ := time.ParseDuration(getEnv("PAYMENT_EXPIRY_SWEEP_INTERVAL", "1m"))
+	paymentReconciliationInterval, _ := time.ParseDuration(getEnv("PAYMENT_RECONCILIATION_INTERVAL", "10m"))
+
+	orderStreamBufferSize, _ := strconv.Atoi(getEnv("ORDER_STREAM_BUFFER_SIZE", "64"))
+	var orderStreamOrigins []string
+	for _, origin := range strings.Split(getEnv("ORDER_STREAM_ORIGINS", ""), ",") {
+		if origin = strings.TrimSpace(origin); origin != "" {
+			orderStreamOrigins = append(orderStreamOrigins, origin)
+		}
+	}
+
+	orderPrepTarget, _ := time.ParseDuration(getEnv("ORDER_PREP_TARGET", "15m"))
+	orderSLACheckInterval, _ := time.ParseDuration(getEnv("ORDER_SLA_CHECK_INTERVAL", "1m"))
+
+	kitchenCapacity, _ := strconv.Atoi(getEnv("KITCHEN_CAPACITY", "2"))
+	orderPrepEstimate, _ := time.ParseDuration(getEnv("ORDER_PREP_ESTIMATE", "10m"))
+
+	readyOrderTimeout, _ := time.ParseDuration(getEnv("READY_ORDER_TIMEOUT", "30m"))
+	openOrderTimeout, _ := time.ParseDuration(getEnv("OPEN_ORDER_TIMEOUT", "2h"))
+	orderExpiryInterval, _ := time.ParseDuration(getEnv("ORDER_EXPIRY_INTERVAL", "1m"))
+
 	jwtExpirationStr := getEnv("JWT_EXPIRATION", "24h")
 	jwtExpiration, err := time.ParseDuration(jwtExpirationStr)
 	if err != nil {
@@ -69,6 +142,16 @@ func LoadConfig() *Config {
 		jwtExpiration = 24 * time.Hour
 	}
 
+	jwtRefreshExpirationStr := getEnv("JWT_REFRESH_EXPIRATION", "720h")
+	jwtRefreshExpiration, err := time.ParseDuration(jwtRefreshExpirationStr)
+	if err != nil {
+		log.Printf("Warning: invalid JWT_REFRESH_EXPIRATION value %q: %v. Using default value 720h.", jwtRefreshExpirationStr, err)
+		jwtRefreshExpiration = 720 * time.Hour
+	}
+
+	jwtRevocationCacheTTL, _ := time.ParseDuration(getEnv("JWT_REVOCATION_CACHE_TTL", "30s"))
+	jwtKeyRotationInterval, _ := time.ParseDuration(getEnv("JWT_KEY_ROTATION_INTERVAL", "24h"))
+
 	return &Config{
 		// Database settings
 		DBHost:         getEnv("DB_HOST", "localhost"),
@@ -93,15 +176,62 @@ func LoadConfig() *Config {
 		// Mercado Pago
 		FakeMercadoPagoURL:             getEnv("FAKE_MERCADO_PAGO_URL", "url"),
 		FakeMercadoPagoNotificationURL: getEnv("FAKE_MERCADO_PAGO_NOTIFICATION_URL", "url"),
+		FakeMercadoPagoRefundURL:       getEnv("FAKE_MERCADO_PAGO_REFUND_URL", "url"),
+		FakeMercadoPagoStatusURL:       getEnv("FAKE_MERCADO_PAGO_STATUS_URL", "url"),
 		MercadoPagoToken:               getEnv("MERCADO_PAGO_TOKEN", "token"),
 		MercadoPagoURL:                 getEnv("MERCADO_PAGO_URL", "url"),
 		MercadoPagoTimeout:             mercadoPagoTimeout,
 		MercadoPagoRetryCount:          mercadoPagoRetryCount,
 		MercadoPagoNotificationURL:     getEnv("MERCADO_PAGO_NOTIFICATION_URL", "url"),
+		MercadoPagoMerchantOrdersURL:   getEnv("MERCADO_PAGO_MERCHANT_ORDERS_URL", "https://api.mercadopago.com/merchant_orders/search"),
+		MercadoPagoPaymentsURL:         getEnv("MERCADO_PAGO_PAYMENTS_URL", "https://api.mercadopago.com/v1/payments"),
+		MercadoPagoWebhookSecret:       getEnv("MERCADO_PAGO_WEBHOOK_SECRET", ""),
+		MercadoPagoWebhookTolerance:    mercadoPagoWebhookTolerance,
+
+		// Payment settings
+		PaymentProvider:                getEnv("PAYMENT_PROVIDER", "fake"),
+		PaymentMaxAttempts:             paymentMaxAttempts,
+		PaymentExpiration:              paymentExpiration,
+		PaymentExpirySweepInterval:     paymentExpirySweepInterval,
+		PaymentReconciliationInterval:  paymentReconciliationInterval,
+		PaymentReconciliationReportDir: getEnv("PAYMENT_RECONCILIATION_REPORT_DIR", ""),
+
+		// Order status stream
+		OrderStreamBufferSize: orderStreamBufferSize,
+		OrderStreamOrigins:    orderStreamOrigins,
+
+		// Order SLA
+		OrderPrepTarget:       orderPrepTarget,
+		OrderSLACheckInterval: orderSLACheckInterval,
+
+		// Order estimated ready time
+		KitchenCapacity:   kitchenCapacity,
+		OrderPrepEstimate: orderPrepEstimate,
+
+		// Order expiry
+		ReadyOrderTimeout:       readyOrderTimeout,
+		ReadyOrderTimeoutStatus: getEnv("READY_ORDER_TIMEOUT_STATUS", "ABANDONED"),
+		OpenOrderTimeout:        openOrderTimeout,
+		OrderExpiryInterval:     orderExpiryInterval,
+
+		// Order state machine
+		OrderStateMachineFile: getEnv("ORDER_STATE_MACHINE_FILE", ""),
+
+		// Static PIX
+		PixKey:          getEnv("PIX_KEY", ""),
+		PixMerchantName: getEnv("PIX_MERCHANT_NAME", "FIAP TECH CHALLENGE"),
+		PixMerchantCity: getEnv("PIX_MERCHANT_CITY", "SAO PAULO"),
 
 		// JWT Settings
-		JWTSecret:     getEnv("JWT_SECRET", "SUPER_SECRET_KEY_DONT_TELL_ANYONE"),
-		JWTExpiration: jwtExpiration,
+		JWTAlgorithm:           getEnv("JWT_ALGORITHM", "RS256"),
+		JWTKeysDir:             getEnv("JWT_KEYS_DIR", ""),
+		JWTPrivateKey:          getEnv("JWT_PRIVATE_KEY", ""),
+		JWTKeyID:               getEnv("JWT_KEY_ID", "default"),
+		JWTSigningKeyID:        getEnv("JWT_SIGNING_KEY_ID", ""),
+		JWTKeyRotationInterval: jwtKeyRotationInterval,
+		JWTExpiration:          jwtExpiration,
+		JWTRefreshExpiration:   jwtRefreshExpiration,
+		JWTRevocationCacheTTL:  jwtRevocationCacheTTL,
 	}
 }
 
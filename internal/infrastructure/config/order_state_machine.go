@@ -0,0 +1,85 @@
+package config
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
+	valueobject "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/value_object"
+)
+
+// defaultOrderStateMachine is the state machine used without ORDER_STATE_MACHINE_FILE
+//
+//go:embed order_state_machine.yaml
+var defaultOrderStateMachine []byte
+
+type orderStateMachineDefinition struct {
+	Transitions []orderTransitionDefinition `yaml:"transitions"`
+}
+
+type orderTransitionDefinition struct {
+	From   string   `yaml:"from"`
+	To     string   `yaml:"to"`
+	Staff  bool     `yaml:"staff"`
+	Roles  []string `yaml:"roles"`
+	Guards []string `yaml:"guards"`
+}
+
+// LoadOrderStateMachine reads the order state machine from the YAML or JSON file at path, or the default one when
+// path is empty, and validates it allows the required transitions
+func LoadOrderStateMachine(path string, required ...entity.OrderTransition) (*entity.OrderStateMachine, error) {
+	content := defaultOrderStateMachine
+	if path != "" {
+		var err error
+		if content, err = os.ReadFile(path); err != nil {
+			return nil, fmt.Errorf("error reading order state machine: %w", err)
+		}
+	}
+
+	// JSON is valid YAML, so both are read the same way
+	var definition orderStateMachineDefinition
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&definition); err != nil {
+		return nil, fmt.Errorf("error decoding order state machine: %w", err)
+	}
+
+	transitions := make([]entity.OrderTransition, 0, len(definition.Transitions))
+	for i, t := range definition.Transitions {
+		from, ok := valueobject.ToOrderStatus(t.From)
+		if !ok {
+			return nil, fmt.Errorf("invalid order state machine: transition %d: invalid status %q", i+1, t.From)
+		}
+		to, ok := valueobject.ToOrderStatus(t.To)
+		if !ok {
+			return nil, fmt.Errorf("invalid order state machine: transition %d: invalid status %q", i+1, t.To)
+		}
+
+		transition := entity.OrderTransition{From: from, To: to, Staff: t.Staff}
+		for _, role := range t.Roles {
+			if !valueobject.IsValidStaffRole(role) {
+				return nil, fmt.Errorf("invalid order state machine: transition %d: invalid role %q", i+1, role)
+			}
+			transition.Roles = append(transition.Roles, valueobject.ToStaffRole(role))
+		}
+		for _, guard := range t.Guards {
+			if !valueobject.IsValidOrderGuard(guard) {
+				return nil, fmt.Errorf("invalid order state machine: transition %d: invalid guard %q", i+1, guard)
+			}
+			transition.Guards = append(transition.Guards, valueobject.ToOrderGuard(guard))
+		}
+
+		transitions = append(transitions, transition)
+	}
+
+	stateMachine, err := entity.NewOrderStateMachine(transitions, required...)
+	if err != nil {
+		return nil, fmt.Errorf("invalid order state machine: %w", err)
+	}
+
+	return stateMachine, nil
+}
@@ -0,0 +1,104 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
+	valueobject "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/value_object"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/infrastructure/config"
+)
+
+func TestLoadOrderStateMachine(t *testing.T) {
+	tests := []struct {
+		name       string
+		definition string
+		required   []entity.OrderTransition
+		wantErr    string
+	}{
+		{
+			name: "should load a YAML definition",
+			definition: `
+transitions:
+  - { from: OPEN, to: PENDING, guards: [HAS_PRODUCTS] }
+  - { from: PENDING, to: RECEIVED, staff: true, roles: [ATTENDANT, MANAGER] }
+  - { from: PENDING, to: OPEN }
+`,
+		},
+		{
+			name:       "should load a JSON definition",
+			definition: `{"transitions": [{"from": "open", "to": "pending"}, {"from": "pending", "to": "received"}, {"from": "pending", "to": "open"}]}`,
+		},
+		{
+			name:       "should refuse a definition without a transition of the order expiry",
+			definition: "transitions:\n  - { from: OPEN, to: PENDING }\n  - { from: PENDING, to: RECEIVED }\n  - { from: PENDING, to: OPEN }\n",
+			required:   []entity.OrderTransition{{From: valueobject.OPEN, To: valueobject.CANCELLED}},
+			wantErr:    "invalid order state machine: missing transition OPEN -> CANCELLED",
+		},
+		{
+			name:       "should refuse an unknown status",
+			definition: "transitions:\n  - { from: OPEN, to: SHIPPED }\n",
+			wantErr:    `invalid order state machine: transition 1: invalid status "SHIPPED"`,
+		},
+		{
+			name:       "should refuse an unknown role",
+			definition: "transitions:\n  - { from: OPEN, to: PENDING, roles: [CHEF] }\n",
+			wantErr:    `invalid order state machine: transition 1: invalid role "CHEF"`,
+		},
+		{
+			name:       "should refuse an unknown guard",
+			definition: "transitions:\n  - { from: OPEN, to: PENDING, guards: [IS_PAID] }\n",
+			wantErr:    `invalid order state machine: transition 1: invalid guard "IS_PAID"`,
+		},
+		{
+			name:       "should refuse an unknown field",
+			definition: "transitions:\n  - { from: OPEN, to: PENDING, role: MANAGER }\n",
+			wantErr:    "error decoding order state machine",
+		},
+		{
+			name:       "should refuse a status that cannot be reached",
+			definition: "transitions:\n  - { from: OPEN, to: PENDING }\n  - { from: PENDING, to: RECEIVED }\n  - { from: PENDING, to: OPEN }\n  - { from: READY, to: COMPLETED }\n",
+			wantErr:    "invalid order state machine: transition 4: READY is unreachable from OPEN",
+		},
+		{
+			name:       "should refuse a state machine the orders cannot leave OPEN",
+			definition: "transitions:\n  - { from: PENDING, to: OPEN }\n",
+			wantErr:    "invalid order state machine: missing transition OPEN -> PENDING",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "order_state_machine.yaml")
+			assert.NoError(t, os.WriteFile(path, []byte(tt.definition), 0o600))
+
+			stateMachine, err := config.LoadOrderStateMachine(path, tt.required...)
+
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				assert.Nil(t, stateMachine)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.True(t, stateMachine.CanTransition(valueobject.OPEN, valueobject.PENDING))
+			assert.True(t, stateMachine.CanTransition(valueobject.PENDING, valueobject.RECEIVED))
+		})
+	}
+}
+
+func TestLoadOrderStateMachine_Default(t *testing.T) {
+	stateMachine, err := config.LoadOrderStateMachine("")
+	assert.NoError(t, err)
+	assert.True(t, stateMachine.CanTransition(valueobject.OPEN, valueobject.PENDING))
+	assert.True(t, stateMachine.CanTransition(valueobject.READY, valueobject.COMPLETED))
+	assert.False(t, stateMachine.CanTransition(valueobject.COMPLETED, valueobject.OPEN))
+}
+
+func TestLoadOrderStateMachine_MissingFile(t *testing.T) {
+	_, err := config.LoadOrderStateMachine(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.ErrorContains(t, err, "error reading order state machine")
+}
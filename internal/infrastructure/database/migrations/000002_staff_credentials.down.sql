@@ -0,0 +1,2 @@
+ALTER TABLE staffs
+    DROP COLUMN IF EXISTS password_hash;
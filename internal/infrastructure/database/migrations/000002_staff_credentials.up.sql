@@ -0,0 +1,4 @@
+ALTER TABLE staffs
+    ADD COLUMN IF NOT EXISTS password_hash VARCHAR;
+
+-- Staffs without a password cannot log in until one is set (`set-staff-password` command or by a manager)
@@ -0,0 +1,25 @@
+CREATE TABLE IF NOT EXISTS refresh_tokens
+(
+    id                      SERIAL PRIMARY KEY,
+    family_id               VARCHAR   NOT NULL,
+    token_hash              VARCHAR   NOT NULL UNIQUE,
+    subject_type            VARCHAR   NOT NULL CHECK (subject_type IN ('CUSTOMER', 'STAFF')),
+    subject_id              INT       NOT NULL,
+    role                    VARCHAR,
+    access_token_id         VARCHAR,
+    access_token_expires_at TIMESTAMP,
+    expires_at              TIMESTAMP NOT NULL,
+    used_at                 TIMESTAMP,
+    revoked_at              TIMESTAMP,
+    created_at              TIMESTAMP NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_family_id ON refresh_tokens (family_id);
+
+CREATE TABLE IF NOT EXISTS revoked_tokens
+(
+    id         SERIAL PRIMARY KEY,
+    jti        VARCHAR   NOT NULL UNIQUE,
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT now()
+);
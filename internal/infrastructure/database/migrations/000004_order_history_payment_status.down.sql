@@ -0,0 +1,2 @@
+ALTER TABLE order_histories
+    DROP COLUMN IF EXISTS payment_status;
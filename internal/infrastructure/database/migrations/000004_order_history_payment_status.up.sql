@@ -0,0 +1,2 @@
+ALTER TABLE order_histories
+    ADD COLUMN IF NOT EXISTS payment_status VARCHAR CHECK (payment_status IN ('PROCESSING', 'CONFIRMED', 'ABORTED', 'FAILED'));
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS payment_notifications
+(
+    id           SERIAL PRIMARY KEY,
+    event_id     VARCHAR   NOT NULL UNIQUE,
+    resource     VARCHAR   NOT NULL,
+    topic        VARCHAR,
+    status       VARCHAR CHECK (status IN ('PROCESSING', 'CONFIRMED', 'ABORTED', 'FAILED', '')),
+    processed_at TIMESTAMP,
+    created_at   TIMESTAMP NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_payment_notifications_resource ON payment_notifications (resource);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_payments_status_expires_at;
+
+ALTER TABLE payments
+    DROP COLUMN IF EXISTS expires_at;
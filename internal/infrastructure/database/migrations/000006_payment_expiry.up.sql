@@ -0,0 +1,10 @@
+ALTER TABLE payments
+    ADD COLUMN IF NOT EXISTS expires_at TIMESTAMP;
+
+-- Payments created before the expiry get the default expiration
+UPDATE payments
+SET expires_at = created_at + INTERVAL '15 minutes'
+WHERE status = 'PROCESSING'
+  AND expires_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_payments_status_expires_at ON payments (status, expires_at);
@@ -0,0 +1,32 @@
+DROP TABLE IF EXISTS payment_refunds;
+
+UPDATE payments
+SET status = 'CONFIRMED'
+WHERE status IN ('PARTIALLY_REFUNDED', 'REFUNDED');
+
+UPDATE order_histories
+SET payment_status = 'CONFIRMED'
+WHERE payment_status IN ('PARTIALLY_REFUNDED', 'REFUNDED');
+
+UPDATE payment_notifications
+SET status = ''
+WHERE status IN ('PARTIALLY_REFUNDED', 'REFUNDED');
+
+ALTER TABLE payment_notifications
+    DROP CONSTRAINT IF EXISTS payment_notifications_status_check,
+    ADD CONSTRAINT payment_notifications_status_check
+        CHECK (status IN ('PROCESSING', 'CONFIRMED', 'ABORTED', 'FAILED', ''));
+
+ALTER TABLE order_histories
+    DROP CONSTRAINT IF EXISTS order_histories_payment_status_check,
+    ADD CONSTRAINT order_histories_payment_status_check
+        CHECK (payment_status IN ('PROCESSING', 'CONFIRMED', 'ABORTED', 'FAILED'));
+
+ALTER TABLE payments
+    DROP CONSTRAINT IF EXISTS payments_status_check,
+    ADD CONSTRAINT payments_status_check
+        CHECK (status IN ('PROCESSING', 'CONFIRMED', 'ABORTED', 'FAILED'));
+
+ALTER TABLE payments
+    DROP COLUMN IF EXISTS refunded_amount,
+    DROP COLUMN IF EXISTS amount;
@@ -0,0 +1,43 @@
+ALTER TABLE payments
+    ADD COLUMN IF NOT EXISTS amount          DECIMAL(19, 2),
+    ADD COLUMN IF NOT EXISTS refunded_amount DECIMAL(19, 2) NOT NULL DEFAULT 0;
+
+-- Payments created before the refunds get the total of their order
+UPDATE payments
+SET amount = (SELECT COALESCE(SUM(op.quantity * p.price), 0)
+              FROM order_products op
+                       JOIN products p ON p.id = op.product_id
+              WHERE op.order_id = payments.order_id)
+WHERE amount IS NULL;
+
+ALTER TABLE payments
+    ALTER COLUMN amount SET NOT NULL,
+    ALTER COLUMN amount SET DEFAULT 0;
+
+ALTER TABLE payments
+    DROP CONSTRAINT IF EXISTS payments_status_check,
+    ADD CONSTRAINT payments_status_check
+        CHECK (status IN ('PROCESSING', 'CONFIRMED', 'ABORTED', 'FAILED', 'PARTIALLY_REFUNDED', 'REFUNDED'));
+
+ALTER TABLE order_histories
+    DROP CONSTRAINT IF EXISTS order_histories_payment_status_check,
+    ADD CONSTRAINT order_histories_payment_status_check
+        CHECK (payment_status IN ('PROCESSING', 'CONFIRMED', 'ABORTED', 'FAILED', 'PARTIALLY_REFUNDED', 'REFUNDED'));
+
+ALTER TABLE payment_notifications
+    DROP CONSTRAINT IF EXISTS payment_notifications_status_check,
+    ADD CONSTRAINT payment_notifications_status_check
+        CHECK (status IN ('PROCESSING', 'CONFIRMED', 'ABORTED', 'FAILED', 'PARTIALLY_REFUNDED', 'REFUNDED', ''));
+
+CREATE TABLE IF NOT EXISTS payment_refunds
+(
+    id                 SERIAL PRIMARY KEY,
+    payment_id         INT REFERENCES payments (id) NOT NULL,
+    external_refund_id VARCHAR,
+    amount             DECIMAL(19, 2)               NOT NULL CHECK (amount > 0),
+    reason             VARCHAR                      NOT NULL,
+    staff_id           INT REFERENCES staffs (id)   NOT NULL,
+    created_at         TIMESTAMP                    NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_payment_refunds_payment_id ON payment_refunds (payment_id);
@@ -0,0 +1,7 @@
+-- Payments created before the registry were handled by the fake Mercado Pago
+ALTER TABLE payments
+    ADD COLUMN IF NOT EXISTS provider VARCHAR NOT NULL DEFAULT 'fake'
+        CHECK (provider IN ('mercadopago', 'fake', 'cash', 'pix-static'));
+
+ALTER TABLE payments
+    ALTER COLUMN provider DROP DEFAULT;
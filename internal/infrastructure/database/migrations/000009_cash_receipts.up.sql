@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS cash_receipts
+(
+    id              SERIAL PRIMARY KEY,
+    payment_id      INT REFERENCES payments (id) NOT NULL UNIQUE,
+    amount_received DECIMAL(19, 2)               NOT NULL CHECK (amount_received > 0),
+    change_given    DECIMAL(19, 2)               NOT NULL DEFAULT 0 CHECK (change_given >= 0),
+    staff_id        INT REFERENCES staffs (id)   NOT NULL,
+    created_at      TIMESTAMP                    NOT NULL DEFAULT now()
+);
@@ -0,0 +1,4 @@
+ALTER TABLE order_products
+    DROP COLUMN IF EXISTS category_id,
+    DROP COLUMN IF EXISTS product_name,
+    DROP COLUMN IF EXISTS unit_price;
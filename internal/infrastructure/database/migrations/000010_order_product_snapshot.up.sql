@@ -0,0 +1,18 @@
+ALTER TABLE order_products
+    ADD COLUMN IF NOT EXISTS unit_price   DECIMAL(19, 2),
+    ADD COLUMN IF NOT EXISTS product_name VARCHAR,
+    ADD COLUMN IF NOT EXISTS category_id  INT REFERENCES categories (id);
+
+-- Products added before the snapshot keep the current price of the product
+UPDATE order_products op
+SET unit_price   = p.price,
+    product_name = p.name,
+    category_id  = p.category_id
+FROM products p
+WHERE p.id = op.product_id
+  AND op.unit_price IS NULL;
+
+ALTER TABLE order_products
+    ALTER COLUMN unit_price SET NOT NULL,
+    ALTER COLUMN product_name SET NOT NULL,
+    ALTER COLUMN category_id SET NOT NULL;
@@ -0,0 +1,6 @@
+-- Incremented on every update, an update based on an older version is refused
+ALTER TABLE orders
+    ADD COLUMN IF NOT EXISTS version INT NOT NULL DEFAULT 0;
+
+ALTER TABLE products
+    ADD COLUMN IF NOT EXISTS version INT NOT NULL DEFAULT 0;
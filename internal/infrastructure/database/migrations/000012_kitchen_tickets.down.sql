@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS kitchen_ticket_bumps;
+
+ALTER TABLE categories
+    DROP COLUMN IF EXISTS station;
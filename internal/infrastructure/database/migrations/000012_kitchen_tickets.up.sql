@@ -0,0 +1,17 @@
+ALTER TABLE categories
+    ADD COLUMN IF NOT EXISTS station VARCHAR(20) NOT NULL DEFAULT 'GRILL'
+        CHECK (station IN ('GRILL', 'DRINKS', 'DESSERTS'));
+
+UPDATE categories SET station = 'DRINKS' WHERE name = 'Bebidas';
+UPDATE categories SET station = 'DESSERTS' WHERE name = 'Sobremesas';
+
+-- A ticket is done once bumped, the pending ones are the order products of the stations without a bump
+CREATE TABLE IF NOT EXISTS kitchen_ticket_bumps
+(
+    id         SERIAL PRIMARY KEY,
+    order_id   INT REFERENCES orders (id) NOT NULL,
+    station    VARCHAR(20)                NOT NULL,
+    staff_id   INT REFERENCES staffs (id) NOT NULL,
+    created_at TIMESTAMP                  NOT NULL DEFAULT now(),
+    UNIQUE (order_id, station)
+);
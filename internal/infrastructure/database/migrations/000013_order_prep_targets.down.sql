@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_order_histories_order_id;
+
+ALTER TABLE categories DROP COLUMN IF EXISTS prep_target_minutes;
@@ -0,0 +1,9 @@
+-- Minutes the kitchen has to get an order with products of the category READY, 0 uses ORDER_PREP_TARGET
+ALTER TABLE categories
+    ADD COLUMN IF NOT EXISTS prep_target_minutes INT NOT NULL DEFAULT 0
+        CHECK (prep_target_minutes >= 0);
+
+UPDATE categories SET prep_target_minutes = 5 WHERE name IN ('Bebidas', 'Sobremesas');
+
+-- Time in status reads the histories of each order
+CREATE INDEX IF NOT EXISTS idx_order_histories_order_id ON order_histories (order_id);
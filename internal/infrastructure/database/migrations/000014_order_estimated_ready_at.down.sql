@@ -0,0 +1,3 @@
+ALTER TABLE products DROP COLUMN IF EXISTS prep_minutes;
+
+ALTER TABLE orders DROP COLUMN IF EXISTS estimated_ready_at;
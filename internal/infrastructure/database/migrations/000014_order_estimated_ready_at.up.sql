@@ -0,0 +1,6 @@
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS estimated_ready_at TIMESTAMP;
+
+-- Estimated minutes to prepare the product, 0 when unknown
+ALTER TABLE products
+    ADD COLUMN IF NOT EXISTS prep_minutes INT NOT NULL DEFAULT 0
+        CHECK (prep_minutes >= 0);
@@ -0,0 +1,21 @@
+DROP INDEX IF EXISTS idx_orders_status_updated_at;
+
+ALTER TABLE order_histories DROP COLUMN IF EXISTS system_actor;
+
+-- An enum value cannot be dropped, so the type is created again without it
+UPDATE orders SET status = 'COMPLETED' WHERE status = 'ABANDONED';
+UPDATE order_histories SET status = 'COMPLETED' WHERE status = 'ABANDONED';
+
+ALTER TYPE order_status RENAME TO order_status_old;
+CREATE TYPE order_status AS ENUM ('OPEN','CANCELLED','PENDING','RECEIVED', 'PREPARING', 'READY', 'COMPLETED');
+
+ALTER TABLE orders
+    ALTER COLUMN status DROP DEFAULT,
+    ALTER COLUMN status TYPE order_status USING status::text::order_status,
+    ALTER COLUMN status SET DEFAULT 'OPEN';
+ALTER TABLE order_histories
+    ALTER COLUMN status DROP DEFAULT,
+    ALTER COLUMN status TYPE order_status USING status::text::order_status,
+    ALTER COLUMN status SET DEFAULT 'OPEN';
+
+DROP TYPE order_status_old;
@@ -0,0 +1,9 @@
+-- READY orders never collected may be flagged as abandoned
+ALTER TYPE order_status ADD VALUE IF NOT EXISTS 'ABANDONED';
+
+-- Changes made by an automatic process instead of a staff
+ALTER TABLE order_histories
+    ADD COLUMN IF NOT EXISTS system_actor VARCHAR CHECK (system_actor IN ('ORDER_EXPIRY'));
+
+-- The expiry looks for the orders idle on a status
+CREATE INDEX IF NOT EXISTS idx_orders_status_updated_at ON orders (status, updated_at);
@@ -0,0 +1,2 @@
+ALTER TABLE orders
+    DROP COLUMN IF EXISTS guest_token_hash;
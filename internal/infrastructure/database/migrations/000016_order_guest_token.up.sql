@@ -0,0 +1,3 @@
+-- Hash of the secret given to the guest who placed the order, required to reach it while it has no customer
+ALTER TABLE orders
+    ADD COLUMN IF NOT EXISTS guest_token_hash VARCHAR(64) NOT NULL DEFAULT '';
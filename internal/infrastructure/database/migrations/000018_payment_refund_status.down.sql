@@ -0,0 +1,11 @@
+DROP INDEX IF EXISTS idx_payment_refunds_pending;
+DROP INDEX IF EXISTS idx_payment_refunds_idempotency_key;
+
+DELETE FROM payment_refunds WHERE status = 'PENDING' OR staff_id IS NULL;
+
+ALTER TABLE payment_refunds
+    ALTER COLUMN staff_id SET NOT NULL;
+
+ALTER TABLE payment_refunds
+    DROP COLUMN IF EXISTS idempotency_key,
+    DROP COLUMN IF EXISTS status;
@@ -0,0 +1,13 @@
+-- A refund is recorded as PENDING before the payment provider is called, and COMPLETED once it gave the money back
+ALTER TABLE payment_refunds
+    ADD COLUMN IF NOT EXISTS status          VARCHAR NOT NULL DEFAULT 'COMPLETED' CHECK (status IN ('PENDING', 'COMPLETED')),
+    ADD COLUMN IF NOT EXISTS idempotency_key VARCHAR;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_payment_refunds_idempotency_key ON payment_refunds (idempotency_key);
+
+-- A payment has at most one refund in progress
+CREATE UNIQUE INDEX IF NOT EXISTS idx_payment_refunds_pending ON payment_refunds (payment_id) WHERE status = 'PENDING';
+
+-- The refunds of the orders cancelled by an automatic process (ex: order expiry) have no staff
+ALTER TABLE payment_refunds
+    ALTER COLUMN staff_id DROP NOT NULL;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_order_histories_snapshot_xmax;
+
+ALTER TABLE order_histories DROP COLUMN IF EXISTS snapshot_xmax;
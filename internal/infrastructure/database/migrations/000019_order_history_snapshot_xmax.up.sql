@@ -0,0 +1,10 @@
+-- The xmax of the snapshot each history was inserted with: once every transaction below it ended, no history with a
+-- lower ID can be committed anymore, so the streams read the histories in the order of their IDs without missing any
+ALTER TABLE order_histories
+    ADD COLUMN IF NOT EXISTS snapshot_xmax xid8 NOT NULL DEFAULT '0';
+
+ALTER TABLE order_histories
+    ALTER COLUMN snapshot_xmax SET DEFAULT pg_snapshot_xmax(pg_current_snapshot());
+
+-- The streams look for the histories still waiting for an older transaction
+CREATE INDEX IF NOT EXISTS idx_order_histories_snapshot_xmax ON order_histories (snapshot_xmax);
@@ -0,0 +1,34 @@
+package datasource
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
+	valueobject "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/value_object"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/port"
+)
+
+// CashPaymentExternalDataSource is the provider of the payments made at the counter, nothing is sent anywhere
+type CashPaymentExternalDataSource struct{}
+
+func NewCashPaymentExternalDataSource() port.PaymentExternalDatasource {
+	return &CashPaymentExternalDataSource{}
+}
+
+// Create identifies the payment, there is no QR code to pay
+func (ds *CashPaymentExternalDataSource) Create(_ context.Context, _ *entity.CreatePaymentExternalInput) (*entity.CreatePaymentExternalOutput, error) {
+	return &entity.CreatePaymentExternalOutput{InStoreOrderID: uuid.NewString()}, nil
+}
+
+// GetStatus is not supported, the payment is confirmed by the attendant who receives the money
+func (ds *CashPaymentExternalDataSource) GetStatus(_ context.Context, _ *entity.Payment) (valueobject.PaymentStatus, error) {
+	return valueobject.UNDEFINDED_P, domain.ErrPaymentStatusUnavailable
+}
+
+// Refund identifies the refund, the money is given back at the counter
+func (ds *CashPaymentExternalDataSource) Refund(_ context.Context, _ *entity.RefundPaymentExternalInput) (*entity.RefundPaymentExternalOutput, error) {
+	return &entity.RefundPaymentExternalOutput{ExternalRefundID: uuid.NewString()}, nil
+}
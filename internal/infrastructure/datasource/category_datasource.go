@@ -20,7 +20,7 @@ func NewCategoryDataSource(db *gorm.DB) port.CategoryDataSource {
 
 func (ds *categoryDataSource) FindByID(ctx context.Context, id uint64) (*entity.Category, error) {
 	var category entity.Category
-	result := ds.db.WithContext(ctx).First(&category, id)
+	result := dbFromContext(ctx, ds.db).First(&category, id)
 	if result.Error != nil {
 		if result.Error == gorm.ErrRecordNotFound {
 			return nil, nil
@@ -34,7 +34,7 @@ func (ds *categoryDataSource) FindAll(ctx context.Context, filters map[string]in
 	var categorys []*entity.Category
 	var total int64
 
-	query := ds.db.WithContext(ctx)
+	query := dbFromContext(ctx, ds.db)
 
 	// Apply filters
 	for key, value := range filters {
@@ -61,14 +61,14 @@ func (ds *categoryDataSource) FindAll(ctx context.Context, filters map[string]in
 }
 
 func (ds *categoryDataSource) Create(ctx context.Context, category *entity.Category) error {
-	if err := ds.db.WithContext(ctx).Create(category).Error; err != nil {
+	if err := dbFromContext(ctx, ds.db).Create(category).Error; err != nil {
 		return fmt.Errorf("error creating category: %w", err)
 	}
 	return nil
 }
 
 func (ds *categoryDataSource) Update(ctx context.Context, category *entity.Category) error {
-	result := ds.db.WithContext(ctx).Save(category)
+	result := dbFromContext(ctx, ds.db).Save(category)
 	if result.Error != nil {
 		return fmt.Errorf("error updating category: %w", result.Error)
 	}
@@ -79,7 +79,7 @@ func (ds *categoryDataSource) Update(ctx context.Context, category *entity.Categ
 }
 
 func (ds *categoryDataSource) Delete(ctx context.Context, id uint64) error {
-	result := ds.db.WithContext(ctx).Delete(&entity.Category{}, id)
+	result := dbFromContext(ctx, ds.db).Delete(&entity.Category{}, id)
 	if result.Error != nil {
 		return fmt.Errorf("error deleting category: %w", result.Error)
 	}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/google/uuid"
 	"gorm.io/gorm"
 
 	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
@@ -15,15 +14,13 @@ type customerDataSource struct {
 	db *gorm.DB
 }
 
-type customerKey string
-
 func NewCustomerDataSource(db *gorm.DB) port.CustomerDataSource {
 	return &customerDataSource{db}
 }
 
 func (ds *customerDataSource) FindByID(ctx context.Context, id uint64) (*entity.Customer, error) {
 	var customer entity.Customer
-	result := ds.db.WithContext(ctx).First(&customer, id)
+	result := dbFromContext(ctx, ds.db).First(&customer, id)
 	if result.Error != nil {
 		if result.Error == gorm.ErrRecordNotFound {
 			return nil, nil
@@ -35,7 +32,7 @@ func (ds *customerDataSource) FindByID(ctx context.Context, id uint64) (*entity.
 
 func (ds *customerDataSource) FindByCPF(ctx context.Context, cpf string) (*entity.Customer, error) {
 	var customer entity.Customer
-	result := ds.db.WithContext(ctx).Where("cpf = ?", cpf).First(&customer)
+	result := dbFromContext(ctx, ds.db).Where("cpf = ?", cpf).First(&customer)
 	if result.Error != nil {
 		if result.Error == gorm.ErrRecordNotFound {
 			return nil, nil
@@ -49,7 +46,7 @@ func (ds *customerDataSource) FindAll(ctx context.Context, filters map[string]in
 	var customers []*entity.Customer
 	var total int64
 
-	query := ds.db.WithContext(ctx)
+	query := dbFromContext(ctx, ds.db)
 
 	// Apply filters
 	for key, value := range filters {
@@ -76,14 +73,14 @@ func (ds *customerDataSource) FindAll(ctx context.Context, filters map[string]in
 }
 
 func (ds *customerDataSource) Create(ctx context.Context, customer *entity.Customer) error {
-	if err := ds.db.WithContext(ctx).Create(customer).Error; err != nil {
+	if err := dbFromContext(ctx, ds.db).Create(customer).Error; err != nil {
 		return fmt.Errorf("error creating customer: %w", err)
 	}
 	return nil
 }
 
 func (ds *customerDataSource) Update(ctx context.Context, customer *entity.Customer) error {
-	result := ds.db.WithContext(ctx).Save(customer)
+	result := dbFromContext(ctx, ds.db).Save(customer)
 	if result.Error != nil {
 		return fmt.Errorf("error updating customer: %w", result.Error)
 	}
@@ -94,7 +91,7 @@ func (ds *customerDataSource) Update(ctx context.Context, customer *entity.Custo
 }
 
 func (ds *customerDataSource) Delete(ctx context.Context, id uint64) error {
-	result := ds.db.WithContext(ctx).Delete(&entity.Customer{}, id)
+	result := dbFromContext(ctx, ds.db).Delete(&entity.Customer{}, id)
 	if result.Error != nil {
 		return fmt.Errorf("error deleting customer: %w", result.Error)
 	}
@@ -103,12 +100,3 @@ func (ds *customerDataSource) Delete(ctx context.Context, id uint64) error {
 	}
 	return nil
 }
-
-func (ds *customerDataSource) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
-	return ds.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		// Create a new context with the transaction
-		keyPrincipalID := customerKey(uuid.NewString())
-		txCtx := context.WithValue(ctx, keyPrincipalID, tx)
-		return fn(txCtx)
-	})
-}
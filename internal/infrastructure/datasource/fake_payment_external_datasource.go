@@ -4,12 +4,14 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 
 	datasource_request "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/infrastructure/datasource/request"
 	datasource_response "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/infrastructure/datasource/response"
 
 	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain"
 	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
+	valueobject "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/value_object"
 	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/port"
 	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/infrastructure/config"
 	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/infrastructure/httpclient"
@@ -49,3 +51,48 @@ func (ds *FakePaymentExternalDataSource) Create(ctx context.Context, p *entity.C
 
 	return datasource_response.NewCreatePaymentExternalOutput(&fakeMercadoPagoResponse), nil
 }
+
+// GetStatus gets the status of the payment by its resource in the fake provider
+func (ds *FakePaymentExternalDataSource) GetStatus(ctx context.Context, p *entity.Payment) (valueobject.PaymentStatus, error) {
+	fakeMercadoPagoResponse := datasource_response.FakeMercadoPagoStatusResponse{}
+
+	response, err := ds.client.NewRequest().
+		SetContext(ctx).
+		SetPathParam("resource", p.ExternalPaymentID).
+		SetResult(&fakeMercadoPagoResponse).
+		Get(ds.cfg.FakeMercadoPagoStatusURL + "/{resource}")
+	if err != nil {
+		return valueobject.UNDEFINDED_P, fmt.Errorf("error to get payment status: %w", err)
+	}
+
+	if response.StatusCode() == 404 {
+		return valueobject.UNDEFINDED_P, domain.NewNotFoundError(domain.ErrPaymentNotFoundExternal)
+	}
+
+	if response.StatusCode() != 200 {
+		return valueobject.UNDEFINDED_P, fmt.Errorf("error: response status %d", response.StatusCode())
+	}
+
+	return valueobject.ToPaymentStatus(fakeMercadoPagoResponse.Status), nil
+}
+
+func (ds *FakePaymentExternalDataSource) Refund(ctx context.Context, r *entity.RefundPaymentExternalInput) (*entity.RefundPaymentExternalOutput, error) {
+	fakeMercadoPagoResponse := datasource_response.FakeMercadoPagoRefundResponse{}
+
+	response, err := ds.client.NewRequest().
+		SetContext(ctx).
+		SetHeader("Content-Type", "application/json").
+		SetHeader("X-Idempotency-Key", r.IdempotencyKey).
+		SetBody(datasource_request.NewFakeMercadoPagoRefundRequest(r)).
+		SetResult(&fakeMercadoPagoResponse).
+		Post(ds.cfg.FakeMercadoPagoRefundURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode() != 201 {
+		return nil, errors.New(domain.ErrFailedToRefundPaymentExternal)
+	}
+
+	return fakeMercadoPagoResponse.ToEntity(), nil
+}
@@ -0,0 +1,64 @@
+package datasource
+
+import (
+	"context"
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/port"
+)
+
+type kitchenTicketBumpDataSource struct {
+	db *gorm.DB
+}
+
+func NewKitchenTicketBumpDataSource(db *gorm.DB) port.KitchenTicketBumpDataSource {
+	return &kitchenTicketBumpDataSource{db}
+}
+
+func (ds *kitchenTicketBumpDataSource) FindAll(ctx context.Context, filters map[string]interface{}) ([]*entity.KitchenTicketBump, error) {
+	var bumps []*entity.KitchenTicketBump
+
+	query := dbFromContext(ctx, ds.db)
+
+	// Apply filters
+	for key, value := range filters {
+		switch key {
+		case "orderIDs":
+			if orderIDs, ok := value.([]uint64); ok {
+				// No order has no bumps, rather than every bump of every order
+				if len(orderIDs) == 0 {
+					return []*entity.KitchenTicketBump{}, nil
+				}
+				query = query.Where("order_id IN ?", orderIDs)
+			}
+		}
+	}
+
+	if err := query.Order("id").Find(&bumps).Error; err != nil {
+		return nil, fmt.Errorf("error finding kitchen ticket bumps: %w", err)
+	}
+
+	return bumps, nil
+}
+
+func (ds *kitchenTicketBumpDataSource) Create(ctx context.Context, bump *entity.KitchenTicketBump) (bool, error) {
+	db := dbFromContext(ctx, ds.db)
+
+	// Locking the order makes the concurrent bumps of its tickets wait for each other, so the last one sees all of them
+	var orderIDs []uint64
+	if err := db.Model(&entity.Order{}).Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", bump.OrderID).Pluck("id", &orderIDs).Error; err != nil {
+		return false, fmt.Errorf("error locking order of kitchen ticket bump: %w", err)
+	}
+
+	// The unique order and station make a ticket bumped twice insert a single row
+	result := db.Clauses(clause.OnConflict{DoNothing: true}).Create(bump)
+	if result.Error != nil {
+		return false, fmt.Errorf("error creating kitchen ticket bump: %w", result.Error)
+	}
+	return result.RowsAffected == 1, nil
+}
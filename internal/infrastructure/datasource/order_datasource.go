@@ -3,9 +3,10 @@ package datasource
 import (
 	"context"
 	"fmt"
+	"time"
 
-	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
 	valueobject "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/value_object"
@@ -16,15 +17,21 @@ type orderDataSource struct {
 	db *gorm.DB
 }
 
-type orderKey string
-
 func NewOrderDataSource(db *gorm.DB) port.OrderDataSource {
 	return &orderDataSource{db}
 }
 
 func (ds *orderDataSource) FindByID(ctx context.Context, id uint64) (*entity.Order, error) {
+	return ds.findByID(dbFromContext(ctx, ds.db), id)
+}
+
+func (ds *orderDataSource) FindByIDForUpdate(ctx context.Context, id uint64) (*entity.Order, error) {
+	return ds.findByID(dbFromContext(ctx, ds.db).Clauses(clause.Locking{Strength: "UPDATE"}), id)
+}
+
+func (ds *orderDataSource) findByID(db *gorm.DB, id uint64) (*entity.Order, error) {
 	var order entity.Order
-	result := ds.db.WithContext(ctx).Preload("Customer").Preload("OrderProducts.Product").First(&order, id)
+	result := db.Preload("Customer").Preload("OrderProducts.Product").First(&order, id)
 	if result.Error != nil {
 		if result.Error == gorm.ErrRecordNotFound {
 			return nil, nil
@@ -38,7 +45,7 @@ func (ds *orderDataSource) FindAll(ctx context.Context, filters map[string]any,
 	var orders []*entity.Order
 	var total int64
 
-	query := ds.db.WithContext(ctx).Preload("Customer").Preload("OrderProducts.Product")
+	query := dbFromContext(ctx, ds.db).Preload("Customer").Preload("OrderProducts.Product")
 
 	// Apply filters
 	for key, value := range filters {
@@ -77,32 +84,68 @@ func (ds *orderDataSource) FindAll(ctx context.Context, filters map[string]any,
 	return orders, total, nil
 }
 
+func (ds *orderDataSource) FindIdle(ctx context.Context, status valueobject.OrderStatus, idleSince time.Time, limit int) ([]*entity.Order, error) {
+	var orders []*entity.Order
+	// Adding or changing a product of the order is an activity too
+	err := dbFromContext(ctx, ds.db).
+		Where("status = ? AND updated_at < ?", status, idleSince).
+		Where("NOT EXISTS (SELECT 1 FROM order_products WHERE order_products.order_id = orders.id AND order_products.updated_at >= ?)", idleSince).
+		Order("updated_at").
+		Limit(limit).
+		Find(&orders).Error
+	if err != nil {
+		return nil, fmt.Errorf("error finding idle orders: %w", err)
+	}
+	return orders, nil
+}
+
 func (ds *orderDataSource) Create(ctx context.Context, order *entity.Order) error {
-	if err := ds.db.WithContext(ctx).Create(order).Error; err != nil {
+	if err := dbFromContext(ctx, ds.db).Create(order).Error; err != nil {
 		return fmt.Errorf("error creating order: %w", err)
 	}
 	return nil
 }
 
-func (ds *orderDataSource) Update(ctx context.Context, order *entity.Order) error {
-	result := ds.db.WithContext(ctx).Preload("OrderProducts").Save(order)
+// Update saves the order only if it is still on the version it was read, returning false when it was updated
+// meanwhile. The version of the order is incremented. The estimated ready time is only saved by
+// UpdateEstimatedReadyAt, so an order read before it was estimated does not overwrite it.
+func (ds *orderDataSource) Update(ctx context.Context, order *entity.Order) (bool, error) {
+	version := order.Version
+	order.Version++
+
+	result := dbFromContext(ctx, ds.db).
+		Model(order).
+		Where("version = ?", version).
+		Select("*").
+		Omit(clause.Associations, "EstimatedReadyAt").
+		Updates(order)
 	if result.Error != nil {
-		return fmt.Errorf("error updating order: %w", result.Error)
+		order.Version = version
+		return false, fmt.Errorf("error updating order: %w", result.Error)
 	}
 
 	if result.RowsAffected == 0 {
-		return nil
+		order.Version = version
+		return false, nil
+	}
+	return true, nil
+}
+
+func (ds *orderDataSource) UpdateEstimatedReadyAt(ctx context.Context, id uint64, estimatedReadyAt time.Time) error {
+	if err := dbFromContext(ctx, ds.db).Model(&entity.Order{}).Where("id = ?", id).
+		UpdateColumn("estimated_ready_at", estimatedReadyAt).Error; err != nil {
+		return fmt.Errorf("error updating estimated ready time of order: %w", err)
 	}
 	return nil
 }
 
 func (ds *orderDataSource) Delete(ctx context.Context, id uint64) error {
 	// Delete all order products first
-	if err := ds.db.WithContext(ctx).Where("order_id = ?", id).Delete(&entity.OrderProduct{}).Error; err != nil {
+	if err := dbFromContext(ctx, ds.db).Where("order_id = ?", id).Delete(&entity.OrderProduct{}).Error; err != nil {
 		return fmt.Errorf("error deleting order products: %w", err)
 	}
 
-	result := ds.db.WithContext(ctx).Delete(&entity.Order{}, id)
+	result := dbFromContext(ctx, ds.db).Delete(&entity.Order{}, id)
 	if result.Error != nil {
 		return fmt.Errorf("error deleting order: %w", result.Error)
 	}
@@ -111,12 +154,3 @@ func (ds *orderDataSource) Delete(ctx context.Context, id uint64) error {
 	}
 	return nil
 }
-
-func (ds *orderDataSource) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
-	return ds.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		// Create a new context with the transaction
-		keyPrincipalID := orderKey(uuid.NewString())
-		txCtx := context.WithValue(ctx, keyPrincipalID, tx)
-		return fn(txCtx)
-	})
-}
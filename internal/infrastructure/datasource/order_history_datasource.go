@@ -4,20 +4,26 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
+	"time"
 
-	"github.com/google/uuid"
 	"gorm.io/gorm"
 
 	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
+	valueobject "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/value_object"
 	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/port"
 )
 
+// unsettledOrderHistorySQL selects the lowest ID after the given one of the histories inserted while a transaction
+// that is still running had already started: it may still commit a history with a lower ID. The histories from it on
+// are not read yet, so a stream resuming after an ID cannot miss a history committed later with a lower one.
+const unsettledOrderHistorySQL = `SELECT MIN(unsettled.id) FROM order_histories AS unsettled
+	WHERE unsettled.id > ? AND unsettled.snapshot_xmax > pg_snapshot_xmin(pg_current_snapshot())`
+
 type orderHistoryDataSource struct {
 	db *gorm.DB
 }
 
-type orderHistoryKey string
-
 func NewOrderHistoryDataSource(db *gorm.DB) port.OrderHistoryDataSource {
 	return &orderHistoryDataSource{
 		db: db,
@@ -26,7 +32,7 @@ func NewOrderHistoryDataSource(db *gorm.DB) port.OrderHistoryDataSource {
 
 func (ds *orderHistoryDataSource) FindByID(ctx context.Context, id uint64) (*entity.OrderHistory, error) {
 	var orderHistory entity.OrderHistory
-	result := ds.db.WithContext(ctx).First(&orderHistory, id)
+	result := dbFromContext(ctx, ds.db).First(&orderHistory, id)
 	if result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
 			return nil, nil
@@ -40,7 +46,7 @@ func (ds *orderHistoryDataSource) FindAll(ctx context.Context, filters map[strin
 	var orderHistories []*entity.OrderHistory
 	var total int64
 
-	query := ds.db.WithContext(ctx)
+	query := dbFromContext(ctx, ds.db)
 
 	// Apply filters
 	for key, value := range filters {
@@ -71,15 +77,102 @@ func (ds *orderHistoryDataSource) FindAll(ctx context.Context, filters map[strin
 	return orderHistories, total, nil
 }
 
+func (ds *orderHistoryDataSource) FindAfter(ctx context.Context, afterID uint64, filters map[string]interface{}, limit int) ([]*entity.OrderHistory, error) {
+	var orderHistories []*entity.OrderHistory
+
+	query := dbFromContext(ctx, ds.db).
+		Preload("Order").
+		Where("order_histories.id > ?", afterID).
+		Where("order_histories.id < COALESCE(("+unsettledOrderHistorySQL+"), ?)", afterID, int64(math.MaxInt64))
+
+	// Apply filters
+	for key, value := range filters {
+		switch key {
+		case "statuses":
+			if statuses, ok := value.([]valueobject.OrderStatus); ok && len(statuses) > 0 {
+				query = query.Where("order_histories.status IN ?", statuses)
+			}
+		case "customerID":
+			if customerID, ok := value.(uint64); ok && customerID != 0 {
+				query = query.Joins("JOIN orders ON orders.id = order_histories.order_id").
+					Where("orders.customer_id = ?", customerID)
+			}
+		}
+	}
+
+	if err := query.Order("order_histories.id").Limit(limit).Find(&orderHistories).Error; err != nil {
+		return nil, fmt.Errorf("error finding orderHistories after %d: %w", afterID, err)
+	}
+
+	return orderHistories, nil
+}
+
+func (ds *orderHistoryDataSource) FindByOrderIDs(ctx context.Context, orderIDs []uint64) ([]*entity.OrderHistory, error) {
+	var orderHistories []*entity.OrderHistory
+	if err := dbFromContext(ctx, ds.db).Where("order_id IN ?", orderIDs).Order("id").Find(&orderHistories).Error; err != nil {
+		return nil, fmt.Errorf("error finding orderHistories of orders: %w", err)
+	}
+	return orderHistories, nil
+}
+
+func (ds *orderHistoryDataSource) AveragePrepTime(ctx context.Context, sampleSize int) (time.Duration, error) {
+	var seconds float64
+	// Each READY history is paired with the PREPARING one before it
+	err := dbFromContext(ctx, ds.db).Raw(`
+		SELECT COALESCE(AVG(EXTRACT(EPOCH FROM ready.created_at - preparing.created_at)), 0)
+		FROM (
+			SELECT order_id, created_at FROM order_histories
+			WHERE status = ? ORDER BY id DESC LIMIT ?
+		) AS ready
+		JOIN LATERAL (
+			SELECT created_at FROM order_histories
+			WHERE order_id = ready.order_id AND status = ? AND created_at <= ready.created_at
+			ORDER BY id DESC LIMIT 1
+		) AS preparing ON true`,
+		valueobject.READY, sampleSize, valueobject.PREPARING,
+	).Scan(&seconds).Error
+	if err != nil {
+		return 0, fmt.Errorf("error averaging preparation time: %w", err)
+	}
+	return time.Duration(seconds * float64(time.Second)), nil
+}
+
+// Create stores the history with the xmax of a snapshot taken after its ID, see unsettledOrderHistorySQL. The
+// transaction gets its own ID before the history does, so a concurrent history inserted later waits for it.
 func (ds *orderHistoryDataSource) Create(ctx context.Context, orderHistory *entity.OrderHistory) error {
-	if err := ds.db.WithContext(ctx).Create(orderHistory).Error; err != nil {
-		return fmt.Errorf("error creating orderHistory: %w", err)
+	// Inside a unit of work this is a savepoint of the transaction of the unit of work
+	return dbFromContext(ctx, ds.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT pg_current_xact_id()").Error; err != nil {
+			return fmt.Errorf("error starting orderHistory transaction: %w", err)
+		}
+
+		// The snapshot of the insert is taken once the ID is, in its own statement
+		if err := tx.Raw("SELECT nextval(pg_get_serial_sequence('order_histories', 'id'))").Scan(&orderHistory.ID).Error; err != nil {
+			return fmt.Errorf("error generating orderHistory ID: %w", err)
+		}
+
+		if err := tx.Create(orderHistory).Error; err != nil {
+			return fmt.Errorf("error creating orderHistory: %w", err)
+		}
+		return nil
+	})
+}
+
+func (ds *orderHistoryDataSource) FindLastID(ctx context.Context) (uint64, error) {
+	var lastID uint64
+	err := dbFromContext(ctx, ds.db).
+		Model(&entity.OrderHistory{}).
+		Select("COALESCE(MAX(id), 0)").
+		Where("id < COALESCE(("+unsettledOrderHistorySQL+"), ?)", 0, int64(math.MaxInt64)).
+		Scan(&lastID).Error
+	if err != nil {
+		return 0, fmt.Errorf("error finding last orderHistory ID: %w", err)
 	}
-	return nil
+	return lastID, nil
 }
 
 func (ds *orderHistoryDataSource) Update(ctx context.Context, orderHistory *entity.OrderHistory) error {
-	result := ds.db.WithContext(ctx).Save(orderHistory)
+	result := dbFromContext(ctx, ds.db).Save(orderHistory)
 	if result.Error != nil {
 		return fmt.Errorf("error updating orderHistory: %w", result.Error)
 	}
@@ -90,7 +183,7 @@ func (ds *orderHistoryDataSource) Update(ctx context.Context, orderHistory *enti
 }
 
 func (ds *orderHistoryDataSource) Delete(ctx context.Context, id uint64) error {
-	result := ds.db.WithContext(ctx).Delete(&entity.OrderHistory{}, id)
+	result := dbFromContext(ctx, ds.db).Delete(&entity.OrderHistory{}, id)
 	if result.Error != nil {
 		return fmt.Errorf("error deleting orderHistory: %w", result.Error)
 	}
@@ -99,12 +192,3 @@ func (ds *orderHistoryDataSource) Delete(ctx context.Context, id uint64) error {
 	}
 	return nil
 }
-
-func (ds *orderHistoryDataSource) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
-	return ds.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		// Create a new context with the transaction
-		keyPrincipalID := orderHistoryKey(uuid.NewString())
-		txCtx := context.WithValue(ctx, keyPrincipalID, tx)
-		return fn(txCtx)
-	})
-}
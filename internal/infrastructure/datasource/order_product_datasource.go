@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/google/uuid"
 	"gorm.io/gorm"
 
 	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
@@ -15,15 +14,13 @@ type orderProductDataSource struct {
 	db *gorm.DB
 }
 
-type orderProductKey string
-
 func NewOrderProductDataSource(db *gorm.DB) port.OrderProductDataSource {
 	return &orderProductDataSource{db}
 }
 
 func (ds *orderProductDataSource) FindByID(ctx context.Context, orderId, productId uint64) (*entity.OrderProduct, error) {
 	var orderProduct entity.OrderProduct
-	result := ds.db.WithContext(ctx).Preload("Order").Preload("Product").First(&orderProduct, "order_id = ? AND product_id = ?", orderId, productId)
+	result := dbFromContext(ctx, ds.db).Preload("Order").Preload("Product").First(&orderProduct, "order_id = ? AND product_id = ?", orderId, productId)
 	if result.Error != nil {
 		if result.Error == gorm.ErrRecordNotFound {
 			return nil, nil
@@ -37,7 +34,7 @@ func (ds *orderProductDataSource) FindAll(ctx context.Context, filters map[strin
 	var orderProducts []*entity.OrderProduct
 	var total int64
 
-	query := ds.db.WithContext(ctx).Preload("Order").Preload("Product")
+	query := dbFromContext(ctx, ds.db).Preload("Order").Preload("Product")
 
 	// Apply filters
 	for key, value := range filters {
@@ -64,12 +61,12 @@ func (ds *orderProductDataSource) FindAll(ctx context.Context, filters map[strin
 }
 
 func (ds *orderProductDataSource) Create(ctx context.Context, orderProduct *entity.OrderProduct) error {
-	if err := ds.db.WithContext(ctx).Create(orderProduct).Error; err != nil {
+	if err := dbFromContext(ctx, ds.db).Create(orderProduct).Error; err != nil {
 		return fmt.Errorf("error creating orderProduct: %w", err)
 	}
 
 	// Preload related entities
-	if err := ds.db.WithContext(ctx).Preload("Order").Preload("Product").First(orderProduct, "order_id = ? AND product_id = ?", orderProduct.OrderID, orderProduct.ProductID).Error; err != nil {
+	if err := dbFromContext(ctx, ds.db).Preload("Order").Preload("Product").First(orderProduct, "order_id = ? AND product_id = ?", orderProduct.OrderID, orderProduct.ProductID).Error; err != nil {
 		return fmt.Errorf("error preloading orderProduct: %w", err)
 	}
 
@@ -77,7 +74,7 @@ func (ds *orderProductDataSource) Create(ctx context.Context, orderProduct *enti
 }
 
 func (ds *orderProductDataSource) Update(ctx context.Context, orderProduct *entity.OrderProduct) error {
-	result := ds.db.WithContext(ctx).Model(orderProduct).Where("order_id = ? AND product_id = ?", orderProduct.OrderID, orderProduct.ProductID).Updates(orderProduct)
+	result := dbFromContext(ctx, ds.db).Model(orderProduct).Where("order_id = ? AND product_id = ?", orderProduct.OrderID, orderProduct.ProductID).Updates(orderProduct)
 	if result.Error != nil {
 		return fmt.Errorf("error updating orderProduct: %w", result.Error)
 	}
@@ -88,7 +85,7 @@ func (ds *orderProductDataSource) Update(ctx context.Context, orderProduct *enti
 }
 
 func (ds *orderProductDataSource) Delete(ctx context.Context, orderId, productId uint64) error {
-	result := ds.db.WithContext(ctx).Delete(&entity.OrderProduct{}, "order_id = ? AND product_id = ?", orderId, productId)
+	result := dbFromContext(ctx, ds.db).Delete(&entity.OrderProduct{}, "order_id = ? AND product_id = ?", orderId, productId)
 	if result.Error != nil {
 		return fmt.Errorf("error deleting orderProduct: %w", result.Error)
 	}
@@ -97,12 +94,3 @@ func (ds *orderProductDataSource) Delete(ctx context.Context, orderId, productId
 	}
 	return nil
 }
-
-func (ds *orderProductDataSource) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
-	return ds.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		// Create a new context with the transaction
-		keyPrincipalID := orderProductKey(uuid.NewString())
-		txCtx := context.WithValue(ctx, keyPrincipalID, tx)
-		return fn(txCtx)
-	})
-}
@@ -3,8 +3,11 @@ package datasource
 import (
 	"context"
 	"errors"
+	"fmt"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
 	valueobject "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/value_object"
@@ -15,23 +18,22 @@ type paymentDataSource struct {
 	db *gorm.DB
 }
 
-type PaymentKey string
-
 func NewPaymentDataSource(db *gorm.DB) port.PaymentDataSource {
 	return &paymentDataSource{db}
 }
 
 func (ds *paymentDataSource) Create(ctx context.Context, p *entity.Payment) (*entity.Payment, error) {
-	if err := ds.db.WithContext(ctx).Create(p).Error; err != nil {
+	if err := dbFromContext(ctx, ds.db).Create(p).Error; err != nil {
 		return nil, err
 	}
 
 	return p, nil
 }
 
+// GetByOrderID returns the last payment of the order, an order has a new payment at every checkout retry
 func (ds *paymentDataSource) GetByOrderID(ctx context.Context, orderID uint64) (*entity.Payment, error) {
 	var p entity.Payment
-	if err := ds.db.WithContext(ctx).Where("order_id = ?", orderID).First(&p).Error; err != nil {
+	if err := dbFromContext(ctx, ds.db).Where("order_id = ?", orderID).Order("id DESC").First(&p).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, nil
 		}
@@ -43,7 +45,7 @@ func (ds *paymentDataSource) GetByOrderID(ctx context.Context, orderID uint64) (
 
 func (ds *paymentDataSource) GetByOrderIDAndStatusProcessing(ctx context.Context, orderID uint64) (*entity.Payment, error) {
 	var p entity.Payment
-	if err := ds.db.WithContext(ctx).Where("order_id = ? AND status = ?", orderID, valueobject.PROCESSING).First(&p).Error; err != nil {
+	if err := dbFromContext(ctx, ds.db).Where("order_id = ? AND status = ?", orderID, valueobject.PROCESSING).First(&p).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return &p, nil
 		}
@@ -54,17 +56,176 @@ func (ds *paymentDataSource) GetByOrderIDAndStatusProcessing(ctx context.Context
 }
 
 func (ds *paymentDataSource) UpdateStatus(ctx context.Context, status valueobject.PaymentStatus, epID string) error {
-	if err := ds.db.WithContext(ctx).Model(&entity.Payment{}).Where("external_payment_id = ?", epID).Update("status", status).Error; err != nil {
+	if err := dbFromContext(ctx, ds.db).Model(&entity.Payment{}).Where("external_payment_id = ?", epID).Update("status", status).Error; err != nil {
 		return err
 	}
 
 	return nil
 }
 
+func (ds *paymentDataSource) CountByOrderIDAndStatus(ctx context.Context, orderID uint64, statuses []valueobject.PaymentStatus) (int64, error) {
+	var count int64
+	if err := dbFromContext(ctx, ds.db).Model(&entity.Payment{}).Where("order_id = ? AND status IN ?", orderID, statuses).Count(&count).Error; err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (ds *paymentDataSource) GetExpiredProcessing(ctx context.Context, now time.Time, limit int) ([]*entity.Payment, error) {
+	var payments []*entity.Payment
+	if err := dbFromContext(ctx, ds.db).
+		Where("status = ? AND expires_at <= ?", valueobject.PROCESSING, now).
+		Order("expires_at").
+		Limit(limit).
+		Find(&payments).Error; err != nil {
+		return nil, fmt.Errorf("error getting expired payments: %w", err)
+	}
+
+	return payments, nil
+}
+
+func (ds *paymentDataSource) GetProcessing(ctx context.Context, afterID uint64, limit int) ([]*entity.Payment, error) {
+	var payments []*entity.Payment
+	if err := dbFromContext(ctx, ds.db).
+		Where("status = ? AND id > ?", valueobject.PROCESSING, afterID).
+		Order("id").
+		Limit(limit).
+		Find(&payments).Error; err != nil {
+		return nil, fmt.Errorf("error getting processing payments: %w", err)
+	}
+
+	return payments, nil
+}
+
+// UpdateStatusIfProcessing returns false when the payment was settled meanwhile
+func (ds *paymentDataSource) UpdateStatusIfProcessing(ctx context.Context, id uint64, status valueobject.PaymentStatus) (bool, error) {
+	result := dbFromContext(ctx, ds.db).
+		Model(&entity.Payment{}).
+		Where("id = ? AND status = ?", id, valueobject.PROCESSING).
+		Update("status", status)
+	if result.Error != nil {
+		return false, fmt.Errorf("error updating payment status: %w", result.Error)
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+func (ds *paymentDataSource) GetLastByOrderIDAndStatus(ctx context.Context, orderID uint64, statuses []valueobject.PaymentStatus) (*entity.Payment, error) {
+	var p entity.Payment
+	if err := dbFromContext(ctx, ds.db).
+		Where("order_id = ? AND status IN ?", orderID, statuses).
+		Order("id DESC").
+		First(&p).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error finding payment: %w", err)
+	}
+
+	return &p, nil
+}
+
+// CreatePendingRefund records the refund before the payment provider is called. The payment is locked, so the refund
+// is only recorded when the payment was not refunded since it was read and has no other refund in progress, else
+// it returns false. A pending refund with the same idempotency key is a retry, it is loaded into refund instead.
+func (ds *paymentDataSource) CreatePendingRefund(ctx context.Context, refund *entity.PaymentRefund, refundedAmount valueobject.Money) (bool, error) {
+	created := false
+	err := dbFromContext(ctx, ds.db).Transaction(func(tx *gorm.DB) error {
+		var payment entity.Payment
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&payment, refund.PaymentID).Error; err != nil {
+			return fmt.Errorf("error locking payment to refund: %w", err)
+		}
+		if !payment.RefundedAmount.Equal(refundedAmount) {
+			return nil
+		}
+
+		var pending []*entity.PaymentRefund
+		if err := tx.Where("payment_id = ? AND status = ?", refund.PaymentID, valueobject.REFUND_PENDING).
+			Find(&pending).Error; err != nil {
+			return fmt.Errorf("error finding pending payment refund: %w", err)
+		}
+		if len(pending) > 0 {
+			if pending[0].IdempotencyKey != refund.IdempotencyKey {
+				return nil
+			}
+			*refund = *pending[0]
+			created = true
+			return nil
+		}
+
+		if err := tx.Create(refund).Error; err != nil {
+			return fmt.Errorf("error creating payment refund: %w", err)
+		}
+		created = true
+		return nil
+	})
+	return created, err
+}
+
+// CompleteRefund completes the pending refund given back by the payment provider and adds it to the payment, a refund
+// already completed is left as is
+func (ds *paymentDataSource) CompleteRefund(ctx context.Context, refund *entity.PaymentRefund, status valueobject.PaymentStatus) error {
+	return dbFromContext(ctx, ds.db).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&entity.PaymentRefund{}).
+			Where("id = ? AND status = ?", refund.ID, valueobject.REFUND_PENDING).
+			Updates(map[string]any{
+				"status":             valueobject.REFUND_COMPLETED,
+				"external_refund_id": refund.ExternalRefundID,
+			})
+		if result.Error != nil {
+			return fmt.Errorf("error completing payment refund: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+
+		if err := tx.Model(&entity.Payment{}).
+			Where("id = ?", refund.PaymentID).
+			Updates(map[string]any{
+				"status":          status,
+				"refunded_amount": gorm.Expr("refunded_amount + ?", refund.Amount),
+			}).Error; err != nil {
+			return fmt.Errorf("error updating refunded payment: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// ConfirmCash stores the cash receipt and confirms the payment in a single transaction, it returns false when the
+// payment was settled meanwhile
+func (ds *paymentDataSource) ConfirmCash(ctx context.Context, receipt *entity.CashReceipt) (bool, error) {
+	confirmed := false
+	err := dbFromContext(ctx, ds.db).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&entity.Payment{}).
+			Where("id = ? AND status = ?", receipt.PaymentID, valueobject.PROCESSING).
+			Update("status", valueobject.CONFIRMED)
+		if result.Error != nil {
+			return fmt.Errorf("error confirming cash payment: %w", result.Error)
+		}
+		if result.RowsAffected != 1 {
+			return nil
+		}
+
+		if err := tx.Create(receipt).Error; err != nil {
+			return fmt.Errorf("error creating cash receipt: %w", err)
+		}
+
+		confirmed = true
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return confirmed, nil
+}
+
 func (ds *paymentDataSource) GetByExternalPaymentID(ctx context.Context, epID string) (*entity.Payment, error) {
 	var payment entity.Payment
 
-	if err := ds.db.WithContext(ctx).Where("external_payment_id = ?", epID).First(&payment); errors.Is(err.Error, gorm.ErrRecordNotFound) {
+	if err := dbFromContext(ctx, ds.db).Where("external_payment_id = ?", epID).First(&payment); errors.Is(err.Error, gorm.ErrRecordNotFound) {
 		return nil, gorm.ErrRecordNotFound
 	}
 
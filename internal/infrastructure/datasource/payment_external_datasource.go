@@ -3,10 +3,15 @@ package datasource
 import (
 	"context"
 	"fmt"
+	"strconv"
 
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain"
 	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
+	valueobject "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/value_object"
 	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/port"
 	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/infrastructure/config"
+	datasource_request "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/infrastructure/datasource/request"
+	datasource_response "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/infrastructure/datasource/response"
 	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/infrastructure/handler/request"
 	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/infrastructure/handler/response"
 	"github.com/go-resty/resty/v2"
@@ -42,3 +47,80 @@ func (ds *PaymentExternalDataSource) Create(ctx context.Context, p *entity.Creat
 
 	return result.ToEntity(), nil
 }
+
+// GetStatus searches the merchant orders of the order ID, the external reference, matching the one of the payment
+func (ds *PaymentExternalDataSource) GetStatus(ctx context.Context, p *entity.Payment) (valueobject.PaymentStatus, error) {
+	cfg := config.LoadConfig()
+
+	var result datasource_response.MercadoPagoMerchantOrdersResponse
+	resp, err := ds.httpClient.R().
+		SetContext(ctx).
+		SetHeader("Authorization", "Bearer "+cfg.MercadoPagoToken).
+		SetQueryParam("external_reference", strconv.FormatUint(p.OrderID, 10)).
+		SetResult(&result).
+		Get(cfg.MercadoPagoMerchantOrdersURL)
+	if err != nil {
+		return valueobject.UNDEFINDED_P, fmt.Errorf("error to get payment status: %w", err)
+	}
+
+	if resp.StatusCode() != 200 {
+		return valueobject.UNDEFINDED_P, fmt.Errorf("error: response status %d", resp.StatusCode())
+	}
+
+	merchantOrder, ok := result.FindByExternalPaymentID(p.ExternalPaymentID)
+	if !ok {
+		return valueobject.UNDEFINDED_P, domain.NewNotFoundError(domain.ErrPaymentNotFoundExternal)
+	}
+
+	return merchantOrder.ToPaymentStatus(), nil
+}
+
+// Refund refunds the approved payment of the merchant order of the QR code, searched by the order ID, the external
+// reference, and matched on the external payment ID
+func (ds *PaymentExternalDataSource) Refund(ctx context.Context, r *entity.RefundPaymentExternalInput) (*entity.RefundPaymentExternalOutput, error) {
+	cfg := config.LoadConfig()
+
+	var merchantOrders datasource_response.MercadoPagoMerchantOrdersResponse
+	resp, err := ds.httpClient.R().
+		SetContext(ctx).
+		SetHeader("Authorization", "Bearer "+cfg.MercadoPagoToken).
+		SetQueryParam("external_reference", r.ExternalReference).
+		SetResult(&merchantOrders).
+		Get(cfg.MercadoPagoMerchantOrdersURL)
+	if err != nil {
+		return nil, fmt.Errorf("error to get payment to refund: %w", err)
+	}
+
+	if resp.StatusCode() != 200 {
+		return nil, fmt.Errorf("error: response status %d", resp.StatusCode())
+	}
+
+	merchantOrder, ok := merchantOrders.FindByExternalPaymentID(r.ExternalPaymentID)
+	if !ok {
+		return nil, fmt.Errorf("error: no merchant order for external payment %s", r.ExternalPaymentID)
+	}
+
+	paymentID, ok := merchantOrder.ApprovedPaymentID()
+	if !ok {
+		return nil, fmt.Errorf("error: no approved payment for external payment %s", r.ExternalPaymentID)
+	}
+
+	var result datasource_response.MercadoPagoRefundResponse
+	resp, err = ds.httpClient.R().
+		SetContext(ctx).
+		SetHeader("Content-Type", "application/json").
+		SetHeader("Authorization", "Bearer "+cfg.MercadoPagoToken).
+		SetHeader("X-Idempotency-Key", r.IdempotencyKey).
+		SetBody(datasource_request.NewMercadoPagoRefundRequest(r)).
+		SetResult(&result).
+		Post(fmt.Sprintf("%s/%d/refunds", cfg.MercadoPagoPaymentsURL, paymentID))
+	if err != nil {
+		return nil, fmt.Errorf("error to refund payment: %w", err)
+	}
+
+	if resp.StatusCode() != 201 {
+		return nil, fmt.Errorf("error: response status %d", resp.StatusCode())
+	}
+
+	return result.ToEntity(), nil
+}
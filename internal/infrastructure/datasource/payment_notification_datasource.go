@@ -0,0 +1,69 @@
+package datasource
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/port"
+)
+
+type paymentNotificationDataSource struct {
+	db *gorm.DB
+}
+
+func NewPaymentNotificationDataSource(db *gorm.DB) port.PaymentNotificationDataSource {
+	return &paymentNotificationDataSource{db}
+}
+
+func (ds *paymentNotificationDataSource) FindByEventID(ctx context.Context, eventID string) (*entity.PaymentNotification, error) {
+	return ds.findByEventID(dbFromContext(ctx, ds.db), eventID)
+}
+
+func (ds *paymentNotificationDataSource) FindByEventIDForUpdate(ctx context.Context, eventID string) (*entity.PaymentNotification, error) {
+	return ds.findByEventID(dbFromContext(ctx, ds.db).Clauses(clause.Locking{Strength: "UPDATE"}), eventID)
+}
+
+func (ds *paymentNotificationDataSource) findByEventID(db *gorm.DB, eventID string) (*entity.PaymentNotification, error) {
+	var notification entity.PaymentNotification
+	if err := db.Where("event_id = ?", eventID).First(&notification).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error finding payment notification: %w", err)
+	}
+	return &notification, nil
+}
+
+func (ds *paymentNotificationDataSource) Create(ctx context.Context, notification *entity.PaymentNotification) (bool, error) {
+	// The unique event ID makes concurrent deliveries of the same event insert a single row
+	result := dbFromContext(ctx, ds.db).Clauses(clause.OnConflict{DoNothing: true}).Create(notification)
+	if result.Error != nil {
+		return false, fmt.Errorf("error creating payment notification: %w", result.Error)
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (ds *paymentNotificationDataSource) MarkProcessed(ctx context.Context, id uint64, processedAt time.Time) error {
+	if err := dbFromContext(ctx, ds.db).Model(&entity.PaymentNotification{}).Where("id = ?", id).Update("processed_at", processedAt).Error; err != nil {
+		return fmt.Errorf("error updating payment notification: %w", err)
+	}
+	return nil
+}
+
+func (ds *paymentNotificationDataSource) FindUnprocessed(ctx context.Context, receivedBefore time.Time, afterID uint64, limit int) ([]*entity.PaymentNotification, error) {
+	var notifications []*entity.PaymentNotification
+	if err := dbFromContext(ctx, ds.db).
+		Where("processed_at IS NULL AND created_at < ? AND id > ?", receivedBefore, afterID).
+		Order("id").
+		Limit(limit).
+		Find(&notifications).Error; err != nil {
+		return nil, fmt.Errorf("error finding unprocessed payment notifications: %w", err)
+	}
+	return notifications, nil
+}
@@ -0,0 +1,38 @@
+package datasource
+
+import (
+	"fmt"
+
+	valueobject "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/value_object"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/port"
+)
+
+type paymentProviderRegistry struct {
+	defaultProvider valueobject.PaymentProvider
+	providers       map[valueobject.PaymentProvider]port.PaymentExternalDatasource
+}
+
+// NewPaymentProviderRegistry creates the registry of the given providers, the default provider must be one of them
+func NewPaymentProviderRegistry(
+	defaultProvider valueobject.PaymentProvider,
+	providers map[valueobject.PaymentProvider]port.PaymentExternalDatasource,
+) (port.PaymentProviderRegistry, error) {
+	if _, ok := providers[defaultProvider]; !ok {
+		return nil, fmt.Errorf("default payment provider %q is not registered", defaultProvider)
+	}
+
+	return &paymentProviderRegistry{defaultProvider, providers}, nil
+}
+
+func (r *paymentProviderRegistry) Get(provider valueobject.PaymentProvider) (valueobject.PaymentProvider, port.PaymentExternalDatasource, error) {
+	if provider == valueobject.UNDEFINED_PROVIDER {
+		provider = r.defaultProvider
+	}
+
+	ds, ok := r.providers[provider]
+	if !ok {
+		return valueobject.UNDEFINED_PROVIDER, nil, fmt.Errorf("payment provider %q is not registered", provider)
+	}
+
+	return provider, ds, nil
+}
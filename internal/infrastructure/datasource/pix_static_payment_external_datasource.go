@@ -0,0 +1,115 @@
+package datasource
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/google/uuid"
+
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
+	valueobject "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/value_object"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/port"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/infrastructure/config"
+)
+
+const (
+	pixTxIDMaxLength         = 25
+	pixMerchantNameMaxLength = 25
+	pixMerchantCityMaxLength = 15
+)
+
+// PixStaticPaymentExternalDataSource issues static PIX QR codes (BR Code) paid straight to the key of the store.
+// There is no API behind it: the payment is confirmed by an attendant and refunds are transferred by hand.
+type PixStaticPaymentExternalDataSource struct {
+	cfg *config.Config
+}
+
+func NewPixStaticPaymentExternalDataSource(cfg *config.Config) port.PaymentExternalDatasource {
+	return &PixStaticPaymentExternalDataSource{cfg: cfg}
+}
+
+// Create builds the "copy and paste" BR Code of the payment, the transaction ID identifies the payment
+func (ds *PixStaticPaymentExternalDataSource) Create(_ context.Context, p *entity.CreatePaymentExternalInput) (*entity.CreatePaymentExternalOutput, error) {
+	if ds.cfg.PixKey == "" {
+		return nil, errors.New("error: PIX_KEY is not configured")
+	}
+
+	txID := newPixTxID(p.ExternalReference)
+
+	return &entity.CreatePaymentExternalOutput{
+		InStoreOrderID: txID,
+		QrData:         newPixBRCode(ds.cfg.PixKey, ds.cfg.PixMerchantName, ds.cfg.PixMerchantCity, p.TotalAmount, txID),
+	}, nil
+}
+
+// GetStatus is not supported, the transfer is confirmed by an attendant (see paymentUseCase.ConfirmCash)
+func (ds *PixStaticPaymentExternalDataSource) GetStatus(_ context.Context, _ *entity.Payment) (valueobject.PaymentStatus, error) {
+	return valueobject.UNDEFINDED_P, domain.ErrPaymentStatusUnavailable
+}
+
+// Refund identifies the refund, the money is transferred back by hand
+func (ds *PixStaticPaymentExternalDataSource) Refund(_ context.Context, _ *entity.RefundPaymentExternalInput) (*entity.RefundPaymentExternalOutput, error) {
+	return &entity.RefundPaymentExternalOutput{ExternalRefundID: uuid.NewString()}, nil
+}
+
+// newPixTxID returns an alphanumeric transaction ID made of the order ID and a random suffix
+func newPixTxID(externalReference string) string {
+	txID := "ORDER" + externalReference + strings.ReplaceAll(uuid.NewString(), "-", "")
+	return txID[:pixTxIDMaxLength]
+}
+
+// newPixBRCode builds the EMV payload of a static PIX QR code, as specified by the Banco Central do Brasil
+func newPixBRCode(key, merchantName, merchantCity string, amount valueobject.Money, txID string) string {
+	var payload strings.Builder
+	payload.WriteString(emvField("00", "01")) // payload format indicator
+	payload.WriteString(emvField("26", emvField("00", "br.gov.bcb.pix")+emvField("01", key)))
+	payload.WriteString(emvField("52", "0000")) // merchant category code
+	payload.WriteString(emvField("53", "986"))  // BRL
+	payload.WriteString(emvField("54", amount.String()))
+	payload.WriteString(emvField("58", "BR"))
+	payload.WriteString(emvField("59", emvText(merchantName, pixMerchantNameMaxLength)))
+	payload.WriteString(emvField("60", emvText(merchantCity, pixMerchantCityMaxLength)))
+	payload.WriteString(emvField("62", emvField("05", txID)))
+	payload.WriteString("6304") // CRC of the whole payload, including its own ID and length
+
+	return payload.String() + fmt.Sprintf("%04X", crc16CCITT(payload.String()))
+}
+
+func emvField(id, value string) string {
+	return fmt.Sprintf("%s%02d%s", id, len(value), value)
+}
+
+// emvText keeps the ASCII letters, digits and spaces of the text, up to maxLength
+func emvText(text string, maxLength int) string {
+	text = strings.Map(func(r rune) rune {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r) || r == ' ') {
+			return unicode.ToUpper(r)
+		}
+		return -1
+	}, text)
+
+	if len(text) > maxLength {
+		return text[:maxLength]
+	}
+	return text
+}
+
+// crc16CCITT is the CRC-16/CCITT-FALSE checksum (polynomial 0x1021, initial value 0xFFFF)
+func crc16CCITT(data string) uint16 {
+	crc := uint16(0xFFFF)
+	for i := 0; i < len(data); i++ {
+		crc ^= uint16(data[i]) << 8
+		for range 8 {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return crc
+}
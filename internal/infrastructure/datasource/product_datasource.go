@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/google/uuid"
 	"gorm.io/gorm"
 
 	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
@@ -15,15 +14,13 @@ type productDataSource struct {
 	db *gorm.DB
 }
 
-type productKey string
-
 func NewProductDataSource(db *gorm.DB) port.ProductDataSource {
 	return &productDataSource{db}
 }
 
 func (ds *productDataSource) FindByID(ctx context.Context, id uint64) (*entity.Product, error) {
 	var product entity.Product
-	result := ds.db.WithContext(ctx).First(&product, id)
+	result := dbFromContext(ctx, ds.db).First(&product, id)
 	if result.Error != nil {
 		if result.Error == gorm.ErrRecordNotFound {
 			return nil, nil
@@ -37,7 +34,7 @@ func (ds *productDataSource) FindAll(ctx context.Context, filters map[string]int
 	var products []*entity.Product
 	var total int64
 
-	query := ds.db.WithContext(ctx)
+	query := dbFromContext(ctx, ds.db)
 
 	// Apply filters
 	for key, value := range filters {
@@ -68,25 +65,36 @@ func (ds *productDataSource) FindAll(ctx context.Context, filters map[string]int
 }
 
 func (ds *productDataSource) Create(ctx context.Context, product *entity.Product) error {
-	if err := ds.db.WithContext(ctx).Create(product).Error; err != nil {
+	if err := dbFromContext(ctx, ds.db).Create(product).Error; err != nil {
 		return fmt.Errorf("error creating product: %w", err)
 	}
 	return nil
 }
 
-func (ds *productDataSource) Update(ctx context.Context, product *entity.Product) error {
-	result := ds.db.WithContext(ctx).Save(product)
+// Update saves the product only if it is still on the version it was read, returning false when it was updated
+// meanwhile. The version of the product is incremented.
+func (ds *productDataSource) Update(ctx context.Context, product *entity.Product) (bool, error) {
+	version := product.Version
+	product.Version++
+
+	result := dbFromContext(ctx, ds.db).
+		Model(product).
+		Where("version = ?", version).
+		Select("*").
+		Updates(product)
 	if result.Error != nil {
-		return fmt.Errorf("error updating product: %w", result.Error)
+		product.Version = version
+		return false, fmt.Errorf("error updating product: %w", result.Error)
 	}
 	if result.RowsAffected == 0 {
-		return nil
+		product.Version = version
+		return false, nil
 	}
-	return nil
+	return true, nil
 }
 
 func (ds *productDataSource) Delete(ctx context.Context, id uint64) error {
-	result := ds.db.WithContext(ctx).Delete(&entity.Product{}, id)
+	result := dbFromContext(ctx, ds.db).Delete(&entity.Product{}, id)
 	if result.Error != nil {
 		return fmt.Errorf("error deleting product: %w", result.Error)
 	}
@@ -95,12 +103,3 @@ func (ds *productDataSource) Delete(ctx context.Context, id uint64) error {
 	}
 	return nil
 }
-
-func (ds *productDataSource) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
-	return ds.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		// Create a new context with the transaction
-		keyPrincipalID := productKey(uuid.NewString())
-		txCtx := context.WithValue(ctx, keyPrincipalID, tx)
-		return fn(txCtx)
-	})
-}
@@ -0,0 +1,70 @@
+package datasource
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/port"
+)
+
+type refreshTokenDataSource struct {
+	db *gorm.DB
+}
+
+func NewRefreshTokenDataSource(db *gorm.DB) port.RefreshTokenDataSource {
+	return &refreshTokenDataSource{db}
+}
+
+func (ds *refreshTokenDataSource) FindByHash(ctx context.Context, hash string) (*entity.RefreshToken, error) {
+	var token entity.RefreshToken
+	result := dbFromContext(ctx, ds.db).Where("token_hash = ?", hash).First(&token)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error finding refresh token: %w", result.Error)
+	}
+	return &token, nil
+}
+
+func (ds *refreshTokenDataSource) FindByFamilyID(ctx context.Context, familyID string) ([]*entity.RefreshToken, error) {
+	var tokens []*entity.RefreshToken
+	if err := dbFromContext(ctx, ds.db).Where("family_id = ?", familyID).Find(&tokens).Error; err != nil {
+		return nil, fmt.Errorf("error finding refresh tokens: %w", err)
+	}
+	return tokens, nil
+}
+
+func (ds *refreshTokenDataSource) Create(ctx context.Context, token *entity.RefreshToken) error {
+	if err := dbFromContext(ctx, ds.db).Create(token).Error; err != nil {
+		return fmt.Errorf("error creating refresh token: %w", err)
+	}
+	return nil
+}
+
+func (ds *refreshTokenDataSource) MarkUsed(ctx context.Context, id uint64, usedAt time.Time) (bool, error) {
+	// Conditional update, so concurrent refreshes with the same token can't both succeed
+	result := dbFromContext(ctx, ds.db).
+		Model(&entity.RefreshToken{}).
+		Where("id = ? AND used_at IS NULL AND revoked_at IS NULL", id).
+		Update("used_at", usedAt)
+	if result.Error != nil {
+		return false, fmt.Errorf("error updating refresh token: %w", result.Error)
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (ds *refreshTokenDataSource) RevokeFamily(ctx context.Context, familyID string, revokedAt time.Time) error {
+	result := dbFromContext(ctx, ds.db).
+		Model(&entity.RefreshToken{}).
+		Where("family_id = ? AND revoked_at IS NULL", familyID).
+		Update("revoked_at", revokedAt)
+	if result.Error != nil {
+		return fmt.Errorf("error revoking refresh tokens: %w", result.Error)
+	}
+	return nil
+}
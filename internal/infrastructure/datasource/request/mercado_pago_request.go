@@ -1,24 +1,31 @@
 package datasource_request
 
-import "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
+import (
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
+	valueobject "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/value_object"
+)
+
+// MercadoPagoDateLayout is the ISO 8601 layout, with milliseconds and offset, expected by Mercado Pago
+const MercadoPagoDateLayout = "2006-01-02T15:04:05.000-07:00"
 
 type FakeMercadoPagoRequest struct {
 	ExternalReference string                        `json:"external_reference"`
 	NotificationUrl   string                        `json:"notification_url"`
-	TotalAmount       float32                       `json:"total_amount"`
+	TotalAmount       valueobject.Money             `json:"total_amount"`
 	Title             string                        `json:"title"`
 	Description       string                        `json:"description"`
 	Items             []FakeMercadoPagoItemsRequest `json:"items"`
+	ExpirationDate    string                        `json:"expiration_date,omitempty"`
 }
 
 type FakeMercadoPagoItemsRequest struct {
-	Category    string  `json:"category"`
-	Title       string  `json:"title"`
-	Description string  `json:"description"`
-	UnitPrice   float32 `json:"unit_price"`
-	Quantity    uint64  `json:"quantity"`
-	UnitMeasure string  `json:"unit_measure"`
-	TotalAmount float32 `json:"total_amount"`
+	Category    string            `json:"category"`
+	Title       string            `json:"title"`
+	Description string            `json:"description"`
+	UnitPrice   valueobject.Money `json:"unit_price"`
+	Quantity    uint64            `json:"quantity"`
+	UnitMeasure string            `json:"unit_measure"`
+	TotalAmount valueobject.Money `json:"total_amount"`
 }
 
 func NewFakeMercadoPagoRequest(p *entity.CreatePaymentExternalInput) *FakeMercadoPagoRequest {
@@ -29,6 +36,9 @@ func NewFakeMercadoPagoRequest(p *entity.CreatePaymentExternalInput) *FakeMercad
 		Description:       p.Description,
 		NotificationUrl:   p.NotificationUrl,
 	}
+	if p.ExpirationDate != nil {
+		fake.ExpirationDate = p.ExpirationDate.Format(MercadoPagoDateLayout)
+	}
 	for _, item := range p.Items {
 		fake.Items = append(fake.Items, *newFakeMercadoPagoItemsRequest(&item))
 	}
@@ -46,3 +56,30 @@ func newFakeMercadoPagoItemsRequest(p *entity.PaymentExternalItemsInput) *FakeMe
 		TotalAmount: p.TotalAmount,
 	}
 }
+
+type FakeMercadoPagoRefundRequest struct {
+	InStoreOrderID    string            `json:"in_store_order_id"`
+	ExternalReference string            `json:"external_reference"`
+	Amount            valueobject.Money `json:"amount"`
+}
+
+func NewFakeMercadoPagoRefundRequest(r *entity.RefundPaymentExternalInput) *FakeMercadoPagoRefundRequest {
+	return &FakeMercadoPagoRefundRequest{
+		InStoreOrderID:    r.ExternalPaymentID,
+		ExternalReference: r.ExternalReference,
+		Amount:            r.Amount,
+	}
+}
+
+// MercadoPagoRefundRequest is the body of a refund of a payment, the whole payment is refunded without an amount
+type MercadoPagoRefundRequest struct {
+	Amount *valueobject.Money `json:"amount,omitempty"`
+}
+
+func NewMercadoPagoRefundRequest(r *entity.RefundPaymentExternalInput) *MercadoPagoRefundRequest {
+	if r.Amount.IsZero() {
+		return &MercadoPagoRefundRequest{}
+	}
+	amount := r.Amount
+	return &MercadoPagoRefundRequest{Amount: &amount}
+}
@@ -1,6 +1,11 @@
 package datasource_response
 
-import "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
+import (
+	"strconv"
+
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
+	valueobject "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/value_object"
+)
 
 type MercadoPagoResponse struct {
 	InStoreOrderID string `json:"in_store_order_id"`
@@ -13,3 +18,85 @@ func NewCreatePaymentExternalOutput(r *MercadoPagoResponse) *entity.CreatePaymen
 		QrData:         r.QrData,
 	}
 }
+
+// MercadoPagoMerchantOrdersResponse is the result of a merchant orders search by external reference
+type MercadoPagoMerchantOrdersResponse struct {
+	Elements []MercadoPagoMerchantOrder `json:"elements"`
+}
+
+type MercadoPagoMerchantOrder struct {
+	PreferenceID string                            `json:"preference_id"` // the in_store_order_id of the QR code order
+	OrderStatus  string                            `json:"order_status"`
+	Payments     []MercadoPagoMerchantOrderPayment `json:"payments"`
+}
+
+type MercadoPagoMerchantOrderPayment struct {
+	ID     int64  `json:"id"`
+	Status string `json:"status"`
+}
+
+// FindByExternalPaymentID returns the merchant order of the payment. A reopened order is paid again with the same
+// external reference, so the merchant orders of its former payments are found as well.
+func (r *MercadoPagoMerchantOrdersResponse) FindByExternalPaymentID(externalPaymentID string) (*MercadoPagoMerchantOrder, bool) {
+	for i := range r.Elements {
+		if r.Elements[i].PreferenceID == externalPaymentID {
+			return &r.Elements[i], true
+		}
+	}
+	return nil, false
+}
+
+// ToPaymentStatus maps the merchant order to a payment status. An order without an approved nor a rejected payment
+// is still PROCESSING.
+func (o *MercadoPagoMerchantOrder) ToPaymentStatus() valueobject.PaymentStatus {
+	switch o.OrderStatus {
+	case "paid":
+		return valueobject.CONFIRMED
+	case "expired":
+		return valueobject.ABORTED
+	}
+
+	for _, payment := range o.Payments {
+		if payment.Status == "rejected" || payment.Status == "cancelled" {
+			return valueobject.FAILED
+		}
+	}
+
+	return valueobject.PROCESSING
+}
+
+// ApprovedPaymentID returns the ID of the approved payment of the merchant order
+func (o *MercadoPagoMerchantOrder) ApprovedPaymentID() (int64, bool) {
+	for _, payment := range o.Payments {
+		if payment.Status == "approved" {
+			return payment.ID, true
+		}
+	}
+
+	return 0, false
+}
+
+type MercadoPagoRefundResponse struct {
+	ID int64 `json:"id"`
+}
+
+func (r *MercadoPagoRefundResponse) ToEntity() *entity.RefundPaymentExternalOutput {
+	return &entity.RefundPaymentExternalOutput{
+		ExternalRefundID: strconv.FormatInt(r.ID, 10),
+	}
+}
+
+// FakeMercadoPagoStatusResponse is the status of a payment in the fake provider, already as a payment status
+type FakeMercadoPagoStatusResponse struct {
+	Status string `json:"status"`
+}
+
+type FakeMercadoPagoRefundResponse struct {
+	ID string `json:"id"`
+}
+
+func (r *FakeMercadoPagoRefundResponse) ToEntity() *entity.RefundPaymentExternalOutput {
+	return &entity.RefundPaymentExternalOutput{
+		ExternalRefundID: r.ID,
+	}
+}
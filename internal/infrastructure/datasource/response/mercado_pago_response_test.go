@@ -0,0 +1,99 @@
+package datasource_response_test
+
+import (
+	"testing"
+
+	valueobject "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/value_object"
+	datasource_response "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/infrastructure/datasource/response"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMercadoPagoMerchantOrdersResponse_FindByExternalPaymentID(t *testing.T) {
+	// The order was reopened, its first payment is still the last merchant order found
+	result := &datasource_response.MercadoPagoMerchantOrdersResponse{
+		Elements: []datasource_response.MercadoPagoMerchantOrder{
+			{PreferenceID: "second", OrderStatus: "paid", Payments: []datasource_response.MercadoPagoMerchantOrderPayment{{ID: 2, Status: "approved"}}},
+			{PreferenceID: "first", OrderStatus: "expired"},
+		},
+	}
+
+	tests := []struct {
+		name              string
+		externalPaymentID string
+		wantFound         bool
+		wantStatus        valueobject.PaymentStatus
+		wantApprovedID    int64
+	}{
+		{
+			name:              "should match the merchant order of the payment, not the last one",
+			externalPaymentID: "second",
+			wantFound:         true,
+			wantStatus:        valueobject.CONFIRMED,
+			wantApprovedID:    2,
+		},
+		{
+			name:              "should match a former merchant order of the order",
+			externalPaymentID: "first",
+			wantFound:         true,
+			wantStatus:        valueobject.ABORTED,
+		},
+		{
+			name:              "should not find the merchant order of another payment",
+			externalPaymentID: "unknown",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			merchantOrder, ok := result.FindByExternalPaymentID(tt.externalPaymentID)
+			assert.Equal(t, tt.wantFound, ok)
+			if !tt.wantFound {
+				assert.Nil(t, merchantOrder)
+				return
+			}
+
+			assert.Equal(t, tt.wantStatus, merchantOrder.ToPaymentStatus())
+			approvedID, approved := merchantOrder.ApprovedPaymentID()
+			assert.Equal(t, tt.wantApprovedID, approvedID)
+			assert.Equal(t, tt.wantApprovedID != 0, approved)
+		})
+	}
+}
+
+func TestMercadoPagoMerchantOrder_ToPaymentStatus(t *testing.T) {
+	tests := []struct {
+		name          string
+		merchantOrder datasource_response.MercadoPagoMerchantOrder
+		want          valueobject.PaymentStatus
+	}{
+		{
+			name:          "should be confirmed when paid",
+			merchantOrder: datasource_response.MercadoPagoMerchantOrder{OrderStatus: "paid"},
+			want:          valueobject.CONFIRMED,
+		},
+		{
+			name:          "should be aborted when expired",
+			merchantOrder: datasource_response.MercadoPagoMerchantOrder{OrderStatus: "expired"},
+			want:          valueobject.ABORTED,
+		},
+		{
+			name: "should fail when a payment was rejected",
+			merchantOrder: datasource_response.MercadoPagoMerchantOrder{
+				OrderStatus: "opened",
+				Payments:    []datasource_response.MercadoPagoMerchantOrderPayment{{ID: 1, Status: "rejected"}},
+			},
+			want: valueobject.FAILED,
+		},
+		{
+			name:          "should still be processing without payments",
+			merchantOrder: datasource_response.MercadoPagoMerchantOrder{OrderStatus: "opened"},
+			want:          valueobject.PROCESSING,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.merchantOrder.ToPaymentStatus())
+		})
+	}
+}
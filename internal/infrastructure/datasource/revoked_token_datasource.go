@@ -0,0 +1,36 @@
+package datasource
+
+import (
+	"context"
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/port"
+)
+
+type revokedTokenDataSource struct {
+	db *gorm.DB
+}
+
+func NewRevokedTokenDataSource(db *gorm.DB) port.RevokedTokenDataSource {
+	return &revokedTokenDataSource{db}
+}
+
+func (ds *revokedTokenDataSource) ExistsByJTI(ctx context.Context, jti string) (bool, error) {
+	var count int64
+	if err := dbFromContext(ctx, ds.db).Model(&entity.RevokedToken{}).Where("jti = ?", jti).Count(&count).Error; err != nil {
+		return false, fmt.Errorf("error finding revoked token: %w", err)
+	}
+	return count > 0, nil
+}
+
+func (ds *revokedTokenDataSource) Create(ctx context.Context, token *entity.RevokedToken) error {
+	// Revoking twice is not an error
+	if err := dbFromContext(ctx, ds.db).Clauses(clause.OnConflict{DoNothing: true}).Create(token).Error; err != nil {
+		return fmt.Errorf("error creating revoked token: %w", err)
+	}
+	return nil
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/google/uuid"
 	"gorm.io/gorm"
 
 	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
@@ -15,15 +14,13 @@ type staffDataSource struct {
 	db *gorm.DB
 }
 
-type staffKey string
-
 func NewStaffDataSource(db *gorm.DB) port.StaffDataSource {
 	return &staffDataSource{db}
 }
 
 func (ds *staffDataSource) FindByID(ctx context.Context, id uint64) (*entity.Staff, error) {
 	var staff entity.Staff
-	result := ds.db.WithContext(ctx).First(&staff, id)
+	result := dbFromContext(ctx, ds.db).First(&staff, id)
 	if result.Error != nil {
 		if result.Error == gorm.ErrRecordNotFound {
 			return nil, nil
@@ -37,7 +34,7 @@ func (ds *staffDataSource) FindAll(ctx context.Context, filters map[string]inter
 	var staffs []*entity.Staff
 	var total int64
 
-	query := ds.db.WithContext(ctx)
+	query := dbFromContext(ctx, ds.db)
 
 	// Apply filters
 	for key, value := range filters {
@@ -69,14 +66,14 @@ func (ds *staffDataSource) FindAll(ctx context.Context, filters map[string]inter
 }
 
 func (ds *staffDataSource) Create(ctx context.Context, staff *entity.Staff) error {
-	if err := ds.db.WithContext(ctx).Create(staff).Error; err != nil {
+	if err := dbFromContext(ctx, ds.db).Create(staff).Error; err != nil {
 		return fmt.Errorf("error creating staff: %w", err)
 	}
 	return nil
 }
 
 func (ds *staffDataSource) Update(ctx context.Context, staff *entity.Staff) error {
-	result := ds.db.WithContext(ctx).Save(staff)
+	result := dbFromContext(ctx, ds.db).Save(staff)
 	if result.Error != nil {
 		return fmt.Errorf("error updating staff: %w", result.Error)
 	}
@@ -87,7 +84,7 @@ func (ds *staffDataSource) Update(ctx context.Context, staff *entity.Staff) erro
 }
 
 func (ds *staffDataSource) Delete(ctx context.Context, id uint64) error {
-	result := ds.db.WithContext(ctx).Delete(&entity.Staff{}, id)
+	result := dbFromContext(ctx, ds.db).Delete(&entity.Staff{}, id)
 	if result.Error != nil {
 		return fmt.Errorf("error deleting staff: %w", result.Error)
 	}
@@ -96,12 +93,3 @@ func (ds *staffDataSource) Delete(ctx context.Context, id uint64) error {
 	}
 	return nil
 }
-
-func (ds *staffDataSource) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
-	return ds.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		// Create a new context with the transaction
-		keyPrincipalID := staffKey(uuid.NewString())
-		txCtx := context.WithValue(ctx, keyPrincipalID, tx)
-		return fn(txCtx)
-	})
-}
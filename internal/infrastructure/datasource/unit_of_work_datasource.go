@@ -0,0 +1,75 @@
+package datasource
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/port"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/infrastructure/logger"
+)
+
+// txContextKey keeps the transaction of the unit of work in the context
+type txContextKey struct{}
+
+// afterCommitContextKey keeps the functions to call once the unit of work commits in the context
+type afterCommitContextKey struct{}
+
+type afterCommitHooks struct {
+	fns []func(ctx context.Context) error
+}
+
+type unitOfWork struct {
+	db     *gorm.DB
+	logger *logger.Logger
+}
+
+func NewUnitOfWork(db *gorm.DB, logger *logger.Logger) port.UnitOfWork {
+	return &unitOfWork{db, logger}
+}
+
+func (u *unitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	hooks := &afterCommitHooks{}
+
+	// Inside another unit of work the transaction is nested in a savepoint
+	err := dbFromContext(ctx, u.db).Transaction(func(tx *gorm.DB) error {
+		txCtx := context.WithValue(ctx, txContextKey{}, tx)
+		return fn(context.WithValue(txCtx, afterCommitContextKey{}, hooks))
+	})
+	if err != nil {
+		return err
+	}
+
+	// A savepoint is released, not committed, so its hooks wait for the outer transaction
+	if parent, ok := ctx.Value(afterCommitContextKey{}).(*afterCommitHooks); ok {
+		parent.fns = append(parent.fns, hooks.fns...)
+		return nil
+	}
+
+	for _, hook := range hooks.fns {
+		u.run(ctx, hook)
+	}
+	return nil
+}
+
+func (u *unitOfWork) AfterCommit(ctx context.Context, fn func(ctx context.Context) error) {
+	if hooks, ok := ctx.Value(afterCommitContextKey{}).(*afterCommitHooks); ok {
+		hooks.fns = append(hooks.fns, fn)
+		return
+	}
+	u.run(ctx, fn)
+}
+
+func (u *unitOfWork) run(ctx context.Context, fn func(ctx context.Context) error) {
+	if err := fn(ctx); err != nil {
+		u.logger.Error("failed to run after commit", "error", err)
+	}
+}
+
+// dbFromContext returns the transaction of the unit of work running in the context, or db outside of one
+func dbFromContext(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := ctx.Value(txContextKey{}).(*gorm.DB); ok {
+		return tx.WithContext(ctx)
+	}
+	return db.WithContext(ctx)
+}
@@ -22,12 +22,15 @@ func NewAuthHandler(controller port.AuthController) *AuthHandler {
 
 func (h *AuthHandler) Register(router *gin.RouterGroup) {
 	router.POST("/", h.Authenticate)
+	router.POST("/staff", h.AuthenticateStaff)
+	router.POST("/refresh", h.Refresh)
+	router.POST("/logout", h.Logout)
 }
 
 // Authenticate godoc
 //
 //	@Summary		Authenticate user
-//	@Description	Authenticates a user by CPF and returns a JWT token
+//	@Description	Authenticates a user by CPF and returns a JWT access token and a refresh token
 //	@Tags			sign-in
 //	@Accept			json
 //	@Produce		json
@@ -61,3 +64,113 @@ func (h *AuthHandler) Authenticate(c *gin.Context) {
 
 	c.Data(http.StatusOK, "application/json", output)
 }
+
+// AuthenticateStaff godoc
+//
+//	@Summary		Authenticate staff
+//	@Description	Authenticates a staff by ID and password and returns a JWT access token carrying the staff role and a refresh token
+//	@Tags			sign-in
+//	@Accept			json
+//	@Produce		json
+//	@Param			authentication	body		request.AuthenticateStaffBodyRequest	true	"Staff credentials"
+//	@Success		200				{object}	presenter.AuthenticationResponse		"OK"
+//	@Failure		400				{object}	middleware.ErrorJsonResponse			"Bad Request"
+//	@Failure		401				{object}	middleware.ErrorJsonResponse			"Unauthorized"
+//	@Failure		500				{object}	middleware.ErrorJsonResponse			"Internal Server Error"
+//	@Router			/auth/staff [post]
+func (h *AuthHandler) AuthenticateStaff(c *gin.Context) {
+	var body request.AuthenticateStaffBodyRequest
+	if err := c.ShouldBindJSON(&body); err != nil {
+		_ = c.Error(domain.NewInvalidInputError(domain.ErrInvalidBody))
+		return
+	}
+
+	input := dto.AuthenticateStaffInput{
+		StaffID:  body.StaffID,
+		Password: body.Password,
+	}
+
+	output, err := h.controller.AuthenticateStaff(
+		c.Request.Context(),
+		presenter.NewAuthPresenter(),
+		input,
+	)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.Data(http.StatusOK, "application/json", output)
+}
+
+// Refresh godoc
+//
+//	@Summary		Refresh tokens
+//	@Description	Exchanges a refresh token for a new access token and refresh token
+//	@Description	A refresh token can only be used once, reusing it revokes every token issued from the same login
+//	@Tags			sign-in
+//	@Accept			json
+//	@Produce		json
+//	@Param			refresh	body		request.RefreshTokenBodyRequest		true	"Refresh token"
+//	@Success		200		{object}	presenter.AuthenticationResponse	"OK"
+//	@Failure		400		{object}	middleware.ErrorJsonResponse		"Bad Request"
+//	@Failure		401		{object}	middleware.ErrorJsonResponse		"Unauthorized"
+//	@Failure		500		{object}	middleware.ErrorJsonResponse		"Internal Server Error"
+//	@Router			/auth/refresh [post]
+func (h *AuthHandler) Refresh(c *gin.Context) {
+	var body request.RefreshTokenBodyRequest
+	if err := c.ShouldBindJSON(&body); err != nil {
+		_ = c.Error(domain.NewInvalidInputError(domain.ErrInvalidBody))
+		return
+	}
+
+	input := dto.RefreshTokenInput{
+		RefreshToken: body.RefreshToken,
+	}
+
+	output, err := h.controller.Refresh(
+		c.Request.Context(),
+		presenter.NewAuthPresenter(),
+		input,
+	)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.Data(http.StatusOK, "application/json", output)
+}
+
+// Logout godoc
+//
+//	@Summary		Logout
+//	@Description	Revokes the access token, and the given refresh token with every token issued from the same login
+//	@Tags			sign-in
+//	@Accept			json
+//	@Security		BearerAuth
+//	@Param			logout	body	request.LogoutBodyRequest	false	"Refresh token"
+//	@Success		204		"No Content"
+//	@Failure		400		{object}	middleware.ErrorJsonResponse	"Bad Request"
+//	@Failure		401		{object}	middleware.ErrorJsonResponse	"Unauthorized"
+//	@Failure		500		{object}	middleware.ErrorJsonResponse	"Internal Server Error"
+//	@Router			/auth/logout [post]
+func (h *AuthHandler) Logout(c *gin.Context) {
+	var body request.LogoutBodyRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&body); err != nil {
+			_ = c.Error(domain.NewInvalidInputError(domain.ErrInvalidBody))
+			return
+		}
+	}
+
+	input := dto.LogoutInput{
+		RefreshToken: body.RefreshToken,
+	}
+
+	if err := h.controller.Logout(c.Request.Context(), input); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/adapter/presenter"
 	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain"
+	valueobject "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/value_object"
 	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/dto"
 	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/port"
 	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/infrastructure/handler/request"
@@ -74,6 +75,7 @@ func (h *CategoryHandler) List(c *gin.Context) {
 //	@Tags			category
 //	@Accept			json
 //	@Produce		json
+//	@Security		BearerAuth
 //	@Param			category	body		request.CreateCategoryBodyRequest	true	"Category data"
 //	@Success		201			{object}	presenter.CategoryJsonResponse		"Created"
 //	@Failure		400			{object}	middleware.ErrorJsonResponse		"Bad Request"
@@ -87,7 +89,9 @@ func (h *CategoryHandler) Create(c *gin.Context) {
 	}
 
 	input := dto.CreateCategoryInput{
-		Name: body.Name,
+		Name:              body.Name,
+		Station:           valueobject.ToKitchenStation(body.Station),
+		PrepTargetMinutes: body.PrepTargetMinutes,
 	}
 
 	output, err := h.controller.Create(
@@ -147,6 +151,7 @@ func (h *CategoryHandler) Get(c *gin.Context) {
 //	@Tags			category
 //	@Accept			json
 //	@Produce		json
+//	@Security		BearerAuth
 //	@Param			id			path		int									true	"Category ID"
 //	@Param			category	body		request.UpdateCategoryBodyRequest	true	"Category data"
 //	@Success		200			{object}	presenter.CategoryJsonResponse		"OK"
@@ -168,8 +173,10 @@ func (h *CategoryHandler) Update(c *gin.Context) {
 	}
 
 	input := dto.UpdateCategoryInput{
-		ID:   uri.ID,
-		Name: body.Name,
+		ID:                uri.ID,
+		Name:              body.Name,
+		Station:           valueobject.ToKitchenStation(body.Station),
+		PrepTargetMinutes: body.PrepTargetMinutes,
 	}
 
 	output, err := h.controller.Update(
@@ -191,6 +198,7 @@ func (h *CategoryHandler) Update(c *gin.Context) {
 //	@Description	Deletes a category by ID
 //	@Tags			category
 //	@Produce		json
+//	@Security		BearerAuth
 //	@Param			id	path		int								true	"Category ID"
 //	@Success		200	{object}	presenter.CategoryJsonResponse	"OK"
 //	@Failure		400	{object}	middleware.ErrorJsonResponse	"Bad Request"
@@ -35,6 +35,7 @@ func (h *CustomerHandler) Register(router *gin.RouterGroup) {
 //	@Tags			customers
 //	@Accept			json
 //	@Produce		json
+//	@Security		BearerAuth
 //	@Param			name	query		string									false	"Filter by name"
 //	@Param			page	query		int										false	"Page number"		default(1)
 //	@Param			limit	query		int										false	"Items per page"	default(10)
@@ -110,9 +111,11 @@ func (h *CustomerHandler) Create(c *gin.Context) {
 //
 //	@Summary		Get customer
 //	@Description	Search for a customer by ID
+//	@Description	Customers can only read themselves
 //	@Tags			customers
 //	@Accept			json
 //	@Produce		json
+//	@Security		BearerAuth
 //	@Param			id	path		int								true	"Customer ID"
 //	@Success		200	{object}	presenter.CustomerJsonResponse	"OK"
 //	@Failure		400	{object}	middleware.ErrorJsonResponse	"Bad Request"
@@ -147,9 +150,11 @@ func (h *CustomerHandler) Get(c *gin.Context) {
 //
 //	@Summary		Update customer
 //	@Description	Update an existing customer
+//	@Description	Customers can only update themselves
 //	@Tags			customers
 //	@Accept			json
 //	@Produce		json
+//	@Security		BearerAuth
 //	@Param			id			path		int									true	"Customer ID"
 //	@Param			customer	body		request.UpdateCustomerBodyRequest	true	"Customer data"
 //	@Success		200			{object}	presenter.CustomerJsonResponse		"OK"
@@ -195,6 +200,7 @@ func (h *CustomerHandler) Update(c *gin.Context) {
 //	@Description	Deletes a customer by ID
 //	@Tags			customers
 //	@Produce		json
+//	@Security		BearerAuth
 //	@Param			id	path		int								true	"Customer ID"
 //	@Success		200	{object}	presenter.CustomerJsonResponse	"OK"
 //	@Failure		400	{object}	middleware.ErrorJsonResponse	"Bad Request"
@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/dto"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/port"
+)
+
+// versionedPresenter keeps the version of the order or product it presents, to be sent as its ETag
+type versionedPresenter struct {
+	port.Presenter
+	version *uint64
+}
+
+func withVersion(p port.Presenter) *versionedPresenter {
+	return &versionedPresenter{Presenter: p}
+}
+
+// Present write the response to the client
+func (p *versionedPresenter) Present(pp dto.PresenterInput) ([]byte, error) {
+	switch v := pp.Result.(type) {
+	case *entity.Order:
+		p.version = &v.Version
+	case *entity.Product:
+		p.version = &v.Version
+	}
+	return p.Presenter.Present(pp)
+}
+
+// setETag sends the version of the presented order or product as a strong ETag (ex: "3")
+func (p *versionedPresenter) setETag(c *gin.Context) {
+	if p.version != nil {
+		c.Header("ETag", strconv.Quote(strconv.FormatUint(*p.version, 10)))
+	}
+}
+
+// ifMatchVersion returns the version given by the If-Match header, nil when the header is absent or "*". Only a
+// single strong ETag can match a version, any other value fails the precondition.
+func ifMatchVersion(c *gin.Context) (*uint64, error) {
+	ifMatch := strings.TrimSpace(c.GetHeader("If-Match"))
+	if ifMatch == "" || ifMatch == "*" {
+		return nil, nil
+	}
+
+	tag, err := strconv.Unquote(ifMatch)
+	if err != nil || !strings.HasPrefix(ifMatch, `"`) {
+		return nil, domain.NewPreconditionFailedError(domain.ErrVersionMismatch)
+	}
+
+	version, err := strconv.ParseUint(tag, 10, 64)
+	if err != nil {
+		return nil, domain.NewPreconditionFailedError(domain.ErrVersionMismatch)
+	}
+
+	return &version, nil
+}
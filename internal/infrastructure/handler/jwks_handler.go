@@ -0,0 +1,37 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/port"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/infrastructure/handler/response"
+)
+
+type JWKSHandler struct {
+	keyStore port.JWTKeyStore
+}
+
+func NewJWKSHandler(keyStore port.JWTKeyStore) *JWKSHandler {
+	return &JWKSHandler{keyStore: keyStore}
+}
+
+func (h *JWKSHandler) Register(router *gin.RouterGroup) {
+	router.GET("/jwks.json", h.JWKS)
+}
+
+// JWKS godoc
+//
+//	@Summary		JSON Web Key Set
+//	@Description	Lists the public keys access tokens are signed with, so clients can verify them offline.
+//	@Description	Tokens carry the key ID in the "kid" header. The route is served at the root, outside /api/v1.
+//	@Tags			sign-in
+//	@Produce		json
+//	@Success		200	{object}	response.JWKSResponse
+//	@Router			/.well-known/jwks.json [GET]
+func (h *JWKSHandler) JWKS(c *gin.Context) {
+	// Clients may cache the keys for a while, but must pick up a rotation
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, response.NewJWKSResponse(h.keyStore.PublicKeys()))
+}
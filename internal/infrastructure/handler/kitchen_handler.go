@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/adapter/presenter"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain"
+	valueobject "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/value_object"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/dto"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/port"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/infrastructure/handler/request"
+)
+
+type KitchenHandler struct {
+	controller port.KitchenController
+}
+
+func NewKitchenHandler(controller port.KitchenController) *KitchenHandler {
+	return &KitchenHandler{controller: controller}
+}
+
+func (h *KitchenHandler) Register(router *gin.RouterGroup) {
+	router.GET("/tickets", h.ListTickets)
+	router.POST("/tickets/:order_id/:station/bump", h.BumpTicket)
+}
+
+// ListTickets godoc
+//
+//	@Summary		List kitchen tickets
+//	@Description	Lists the pending tickets of the kitchen display, oldest order first
+//	@Description	- Each RECEIVED or PREPARING order is split into one ticket per station, by the station of the category of its products
+//	@Description	- The tickets already bumped are not listed
+//	@Tags			kitchen
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			station	query		string								false	"Filter by station. Available options: GRILL, DRINKS, DESSERTS"
+//	@Success		200		{object}	presenter.KitchenTicketsJsonResponse	"OK"
+//	@Failure		400		{object}	middleware.ErrorJsonResponse			"Bad Request"
+//	@Failure		401		{object}	middleware.ErrorJsonResponse			"Unauthorized"
+//	@Failure		403		{object}	middleware.ErrorJsonResponse			"Forbidden"
+//	@Failure		500		{object}	middleware.ErrorJsonResponse			"Internal Server Error"
+//	@Router			/kitchen/tickets [get]
+func (h *KitchenHandler) ListTickets(c *gin.Context) {
+	var query request.ListKitchenTicketsQueryRequest
+	if err := c.ShouldBindQuery(&query); err != nil {
+		_ = c.Error(domain.NewInvalidInputError(domain.ErrInvalidQueryParams))
+		return
+	}
+
+	output, err := h.controller.ListTickets(
+		c.Request.Context(),
+		presenter.NewKitchenTicketJsonPresenter(),
+		dto.ListKitchenTicketsInput{Station: valueobject.ToKitchenStation(query.Station)},
+	)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.Data(http.StatusOK, "application/json", output)
+}
+
+// BumpTicket godoc
+//
+//	@Summary		Bump a kitchen ticket
+//	@Description	Marks the ticket of the station as done, recorded with the staff of the access token
+//	@Description
+//	@Description	> The first bump of a RECEIVED order moves it to PREPARING.
+//	@Description	> Once the tickets of every station are bumped the order moves to READY.
+//	@Tags			kitchen
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			order_id	path		int								true	"Order ID"
+//	@Param			station		path		string							true	"Station. Available options: GRILL, DRINKS, DESSERTS"
+//	@Success		200			{object}	presenter.KitchenTicketJsonResponse	"OK"
+//	@Failure		400			{object}	middleware.ErrorJsonResponse		"Bad Request"
+//	@Failure		401			{object}	middleware.ErrorJsonResponse		"Unauthorized"
+//	@Failure		403			{object}	middleware.ErrorJsonResponse		"Forbidden"
+//	@Failure		404			{object}	middleware.ErrorJsonResponse		"Not Found"
+//	@Failure		500			{object}	middleware.ErrorJsonResponse		"Internal Server Error"
+//	@Router			/kitchen/tickets/{order_id}/{station}/bump [post]
+func (h *KitchenHandler) BumpTicket(c *gin.Context) {
+	var uri request.BumpKitchenTicketUriRequest
+	if err := c.ShouldBindUri(&uri); err != nil {
+		_ = c.Error(domain.NewInvalidInputError(domain.ErrInvalidParam))
+		return
+	}
+
+	input := dto.BumpKitchenTicketInput{
+		OrderID: uri.OrderID,
+		Station: valueobject.ToKitchenStation(uri.Station),
+		StaffID: staffIDFromContext(c),
+	}
+
+	output, err := h.controller.BumpTicket(
+		c.Request.Context(),
+		presenter.NewKitchenTicketJsonPresenter(),
+		input,
+	)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.Data(http.StatusOK, "application/json", output)
+}
@@ -1,26 +1,41 @@
 package handler
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"golang.org/x/net/websocket"
 
 	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/adapter/presenter"
 	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
 	valueobject "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/value_object"
 	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/dto"
 	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/port"
 	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/infrastructure/handler/request"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/infrastructure/middleware"
 )
 
+// orderStatusHeartbeatInterval is how often an idle order status stream sends a comment, so the proxies keep it open
+const orderStatusHeartbeatInterval = 15 * time.Second
+
 type OrderHandler struct {
-	controller port.OrderController
+	controller    port.OrderController
+	streamOrigins []string
 }
 
-func NewOrderHandler(controller port.OrderController) *OrderHandler {
-	return &OrderHandler{controller: controller}
+// NewOrderHandler creates the handler, the WebSocket stream accepting the pages of the API own origin or of
+// streamOrigins
+func NewOrderHandler(controller port.OrderController, streamOrigins []string) *OrderHandler {
+	return &OrderHandler{controller: controller, streamOrigins: streamOrigins}
 }
 
 func (h *OrderHandler) Register(router *gin.RouterGroup) {
@@ -29,7 +44,10 @@ func (h *OrderHandler) Register(router *gin.RouterGroup) {
 	router.GET("/:id", h.Get)
 	router.PUT("/:id", h.Update)
 	router.PATCH("/:id", h.UpdatePartial)
+	router.PATCH("/:id/customer", h.AttachCustomer)
 	router.DELETE("/:id", h.Delete)
+	router.GET("/events", h.StreamStatus)
+	router.GET("/events/ws", h.StreamStatusWebSocket)
 }
 
 // List godoc
@@ -39,13 +57,16 @@ func (h *OrderHandler) Register(router *gin.RouterGroup) {
 //	@Description	## Order list is sorted by:
 //	@Description	- **Status** in **descending** order (`READY` > `PREPARING` > `RECEIVED` > `PENDING` > `OPEN`)
 //	@Description	- **Created date** (CreatedAt) in **ascending** order (oldest first)
-//	@Description	Obs: Status CANCELLED and COMPLETED are not included in the list by default
+//	@Description	Obs: Status CANCELLED, COMPLETED and ABANDONED are not included in the list by default
+//	@Description	Customers only see their own orders
+//	@Description	Each order has its `timing` in each status after the payment, `late` while in the kitchen past its target
 //	@Tags			orders
 //	@Accept			json
 //	@Produce		json
+//	@Security		BearerAuth
 //	@Param			customer_id		query		int										false	"Filter by customer ID"
 //	@Param			status			query		string									false	"Filter by status (Accept many), options: <sub>OPEN, PENDING, RECEIVED, PREPARING, READY</sub>, ex: <sub>PENDING</sub> or <sub>OPEN,PENDING</sub>"
-//	@Param			status_exclude	query		string									false	"Exclude by status (Accept many), options: <sub>NONE, OPEN, PENDING, RECEIVED, PREPARING, READY, CANCELLED, COMPLETED</sub>, ex: <sub>CANCELLED</sub> or <sub>CANCELLED,COMPLETED</sub> (default)"	default(CANCELLED,COMPLETED)
+//	@Param			status_exclude	query		string									false	"Exclude by status (Accept many), options: <sub>NONE, OPEN, PENDING, RECEIVED, PREPARING, READY, CANCELLED, COMPLETED, ABANDONED</sub>, ex: <sub>CANCELLED</sub> or <sub>CANCELLED,COMPLETED,ABANDONED</sub> (default)"	default(CANCELLED,COMPLETED,ABANDONED)
 //	@Param			sort			query		string									false	"Sort by field (Accept many). Use `<field_name>:d` for descending, and the default order is ascending"																								default(status:d,created_at)
 //	@Param			page			query		int										false	"Page number"																																														default(1)
 //	@Param			limit			query		int										false	"Items per page"																																													default(10)
@@ -68,7 +89,7 @@ func (h *OrderHandler) List(c *gin.Context) {
 	// Default status_exclude
 	var statusExclude []valueobject.OrderStatus
 	if query.StatusExclude == "" {
-		query.StatusExclude = "CANCELLED,COMPLETED"
+		query.StatusExclude = "CANCELLED,COMPLETED,ABANDONED"
 	}
 
 	// Convert status_exclude
@@ -117,9 +138,12 @@ func (h *OrderHandler) List(c *gin.Context) {
 //
 //	@Summary		Create order
 //	@Description	Creates a new order
+//	@Description	Without `customer_id` (and without access token) the order is placed as a guest
+//	@Description	A guest order is returned with its `guest_token`, to be sent in the X-Guest-Token header to reach it
 //	@Tags			orders
 //	@Accept			json
 //	@Produce		json
+//	@Security		BearerAuth
 //	@Param			order	body		request.CreateOrderBodyRequest	true	"Order data"
 //	@Success		201		{object}	presenter.OrderJsonResponse		"Created"
 //	@Failure		400		{object}	middleware.ErrorJsonResponse	"Bad Request"
@@ -136,9 +160,10 @@ func (h *OrderHandler) Create(c *gin.Context) {
 		CustomerID: body.CustomerID,
 	}
 
+	p := withVersion(presenter.NewOrderJsonPresenter())
 	output, err := h.controller.Create(
 		c.Request.Context(),
-		presenter.NewOrderJsonPresenter(),
+		p,
 		input,
 	)
 	if err != nil {
@@ -146,6 +171,7 @@ func (h *OrderHandler) Create(c *gin.Context) {
 		return
 	}
 
+	p.setETag(c)
 	c.Data(http.StatusCreated, "application/json", output)
 }
 
@@ -156,6 +182,7 @@ func (h *OrderHandler) Create(c *gin.Context) {
 //	@Tags			orders
 //	@Accept			json
 //	@Produce		json
+//	@Security		BearerAuth
 //	@Param			id	path		int								true	"Order ID"
 //	@Success		200	{object}	presenter.OrderJsonResponse		"OK"
 //	@Failure		400	{object}	middleware.ErrorJsonResponse	"Bad Request"
@@ -173,9 +200,10 @@ func (h *OrderHandler) Get(c *gin.Context) {
 		ID: uri.ID,
 	}
 
+	p := withVersion(presenter.NewOrderJsonPresenter())
 	output, err := h.controller.Get(
 		c.Request.Context(),
-		presenter.NewOrderJsonPresenter(),
+		p,
 		input,
 	)
 	if err != nil {
@@ -183,6 +211,7 @@ func (h *OrderHandler) Get(c *gin.Context) {
 		return
 	}
 
+	p.setETag(c)
 	c.Data(http.StatusOK, "application/json", output)
 }
 
@@ -190,23 +219,33 @@ func (h *OrderHandler) Get(c *gin.Context) {
 //
 //	@Summary		Update order
 //	@Description	Update an existing order
-//	@Description	The status are: **OPEN**, **CANCELLED**, **PENDING**, **RECEIVED**, **PREPARING**, **READY**, **COMPLETED**
-//	@Description	## Transition of status:
+//	@Description	The status are: **OPEN**, **CANCELLED**, **PENDING**, **RECEIVED**, **PREPARING**, **READY**, **COMPLETED**, **ABANDONED**
+//	@Description	## Transition of status (default state machine, replaced by ORDER_STATE_MACHINE_FILE):
 //	@Description	- OPEN      -> CANCELLED || PENDING
 //	@Description	- CANCELLED -> {},
 //	@Description	- PENDING   -> OPEN || RECEIVED
 //	@Description	- RECEIVED  -> PREPARING
 //	@Description	- PREPARING -> READY
-//	@Description	- READY     -> COMPLETED
+//	@Description	- READY     -> COMPLETED || ABANDONED
 //	@Description	- COMPLETED -> {}
+//	@Description	- ABANDONED -> {}
+//	@Description	Transitions to PREPARING, READY, COMPLETED and ABANDONED are recorded with the staff of the access token
+//	@Description	A transition may be limited to some staff roles and to the orders meeting its guards
+//	@Description	READY orders not collected and idle OPEN orders are expired automatically, recorded with the ORDER_EXPIRY system actor
+//	@Description	Cancelling a paid order refunds its payment, so only managers may cancel it
+//	@Description	Changes to the kitchen queue recompute the estimated_ready_at of the paid orders
 //	@Tags			orders
 //	@Accept			json
 //	@Produce		json
+//	@Security		BearerAuth
 //	@Param			id		path		int								true	"Order ID"
 //	@Param			order	body		request.UpdateOrderBodyRequest	true	"Order data"
+//	@Param			If-Match	header		string							false	"ETag of the order the change is based on"
 //	@Success		200		{object}	presenter.OrderJsonResponse		"OK"
 //	@Failure		400		{object}	middleware.ErrorJsonResponse	"Bad Request"
 //	@Failure		404		{object}	middleware.ErrorJsonResponse	"Not Found"
+//	@Failure		409		{object}	middleware.ErrorJsonResponse	"Conflict"
+//	@Failure		412		{object}	middleware.ErrorJsonResponse	"Precondition Failed"
 //	@Failure		500		{object}	middleware.ErrorJsonResponse	"Internal Server Error"
 //	@Router			/orders/{id} [put]
 func (h *OrderHandler) Update(c *gin.Context) {
@@ -222,16 +261,24 @@ func (h *OrderHandler) Update(c *gin.Context) {
 		return
 	}
 
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
 	input := dto.UpdateOrderInput{
 		ID:         uri.ID,
 		CustomerID: body.CustomerID,
 		Status:     body.Status,
-		StaffID:    body.StaffID,
+		StaffID:    staffIDFromContext(c),
+		Version:    version,
 	}
 
+	p := withVersion(presenter.NewOrderJsonPresenter())
 	output, err := h.controller.Update(
 		c.Request.Context(),
-		presenter.NewOrderJsonPresenter(),
+		p,
 		input,
 	)
 	if err != nil {
@@ -239,6 +286,7 @@ func (h *OrderHandler) Update(c *gin.Context) {
 		return
 	}
 
+	p.setETag(c)
 	c.Data(http.StatusOK, "application/json", output)
 }
 
@@ -246,23 +294,32 @@ func (h *OrderHandler) Update(c *gin.Context) {
 //
 //	@Summary		Partial update order (Reference TC-2 1.a.v)
 //	@Description	Partially updates an existing order
-//	@Description	The status are: **OPEN**, **CANCELLED**, **PENDING**, **RECEIVED**, **PREPARING**, **READY**, **COMPLETED**
-//	@Description	## Transition of status:
+//	@Description	The status are: **OPEN**, **CANCELLED**, **PENDING**, **RECEIVED**, **PREPARING**, **READY**, **COMPLETED**, **ABANDONED**
+//	@Description	## Transition of status (default state machine, replaced by ORDER_STATE_MACHINE_FILE):
 //	@Description	- OPEN      -> CANCELLED || PENDING
 //	@Description	- CANCELLED -> {},
 //	@Description	- PENDING   -> OPEN || RECEIVED
 //	@Description	- RECEIVED  -> PREPARING
 //	@Description	- PREPARING -> READY
-//	@Description	- READY     -> COMPLETED
+//	@Description	- READY     -> COMPLETED || ABANDONED
 //	@Description	- COMPLETED -> {}
+//	@Description	- ABANDONED -> {}
+//	@Description	Transitions to PREPARING, READY, COMPLETED and ABANDONED are recorded with the staff of the access token
+//	@Description	A transition may be limited to some staff roles and to the orders meeting its guards
+//	@Description	READY orders not collected and idle OPEN orders are expired automatically, recorded with the ORDER_EXPIRY system actor
+//	@Description	Cancelling a paid order refunds its payment, so only managers may cancel it
 //	@Tags			orders
 //	@Accept			json
 //	@Produce		json
+//	@Security		BearerAuth
 //	@Param			id		path		int									true	"Order ID"
 //	@Param			order	body		request.UpdateOrderPartilRequest	true	"Order data"
+//	@Param			If-Match	header		string							false	"ETag of the order the change is based on"
 //	@Success		200		{object}	presenter.OrderJsonResponse			"OK"
 //	@Failure		400		{object}	middleware.ErrorJsonResponse		"Bad Request"
 //	@Failure		404		{object}	middleware.ErrorJsonResponse		"Not Found"
+//	@Failure		409		{object}	middleware.ErrorJsonResponse	"Conflict"
+//	@Failure		412		{object}	middleware.ErrorJsonResponse	"Precondition Failed"
 //	@Failure		500		{object}	middleware.ErrorJsonResponse		"Internal Server Error"
 //	@Router			/orders/{id} [patch]
 func (h *OrderHandler) UpdatePartial(c *gin.Context) {
@@ -279,16 +336,79 @@ func (h *OrderHandler) UpdatePartial(c *gin.Context) {
 		return
 	}
 
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
 	input := dto.UpdateOrderInput{
 		ID:         uri.ID,
 		CustomerID: body.CustomerID,
 		Status:     body.Status,
-		StaffID:    body.StaffID,
+		StaffID:    staffIDFromContext(c),
+		Version:    version,
 	}
 
+	p := withVersion(presenter.NewOrderJsonPresenter())
 	output, err := h.controller.Update(
 		c.Request.Context(),
-		presenter.NewOrderJsonPresenter(),
+		p,
+		input,
+	)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	p.setETag(c)
+	c.Data(http.StatusOK, "application/json", output)
+}
+
+// AttachCustomer godoc
+//
+//	@Summary		Attach customer to a guest order
+//	@Description	Identifies the customer of a guest order on status OPEN or PENDING
+//	@Description	Customers can only identify themselves, `customer_id` is taken from the access token,
+//	@Description	and must send the `guest_token` given when the guest order was created
+//	@Tags			orders
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id				path		int										true	"Order ID"
+//	@Param			X-Guest-Token	header		string									false	"Guest token of the order, required for customers"
+//	@Param			customer		body		request.AttachOrderCustomerBodyRequest	false	"Customer data"
+//	@Success		200			{object}	presenter.OrderJsonResponse				"OK"
+//	@Failure		400			{object}	middleware.ErrorJsonResponse			"Bad Request"
+//	@Failure		403			{object}	middleware.ErrorJsonResponse			"Forbidden"
+//	@Failure		404			{object}	middleware.ErrorJsonResponse			"Not Found"
+//	@Failure		500			{object}	middleware.ErrorJsonResponse			"Internal Server Error"
+//	@Router			/orders/{id}/customer [patch]
+func (h *OrderHandler) AttachCustomer(c *gin.Context) {
+	var uri request.AttachOrderCustomerUriRequest
+	if err := c.ShouldBindUri(&uri); err != nil {
+		_ = c.Error(domain.NewInvalidInputError(domain.ErrInvalidParam))
+		return
+	}
+
+	var body request.AttachOrderCustomerBodyRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&body); err != nil {
+			_ = c.Error(domain.NewInvalidInputError(domain.ErrInvalidBody))
+			return
+		}
+	}
+
+	input := dto.AttachOrderCustomerInput{
+		ID:         uri.ID,
+		CustomerID: body.CustomerID,
+		GuestToken: c.GetHeader(middleware.GuestTokenHeader),
+	}
+
+	p := withVersion(presenter.NewOrderJsonPresenter())
+	output, err := h.controller.AttachCustomer(
+		c.Request.Context(),
+		p,
 		input,
 	)
 	if err != nil {
@@ -296,6 +416,7 @@ func (h *OrderHandler) UpdatePartial(c *gin.Context) {
 		return
 	}
 
+	p.setETag(c)
 	c.Data(http.StatusOK, "application/json", output)
 }
 
@@ -305,6 +426,7 @@ func (h *OrderHandler) UpdatePartial(c *gin.Context) {
 //	@Description	Deletes a order by ID
 //	@Tags			orders
 //	@Produce		json
+//	@Security		BearerAuth
 //	@Param			id	path		int								true	"Order ID"
 //	@Success		200	{object}	presenter.OrderJsonResponse		"OK"
 //	@Failure		400	{object}	middleware.ErrorJsonResponse	"Bad Request"
@@ -334,3 +456,190 @@ func (h *OrderHandler) Delete(c *gin.Context) {
 
 	c.Data(http.StatusOK, "application/json", output)
 }
+
+// staffIDFromContext returns the ID of the authenticated staff, or 0 when the caller is not a staff
+func staffIDFromContext(c *gin.Context) uint64 {
+	principal, ok := entity.PrincipalFromContext(c.Request.Context())
+	if !ok || !principal.IsStaff() {
+		return 0
+	}
+	return principal.ID
+}
+
+// StreamStatus godoc
+//
+//	@Summary		Stream order status changes
+//	@Description	Server-Sent Events with the status changes of the orders, for the pickup display (`event: order_status`)
+//	@Description	The `id` of each event is the order history ID. Send it back in the `Last-Event-ID` header (set by the browsers on reconnect) or in `after_id` to receive the changes missed while disconnected
+//	@Description	Customers only receive their own orders. The `EventSource` of the browsers sends the token in `access_token`, as it cannot set the header
+//	@Tags			orders
+//	@Produce		text/event-stream
+//	@Security		BearerAuth
+//	@Param			access_token	query		string									false	"Access token, instead of the Authorization header"
+//	@Param			status			query		string									false	"Filter by status (Accept many), ex: <sub>RECEIVED,PREPARING,READY</sub>"
+//	@Param			customer_id		query		int										false	"Filter by customer ID"
+//	@Param			after_id		query		int										false	"ID of the last event received"
+//	@Param			Last-Event-ID	header		int										false	"ID of the last event received"
+//	@Success		200				{object}	presenter.OrderStatusEventJsonResponse	"Stream of events"
+//	@Failure		400				{object}	middleware.ErrorJsonResponse			"Bad Request"
+//	@Failure		403				{object}	middleware.ErrorJsonResponse			"Forbidden"
+//	@Failure		500				{object}	middleware.ErrorJsonResponse			"Internal Server Error"
+//	@Router			/orders/events [get]
+func (h *OrderHandler) StreamStatus(c *gin.Context) {
+	input, err := subscribeOrderStatusInput(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	messages, err := h.controller.SubscribeStatus(
+		c.Request.Context(),
+		presenter.NewOrderStatusEventJsonPresenter(),
+		input,
+	)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	disableDeadlines(c)
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(orderStatusHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case message, ok := <-messages:
+			// closed when the client falls behind, it reconnects from the last event
+			if !ok {
+				return
+			}
+			_, _ = fmt.Fprintf(c.Writer, "id: %d\nevent: order_status\ndata: %s\n\n", message.ID, message.Data)
+		case <-heartbeat.C:
+			_, _ = fmt.Fprint(c.Writer, ": heartbeat\n\n")
+		case <-c.Request.Context().Done():
+			return
+		}
+		c.Writer.Flush()
+	}
+}
+
+// StreamStatusWebSocket godoc
+//
+//	@Summary		Stream order status changes over WebSocket
+//	@Description	WebSocket alternative to `GET /orders/events`, each text message is an order status event
+//	@Description	Browsers send the token in `access_token`, as they cannot set the header. Only pages of the API origin or of ORDER_STREAM_ORIGINS may connect
+//	@Description	The `id` of each event is the order history ID. Send it back in `after_id` to receive the changes missed while disconnected
+//	@Description	Customers only receive their own orders
+//	@Tags			orders
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			access_token	query		string									false	"Access token, instead of the Authorization header"
+//	@Param			status		query		string									false	"Filter by status (Accept many), ex: <sub>RECEIVED,PREPARING,READY</sub>"
+//	@Param			customer_id	query		int										false	"Filter by customer ID"
+//	@Param			after_id	query		int										false	"ID of the last event received"
+//	@Success		101			{object}	presenter.OrderStatusEventJsonResponse	"Switching Protocols"
+//	@Failure		400			{object}	middleware.ErrorJsonResponse			"Bad Request"
+//	@Failure		403			{object}	middleware.ErrorJsonResponse			"Forbidden"
+//	@Failure		500			{object}	middleware.ErrorJsonResponse			"Internal Server Error"
+//	@Router			/orders/events/ws [get]
+func (h *OrderHandler) StreamStatusWebSocket(c *gin.Context) {
+	input, err := subscribeOrderStatusInput(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	ctx, cancel := context.WithCancel(c.Request.Context())
+	defer cancel()
+
+	messages, err := h.controller.SubscribeStatus(ctx, presenter.NewOrderStatusEventJsonPresenter(), input)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	disableDeadlines(c)
+	websocket.Server{
+		// A page of another site would otherwise stream the orders with the token it got from the user
+		Handshake: func(_ *websocket.Config, r *http.Request) error {
+			if !h.allowsOrigin(r) {
+				return domain.NewForbiddenError(domain.ErrPermissionDenied)
+			}
+			return nil
+		},
+		Handler: func(ws *websocket.Conn) {
+			// Messages from the client are ignored, reading only tells when it goes away
+			go func() {
+				_, _ = io.Copy(io.Discard, ws)
+				cancel()
+			}()
+
+			for message := range messages {
+				if err := websocket.Message.Send(ws, string(message.Data)); err != nil {
+					return
+				}
+			}
+		},
+	}.ServeHTTP(c.Writer, c.Request)
+}
+
+// allowsOrigin tells if the page opening the WebSocket is of the API own origin or of an allowed one. Clients other
+// than browsers send no origin.
+func (h *OrderHandler) allowsOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+
+	if u, err := url.Parse(origin); err == nil && u.Host == r.Host {
+		return true
+	}
+
+	return slices.Contains(h.streamOrigins, origin)
+}
+
+// subscribeOrderStatusInput reads the filters and the cursor of an order status stream
+func subscribeOrderStatusInput(c *gin.Context) (dto.SubscribeOrderStatusInput, error) {
+	var query request.StreamOrderStatusQueryRequest
+	if err := c.ShouldBindQuery(&query); err != nil {
+		return dto.SubscribeOrderStatusInput{}, domain.NewInvalidInputError(domain.ErrInvalidQueryParams)
+	}
+
+	var status []valueobject.OrderStatus
+	if query.Status != "" {
+		for _, s := range strings.Split(query.Status, ",") {
+			orderStatus, ok := valueobject.ToOrderStatus(strings.TrimSpace(s))
+			if !ok {
+				return dto.SubscribeOrderStatusInput{}, domain.NewInvalidInputError(domain.ErrInvalidQueryParams)
+			}
+			status = append(status, orderStatus)
+		}
+	}
+
+	if lastEventID := c.GetHeader("Last-Event-ID"); lastEventID != "" {
+		afterID, err := strconv.ParseUint(lastEventID, 10, 64)
+		if err != nil {
+			return dto.SubscribeOrderStatusInput{}, domain.NewInvalidInputError(domain.ErrInvalidParam)
+		}
+		query.AfterID = afterID
+	}
+
+	return dto.SubscribeOrderStatusInput{
+		Status:     status,
+		CustomerID: query.CustomerID,
+		AfterID:    query.AfterID,
+	}, nil
+}
+
+// disableDeadlines lets a stream outlive the read and write timeouts of the server
+func disableDeadlines(c *gin.Context) {
+	rc := http.NewResponseController(c.Writer)
+	_ = rc.SetReadDeadline(time.Time{})
+	_ = rc.SetWriteDeadline(time.Time{})
+}
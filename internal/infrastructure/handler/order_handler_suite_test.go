@@ -31,7 +31,7 @@ func (s *OrderHandlerSuiteTest) SetupTest() {
 	ctrl := gomock.NewController(s.T())
 	defer ctrl.Finish()
 	s.mockController = mockport.NewMockOrderController(ctrl)
-	s.handler = handler.NewOrderHandler(s.mockController)
+	s.handler = handler.NewOrderHandler(s.mockController, []string{"https://pickup.example.com"})
 	s.ctx = context.Background()
 
 	// Register routes
@@ -39,7 +39,10 @@ func (s *OrderHandlerSuiteTest) SetupTest() {
 	s.router.POST("/orders", s.handler.Create)
 	s.router.PUT("/orders/:id", s.handler.Update)
 	s.router.PATCH("/orders/:id", s.handler.UpdatePartial)
+	s.router.PATCH("/orders/:id/customer", s.handler.AttachCustomer)
 	s.router.GET("/orders/:id", s.handler.Get)
+	s.router.GET("/orders/events", s.handler.StreamStatus)
+	s.router.GET("/orders/events/ws", s.handler.StreamStatusWebSocket)
 	s.router.DELETE("/orders/:id", s.handler.Delete)
 
 	// Mock requests
@@ -47,6 +50,7 @@ func (s *OrderHandlerSuiteTest) SetupTest() {
 	s.requests, err = util.ReadFixtureFiles("order",
 		"create_success", "create_invalid_body",
 		"update_success", "update_invalid_body",
+		"attach_customer_success",
 	)
 	assert.NoError(s.T(), err)
 
@@ -55,6 +59,7 @@ func (s *OrderHandlerSuiteTest) SetupTest() {
 		"list_success", "list_success_with_query",
 		"create_success",
 		"update_success",
+		"attach_customer_success",
 		"get_success",
 		"delete_success",
 	)
@@ -1,17 +1,21 @@
 package handler_test
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
 
 	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
 	valueobject "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/value_object"
 	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/dto"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/port"
 	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/util"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
+	"golang.org/x/net/websocket"
 )
 
 func (s *OrderHandlerSuiteTest) TestOrderHandler_List() {
@@ -26,7 +30,7 @@ func (s *OrderHandlerSuiteTest) TestOrderHandler_List() {
 			url:  "/orders",
 			setupMocks: func() {
 				s.mockController.EXPECT().List(gomock.Any(), gomock.Any(), dto.ListOrdersInput{
-					StatusExclude: []valueobject.OrderStatus{valueobject.CANCELLED, valueobject.COMPLETED},
+					StatusExclude: []valueobject.OrderStatus{valueobject.CANCELLED, valueobject.COMPLETED, valueobject.ABANDONED},
 					Page:          1,
 					Limit:         10,
 					Sort:          "status:d,created_at",
@@ -44,7 +48,7 @@ func (s *OrderHandlerSuiteTest) TestOrderHandler_List() {
 				s.mockController.EXPECT().List(gomock.Any(), gomock.Any(), dto.ListOrdersInput{
 					CustomerID:    1,
 					Status:        []valueobject.OrderStatus{valueobject.OPEN, valueobject.PENDING},
-					StatusExclude: []valueobject.OrderStatus{valueobject.CANCELLED, valueobject.COMPLETED},
+					StatusExclude: []valueobject.OrderStatus{valueobject.CANCELLED, valueobject.COMPLETED, valueobject.ABANDONED},
 					Page:          1,
 					Limit:         10,
 					Sort:          "status:d,created_at",
@@ -78,7 +82,7 @@ func (s *OrderHandlerSuiteTest) TestOrderHandler_List() {
 			url:  "/orders",
 			setupMocks: func() {
 				s.mockController.EXPECT().List(gomock.Any(), gomock.Any(), dto.ListOrdersInput{
-					StatusExclude: []valueobject.OrderStatus{valueobject.CANCELLED, valueobject.COMPLETED},
+					StatusExclude: []valueobject.OrderStatus{valueobject.CANCELLED, valueobject.COMPLETED, valueobject.ABANDONED},
 					Page:          1,
 					Limit:         10,
 					Sort:          "status:d,created_at",
@@ -240,9 +244,11 @@ func (s *OrderHandlerSuiteTest) TestOrderHandler_Get() {
 }
 
 func (s *OrderHandlerSuiteTest) TestOrderHandler_Update() {
+	version := uint64(3)
 	tests := []struct {
 		name        string
 		url         string
+		ifMatch     string
 		body        *strings.Reader
 		setupMocks  func()
 		checkResult func(*testing.T, *httptest.ResponseRecorder)
@@ -265,6 +271,51 @@ func (s *OrderHandlerSuiteTest) TestOrderHandler_Update() {
 				assert.Contains(t, util.RemoveAllSpaces(res.Body.String()), s.responses["update_success"])
 			},
 		},
+		{
+			name:    "success - update order matching If-Match, returns the new ETag",
+			url:     "/orders/15",
+			ifMatch: `"3"`,
+			body:    strings.NewReader(s.requests["update_success"]),
+			setupMocks: func() {
+				s.mockController.EXPECT().
+					Update(gomock.Any(), gomock.Any(), dto.UpdateOrderInput{
+						ID:         15,
+						CustomerID: 5,
+						Status:     valueobject.PENDING,
+						Version:    &version,
+					}).
+					DoAndReturn(func(_ context.Context, p port.Presenter, _ dto.UpdateOrderInput) ([]byte, error) {
+						return p.Present(dto.PresenterInput{Result: &entity.Order{ID: 15, Version: 4}})
+					})
+			},
+			checkResult: func(t *testing.T, res *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusOK, res.Code)
+				assert.Equal(t, `"4"`, res.Header().Get("ETag"))
+			},
+		},
+		{
+			name:       "precondition failed - If-Match is not a version",
+			url:        "/orders/15",
+			ifMatch:    `W/"3"`,
+			body:       strings.NewReader(s.requests["update_success"]),
+			setupMocks: func() {},
+			checkResult: func(t *testing.T, res *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusPreconditionFailed, res.Code)
+			},
+		},
+		{
+			name: "conflict - order changed concurrently",
+			url:  "/orders/15",
+			body: strings.NewReader(s.requests["update_success"]),
+			setupMocks: func() {
+				s.mockController.EXPECT().
+					Update(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, domain.NewConflictError(domain.ErrConcurrentUpdate))
+			},
+			checkResult: func(t *testing.T, res *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusConflict, res.Code)
+			},
+		},
 		{
 			name:       "invalid request - body is not a valid json",
 			url:        "/orders/5",
@@ -321,6 +372,9 @@ func (s *OrderHandlerSuiteTest) TestOrderHandler_Update() {
 			tt.setupMocks()
 			w := httptest.NewRecorder()
 			req, _ := http.NewRequest(http.MethodPut, tt.url, tt.body)
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
 
 			// Act
 			s.router.ServeHTTP(w, req)
@@ -423,6 +477,101 @@ func (s *OrderHandlerSuiteTest) TestOrderHandler_UpdatePartial() {
 	}
 }
 
+func (s *OrderHandlerSuiteTest) TestOrderHandler_AttachCustomer() {
+	tests := []struct {
+		name        string
+		url         string
+		body        *strings.Reader
+		setupMocks  func()
+		checkResult func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name: "success",
+			url:  "/orders/15/customer",
+			body: strings.NewReader(s.requests["attach_customer_success"]),
+			setupMocks: func() {
+				s.mockController.EXPECT().
+					AttachCustomer(gomock.Any(), gomock.Any(), dto.AttachOrderCustomerInput{
+						ID:         15,
+						CustomerID: 5,
+					}).
+					Return([]byte(s.responses["attach_customer_success"]), nil)
+			},
+			checkResult: func(t *testing.T, res *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusOK, res.Code)
+				assert.Contains(t, util.RemoveAllSpaces(res.Body.String()), s.responses["attach_customer_success"])
+			},
+		},
+		{
+			name: "success - without body",
+			url:  "/orders/15/customer",
+			body: strings.NewReader(""),
+			setupMocks: func() {
+				s.mockController.EXPECT().
+					AttachCustomer(gomock.Any(), gomock.Any(), dto.AttachOrderCustomerInput{
+						ID: 15,
+					}).
+					Return([]byte(s.responses["attach_customer_success"]), nil)
+			},
+			checkResult: func(t *testing.T, res *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusOK, res.Code)
+			},
+		},
+		{
+			name:       "invalid request - body is not a valid json",
+			url:        "/orders/15/customer",
+			body:       strings.NewReader("invalid"),
+			setupMocks: func() {},
+			checkResult: func(t *testing.T, res *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusBadRequest, res.Code)
+				assert.Contains(t, util.RemoveAllSpaces(res.Body.String()), s.responses["error_invalid_body"])
+			},
+		},
+		{
+			name:       "invalid request - id is not a number",
+			url:        "/orders/invalid/customer",
+			body:       strings.NewReader(s.requests["attach_customer_success"]),
+			setupMocks: func() {},
+			checkResult: func(t *testing.T, res *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusBadRequest, res.Code)
+				assert.Contains(t, util.RemoveAllSpaces(res.Body.String()), s.responses["error_invalid_parameter"])
+			},
+		},
+		{
+			name: "controller error",
+			url:  "/orders/15/customer",
+			body: strings.NewReader(s.requests["attach_customer_success"]),
+			setupMocks: func() {
+				s.mockController.EXPECT().
+					AttachCustomer(gomock.Any(), gomock.Any(), dto.AttachOrderCustomerInput{
+						ID:         15,
+						CustomerID: 5,
+					}).
+					Return(nil, domain.NewInternalError(nil))
+			},
+			checkResult: func(t *testing.T, res *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusInternalServerError, res.Code)
+				assert.Contains(t, util.RemoveAllSpaces(res.Body.String()), s.responses["error_internal_error"])
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.T().Run(tt.name, func(t *testing.T) {
+			// Arrange
+			tt.setupMocks()
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPatch, tt.url, tt.body)
+
+			// Act
+			s.router.ServeHTTP(w, req)
+
+			// Assert
+			tt.checkResult(t, w)
+		})
+	}
+}
+
 func (s *OrderHandlerSuiteTest) TestOrderHandler_Delete() {
 	tests := []struct {
 		name        string
@@ -482,3 +631,145 @@ func (s *OrderHandlerSuiteTest) TestOrderHandler_Delete() {
 		})
 	}
 }
+
+func (s *OrderHandlerSuiteTest) TestOrderHandler_StreamStatus() {
+	tests := []struct {
+		name        string
+		url         string
+		lastEventID string
+		setupMocks  func()
+		checkResult func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name:        "success - sends the events from the last one received",
+			url:         "/orders/events?status=READY&customer_id=1",
+			lastEventID: "10",
+			setupMocks: func() {
+				messages := make(chan dto.OrderStatusMessage, 1)
+				messages <- dto.OrderStatusMessage{ID: 11, Data: []byte(`{"id":11}`)}
+				close(messages)
+
+				s.mockController.EXPECT().
+					SubscribeStatus(gomock.Any(), gomock.Any(), dto.SubscribeOrderStatusInput{
+						Status:     []valueobject.OrderStatus{valueobject.READY},
+						CustomerID: 1,
+						AfterID:    10,
+					}).
+					Return((<-chan dto.OrderStatusMessage)(messages), nil)
+			},
+			checkResult: func(t *testing.T, res *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusOK, res.Code)
+				assert.Equal(t, "text/event-stream", res.Header().Get("Content-Type"))
+				assert.Equal(t, "id: 11\nevent: order_status\ndata: {\"id\":11}\n\n", res.Body.String())
+			},
+		},
+		{
+			name:       "invalid request - unknown status",
+			url:        "/orders/events?status=UNKNOWN",
+			setupMocks: func() {},
+			checkResult: func(t *testing.T, res *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusBadRequest, res.Code)
+			},
+		},
+		{
+			name:        "invalid request - Last-Event-ID is not a number",
+			url:         "/orders/events",
+			lastEventID: "invalid",
+			setupMocks:  func() {},
+			checkResult: func(t *testing.T, res *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusBadRequest, res.Code)
+			},
+		},
+		{
+			name: "controller error",
+			url:  "/orders/events",
+			setupMocks: func() {
+				s.mockController.EXPECT().
+					SubscribeStatus(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, domain.NewForbiddenError(domain.ErrPermissionDenied))
+			},
+			checkResult: func(t *testing.T, res *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusForbidden, res.Code)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.T().Run(tt.name, func(t *testing.T) {
+			// Arrange
+			tt.setupMocks()
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, tt.url, nil)
+			if tt.lastEventID != "" {
+				req.Header.Set("Last-Event-ID", tt.lastEventID)
+			}
+
+			// Act
+			s.router.ServeHTTP(w, req)
+
+			// Assert
+			tt.checkResult(t, w)
+		})
+	}
+}
+
+func (s *OrderHandlerSuiteTest) TestOrderHandler_StreamStatusWebSocket() {
+	server := httptest.NewServer(s.router)
+	defer server.Close()
+
+	tests := []struct {
+		name        string
+		origin      string
+		checkResult func(*testing.T, string, error)
+	}{
+		{
+			name:   "success - page of the API origin",
+			origin: server.URL,
+			checkResult: func(t *testing.T, message string, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, `{"id":11}`, message)
+			},
+		},
+		{
+			name:   "success - page of an allowed origin",
+			origin: "https://pickup.example.com",
+			checkResult: func(t *testing.T, message string, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, `{"id":11}`, message)
+			},
+		},
+		{
+			name:   "forbidden - page of another origin",
+			origin: "https://evil.example.com",
+			checkResult: func(t *testing.T, message string, err error) {
+				assert.Error(t, err)
+				assert.Empty(t, message)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.T().Run(tt.name, func(t *testing.T) {
+			// Arrange
+			messages := make(chan dto.OrderStatusMessage, 1)
+			messages <- dto.OrderStatusMessage{ID: 11, Data: []byte(`{"id":11}`)}
+			close(messages)
+			s.mockController.EXPECT().
+				SubscribeStatus(gomock.Any(), gomock.Any(), gomock.Any()).
+				Return((<-chan dto.OrderStatusMessage)(messages), nil)
+			config, err := websocket.NewConfig("ws"+strings.TrimPrefix(server.URL, "http")+"/orders/events/ws", tt.origin)
+			assert.NoError(t, err)
+
+			// Act
+			var message string
+			ws, err := websocket.DialConfig(config)
+			if err == nil {
+				err = websocket.Message.Receive(ws, &message)
+				_ = ws.Close()
+			}
+
+			// Assert
+			tt.checkResult(t, message, err)
+		})
+	}
+}
@@ -34,8 +34,9 @@ func (h *OrderHistoryHandler) Register(router *gin.RouterGroup) {
 // @Tags			orders
 // @Accept			json
 // @Produce		json
+// @Security		BearerAuth
 // @Param			order_id	query		string										false	"Filter by order_id"
-// @Param			status		query		string										false	"Filter by status. Available options: OPEN, CANCELLED, PENDING, RECEIVED, PREPARING, READY, COMPLETED"
+// @Param			status		query		string										false	"Filter by status. Available options: OPEN, CANCELLED, PENDING, RECEIVED, PREPARING, READY, COMPLETED, ABANDONED"
 // @Param			page		query		int											false	"Page number"		default(1)
 // @Param			limit		query		int											false	"Items per page"	default(10)
 // @Success		200			{object}	presenter.OrderHistoryJsonPaginatedResponse	"OK"
@@ -88,6 +89,7 @@ func (h *OrderHistoryHandler) List(c *gin.Context) {
 //	@Tags			orders
 //	@Accept			json
 //	@Produce		json
+//	@Security		BearerAuth
 //	@Param			id	path		int									true	"OrderHistory ID"
 //	@Success		200	{object}	presenter.OrderHistoryJsonResponse	"OK"
 //	@Failure		400	{object}	middleware.ErrorJsonResponse		"Bad Request"
@@ -124,6 +126,7 @@ func (h *OrderHistoryHandler) Get(c *gin.Context) {
 //	@Description	Deletes a order history by ID
 //	@Tags			orders
 //	@Produce		json
+//	@Security		BearerAuth
 //	@Param			id	path		int									true	"OrderHistory ID"
 //	@Success		200	{object}	presenter.OrderHistoryJsonResponse	"OK"
 //	@Failure		400	{object}	middleware.ErrorJsonResponse		"Bad Request"
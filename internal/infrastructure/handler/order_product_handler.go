@@ -26,6 +26,7 @@ func (h *OrderProductHandler) Register(router *gin.RouterGroup) {
 	router.GET("/:order_id/:product_id", h.Get)
 	router.PUT("/:order_id/:product_id", h.Update)
 	router.DELETE("/:order_id/:product_id", h.Delete)
+	router.POST("/:order_id/reprice", h.Reprice)
 }
 
 // List godoc
@@ -35,6 +36,7 @@ func (h *OrderProductHandler) Register(router *gin.RouterGroup) {
 //	@Tags			orders
 //	@Accept			json
 //	@Produce		json
+//	@Security		BearerAuth
 //	@Param			order_id	query		string										false	"Filter by order ID"
 //	@Param			page		query		int											false	"Page number"		default(1)
 //	@Param			limit		query		int											false	"Items per page"	default(10)
@@ -76,6 +78,7 @@ func (h *OrderProductHandler) List(c *gin.Context) {
 //	@Tags			orders
 //	@Accept			json
 //	@Produce		json
+//	@Security		BearerAuth
 //	@Param			order_id	path		int										true	"Order ID"
 //	@Param			product_id	path		int										true	"Product ID"
 //	@Param			order		body		request.CreateOrderProductBodyRequest	true	"OrderProduct data"
@@ -121,6 +124,7 @@ func (h *OrderProductHandler) Create(c *gin.Context) {
 //	@Tags			orders
 //	@Accept			json
 //	@Produce		json
+//	@Security		BearerAuth
 //	@Param			order_id	path		int									true	"Order ID"
 //	@Param			product_id	path		int									true	"Product ID"
 //	@Success		200			{object}	presenter.OrderProductJsonResponse	"OK"
@@ -160,6 +164,7 @@ func (h *OrderProductHandler) Get(c *gin.Context) {
 //	@Tags			orders
 //	@Accept			json
 //	@Produce		json
+//	@Security		BearerAuth
 //	@Param			order_id	path		int										true	"Order ID"
 //	@Param			product_id	path		int										true	"Product ID"
 //	@Param			order		body		request.UpdateOrderProductBodyRequest	true	"OrderProduct data"
@@ -206,6 +211,7 @@ func (h *OrderProductHandler) Update(c *gin.Context) {
 //	@Description	Deletes a order product by Order ID and Product ID
 //	@Tags			orders
 //	@Produce		json
+//	@Security		BearerAuth
 //	@Param			order_id	path		int									true	"Order ID"
 //	@Param			product_id	path		int									true	"Product ID"
 //	@Success		200			{object}	presenter.OrderProductJsonResponse	"OK"
@@ -237,3 +243,41 @@ func (h *OrderProductHandler) Delete(c *gin.Context) {
 
 	c.Data(http.StatusOK, "application/json", output)
 }
+
+// Reprice godoc
+//
+//	@Summary		Reprice order products
+//	@Description	Updates the price, name and category of every product of an OPEN order to the current ones of the product.
+//	@Description	The products keep the price they had when they were added to the order until it is repriced.
+//	@Tags			orders
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			order_id	path		int								true	"Order ID"
+//	@Success		200			{object}	presenter.OrderJsonResponse		"OK"
+//	@Failure		400			{object}	middleware.ErrorJsonResponse	"Bad Request"
+//	@Failure		404			{object}	middleware.ErrorJsonResponse	"Not Found"
+//	@Failure		500			{object}	middleware.ErrorJsonResponse	"Internal Server Error"
+//	@Router			/orders/products/{order_id}/reprice [post]
+func (h *OrderProductHandler) Reprice(c *gin.Context) {
+	var uri request.RepriceOrderProductsUriRequest
+	if err := c.ShouldBindUri(&uri); err != nil {
+		_ = c.Error(domain.NewInvalidInputError(domain.ErrInvalidParam))
+		return
+	}
+
+	input := dto.RepriceOrderProductsInput{
+		OrderID: uri.OrderID,
+	}
+
+	output, err := h.controller.Reprice(
+		c.Request.Context(),
+		presenter.NewOrderJsonPresenter(),
+		input,
+	)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.Data(http.StatusOK, "application/json", output)
+}
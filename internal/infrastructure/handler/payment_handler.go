@@ -14,17 +14,20 @@ import (
 )
 
 type PaymentHandler struct {
-	controller port.PaymentController
+	controller       port.PaymentController
+	signatureService port.WebhookSignatureService
 }
 
-func NewPaymentHandler(controller port.PaymentController) *PaymentHandler {
-	return &PaymentHandler{controller}
+func NewPaymentHandler(controller port.PaymentController, signatureService port.WebhookSignatureService) *PaymentHandler {
+	return &PaymentHandler{controller, signatureService}
 }
 
 func (h *PaymentHandler) Register(router *gin.RouterGroup) {
 	router.POST("/:order_id/checkout", h.Create)
 	router.POST("/callback", h.Update)
 	router.GET("/:order_id", h.Get)
+	router.POST("/:order_id/refund", h.Refund)
+	router.POST("/:order_id/cash/confirm", h.ConfirmCash)
 }
 
 // Create godoc
@@ -32,10 +35,18 @@ func (h *PaymentHandler) Register(router *gin.RouterGroup) {
 //	@Summary		Create a payment (Checkout) (Reference TC-1 2.b.v; TC-2 1.a.i, 1.a.v)
 //	@Description	Creates a new payment (Checkout)
 //	@Description	The status of the payment will be set to PROCESSING
+//	@Description	The QR code expires after PAYMENT_EXPIRATION: the payment is then ABORTED and the order moved back to OPEN.
+//	@Description	While the pending payment is not expired it is returned, otherwise a new QR code is issued.
+//	@Description	The provider defaults to PAYMENT_PROVIDER: `mercadopago`, `fake`, `cash` or `pix-static`.
+//	@Description	Asking for another provider than the one of the pending payment replaces it.
+//	@Description	The `cash` method is paid at the counter: no QR code is issued and an attendant confirms the payment.
 //	@Tags			payments
 //	@Accept			json
 //	@Produce		json
+//	@Security		BearerAuth
 //	@Param			order_id						path		int								true	"Order ID"
+//	@Param			method							query		string							false	"Payment method"	Enums(qr, cash)
+//	@Param			provider						query		string							false	"Payment provider"	Enums(mercadopago, fake, cash, pix-static)
 //	@Success		201								{object}	presenter.PaymentJsonResponse	"Created"
 //	@Failure		400								{object}	middleware.ErrorJsonResponse	"Bad Request"
 //	@Failure		500								{object}	middleware.ErrorJsonResponse	"Internal Server Error"
@@ -47,8 +58,21 @@ func (h *PaymentHandler) Create(c *gin.Context) {
 		return
 	}
 
+	var query request.CreatePaymentQueryRequest
+	if err := c.ShouldBindQuery(&query); err != nil {
+		_ = c.Error(domain.NewInvalidInputError(domain.ErrInvalidQueryParams))
+		return
+	}
+
+	provider, ok := query.PaymentProvider()
+	if !ok {
+		_ = c.Error(domain.NewInvalidInputError(domain.ErrInvalidQueryParams))
+		return
+	}
+
 	input := dto.CreatePaymentInput{
-		OrderID: uri.OrderID,
+		OrderID:  uri.OrderID,
+		Provider: provider,
 	}
 
 	output, err := h.controller.Create(
@@ -68,21 +92,30 @@ func (h *PaymentHandler) Create(c *gin.Context) {
 //
 //	@Summary		Update a payment (Webhook) (Reference TC-2 1.a.iii)
 //	@Description	Update a new payment (Webhook)
-//	@Description	- resource = external payment id, obtained from the checkout response
+//	@Description	- resource = external payment id, obtained from the checkout response, the same as the signed data.id
 //	@Description	- topic = payment
+//	@Description	The outcome of the payment is fetched from the payment provider.
+//	@Description	The event is identified by the signed data.id and x-request-id, a redelivered event is skipped.
 //	@Description
-//	@Description	> The status of the payment will be set to CONFIRMED if the payment was successful
+//	@Description	> A CONFIRMED payment moves the order to RECEIVED. A FAILED or ABORTED payment moves it back to OPEN,
+//	@Description	> or to CANCELLED after PAYMENT_MAX_ATTEMPTS unsuccessful payments.
+//	@Description	> A confirmation wins over a failure notified before it, while a failure notified after a confirmation is ignored.
 //	@Description	## Possible status:
 //	@Description	- `PROCESSING` (default)
 //	@Description	- `CONFIRMED`
 //	@Description	- `FAILED`
 //	@Description	- `ABORTED`
+//	@Description	- `PARTIALLY_REFUNDED` and `REFUNDED` are set by refunds, the notification is ignored
 //	@Tags			payments
 //	@Accept			json
 //	@Produce		json
+//	@Param			x-signature			header		string							true	"Signature of the notification: ts=<unix time>,v1=<HMAC-SHA256>"
+//	@Param			x-request-id		header		string							true	"Request ID of the notification"
+//	@Param			data.id				query		string							true	"Notified resource, the same as the body resource"
 //	@Param			payment				body		request.UpdatePaymentRequest	true	"Payment data"
 //	@Success		201					{object}	presenter.PaymentJsonResponse	"Created"
 //	@Failure		400					{object}	middleware.ErrorJsonResponse	"Bad Request"
+//	@Failure		401					{object}	middleware.ErrorJsonResponse	"Unauthorized"
 //	@Failure		500					{object}	middleware.ErrorJsonResponse	"Internal Server Error"
 //	@Router			/payments/callback	[post]
 func (h *PaymentHandler) Update(c *gin.Context) {
@@ -94,8 +127,23 @@ func (h *PaymentHandler) Update(c *gin.Context) {
 		return
 	}
 
+	// Only the data ID and the request ID are signed, the body must notify the same resource
+	dataID := c.Query("data.id")
+	requestID := c.GetHeader("x-request-id")
+	if dataID == "" || dataID != body.Resource {
+		_ = c.Error(domain.NewUnauthorizedError(domain.ErrInvalidWebhookSignature))
+		return
+	}
+
+	if err := h.signatureService.Verify(c.GetHeader("x-signature"), dataID, requestID); err != nil {
+		_ = c.Error(domain.NewUnauthorizedError(domain.ErrInvalidWebhookSignature))
+		return
+	}
+
+	// The status is not signed either, it is fetched from the provider
 	input := dto.UpdatePaymentInput{
-		Resource: body.Resource,
+		EventID:  dataID + ":" + requestID,
+		Resource: dataID,
 		Topic:    body.Topic,
 	}
 
@@ -119,6 +167,7 @@ func (h *PaymentHandler) Update(c *gin.Context) {
 //	@Tags			payments
 //	@Accept			json
 //	@Produce		json
+//	@Security		BearerAuth
 //	@Param			order_id				path		int								true	"Order ID"
 //	@Success		201						{object}	presenter.PaymentJsonResponse	"Created"
 //	@Failure		400						{object}	middleware.ErrorJsonResponse	"Bad Request"
@@ -149,3 +198,110 @@ func (h *PaymentHandler) Get(c *gin.Context) {
 
 	c.Data(http.StatusOK, "application/json", output)
 }
+
+// Refund godoc
+//
+//	@Summary		Refund the payment of an order
+//	@Description	Refunds the paid payment of an order, only managers may authorize refunds
+//	@Description	- amount = amount to refund, the whole refundable amount when not sent
+//	@Description	- reason = why the money is given back
+//	@Description
+//	@Description	> The payment becomes PARTIALLY_REFUNDED while part of the amount is left, or REFUNDED otherwise.
+//	@Description	> The refund is recorded with the staff of the access token. Cancelling a paid order refunds it as well.
+//	@Tags			payments
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			order_id					path		int								true	"Order ID"
+//	@Param			refund						body		request.RefundPaymentBodyRequest	true	"Refund data"
+//	@Success		200							{object}	presenter.PaymentJsonResponse	"OK"
+//	@Failure		400							{object}	middleware.ErrorJsonResponse	"Bad Request"
+//	@Failure		403							{object}	middleware.ErrorJsonResponse	"Forbidden"
+//	@Failure		500							{object}	middleware.ErrorJsonResponse	"Internal Server Error"
+//	@Router			/payments/{order_id}/refund	[post]
+func (h *PaymentHandler) Refund(c *gin.Context) {
+	var uri request.RefundPaymentUriRequest
+	if err := c.ShouldBindUri(&uri); err != nil {
+		_ = c.Error(domain.NewInvalidInputError(domain.ErrInvalidParam))
+		return
+	}
+
+	var body request.RefundPaymentBodyRequest
+	if err := c.ShouldBindJSON(&body); err != nil {
+		_ = c.Error(domain.NewInvalidInputError(domain.ErrInvalidBody))
+		return
+	}
+
+	input := dto.CreateRefundInput{
+		OrderID: uri.OrderID,
+		Amount:  body.Amount,
+		Reason:  body.Reason,
+		StaffID: staffIDFromContext(c),
+	}
+
+	output, err := h.controller.Refund(
+		c.Request.Context(),
+		presenter.NewPaymentJsonPresenter(),
+		input,
+	)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.Data(http.StatusOK, "application/json", output)
+}
+
+// ConfirmCash godoc
+//
+//	@Summary		Confirm the cash payment of an order
+//	@Description	Confirms the cash payment of an order once the money was taken at the counter, or its static PIX payment
+//	@Description	once the transfer was received (then amount_received is the payment amount and change_given is 0)
+//	@Description	- amount_received = money given by the customer, at least the payment amount
+//	@Description	- change_given = money given back to the customer, amount_received minus the payment amount
+//	@Description
+//	@Description	> The payment becomes CONFIRMED and the order moves to RECEIVED, as when a provider notifies the payment.
+//	@Description	> The receipt is recorded with the staff of the access token.
+//	@Tags			payments
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			order_id							path		int										true	"Order ID"
+//	@Param			receipt								body		request.ConfirmCashPaymentBodyRequest	true	"Cash receipt"
+//	@Success		200									{object}	presenter.PaymentJsonResponse			"OK"
+//	@Failure		400									{object}	middleware.ErrorJsonResponse			"Bad Request"
+//	@Failure		403									{object}	middleware.ErrorJsonResponse			"Forbidden"
+//	@Failure		500									{object}	middleware.ErrorJsonResponse			"Internal Server Error"
+//	@Router			/payments/{order_id}/cash/confirm	[post]
+func (h *PaymentHandler) ConfirmCash(c *gin.Context) {
+	var uri request.ConfirmCashPaymentUriRequest
+	if err := c.ShouldBindUri(&uri); err != nil {
+		_ = c.Error(domain.NewInvalidInputError(domain.ErrInvalidParam))
+		return
+	}
+
+	var body request.ConfirmCashPaymentBodyRequest
+	if err := c.ShouldBindJSON(&body); err != nil {
+		_ = c.Error(domain.NewInvalidInputError(domain.ErrInvalidBody))
+		return
+	}
+
+	input := dto.ConfirmCashPaymentInput{
+		OrderID:        uri.OrderID,
+		AmountReceived: body.AmountReceived,
+		ChangeGiven:    body.ChangeGiven,
+		StaffID:        staffIDFromContext(c),
+	}
+
+	output, err := h.controller.ConfirmCash(
+		c.Request.Context(),
+		presenter.NewPaymentJsonPresenter(),
+		input,
+	)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.Data(http.StatusOK, "application/json", output)
+}
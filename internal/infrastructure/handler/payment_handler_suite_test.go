@@ -0,0 +1,68 @@
+package handler_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/port"
+	mockport "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/port/mocks"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/infrastructure/config"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/infrastructure/handler"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/infrastructure/service"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/util"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type PaymentHandlerSuiteTest struct {
+	suite.Suite
+	handler          *handler.PaymentHandler
+	router           *gin.Engine
+	mockController   *mockport.MockPaymentController
+	signatureService port.WebhookSignatureService
+	ctx              context.Context
+	requests         map[string]string // Fixture files
+	responses        map[string]string // Golden files
+}
+
+func (s *PaymentHandlerSuiteTest) SetupTest() {
+	// Create a new router
+	s.router = newRouter()
+
+	// Create a new handler, signing the notifications as the provider does
+	ctrl := gomock.NewController(s.T())
+	defer ctrl.Finish()
+	s.mockController = mockport.NewMockPaymentController(ctrl)
+	s.signatureService = service.NewWebhookSignatureService(&config.Config{
+		MercadoPagoWebhookSecret:    "secret",
+		MercadoPagoWebhookTolerance: 5 * time.Minute,
+	})
+	s.handler = handler.NewPaymentHandler(s.mockController, s.signatureService)
+	s.ctx = context.Background()
+
+	// Register routes
+	s.router.POST("/payments/callback", s.handler.Update)
+	s.router.POST("/payments/:order_id/cash/confirm", s.handler.ConfirmCash)
+
+	// Mock requests
+	var err error
+	s.requests, err = util.ReadFixtureFiles("payment",
+		"update_success", "update_invalid_body",
+	)
+	assert.NoError(s.T(), err)
+
+	// Mock responses
+	s.responses, err = util.ReadGoldenFiles("payment",
+		"update_success",
+	)
+	assert.NoError(s.T(), err)
+
+	addCommonResponses(&s.responses)
+}
+
+func TestPaymentHandlerSuiteTest(t *testing.T) {
+	suite.Run(t, new(PaymentHandlerSuiteTest))
+}
@@ -0,0 +1,197 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain"
+	valueobject "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/value_object"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/dto"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/util"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func (s *PaymentHandlerSuiteTest) TestPaymentHandler_Update() {
+	resource := "a0aa0f26-6e0a-4b90-8c49-9f1a9c03ebcc"
+	requestID := "6c4a6c2e-6f4f-4d4a-9c68-0b1a5e1f7d10"
+
+	tests := []struct {
+		name        string
+		url         string
+		body        *strings.Reader
+		signature   string
+		setupMocks  func()
+		checkResult func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name:      "success - status is fetched from the provider",
+			url:       "/payments/callback?data.id=" + resource,
+			body:      strings.NewReader(s.requests["update_success"]),
+			signature: s.signatureService.Sign(resource, requestID, time.Now()),
+			setupMocks: func() {
+				s.mockController.EXPECT().
+					Update(gomock.Any(), gomock.Any(), dto.UpdatePaymentInput{
+						EventID:  resource + ":" + requestID,
+						Resource: resource,
+						Topic:    "payment",
+					}).
+					Return([]byte(s.responses["update_success"]), nil)
+			},
+			checkResult: func(t *testing.T, res *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusOK, res.Code)
+				assert.Contains(t, res.Body.String(), s.responses["update_success"])
+			},
+		},
+		{
+			name:       "unauthorized - data.id is missing",
+			url:        "/payments/callback",
+			body:       strings.NewReader(s.requests["update_success"]),
+			signature:  s.signatureService.Sign(resource, requestID, time.Now()),
+			setupMocks: func() {},
+			checkResult: func(t *testing.T, res *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusUnauthorized, res.Code)
+			},
+		},
+		{
+			name:       "unauthorized - body notifies another resource than data.id",
+			url:        "/payments/callback?data.id=another-resource",
+			body:       strings.NewReader(s.requests["update_success"]),
+			signature:  s.signatureService.Sign("another-resource", requestID, time.Now()),
+			setupMocks: func() {},
+			checkResult: func(t *testing.T, res *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusUnauthorized, res.Code)
+			},
+		},
+		{
+			name:       "unauthorized - signature of another request",
+			url:        "/payments/callback?data.id=" + resource,
+			body:       strings.NewReader(s.requests["update_success"]),
+			signature:  s.signatureService.Sign(resource, "another-request", time.Now()),
+			setupMocks: func() {},
+			checkResult: func(t *testing.T, res *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusUnauthorized, res.Code)
+			},
+		},
+		{
+			name:       "unauthorized - signature out of the tolerance window",
+			url:        "/payments/callback?data.id=" + resource,
+			body:       strings.NewReader(s.requests["update_success"]),
+			signature:  s.signatureService.Sign(resource, requestID, time.Now().Add(-time.Hour)),
+			setupMocks: func() {},
+			checkResult: func(t *testing.T, res *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusUnauthorized, res.Code)
+			},
+		},
+		{
+			name:       "invalid request - topic is missing",
+			url:        "/payments/callback?data.id=" + resource,
+			body:       strings.NewReader(s.requests["update_invalid_body"]),
+			signature:  s.signatureService.Sign(resource, requestID, time.Now()),
+			setupMocks: func() {},
+			checkResult: func(t *testing.T, res *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusBadRequest, res.Code)
+			},
+		},
+		{
+			name:      "controller error",
+			url:       "/payments/callback?data.id=" + resource,
+			body:      strings.NewReader(s.requests["update_success"]),
+			signature: s.signatureService.Sign(resource, requestID, time.Now()),
+			setupMocks: func() {
+				s.mockController.EXPECT().
+					Update(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, domain.NewInternalError(nil))
+			},
+			checkResult: func(t *testing.T, res *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusInternalServerError, res.Code)
+				assert.Contains(t, util.RemoveAllSpaces(res.Body.String()), s.responses["error_internal_error"])
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.T().Run(tt.name, func(t *testing.T) {
+			// Arrange
+			tt.setupMocks()
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPost, tt.url, tt.body)
+			req.Header.Set("x-signature", tt.signature)
+			req.Header.Set("x-request-id", requestID)
+
+			// Act
+			s.router.ServeHTTP(w, req)
+
+			// Assert
+			tt.checkResult(t, w)
+		})
+	}
+}
+
+func (s *PaymentHandlerSuiteTest) TestPaymentHandler_ConfirmCash() {
+	tests := []struct {
+		name        string
+		body        string
+		setupMocks  func()
+		checkResult func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name: "success - amounts are read without a float",
+			body: `{"amount_received": 50.10, "change_given": "9.10"}`,
+			setupMocks: func() {
+				s.mockController.EXPECT().
+					ConfirmCash(gomock.Any(), gomock.Any(), dto.ConfirmCashPaymentInput{
+						OrderID:        1,
+						AmountReceived: valueobject.NewMoney(5010),
+						ChangeGiven:    valueobject.NewMoney(910),
+					}).
+					Return([]byte(`{}`), nil)
+			},
+			checkResult: func(t *testing.T, res *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusOK, res.Code)
+			},
+		},
+		{
+			name:       "bad request - fractions of a cent",
+			body:       `{"amount_received": 50.105, "change_given": 0}`,
+			setupMocks: func() {},
+			checkResult: func(t *testing.T, res *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusBadRequest, res.Code)
+			},
+		},
+		{
+			name:       "bad request - amount received is zero",
+			body:       `{"amount_received": 0, "change_given": 0}`,
+			setupMocks: func() {},
+			checkResult: func(t *testing.T, res *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusBadRequest, res.Code)
+			},
+		},
+		{
+			name:       "bad request - negative change given",
+			body:       `{"amount_received": 50, "change_given": -1}`,
+			setupMocks: func() {},
+			checkResult: func(t *testing.T, res *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusBadRequest, res.Code)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.T().Run(tt.name, func(t *testing.T) {
+			// Arrange
+			tt.setupMocks()
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPost, "/payments/1/cash/confirm", strings.NewReader(tt.body))
+
+			// Act
+			s.router.ServeHTTP(w, req)
+
+			// Assert
+			tt.checkResult(t, w)
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/adapter/presenter"
 	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain"
+	valueobject "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/value_object"
 	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/dto"
 	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/port"
 	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/infrastructure/handler/request"
@@ -77,6 +78,7 @@ func (h *ProductHandler) List(c *gin.Context) {
 //	@Tags			products
 //	@Accept			json
 //	@Produce		json,xml
+//	@Security		BearerAuth
 //	@Param			product	body		request.CreateProductBodyRequest	true	"Product data"
 //	@Success		201		{object}	presenter.ProductJsonResponse		"Created"
 //	@Failure		400		{object}	middleware.ErrorJsonResponse		"Bad Request"
@@ -92,11 +94,13 @@ func (h *ProductHandler) Create(c *gin.Context) {
 	input := dto.CreateProductInput{
 		Name:        body.Name,
 		Description: body.Description,
-		Price:       body.Price,
+		Price:       valueobject.MoneyFromFloat(body.Price),
 		CategoryID:  body.CategoryID,
+		PrepMinutes: body.PrepMinutes,
 	}
 
-	p, contentType := selectOutputConfigs(c.GetHeader("Accept"))
+	selected, contentType := selectOutputConfigs(c.GetHeader("Accept"))
+	p := withVersion(selected)
 
 	output, err := h.controller.Create(c.Request.Context(), p, input)
 	if err != nil {
@@ -104,6 +108,7 @@ func (h *ProductHandler) Create(c *gin.Context) {
 		return
 	}
 
+	p.setETag(c)
 	c.Data(http.StatusCreated, contentType, output)
 }
 
@@ -132,7 +137,8 @@ func (h *ProductHandler) Get(c *gin.Context) {
 		ID: uri.ID,
 	}
 
-	p, contentType := selectOutputConfigs(c.GetHeader("Accept"))
+	selected, contentType := selectOutputConfigs(c.GetHeader("Accept"))
+	p := withVersion(selected)
 
 	output, err := h.controller.Get(c.Request.Context(), p, input)
 	if err != nil {
@@ -140,6 +146,7 @@ func (h *ProductHandler) Get(c *gin.Context) {
 		return
 	}
 
+	p.setETag(c)
 	c.Data(http.StatusOK, contentType, output)
 }
 
@@ -151,11 +158,15 @@ func (h *ProductHandler) Get(c *gin.Context) {
 //	@Tags			products
 //	@Accept			json
 //	@Produce		json,xml
+//	@Security		BearerAuth
 //	@Param			id		path		int									true	"Product ID"
 //	@Param			product	body		request.UpdateProductBodyRequest	true	"Product data"
+//	@Param			If-Match	header		string								false	"ETag of the product the change is based on"
 //	@Success		200		{object}	presenter.ProductJsonResponse		"OK"
 //	@Failure		400		{object}	middleware.ErrorJsonResponse		"Bad Request"
 //	@Failure		404		{object}	middleware.ErrorJsonResponse		"Not Found"
+//	@Failure		409		{object}	middleware.ErrorJsonResponse		"Conflict"
+//	@Failure		412		{object}	middleware.ErrorJsonResponse		"Precondition Failed"
 //	@Failure		500		{object}	middleware.ErrorJsonResponse		"Internal Server Error"
 //	@Router			/products/{id} [put]
 func (h *ProductHandler) Update(c *gin.Context) {
@@ -171,15 +182,24 @@ func (h *ProductHandler) Update(c *gin.Context) {
 		return
 	}
 
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
 	input := dto.UpdateProductInput{
 		ID:          uri.ID,
 		Name:        body.Name,
 		Description: body.Description,
-		Price:       body.Price,
+		Price:       valueobject.MoneyFromFloat(body.Price),
 		CategoryID:  body.CategoryID,
+		PrepMinutes: body.PrepMinutes,
+		Version:     version,
 	}
 
-	p, contentType := selectOutputConfigs(c.GetHeader("Accept"))
+	selected, contentType := selectOutputConfigs(c.GetHeader("Accept"))
+	p := withVersion(selected)
 
 	output, err := h.controller.Update(c.Request.Context(), p, input)
 	if err != nil {
@@ -187,6 +207,7 @@ func (h *ProductHandler) Update(c *gin.Context) {
 		return
 	}
 
+	p.setETag(c)
 	c.Data(http.StatusOK, contentType, output)
 }
 
@@ -198,6 +219,7 @@ func (h *ProductHandler) Update(c *gin.Context) {
 //	@Tags			products
 //	@Accept			json
 //	@Produce		json,xml
+//	@Security		BearerAuth
 //	@Param			id	path		int								true	"Product ID"
 //	@Success		200	{object}	presenter.ProductJsonResponse	"OK"
 //	@Failure		400	{object}	middleware.ErrorJsonResponse	"Bad Request"
@@ -1,6 +1,7 @@
 package handler_test
 
 import (
+	valueobject "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/value_object"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -107,7 +108,7 @@ func (s *ProductHandlerSuiteTest) TestProductHandler_Create() {
 					Create(gomock.Any(), gomock.Any(), dto.CreateProductInput{
 						Name:        "Product X",
 						Description: "Product X description",
-						Price:       13,
+						Price:       valueobject.NewMoney(1300),
 						CategoryID:  1,
 					}).
 					Return([]byte(s.responses["create_success"]), nil)
@@ -144,7 +145,7 @@ func (s *ProductHandlerSuiteTest) TestProductHandler_Create() {
 					Create(gomock.Any(), gomock.Any(), dto.CreateProductInput{
 						Name:        "Product X",
 						Description: "Product X description",
-						Price:       13,
+						Price:       valueobject.NewMoney(1300),
 						CategoryID:  1,
 					}).
 					Return(nil, domain.NewInternalError(nil))
@@ -250,7 +251,7 @@ func (s *ProductHandlerSuiteTest) TestProductHandler_Update() {
 						ID:          5,
 						Name:        "Product X UPDATED",
 						Description: "Product X description UPDATED",
-						Price:       12.11,
+						Price:       valueobject.NewMoney(1211),
 						CategoryID:  1,
 					}).
 					Return([]byte(s.responses["update_success"]), nil)
@@ -300,7 +301,7 @@ func (s *ProductHandlerSuiteTest) TestProductHandler_Update() {
 						ID:          5,
 						Name:        "Product X UPDATED",
 						Description: "Product X description UPDATED",
-						Price:       12.11,
+						Price:       valueobject.NewMoney(1211),
 						CategoryID:  1,
 					}).
 					Return(nil, domain.NewInternalError(nil))
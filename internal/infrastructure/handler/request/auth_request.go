@@ -4,3 +4,19 @@ package request
 type AuthenticateBodyRequest struct {
 	CPF string `json:"cpf" binding:"required" example:"000.000.000-00"`
 }
+
+// AuthenticateStaffBodyRequest representa o corpo da requisição de autenticação de funcionários
+type AuthenticateStaffBodyRequest struct {
+	StaffID  uint64 `json:"staff_id" binding:"required" example:"1"`
+	Password string `json:"password" binding:"required" example:"123456"`
+}
+
+// RefreshTokenBodyRequest representa o corpo da requisição de renovação do token
+type RefreshTokenBodyRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required" example:"6Jk0m1Xc..."`
+}
+
+// LogoutBodyRequest representa o corpo da requisição de logout
+type LogoutBodyRequest struct {
+	RefreshToken string `json:"refresh_token" example:"6Jk0m1Xc..."`
+}
@@ -8,6 +8,10 @@ type ListCategoriesQueryRequest struct {
 
 type CreateCategoryBodyRequest struct {
 	Name string `json:"name" binding:"required,min=3,max=100" example:"Foods"`
+	// Kitchen station that prepares the products of the category (GRILL, DRINKS or DESSERTS), GRILL by default
+	Station string `json:"station" binding:"omitempty,kitchen_station_exists" example:"GRILL"`
+	// Minutes the kitchen has to get the orders with products of the category READY, 0 uses ORDER_PREP_TARGET
+	PrepTargetMinutes uint32 `json:"prep_target_minutes" binding:"omitempty,max=1440" example:"15"`
 }
 
 type GetCategoryUriRequest struct {
@@ -20,6 +24,10 @@ type UpdateCategoryUriRequest struct {
 
 type UpdateCategoryBodyRequest struct {
 	Name string `json:"name" binding:"omitempty,required" example:"Beverages"`
+	// Unchanged when empty
+	Station string `json:"station" binding:"omitempty,kitchen_station_exists" example:"DRINKS"`
+	// Unchanged when absent, 0 uses ORDER_PREP_TARGET
+	PrepTargetMinutes *uint32 `json:"prep_target_minutes" binding:"omitempty,max=1440" example:"5"`
 }
 
 type DeleteCategoryUriRequest struct {
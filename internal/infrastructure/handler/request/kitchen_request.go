@@ -0,0 +1,11 @@
+package request
+
+type ListKitchenTicketsQueryRequest struct {
+	// Lists the tickets of every station when empty
+	Station string `form:"station" binding:"omitempty,kitchen_station_exists" example:"GRILL"`
+}
+
+type BumpKitchenTicketUriRequest struct {
+	OrderID uint64 `uri:"order_id" binding:"required"`
+	Station string `uri:"station" binding:"required,kitchen_station_exists" example:"GRILL"`
+}
@@ -4,7 +4,7 @@ import valueobject "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/c
 
 type ListOrderHistoriesQueryRequest struct {
 	OrderID uint64                  `form:"order_id,default=0" example:"1"`
-	Status  valueobject.OrderStatus `form:"status" binding:"omitempty" example:"OPEN, CANCELLED, PENDING, RECEIVED, PREPARING, READY, COMPLETED"`
+	Status  valueobject.OrderStatus `form:"status" binding:"omitempty" example:"OPEN, CANCELLED, PENDING, RECEIVED, PREPARING, READY, COMPLETED, ABANDONED"`
 	Page    int                     `form:"page,default=1" example:"1"`
 	Limit   int                     `form:"limit,default=10" example:"10"`
 }
@@ -34,3 +34,7 @@ type DeleteOrderProductUriRequest struct {
 	OrderID   uint64 `uri:"order_id" binding:"required"`
 	ProductID uint64 `uri:"product_id" binding:"required"`
 }
+
+type RepriceOrderProductsUriRequest struct {
+	OrderID uint64 `uri:"order_id" binding:"required"`
+}
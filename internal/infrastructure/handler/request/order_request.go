@@ -13,7 +13,8 @@ type ListOrdersQueryRequest struct {
 }
 
 type CreateOrderBodyRequest struct {
-	CustomerID uint64 `json:"customer_id" binding:"required" example:"1"`
+	// Optional, an order without customer is placed as a guest
+	CustomerID uint64 `json:"customer_id" example:"1"`
 }
 
 type GetOrderUriRequest struct {
@@ -25,25 +26,35 @@ type UpdateOrderUriRequest struct {
 }
 
 type UpdateOrderBodyRequest struct {
-	// StaffID is only required when status is PREPARING, READY or COMPLETED
-	StaffID    uint64                  `json:"staff_id" example:"1"`
 	CustomerID uint64                  `json:"customer_id" binding:"required" example:"1"`
 	Status     valueobject.OrderStatus `json:"status" binding:"required,order_status_exists" example:"PENDING"`
 }
 
 type UpdateOrderPartilRequest struct {
-	// StaffID is only required when status is PREPARING, READY or COMPLETED
-	StaffID uint64                  `json:"staff_id" example:"1"`
-	Status  valueobject.OrderStatus `json:"status" example:"PENDING"`
+	Status valueobject.OrderStatus `json:"status" example:"PENDING"`
 }
 
 type UpdateOrderPartilBodyRequest struct {
+	CustomerID uint64                  `json:"customer_id" example:"1"`
+	Status     valueobject.OrderStatus `json:"status" binding:"omitempty,order_status_exists" example:"PENDING"`
+}
+
+type AttachOrderCustomerUriRequest struct {
+	ID uint64 `uri:"id" binding:"required"`
+}
+
+type AttachOrderCustomerBodyRequest struct {
+	// Optional for customers, who can only identify themselves
 	CustomerID uint64 `json:"customer_id" example:"1"`
-	// StaffID is only required when status is PREPARING, READY or COMPLETED
-	StaffID uint64                  `json:"staff_id" example:"1"`
-	Status  valueobject.OrderStatus `json:"status" binding:"omitempty,order_status_exists" example:"PENDING"`
 }
 
 type DeleteOrderUriRequest struct {
 	ID uint64 `uri:"id" binding:"required"`
 }
+
+type StreamOrderStatusQueryRequest struct {
+	Status     string `form:"status" binding:"omitempty" example:"RECEIVED,PREPARING,READY"`
+	CustomerID uint64 `form:"customer_id" example:"1"`
+	// ID of the last event received, the Last-Event-ID header takes precedence
+	AfterID uint64 `form:"after_id" example:"1"`
+}
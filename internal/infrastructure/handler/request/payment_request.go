@@ -1,28 +1,64 @@
 package request
 
-import "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
+import (
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
+	valueobject "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/value_object"
+	datasource_request "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/infrastructure/datasource/request"
+)
 
 type CreatePaymentUriRequest struct {
 	OrderID uint64 `uri:"order_id" binding:"required"`
 }
 
+// Payment methods of the checkout: paid by QR code through a provider, or in cash at the counter
+const (
+	PaymentMethodQR   = "qr"
+	PaymentMethodCash = "cash"
+)
+
+type CreatePaymentQueryRequest struct {
+	Method   string `form:"method" binding:"omitempty,oneof=qr cash" example:"qr"`
+	Provider string `form:"provider" binding:"omitempty,payment_provider_exists" example:"mercadopago"`
+}
+
+// PaymentProvider returns the provider asked by the query, the cash method is handled by the cash provider.
+// It returns false when the method and the provider do not match.
+func (r CreatePaymentQueryRequest) PaymentProvider() (valueobject.PaymentProvider, bool) {
+	provider := valueobject.ToPaymentProvider(r.Provider)
+
+	switch r.Method {
+	case PaymentMethodCash:
+		if provider != valueobject.UNDEFINED_PROVIDER && provider != valueobject.CASH_PROVIDER {
+			return valueobject.UNDEFINED_PROVIDER, false
+		}
+		return valueobject.CASH_PROVIDER, true
+	case PaymentMethodQR:
+		if provider == valueobject.CASH_PROVIDER {
+			return valueobject.UNDEFINED_PROVIDER, false
+		}
+	}
+
+	return provider, true
+}
+
 type CreatePaymentRequest struct {
-	ExternalReference string         `json:"external_reference"`
-	TotalAmount       float32        `json:"total_amount"`
-	Items             []ItemsRequest `json:"items"`
-	Title             string         `json:"title"`
-	Description       string         `json:"description"`
-	NotificationURL   string         `json:"notification_url"`
+	ExternalReference string            `json:"external_reference"`
+	TotalAmount       valueobject.Money `json:"total_amount"`
+	Items             []ItemsRequest    `json:"items"`
+	Title             string            `json:"title"`
+	Description       string            `json:"description"`
+	NotificationURL   string            `json:"notification_url"`
+	ExpirationDate    string            `json:"expiration_date,omitempty"`
 }
 
 type ItemsRequest struct {
-	Category    string  `json:"category"`
-	Title       string  `json:"title"`
-	Description string  `json:"description"`
-	UnitPrice   float32 `json:"unit_price"`
-	Quantity    uint64  `json:"quantity"`
-	UnitMeasure string  `json:"unit_measure"`
-	TotalAmount float32 `json:"total_amount"`
+	Category    string            `json:"category"`
+	Title       string            `json:"title"`
+	Description string            `json:"description"`
+	UnitPrice   valueobject.Money `json:"unit_price"`
+	Quantity    uint64            `json:"quantity"`
+	UnitMeasure string            `json:"unit_measure"`
+	TotalAmount valueobject.Money `json:"total_amount"`
 }
 
 func NewPaymentRequest(payment *entity.CreatePaymentExternalInput) *CreatePaymentRequest {
@@ -43,7 +79,7 @@ func NewPaymentRequest(payment *entity.CreatePaymentExternalInput) *CreatePaymen
 		})
 	}
 
-	return &CreatePaymentRequest{
+	paymentRequest := &CreatePaymentRequest{
 		ExternalReference: payment.ExternalReference,
 		TotalAmount:       payment.TotalAmount,
 		Items:             items,
@@ -51,8 +87,15 @@ func NewPaymentRequest(payment *entity.CreatePaymentExternalInput) *CreatePaymen
 		Description:       payment.Description,
 		NotificationURL:   payment.NotificationUrl,
 	}
+
+	if payment.ExpirationDate != nil {
+		paymentRequest.ExpirationDate = payment.ExpirationDate.Format(datasource_request.MercadoPagoDateLayout)
+	}
+
+	return paymentRequest
 }
 
+// UpdatePaymentRequest is the payment notification, its status is fetched from the provider
 type UpdatePaymentRequest struct {
 	Resource string `json:"resource" binding:"required"`
 	Topic    string `json:"topic" binding:"required"`
@@ -61,3 +104,21 @@ type UpdatePaymentRequest struct {
 type GetPaymentRequest struct {
 	OrderID uint64 `uri:"order_id" binding:"required"`
 }
+
+type RefundPaymentUriRequest struct {
+	OrderID uint64 `uri:"order_id" binding:"required"`
+}
+
+type RefundPaymentBodyRequest struct {
+	Amount valueobject.Money `json:"amount" binding:"omitempty,gt=0" example:"10.90" swaggertype:"number"`
+	Reason string            `json:"reason" binding:"required,max=255" example:"Customer complaint"`
+}
+
+type ConfirmCashPaymentUriRequest struct {
+	OrderID uint64 `uri:"order_id" binding:"required"`
+}
+
+type ConfirmCashPaymentBodyRequest struct {
+	AmountReceived valueobject.Money `json:"amount_received" binding:"required,gt=0" example:"50.00" swaggertype:"number"`
+	ChangeGiven    valueobject.Money `json:"change_given" binding:"gte=0" example:"9.10" swaggertype:"number"`
+}
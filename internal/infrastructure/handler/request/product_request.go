@@ -12,6 +12,8 @@ type CreateProductBodyRequest struct {
 	Description string  `json:"description" binding:"max=500" example:"Product A description"`
 	Price       float64 `json:"price" binding:"required,gt=0" example:"99.99"`
 	CategoryID  uint64  `json:"category_id" binding:"required,gt=0" example:"1"`
+	// Estimated minutes to prepare the product, used for the estimated ready time of the orders
+	PrepMinutes uint32 `json:"prep_minutes" binding:"omitempty,max=240" example:"8"`
 }
 
 // func (p *CreateProductRequest) Validate() error {
@@ -31,6 +33,8 @@ type UpdateProductBodyRequest struct {
 	Description string  `json:"description" binding:"max=500" example:"Product A description"`
 	Price       float64 `json:"price" binding:"required,gt=0" example:"99.99"`
 	CategoryID  uint64  `json:"category_id" binding:"required,gt=0" example:"1"`
+	// Estimated minutes to prepare the product, used for the estimated ready time of the orders
+	PrepMinutes uint32 `json:"prep_minutes" binding:"omitempty,max=240" example:"8"`
 }
 
 type DeleteProductUriRequest struct {
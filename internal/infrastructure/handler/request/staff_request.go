@@ -12,6 +12,8 @@ type ListStaffsQueryRequest struct {
 type CreateStaffBodyRequest struct {
 	Name string                `json:"name" binding:"required,min=3,max=100" example:"John Doe"`
 	Role valueobject.StaffRole `json:"role" binding:"required,staff_role_exists,max=500" example:"COOK"`
+	// Password (or PIN) used to sign in, staffs without password can not sign in
+	Password string `json:"password" binding:"omitempty,min=4,max=72" example:"123456"`
 }
 
 type GetStaffUriRequest struct {
@@ -25,6 +27,8 @@ type UpdateStaffUriRequest struct {
 type UpdateStaffBodyRequest struct {
 	Name string                `json:"name" binding:"required,min=3,max=100" example:"John Doe"`
 	Role valueobject.StaffRole `json:"role" binding:"required,staff_role_exists,max=500" example:"COOK"`
+	// Password (or PIN) used to sign in, it is kept unchanged when empty
+	Password string `json:"password" binding:"omitempty,min=4,max=72" example:"123456"`
 }
 
 type DeleteStaffUriRequest struct {
@@ -0,0 +1,58 @@
+package response
+
+import (
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"encoding/base64"
+	"math/big"
+
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
+)
+
+// JWKSResponse is a JSON Web Key Set (RFC 7517) with the public keys access tokens are verified with
+type JWKSResponse struct {
+	Keys []JWKResponse `json:"keys"`
+}
+
+type JWKResponse struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	N         string `json:"n,omitempty"`   // RSA modulus
+	E         string `json:"e,omitempty"`   // RSA exponent
+	Curve     string `json:"crv,omitempty"` // EC curve
+	X         string `json:"x,omitempty"`   // EC coordinates
+	Y         string `json:"y,omitempty"`
+}
+
+func NewJWKSResponse(keys []*entity.SigningKey) JWKSResponse {
+	response := JWKSResponse{Keys: make([]JWKResponse, 0, len(keys))}
+
+	for _, key := range keys {
+		jwk := JWKResponse{KeyID: key.ID, Use: "sig", Algorithm: key.Algorithm}
+
+		switch publicKey := key.PublicKey().(type) {
+		case *rsa.PublicKey:
+			jwk.KeyType = "RSA"
+			jwk.N = encodeJWKValue(publicKey.N.Bytes())
+			jwk.E = encodeJWKValue(big.NewInt(int64(publicKey.E)).Bytes())
+		case *ecdsa.PublicKey:
+			size := (publicKey.Curve.Params().BitSize + 7) / 8
+			jwk.KeyType = "EC"
+			jwk.Curve = publicKey.Curve.Params().Name
+			jwk.X = encodeJWKValue(publicKey.X.FillBytes(make([]byte, size)))
+			jwk.Y = encodeJWKValue(publicKey.Y.FillBytes(make([]byte, size)))
+		default:
+			continue
+		}
+
+		response.Keys = append(response.Keys, jwk)
+	}
+
+	return response
+}
+
+func encodeJWKValue(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
@@ -35,6 +35,7 @@ func (h *StaffHandler) Register(router *gin.RouterGroup) {
 //	@Tags			staffs
 //	@Accept			json
 //	@Produce		json
+//	@Security		BearerAuth
 //	@Param			name	query		string									false	"Filter by name"
 //	@Param			role	query		string									false	"Filter by role. Available options: COOK, ATTENDANT, MANAGER"
 //	@Param			page	query		int										false	"Page number"		default(1)
@@ -77,6 +78,7 @@ func (h *StaffHandler) List(c *gin.Context) {
 //	@Tags			staffs
 //	@Accept			json
 //	@Produce		json
+//	@Security		BearerAuth
 //	@Param			staff	body		request.CreateStaffBodyRequest	true	"Staff data"
 //	@Success		201		{object}	presenter.StaffJsonResponse		"Created"
 //	@Failure		400		{object}	middleware.ErrorJsonResponse	"Bad Request"
@@ -90,8 +92,9 @@ func (h *StaffHandler) Create(c *gin.Context) {
 	}
 
 	input := dto.CreateStaffInput{
-		Name: body.Name,
-		Role: body.Role,
+		Name:     body.Name,
+		Role:     body.Role,
+		Password: body.Password,
 	}
 
 	output, err := h.controller.Create(
@@ -114,6 +117,7 @@ func (h *StaffHandler) Create(c *gin.Context) {
 //	@Tags			staffs
 //	@Accept			json
 //	@Produce		json
+//	@Security		BearerAuth
 //	@Param			id	path		int								true	"Staff ID"
 //	@Success		200	{object}	presenter.StaffJsonResponse		"OK"
 //	@Failure		400	{object}	middleware.ErrorJsonResponse	"Bad Request"
@@ -151,6 +155,7 @@ func (h *StaffHandler) Get(c *gin.Context) {
 //	@Tags			staffs
 //	@Accept			json
 //	@Produce		json
+//	@Security		BearerAuth
 //	@Param			id		path		int								true	"Staff ID"
 //	@Param			staff	body		request.UpdateStaffBodyRequest	true	"Staff data"
 //	@Success		200		{object}	presenter.StaffJsonResponse		"OK"
@@ -172,9 +177,10 @@ func (h *StaffHandler) Update(c *gin.Context) {
 	}
 
 	input := dto.UpdateStaffInput{
-		ID:   uri.ID,
-		Name: body.Name,
-		Role: body.Role,
+		ID:       uri.ID,
+		Name:     body.Name,
+		Role:     body.Role,
+		Password: body.Password,
 	}
 
 	output, err := h.controller.Update(
@@ -196,6 +202,7 @@ func (h *StaffHandler) Update(c *gin.Context) {
 //	@Description	Deletes a staff by ID
 //	@Tags			staffs
 //	@Produce		json
+//	@Security		BearerAuth
 //	@Param			id	path		int								true	"Staff ID"
 //	@Success		200	{object}	presenter.StaffJsonResponse		"OK"
 //	@Failure		400	{object}	middleware.ErrorJsonResponse	"Bad Request"
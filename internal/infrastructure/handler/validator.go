@@ -1,6 +1,8 @@
 package handler
 
 import (
+	"reflect"
+
 	valueobject "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/value_object"
 	"github.com/go-playground/validator/v10"
 )
@@ -10,7 +12,30 @@ func OrderStatusValidator(fl validator.FieldLevel) bool {
 	return valueobject.IsValidOrderStatus(status)
 }
 
+func PaymentStatusValidator(fl validator.FieldLevel) bool {
+	status := fl.Field().String()
+	return valueobject.IsValidPaymentStatus(status)
+}
+
+func PaymentProviderValidator(fl validator.FieldLevel) bool {
+	provider := fl.Field().String()
+	return valueobject.IsValidPaymentProvider(provider)
+}
+
 func StaffRoleValidator(fl validator.FieldLevel) bool {
 	role := fl.Field().String()
 	return valueobject.IsValidStaffRole(role)
 }
+
+func KitchenStationValidator(fl validator.FieldLevel) bool {
+	station := fl.Field().String()
+	return valueobject.IsValidKitchenStation(station)
+}
+
+// MoneyValue validates the amounts by their cents, so the numeric tags (ex: gt=0) apply to them
+func MoneyValue(field reflect.Value) interface{} {
+	if money, ok := field.Interface().(valueobject.Money); ok {
+		return money.Cents()
+	}
+	return nil
+}
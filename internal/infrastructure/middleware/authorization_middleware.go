@@ -0,0 +1,159 @@
+package middleware
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
+	valueobject "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/value_object"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/port"
+)
+
+const (
+	// GuestTokenHeader carries the secret of the order placed by a guest, given when it is created
+	GuestTokenHeader = "X-Guest-Token"
+	// AccessTokenQueryParam carries the access token of the clients that cannot send headers, ex: EventSource
+	AccessTokenQueryParam = "access_token"
+)
+
+// Rule lists the principals allowed to call a route
+type Rule struct {
+	Anonymous  bool
+	Guest      bool
+	Customer   bool
+	OwnerParam string // path param customers must match with their own ID, when set
+	QueryToken bool   // the access token may be sent in the query instead of the Authorization header
+	StaffRoles []valueobject.StaffRole
+}
+
+// Policy maps the routes of a handler group to the rule that guards them.
+// Keys are either "<METHOD> <path>" (path relative to the group, ex: "POST /callback")
+// or just "<METHOD>". A route without a matching key is denied.
+type Policy map[string]Rule
+
+var (
+	AllStaffRoles = []valueobject.StaffRole{valueobject.COOK, valueobject.ATTENDANT, valueobject.MANAGER}
+
+	// AllowAnonymous allows any caller, authenticated or not
+	AllowAnonymous = Rule{Anonymous: true}
+)
+
+// AllowStaff allows staff members with one of the given roles
+func AllowStaff(roles ...valueobject.StaffRole) Rule {
+	return Rule{StaffRoles: roles}
+}
+
+// AllowCustomerAndStaff allows customers and staff members with one of the given roles
+func AllowCustomerAndStaff(roles ...valueobject.StaffRole) Rule {
+	return Rule{Customer: true, StaffRoles: roles}
+}
+
+// OwnedBy restricts customers to the routes whose path param is their own ID
+func (r Rule) OwnedBy(param string) Rule {
+	r.OwnerParam = param
+	return r
+}
+
+// WithQueryToken also reads the access token from the query, for the EventSource and WebSocket clients of a
+// browser, which cannot set the Authorization header
+func (r Rule) WithQueryToken() Rule {
+	r.QueryToken = true
+	return r
+}
+
+// WithGuest also admits callers without a token, identified as a guest principal
+func (r Rule) WithGuest() Rule {
+	r.Guest = true
+	return r
+}
+
+// Authorize authenticates the request (when a token is sent) and checks the principal against
+// the policy of the group mounted at basePath. Missing or invalid credentials result in 401,
+// and an authenticated principal not allowed by the rule results in 403. Routes open to guests
+// accept requests without a token, which carry a guest principal.
+func Authorize(jwtService port.JWTService, basePath string, policy Policy) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		rule, ok := policy.ruleFor(c.Request.Method, strings.TrimPrefix(c.FullPath(), basePath))
+		if !ok {
+			_ = c.Error(domain.NewForbiddenError(domain.ErrPermissionDenied))
+			c.Abort()
+			return
+		}
+
+		authHeader := c.GetHeader("Authorization")
+		if token := c.Query(AccessTokenQueryParam); authHeader == "" && token != "" && rule.QueryToken {
+			authHeader = "Bearer " + token
+		}
+
+		if authHeader == "" && rule.Anonymous {
+			c.Next()
+			return
+		}
+
+		if authHeader == "" && rule.Guest {
+			guest := &entity.Principal{SubjectType: valueobject.GUEST, GuestToken: c.GetHeader(GuestTokenHeader)}
+			c.Request = c.Request.WithContext(entity.ContextWithPrincipal(c.Request.Context(), guest))
+			c.Next()
+			return
+		}
+
+		principal, err := authenticate(c.Request.Context(), jwtService, authHeader)
+		if err != nil {
+			_ = c.Error(err)
+			c.Abort()
+			return
+		}
+
+		if !rule.allows(principal, c) {
+			_ = c.Error(domain.NewForbiddenError(domain.ErrPermissionDenied))
+			c.Abort()
+			return
+		}
+
+		c.Request = c.Request.WithContext(entity.ContextWithPrincipal(c.Request.Context(), principal))
+		c.Next()
+	}
+}
+
+func authenticate(ctx context.Context, jwtService port.JWTService, authHeader string) (*entity.Principal, error) {
+	token, err := bearerToken(authHeader)
+	if err != nil {
+		return nil, err
+	}
+
+	principal, err := jwtService.ValidateToken(ctx, token)
+	if err != nil {
+		return nil, domain.NewUnauthorizedError(domain.ErrInvalidToken)
+	}
+
+	return principal, nil
+}
+
+func (p Policy) ruleFor(method, path string) (Rule, bool) {
+	if path == "" {
+		path = "/"
+	}
+
+	if rule, ok := p[method+" "+path]; ok {
+		return rule, true
+	}
+
+	rule, ok := p[method]
+	return rule, ok
+}
+
+func (r Rule) allows(principal *entity.Principal, c *gin.Context) bool {
+	if r.Anonymous {
+		return true
+	}
+
+	if principal.IsCustomer() {
+		return r.Customer && (r.OwnerParam == "" || c.Param(r.OwnerParam) == strconv.FormatUint(principal.ID, 10))
+	}
+
+	return principal.HasAnyRole(r.StaffRoles...)
+}
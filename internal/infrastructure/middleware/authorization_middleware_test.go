@@ -0,0 +1,243 @@
+package middleware_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
+	valueobject "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/value_object"
+	mockport "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/port/mocks"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/infrastructure/logger"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/infrastructure/middleware"
+)
+
+func TestAuthorize(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Each token is issued to the principal of the same name
+	principals := map[string]*entity.Principal{
+		"customer-1": {SubjectType: valueobject.CUSTOMER, ID: 1},
+		"customer-2": {SubjectType: valueobject.CUSTOMER, ID: 2},
+		"cook":       {SubjectType: valueobject.STAFF, ID: 3, Role: valueobject.COOK},
+		"manager":    {SubjectType: valueobject.STAFF, ID: 4, Role: valueobject.MANAGER},
+	}
+	jwtService := mockport.NewMockJWTService(ctrl)
+	jwtService.EXPECT().
+		ValidateToken(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, token string) (*entity.Principal, error) {
+			if principal, ok := principals[token]; ok {
+				return principal, nil
+			}
+			return nil, domain.NewUnauthorizedError(domain.ErrInvalidToken)
+		}).
+		AnyTimes()
+
+	policy := middleware.Policy{
+		"GET /public":  middleware.AllowAnonymous,
+		"GET /guests":  middleware.AllowCustomerAndStaff(valueobject.MANAGER).WithGuest(),
+		"GET /kitchen": middleware.AllowStaff(valueobject.COOK),
+		"GET /events":  middleware.AllowStaff(valueobject.COOK).WithQueryToken(),
+		"GET /:id":     middleware.AllowCustomerAndStaff(valueobject.MANAGER).OwnedBy("id"),
+		"POST":         middleware.AllowStaff(valueobject.MANAGER),
+	}
+
+	var principal *entity.Principal
+	router := gin.New()
+	gin.SetMode(gin.TestMode)
+	router.Use(middleware.ErrorHandler(logger.NewLogger("")))
+	group := router.Group("/api", middleware.Authorize(jwtService, "/api", policy))
+	handle := func(c *gin.Context) {
+		principal, _ = entity.PrincipalFromContext(c.Request.Context())
+		c.Status(http.StatusOK)
+	}
+	group.GET("/public", handle)
+	group.GET("/guests", handle)
+	group.GET("/kitchen", handle)
+	group.GET("/events", handle)
+	group.GET("/:id", handle)
+	group.POST("/", handle)
+	group.PUT("/:id", handle)
+
+	tests := []struct {
+		name          string
+		method        string
+		url           string
+		authHeader    string
+		guestToken    string
+		wantCode      int
+		wantPrincipal *entity.Principal
+	}{
+		{
+			name:     "anonymous route without token",
+			method:   http.MethodGet,
+			url:      "/api/public",
+			wantCode: http.StatusOK,
+		},
+		{
+			name:          "anonymous route with token",
+			method:        http.MethodGet,
+			url:           "/api/public",
+			authHeader:    "Bearer cook",
+			wantCode:      http.StatusOK,
+			wantPrincipal: principals["cook"],
+		},
+		{
+			name:          "guest route without token carries a guest principal",
+			method:        http.MethodGet,
+			url:           "/api/guests",
+			guestToken:    "secret",
+			wantCode:      http.StatusOK,
+			wantPrincipal: &entity.Principal{SubjectType: valueobject.GUEST, GuestToken: "secret"},
+		},
+		{
+			name:          "guest route with customer token",
+			method:        http.MethodGet,
+			url:           "/api/guests",
+			authHeader:    "Bearer customer-1",
+			wantCode:      http.StatusOK,
+			wantPrincipal: principals["customer-1"],
+		},
+		{
+			name:       "guest route with staff of another role",
+			method:     http.MethodGet,
+			url:        "/api/guests",
+			authHeader: "Bearer cook",
+			wantCode:   http.StatusForbidden,
+		},
+		{
+			name:          "query token route with token in the query",
+			method:        http.MethodGet,
+			url:           "/api/events?access_token=cook",
+			wantCode:      http.StatusOK,
+			wantPrincipal: principals["cook"],
+		},
+		{
+			name:     "query token route with invalid token in the query",
+			method:   http.MethodGet,
+			url:      "/api/events?access_token=unknown",
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name:     "staff route ignores the token in the query",
+			method:   http.MethodGet,
+			url:      "/api/kitchen?access_token=cook",
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name:     "staff route without token",
+			method:   http.MethodGet,
+			url:      "/api/kitchen",
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name:       "staff route with invalid token",
+			method:     http.MethodGet,
+			url:        "/api/kitchen",
+			authHeader: "Bearer invalid",
+			wantCode:   http.StatusUnauthorized,
+		},
+		{
+			name:       "staff route with malformed header",
+			method:     http.MethodGet,
+			url:        "/api/kitchen",
+			authHeader: "cook",
+			wantCode:   http.StatusUnauthorized,
+		},
+		{
+			name:          "staff route with allowed role",
+			method:        http.MethodGet,
+			url:           "/api/kitchen",
+			authHeader:    "Bearer cook",
+			wantCode:      http.StatusOK,
+			wantPrincipal: principals["cook"],
+		},
+		{
+			name:       "staff route with another role",
+			method:     http.MethodGet,
+			url:        "/api/kitchen",
+			authHeader: "Bearer manager",
+			wantCode:   http.StatusForbidden,
+		},
+		{
+			name:       "staff route with customer",
+			method:     http.MethodGet,
+			url:        "/api/kitchen",
+			authHeader: "Bearer customer-1",
+			wantCode:   http.StatusForbidden,
+		},
+		{
+			name:          "owned route with its owner",
+			method:        http.MethodGet,
+			url:           "/api/1",
+			authHeader:    "Bearer customer-1",
+			wantCode:      http.StatusOK,
+			wantPrincipal: principals["customer-1"],
+		},
+		{
+			name:       "owned route with another customer",
+			method:     http.MethodGet,
+			url:        "/api/1",
+			authHeader: "Bearer customer-2",
+			wantCode:   http.StatusForbidden,
+		},
+		{
+			name:          "owned route with staff of an allowed role",
+			method:        http.MethodGet,
+			url:           "/api/1",
+			authHeader:    "Bearer manager",
+			wantCode:      http.StatusOK,
+			wantPrincipal: principals["manager"],
+		},
+		{
+			name:     "owned route without token is not open to guests",
+			method:   http.MethodGet,
+			url:      "/api/1",
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name:          "method rule",
+			method:        http.MethodPost,
+			url:           "/api/",
+			authHeader:    "Bearer manager",
+			wantCode:      http.StatusOK,
+			wantPrincipal: principals["manager"],
+		},
+		{
+			name:       "route without rule is denied",
+			method:     http.MethodPut,
+			url:        "/api/1",
+			authHeader: "Bearer manager",
+			wantCode:   http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			principal = nil
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(tt.method, tt.url, nil)
+			if tt.authHeader != "" {
+				req.Header.Set("Authorization", tt.authHeader)
+			}
+			if tt.guestToken != "" {
+				req.Header.Set(middleware.GuestTokenHeader, tt.guestToken)
+			}
+
+			// Act
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.wantCode, w.Code)
+			assert.Equal(t, tt.wantPrincipal, principal)
+		})
+	}
+}
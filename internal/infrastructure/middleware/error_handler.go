@@ -49,6 +49,18 @@ func handleError(c *gin.Context, err error, logger *logger.Logger) {
 		setResponse(c, http.StatusUnauthorized, e.Error())
 		logWarning(logger, domain.ErrUnauthorized, e, c.Request)
 
+	case *domain.ForbiddenError:
+		setResponse(c, http.StatusForbidden, e.Error())
+		logWarning(logger, domain.ErrForbidden, e, c.Request)
+
+	case *domain.ConflictError:
+		setResponse(c, http.StatusConflict, e.Error())
+		logWarning(logger, domain.ErrConflict, e, c.Request)
+
+	case *domain.PreconditionFailedError:
+		setResponse(c, http.StatusPreconditionFailed, e.Error())
+		logWarning(logger, domain.ErrVersionMismatch, e, c.Request)
+
 	case *domain.InternalError:
 		setResponse(c, http.StatusInternalServerError, domain.ErrInternalError)
 		logError(logger, domain.ErrInternalError, e, c.Request)
@@ -6,31 +6,41 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
 	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/port"
 )
 
 func JWTAuthMiddleware(jwtService port.JWTService) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		authHeader := c.GetHeader("Authorization")
-		if authHeader == "" {
-			_ = c.Error(domain.NewUnauthorizedError(domain.ErrMissingAuthHeader))
+		token, err := bearerToken(c.GetHeader("Authorization"))
+		if err != nil {
+			_ = c.Error(err)
 			c.Abort()
 			return
 		}
 
-		parts := strings.Split(authHeader, " ")
-		if len(parts) != 2 || strings.ToLower(parts[0]) != "bearer" {
-			_ = c.Error(domain.NewUnauthorizedError(domain.ErrInvalidAuthHeader))
-			c.Abort()
-			return
-		}
-
-		if err := jwtService.ValidateToken(parts[1]); err != nil {
+		principal, err := jwtService.ValidateToken(c.Request.Context(), token)
+		if err != nil {
 			_ = c.Error(domain.NewUnauthorizedError(domain.ErrInvalidToken))
 			c.Abort()
 			return
 		}
 
+		c.Request = c.Request.WithContext(entity.ContextWithPrincipal(c.Request.Context(), principal))
 		c.Next()
 	}
 }
+
+// bearerToken extracts the token from an "Authorization: Bearer <token>" header
+func bearerToken(authHeader string) (string, error) {
+	if authHeader == "" {
+		return "", domain.NewUnauthorizedError(domain.ErrMissingAuthHeader)
+	}
+
+	parts := strings.Split(authHeader, " ")
+	if len(parts) != 2 || strings.ToLower(parts[0]) != "bearer" {
+		return "", domain.NewUnauthorizedError(domain.ErrInvalidAuthHeader)
+	}
+
+	return parts[1], nil
+}
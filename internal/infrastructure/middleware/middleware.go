@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"net/url"
 	"time"
 
 	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/infrastructure/logger"
@@ -12,7 +13,7 @@ func Logger(log *logger.Logger) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		start := time.Now()
 		path := c.Request.URL.Path
-		raw := c.Request.URL.RawQuery
+		raw := redactQuery(c.Request.URL.RawQuery)
 		requestID := c.GetString("request_id")
 
 		c.Next()
@@ -31,6 +32,16 @@ func Logger(log *logger.Logger) gin.HandlerFunc {
 	}
 }
 
+// redactQuery hides the access token sent in the query, so it is not written to the logs
+func redactQuery(raw string) string {
+	values, err := url.ParseQuery(raw)
+	if err != nil || !values.Has(AccessTokenQueryParam) {
+		return raw
+	}
+	values.Set(AccessTokenQueryParam, "REDACTED")
+	return values.Encode()
+}
+
 func RequestID() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		requestID := c.GetHeader("X-Request-ID")
@@ -47,8 +58,9 @@ func CORS() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		c.Writer.Header().Set("Access-Control-Allow-Origin", "*")
 		c.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
-		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
-		c.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT, DELETE")
+		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With, X-Guest-Token, If-Match")
+		c.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT, PATCH, DELETE")
+		c.Writer.Header().Set("Access-Control-Expose-Headers", "ETag")
 
 		if c.Request.Method == "OPTIONS" {
 			c.AbortWithStatus(204)
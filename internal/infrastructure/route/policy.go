@@ -0,0 +1,85 @@
+package route
+
+import (
+	valueobject "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/value_object"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/infrastructure/middleware"
+)
+
+// Access policies of each handler group
+var (
+	authPolicy = middleware.Policy{
+		"POST /logout": middleware.AllowCustomerAndStaff(middleware.AllStaffRoles...),
+		"POST":         middleware.AllowAnonymous,
+	}
+
+	productPolicy = middleware.Policy{
+		"GET":    middleware.AllowAnonymous,
+		"POST":   middleware.AllowStaff(valueobject.MANAGER),
+		"PUT":    middleware.AllowStaff(valueobject.MANAGER),
+		"DELETE": middleware.AllowStaff(valueobject.MANAGER),
+	}
+
+	categoryPolicy = middleware.Policy{
+		"GET":    middleware.AllowAnonymous,
+		"POST":   middleware.AllowStaff(valueobject.MANAGER),
+		"PUT":    middleware.AllowStaff(valueobject.MANAGER),
+		"DELETE": middleware.AllowStaff(valueobject.MANAGER),
+	}
+
+	customerPolicy = middleware.Policy{
+		"GET /":  middleware.AllowStaff(valueobject.ATTENDANT, valueobject.MANAGER),
+		"POST":   middleware.AllowAnonymous, // sign-up
+		"GET":    middleware.AllowCustomerAndStaff(valueobject.ATTENDANT, valueobject.MANAGER).OwnedBy("id"),
+		"PUT":    middleware.AllowCustomerAndStaff(valueobject.ATTENDANT, valueobject.MANAGER).OwnedBy("id"),
+		"DELETE": middleware.AllowStaff(valueobject.MANAGER),
+	}
+
+	staffPolicy = middleware.Policy{
+		"GET":    middleware.AllowStaff(middleware.AllStaffRoles...),
+		"POST":   middleware.AllowStaff(valueobject.MANAGER),
+		"PUT":    middleware.AllowStaff(valueobject.MANAGER),
+		"DELETE": middleware.AllowStaff(valueobject.MANAGER),
+	}
+
+	orderPolicy = middleware.Policy{
+		"GET /":               middleware.AllowCustomerAndStaff(middleware.AllStaffRoles...),
+		"GET /events":         middleware.AllowCustomerAndStaff(middleware.AllStaffRoles...).WithQueryToken(), // pickup display
+		"GET /events/ws":      middleware.AllowCustomerAndStaff(middleware.AllStaffRoles...).WithQueryToken(),
+		"GET":                 middleware.AllowCustomerAndStaff(middleware.AllStaffRoles...).WithGuest(),
+		"POST":                middleware.AllowCustomerAndStaff(valueobject.ATTENDANT, valueobject.MANAGER).WithGuest(),
+		"PUT":                 middleware.AllowStaff(middleware.AllStaffRoles...), // status changes
+		"PATCH /:id/customer": middleware.AllowCustomerAndStaff(valueobject.ATTENDANT, valueobject.MANAGER),
+		"PATCH":               middleware.AllowStaff(middleware.AllStaffRoles...), // status changes
+		"DELETE":              middleware.AllowStaff(valueobject.MANAGER),
+	}
+
+	orderProductPolicy = middleware.Policy{
+		"GET":                     middleware.AllowCustomerAndStaff(middleware.AllStaffRoles...).WithGuest(),
+		"POST":                    middleware.AllowCustomerAndStaff(valueobject.ATTENDANT, valueobject.MANAGER).WithGuest(),
+		"POST /:order_id/reprice": middleware.AllowStaff(valueobject.ATTENDANT, valueobject.MANAGER),
+		"PUT":                     middleware.AllowCustomerAndStaff(valueobject.ATTENDANT, valueobject.MANAGER).WithGuest(),
+		"DELETE":                  middleware.AllowCustomerAndStaff(valueobject.ATTENDANT, valueobject.MANAGER).WithGuest(),
+	}
+
+	orderHistoryPolicy = middleware.Policy{
+		"GET":    middleware.AllowStaff(middleware.AllStaffRoles...),
+		"DELETE": middleware.AllowStaff(valueobject.MANAGER),
+	}
+
+	paymentPolicy = middleware.Policy{
+		"POST /callback":               middleware.AllowAnonymous, // payment provider webhook
+		"POST /:order_id/checkout":     middleware.AllowCustomerAndStaff(valueobject.ATTENDANT, valueobject.MANAGER).WithGuest(),
+		"POST /:order_id/refund":       middleware.AllowStaff(valueobject.MANAGER),
+		"POST /:order_id/cash/confirm": middleware.AllowStaff(valueobject.ATTENDANT, valueobject.MANAGER),
+		"GET":                          middleware.AllowCustomerAndStaff(middleware.AllStaffRoles...).WithGuest(),
+	}
+
+	kitchenPolicy = middleware.Policy{
+		"GET":  middleware.AllowStaff(valueobject.COOK, valueobject.MANAGER),
+		"POST": middleware.AllowStaff(valueobject.COOK, valueobject.MANAGER), // bumps
+	}
+
+	healthCheckPolicy = middleware.Policy{
+		"GET": middleware.AllowAnonymous,
+	}
+)
@@ -0,0 +1,134 @@
+package route
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
+	valueobject "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/value_object"
+	mockport "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/port/mocks"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/infrastructure/logger"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/infrastructure/middleware"
+)
+
+func TestPolicies(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Each token is issued to the principal of the same name
+	principals := map[string]*entity.Principal{
+		"customer-1": {SubjectType: valueobject.CUSTOMER, ID: 1},
+		"cook":       {SubjectType: valueobject.STAFF, ID: 2, Role: valueobject.COOK},
+		"attendant":  {SubjectType: valueobject.STAFF, ID: 3, Role: valueobject.ATTENDANT},
+		"manager":    {SubjectType: valueobject.STAFF, ID: 4, Role: valueobject.MANAGER},
+	}
+	jwtService := mockport.NewMockJWTService(ctrl)
+	jwtService.EXPECT().
+		ValidateToken(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, token string) (*entity.Principal, error) {
+			if principal, ok := principals[token]; ok {
+				return principal, nil
+			}
+			return nil, domain.NewUnauthorizedError(domain.ErrInvalidToken)
+		}).
+		AnyTimes()
+
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.Use(middleware.ErrorHandler(logger.NewLogger("")))
+	r := &Router{engine: engine, jwtService: jwtService}
+	v1 := engine.Group("/api/v1")
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+
+	customers := r.group(v1, "/customers", customerPolicy)
+	customers.GET("/", ok)
+	customers.POST("/", ok)
+	customers.GET("/:id", ok)
+	customers.PUT("/:id", ok)
+	customers.DELETE("/:id", ok)
+
+	orders := r.group(v1, "/orders", orderPolicy)
+	orders.GET("/", ok)
+	orders.POST("/", ok)
+	orders.GET("/:id", ok)
+	orders.PUT("/:id", ok)
+	orders.PATCH("/:id/customer", ok)
+	orders.GET("/events", ok)
+	orders.GET("/events/ws", ok)
+
+	payments := r.group(v1, "/payments", paymentPolicy)
+	payments.POST("/callback", ok)
+	payments.POST("/:order_id/refund", ok)
+
+	kitchen := r.group(v1, "/kitchen", kitchenPolicy)
+	kitchen.GET("/tickets", ok)
+
+	tests := []struct {
+		name     string
+		method   string
+		url      string
+		token    string
+		wantCode int
+	}{
+		// Customers
+		{name: "customer signs up without token", method: http.MethodPost, url: "/api/v1/customers/", wantCode: http.StatusOK},
+		{name: "customer reads itself", method: http.MethodGet, url: "/api/v1/customers/1", token: "customer-1", wantCode: http.StatusOK},
+		{name: "customer cannot read another customer", method: http.MethodGet, url: "/api/v1/customers/2", token: "customer-1", wantCode: http.StatusForbidden},
+		{name: "customer updates itself", method: http.MethodPut, url: "/api/v1/customers/1", token: "customer-1", wantCode: http.StatusOK},
+		{name: "customer cannot update another customer", method: http.MethodPut, url: "/api/v1/customers/2", token: "customer-1", wantCode: http.StatusForbidden},
+		{name: "customer cannot list customers", method: http.MethodGet, url: "/api/v1/customers/", token: "customer-1", wantCode: http.StatusForbidden},
+		{name: "customer cannot delete itself", method: http.MethodDelete, url: "/api/v1/customers/1", token: "customer-1", wantCode: http.StatusForbidden},
+		{name: "attendant reads any customer", method: http.MethodGet, url: "/api/v1/customers/2", token: "attendant", wantCode: http.StatusOK},
+		{name: "cook cannot read customers", method: http.MethodGet, url: "/api/v1/customers/2", token: "cook", wantCode: http.StatusForbidden},
+		{name: "guest cannot read customers", method: http.MethodGet, url: "/api/v1/customers/1", wantCode: http.StatusUnauthorized},
+		{name: "manager deletes customers", method: http.MethodDelete, url: "/api/v1/customers/1", token: "manager", wantCode: http.StatusOK},
+
+		// Orders
+		{name: "guest places an order", method: http.MethodPost, url: "/api/v1/orders/", wantCode: http.StatusOK},
+		{name: "guest reads an order", method: http.MethodGet, url: "/api/v1/orders/1", wantCode: http.StatusOK},
+		{name: "guest cannot list orders", method: http.MethodGet, url: "/api/v1/orders/", wantCode: http.StatusUnauthorized},
+		{name: "guest cannot attach a customer", method: http.MethodPatch, url: "/api/v1/orders/1/customer", wantCode: http.StatusUnauthorized},
+		{name: "customer attaches itself", method: http.MethodPatch, url: "/api/v1/orders/1/customer", token: "customer-1", wantCode: http.StatusOK},
+		{name: "customer cannot change status", method: http.MethodPut, url: "/api/v1/orders/1", token: "customer-1", wantCode: http.StatusForbidden},
+		{name: "cook changes status", method: http.MethodPut, url: "/api/v1/orders/1", token: "cook", wantCode: http.StatusOK},
+		{name: "pickup display streams with the token in the query", method: http.MethodGet, url: "/api/v1/orders/events?access_token=attendant", wantCode: http.StatusOK},
+		{name: "websocket streams with the token in the query", method: http.MethodGet, url: "/api/v1/orders/events/ws?access_token=customer-1", wantCode: http.StatusOK},
+		{name: "guest cannot stream", method: http.MethodGet, url: "/api/v1/orders/events", wantCode: http.StatusUnauthorized},
+		{name: "order is not read with the token in the query", method: http.MethodGet, url: "/api/v1/orders/?access_token=manager", wantCode: http.StatusUnauthorized},
+
+		// Payments
+		{name: "provider notifies without token", method: http.MethodPost, url: "/api/v1/payments/callback", wantCode: http.StatusOK},
+		{name: "manager refunds", method: http.MethodPost, url: "/api/v1/payments/1/refund", token: "manager", wantCode: http.StatusOK},
+		{name: "attendant cannot refund", method: http.MethodPost, url: "/api/v1/payments/1/refund", token: "attendant", wantCode: http.StatusForbidden},
+		{name: "customer cannot refund", method: http.MethodPost, url: "/api/v1/payments/1/refund", token: "customer-1", wantCode: http.StatusForbidden},
+
+		// Kitchen
+		{name: "cook reads the tickets", method: http.MethodGet, url: "/api/v1/kitchen/tickets", token: "cook", wantCode: http.StatusOK},
+		{name: "attendant cannot read the tickets", method: http.MethodGet, url: "/api/v1/kitchen/tickets", token: "attendant", wantCode: http.StatusForbidden},
+		{name: "guest cannot read the tickets", method: http.MethodGet, url: "/api/v1/kitchen/tickets", wantCode: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(tt.method, tt.url, nil)
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+
+			// Act
+			engine.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.wantCode, w.Code)
+		})
+	}
+}
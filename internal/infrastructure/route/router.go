@@ -6,6 +6,7 @@ import (
 	ginSwagger "github.com/swaggo/gin-swagger"
 
 	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/docs"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/port"
 	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/infrastructure/config"
 	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/infrastructure/handler"
 	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/infrastructure/logger"
@@ -13,11 +14,12 @@ import (
 )
 
 type Router struct {
-	engine *gin.Engine
-	logger *logger.Logger
+	engine     *gin.Engine
+	logger     *logger.Logger
+	jwtService port.JWTService
 }
 
-func NewRouter(logger *logger.Logger, cfg *config.Config) *Router {
+func NewRouter(logger *logger.Logger, cfg *config.Config, jwtService port.JWTService) *Router {
 	// Set Gin mode
 	if cfg.Environment == "production" {
 		gin.SetMode(gin.ReleaseMode)
@@ -38,8 +40,9 @@ func NewRouter(logger *logger.Logger, cfg *config.Config) *Router {
 	engine.GET("/docs/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
 	return &Router{
-		engine: engine,
-		logger: logger,
+		engine:     engine,
+		logger:     logger,
+		jwtService: jwtService,
 	}
 }
 
@@ -48,17 +51,27 @@ func (r *Router) RegisterRoutes(handlers *Handlers) {
 	// API v1
 	v1 := r.engine.Group("/api/v1")
 	{
-		handlers.Auth.Register(v1.Group("/auth"))
-		handlers.Product.Register(v1.Group("/products"))
-		handlers.Customer.Register(v1.Group("/customers"))
-		handlers.Staff.Register(v1.Group("/staffs"))
-		handlers.Order.Register(v1.Group("/orders"))
-		handlers.OrderProduct.Register(v1.Group("/orders/products"))
-		handlers.OrderHistory.Register(v1.Group("/orders/histories"))
-		handlers.Payment.Register(v1.Group("/payments"))
-		handlers.Category.Register(v1.Group("/categories"))
-		handlers.HealthCheck.Register(v1.Group("/health"))
+		handlers.Auth.Register(r.group(v1, "/auth", authPolicy))
+		handlers.Product.Register(r.group(v1, "/products", productPolicy))
+		handlers.Customer.Register(r.group(v1, "/customers", customerPolicy))
+		handlers.Staff.Register(r.group(v1, "/staffs", staffPolicy))
+		handlers.Order.Register(r.group(v1, "/orders", orderPolicy))
+		handlers.OrderProduct.Register(r.group(v1, "/orders/products", orderProductPolicy))
+		handlers.OrderHistory.Register(r.group(v1, "/orders/histories", orderHistoryPolicy))
+		handlers.Payment.Register(r.group(v1, "/payments", paymentPolicy))
+		handlers.Category.Register(r.group(v1, "/categories", categoryPolicy))
+		handlers.Kitchen.Register(r.group(v1, "/kitchen", kitchenPolicy))
+		handlers.HealthCheck.Register(r.group(v1, "/health", healthCheckPolicy))
 	}
+
+	// Public keys of the access tokens, for clients verifying them offline
+	handlers.JWKS.Register(r.engine.Group("/.well-known"))
+}
+
+// group creates a route group guarded by the given access policy
+func (r *Router) group(parent *gin.RouterGroup, relativePath string, policy middleware.Policy) *gin.RouterGroup {
+	basePath := parent.BasePath() + relativePath
+	return parent.Group(relativePath, middleware.Authorize(r.jwtService, basePath, policy))
 }
 
 // Engine returns the gin engine
@@ -77,5 +90,7 @@ type Handlers struct {
 	HealthCheck  *handler.HealthCheckHandler
 	Payment      *handler.PaymentHandler
 	Category     *handler.CategoryHandler
+	Kitchen      *handler.KitchenHandler
 	Auth         *handler.AuthHandler
+	JWKS         *handler.JWKSHandler
 }
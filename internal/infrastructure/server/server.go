@@ -9,6 +9,8 @@ import (
 	"os/signal"
 	"syscall"
 
+	valueobject "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/value_object"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/port"
 	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/infrastructure/config"
 	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/infrastructure/handler"
 	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/infrastructure/logger"
@@ -24,8 +26,8 @@ type Server struct {
 	logger *logger.Logger
 }
 
-func NewServer(cfg *config.Config, logger *logger.Logger, handlers *route.Handlers) *Server {
-	router := route.NewRouter(logger, cfg)
+func NewServer(cfg *config.Config, logger *logger.Logger, handlers *route.Handlers, jwtService port.JWTService) *Server {
+	router := route.NewRouter(logger, cfg, jwtService)
 
 	RegisterCustomValidation()
 	router.RegisterRoutes(handlers)
@@ -89,9 +91,26 @@ func RegisterCustomValidation() {
 			panic(err)
 		}
 
+		err = v.RegisterValidation("payment_status_exists", handler.PaymentStatusValidator)
+		if err != nil {
+			panic(err)
+		}
+
+		err = v.RegisterValidation("payment_provider_exists", handler.PaymentProviderValidator)
+		if err != nil {
+			panic(err)
+		}
+
 		err = v.RegisterValidation("staff_role_exists", handler.StaffRoleValidator)
 		if err != nil {
 			panic(err)
 		}
+
+		err = v.RegisterValidation("kitchen_station_exists", handler.KitchenStationValidator)
+		if err != nil {
+			panic(err)
+		}
+
+		v.RegisterCustomTypeFunc(handler.MoneyValue, valueobject.Money{})
 	}
 }
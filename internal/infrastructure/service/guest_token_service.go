@@ -0,0 +1,34 @@
+package service
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/port"
+)
+
+const guestTokenSize = 32
+
+type guestTokenService struct{}
+
+func NewGuestTokenService() port.GuestTokenService {
+	return &guestTokenService{}
+}
+
+func (s *guestTokenService) Generate() (string, string, error) {
+	b := make([]byte, guestTokenSize)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", err
+	}
+
+	token := base64.RawURLEncoding.EncodeToString(b)
+	return token, s.Hash(token), nil
+}
+
+// Hash returns the SHA-256 of the token, only the hash is persisted
+func (s *guestTokenService) Hash(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
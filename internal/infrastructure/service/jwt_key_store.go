@@ -0,0 +1,289 @@
+package service
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/port"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/infrastructure/config"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/infrastructure/logger"
+)
+
+const (
+	AlgorithmRS256 = "RS256"
+	AlgorithmES256 = "ES256"
+
+	generatedKeyRSABits = 2048
+)
+
+type jwtKeyStore struct {
+	algorithm     string
+	keysDir       string
+	privateKeyPEM string
+	keyID         string
+	signingKeyID  string
+	retention     time.Duration
+
+	mu         sync.RWMutex
+	signingKey *entity.SigningKey
+	keys       map[string]*entity.SigningKey
+}
+
+// NewJWTKeyStore loads the private keys of JWT_KEYS_DIR (one <kid>.pem file per key) and JWT_PRIVATE_KEY.
+// New tokens are signed with JWT_SIGNING_KEY_ID, or the greatest kid when it is not set. Without any key
+// configured an ephemeral key is generated, which is refused in production since the tokens would not be
+// accepted by other instances nor survive a restart.
+func NewJWTKeyStore(cfg *config.Config) (port.JWTKeyStore, error) {
+	s := &jwtKeyStore{
+		algorithm:     cfg.JWTAlgorithm,
+		keysDir:       cfg.JWTKeysDir,
+		privateKeyPEM: cfg.JWTPrivateKey,
+		keyID:         cfg.JWTKeyID,
+		signingKeyID:  cfg.JWTSigningKeyID,
+		retention:     cfg.JWTExpiration,
+		keys:          make(map[string]*entity.SigningKey),
+	}
+
+	if s.algorithm != AlgorithmRS256 && s.algorithm != AlgorithmES256 {
+		return nil, fmt.Errorf("unsupported JWT algorithm %q", s.algorithm)
+	}
+
+	if !s.hasConfiguredKeys() && cfg.Environment == "production" {
+		return nil, errors.New("no JWT signing key configured, set JWT_KEYS_DIR or JWT_PRIVATE_KEY")
+	}
+
+	if err := s.Rotate(); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func (s *jwtKeyStore) SigningKey() (*entity.SigningKey, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.signingKey == nil {
+		return nil, errors.New("no signing key available")
+	}
+	return s.signingKey, nil
+}
+
+func (s *jwtKeyStore) VerificationKey(keyID string) (*entity.SigningKey, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	key, ok := s.keys[keyID]
+	if !ok || s.isExpired(key, time.Now()) {
+		return nil, fmt.Errorf("unknown signing key %q", keyID)
+	}
+	return key, nil
+}
+
+func (s *jwtKeyStore) PublicKeys() []*entity.SigningKey {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	now := time.Now()
+	keys := make([]*entity.SigningKey, 0, len(s.keys))
+	for _, key := range s.keys {
+		if !s.isExpired(key, now) {
+			keys = append(keys, key)
+		}
+	}
+
+	sort.Slice(keys, func(i, j int) bool { return keys[i].ID < keys[j].ID })
+	return keys
+}
+
+func (s *jwtKeyStore) Rotate() error {
+	keys, err := s.loadConfiguredKeys()
+	if err != nil {
+		return err
+	}
+
+	if len(keys) == 0 {
+		key, err := generateSigningKey(s.algorithm)
+		if err != nil {
+			return err
+		}
+		keys[key.ID] = key
+	}
+
+	signingKey, err := s.selectSigningKey(keys)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Keep the keys no longer configured until the tokens they signed expire
+	now := time.Now()
+	for id, key := range s.keys {
+		if _, ok := keys[id]; ok {
+			continue
+		}
+		if !key.IsRetired() {
+			retired := *key
+			retired.RetiredAt = &now
+			key = &retired
+		}
+		if !s.isExpired(key, now) {
+			keys[id] = key
+		}
+	}
+
+	s.keys = keys
+	s.signingKey = signingKey
+	return nil
+}
+
+func (s *jwtKeyStore) hasConfiguredKeys() bool {
+	return s.keysDir != "" || s.privateKeyPEM != ""
+}
+
+// isExpired returns true if every token signed by the retired key has already expired
+func (s *jwtKeyStore) isExpired(key *entity.SigningKey, now time.Time) bool {
+	return key.IsRetired() && !now.Before(key.RetiredAt.Add(s.retention))
+}
+
+func (s *jwtKeyStore) selectSigningKey(keys map[string]*entity.SigningKey) (*entity.SigningKey, error) {
+	if s.signingKeyID != "" {
+		key, ok := keys[s.signingKeyID]
+		if !ok {
+			return nil, fmt.Errorf("signing key %q not found", s.signingKeyID)
+		}
+		return key, nil
+	}
+
+	var signingKey *entity.SigningKey
+	for _, key := range keys {
+		if signingKey == nil || key.ID > signingKey.ID {
+			signingKey = key
+		}
+	}
+	return signingKey, nil
+}
+
+func (s *jwtKeyStore) loadConfiguredKeys() (map[string]*entity.SigningKey, error) {
+	keys := make(map[string]*entity.SigningKey)
+
+	if s.privateKeyPEM != "" {
+		// Line breaks are usually escaped when the key is set in the environment
+		key, err := parseSigningKey(s.keyID, []byte(strings.ReplaceAll(s.privateKeyPEM, `\n`, "\n")))
+		if err != nil {
+			return nil, fmt.Errorf("error parsing JWT_PRIVATE_KEY: %w", err)
+		}
+		keys[key.ID] = key
+	}
+
+	if s.keysDir == "" {
+		return keys, nil
+	}
+
+	files, err := filepath.Glob(filepath.Join(s.keysDir, "*.pem"))
+	if err != nil {
+		return nil, fmt.Errorf("error listing JWT keys: %w", err)
+	}
+
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("error reading JWT key %s: %w", file, err)
+		}
+
+		key, err := parseSigningKey(strings.TrimSuffix(filepath.Base(file), ".pem"), data)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing JWT key %s: %w", file, err)
+		}
+		keys[key.ID] = key
+	}
+
+	return keys, nil
+}
+
+// parseSigningKey parses a PKCS#1, PKCS#8 or SEC 1 PEM private key, the algorithm follows the key type
+func parseSigningKey(keyID string, data []byte) (*entity.SigningKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+
+	var privateKey any
+	var err error
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		privateKey, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		privateKey, err = x509.ParseECPrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		privateKey, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported PEM block %q", block.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	switch key := privateKey.(type) {
+	case *rsa.PrivateKey:
+		return &entity.SigningKey{ID: keyID, Algorithm: AlgorithmRS256, PrivateKey: key}, nil
+	case *ecdsa.PrivateKey:
+		if key.Curve != elliptic.P256() {
+			return nil, errors.New("only P-256 EC keys are supported")
+		}
+		return &entity.SigningKey{ID: keyID, Algorithm: AlgorithmES256, PrivateKey: key}, nil
+	default:
+		return nil, fmt.Errorf("unsupported private key type %T", privateKey)
+	}
+}
+
+// generateSigningKey creates an ephemeral key, the kid is the creation time so the newest key sorts last
+func generateSigningKey(algorithm string) (*entity.SigningKey, error) {
+	var privateKey crypto.Signer
+	var err error
+	switch algorithm {
+	case AlgorithmES256:
+		privateKey, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	default:
+		privateKey, err = rsa.GenerateKey(rand.Reader, generatedKeyRSABits)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error generating JWT key: %w", err)
+	}
+
+	return &entity.SigningKey{
+		ID:         time.Now().UTC().Format("20060102T150405.000000000Z"),
+		Algorithm:  algorithm,
+		PrivateKey: privateKey,
+	}, nil
+}
+
+// ScheduleKeyRotation rotates the keys of the store at every interval, until the returned function is called
+func ScheduleKeyRotation(keyStore port.JWTKeyStore, interval time.Duration, logger *logger.Logger) (stop func()) {
+	return runEvery(interval, func(context.Context) {
+		if err := keyStore.Rotate(); err != nil {
+			logger.Error("failed to rotate JWT keys", "error", err)
+			return
+		}
+		if key, err := keyStore.SigningKey(); err == nil {
+			logger.Info("JWT keys rotated", "signing_kid", key.ID)
+		}
+	})
+}
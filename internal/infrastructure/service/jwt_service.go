@@ -1,62 +1,135 @@
 package service
 
 import (
+	"context"
 	"errors"
 	"strconv"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
+	valueobject "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/value_object"
 	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/port"
 	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/infrastructure/config"
 )
 
 type jwtService struct {
-	secretKey  []byte
-	expiration time.Duration
+	keyStore          port.JWTKeyStore
+	expiration        time.Duration
+	revocationService port.TokenRevocationService
 }
 
-func NewJWTService(cfg *config.Config) port.JWTService {
+// tokenClaims are the claims carried by the access token, the principal ID goes in the "sub" claim
+type tokenClaims struct {
+	SubjectType valueobject.SubjectType `json:"sub_type"`
+	Role        valueobject.StaffRole   `json:"role,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// NewJWTService creates the access token service, tokens are signed with the current key of the key store
+// and carry its ID in the "kid" header
+func NewJWTService(cfg *config.Config, keyStore port.JWTKeyStore, revocationService port.TokenRevocationService) port.JWTService {
 	return &jwtService{
-		secretKey:  []byte(cfg.JWTSecret),
-		expiration: cfg.JWTExpiration,
+		keyStore:          keyStore,
+		expiration:        cfg.JWTExpiration,
+		revocationService: revocationService,
 	}
 }
 
-func (s *jwtService) GenerateToken(id uint64) (string, error) {
+func (s *jwtService) GenerateToken(principal *entity.Principal) (*entity.AccessToken, error) {
+	signingKey, err := s.keyStore.SigningKey()
+	if err != nil {
+		return nil, err
+	}
+
 	expiresAt := time.Now().Add(s.expiration)
-	idStr := strconv.FormatUint(id, 10)
+	idStr := strconv.FormatUint(principal.ID, 10)
+	tokenID := uuid.NewString()
 
-	tokenClaims := jwt.RegisteredClaims{
-		ExpiresAt: jwt.NewNumericDate(expiresAt),
-		IssuedAt:  jwt.NewNumericDate(time.Now()),
-		ID:        idStr,
+	tokenClaims := tokenClaims{
+		SubjectType: principal.SubjectType,
+		Role:        principal.Role,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			Subject:   idStr,
+			ID:        tokenID,
+		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, tokenClaims)
-	signedToken, err := token.SignedString(s.secretKey)
+	token := jwt.NewWithClaims(jwt.GetSigningMethod(signingKey.Algorithm), tokenClaims)
+	token.Header["kid"] = signingKey.ID
+	signedToken, err := token.SignedString(signingKey.PrivateKey)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	return signedToken, nil
+	return &entity.AccessToken{
+		Token:     signedToken,
+		ID:        tokenID,
+		ExpiresAt: expiresAt,
+	}, nil
 }
 
-func (s *jwtService) ValidateToken(tokenString string) error {
-	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+func (s *jwtService) ValidateToken(ctx context.Context, tokenString string) (*entity.Principal, error) {
+	var claims tokenClaims
+	token, err := jwt.ParseWithClaims(tokenString, &claims, func(token *jwt.Token) (interface{}, error) {
+		keyID, _ := token.Header["kid"].(string)
+		verificationKey, err := s.keyStore.VerificationKey(keyID)
+		if err != nil {
+			return nil, err
+		}
+
+		// The key dictates the algorithm, never the token
+		if token.Method.Alg() != verificationKey.Algorithm {
 			return nil, errors.New("invalid signature method")
 		}
-		return s.secretKey, nil
-	})
+		return verificationKey.PublicKey(), nil
+	}, jwt.WithValidMethods([]string{AlgorithmRS256, AlgorithmES256}))
 
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if !token.Valid {
-		return errors.New("invalid token")
+		return nil, errors.New("invalid token")
+	}
+
+	id, err := strconv.ParseUint(claims.Subject, 10, 64)
+	if err != nil {
+		return nil, errors.New("invalid token subject")
+	}
+
+	if claims.ID == "" || claims.ExpiresAt == nil {
+		return nil, errors.New("invalid token id")
+	}
+
+	principal := &entity.Principal{
+		SubjectType:    valueobject.ToSubjectType(claims.SubjectType.String()),
+		ID:             id,
+		Role:           valueobject.ToStaffRole(claims.Role.String()),
+		TokenID:        claims.ID,
+		TokenExpiresAt: claims.ExpiresAt.Time,
+	}
+
+	if !principal.IsCustomer() && !principal.IsStaff() {
+		return nil, errors.New("invalid token subject type")
+	}
+
+	if principal.IsStaff() && principal.Role == valueobject.UNDEFINED {
+		return nil, errors.New("invalid token role")
+	}
+
+	revoked, err := s.revocationService.IsRevoked(ctx, claims.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if revoked {
+		return nil, errors.New("token has been revoked")
 	}
 
-	return nil
+	return principal, nil
 }
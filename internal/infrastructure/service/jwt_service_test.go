@@ -0,0 +1,233 @@
+package service_test
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
+	valueobject "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/value_object"
+	mockport "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/port/mocks"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/infrastructure/config"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/infrastructure/service"
+)
+
+type contextKey struct{}
+
+func TestJWTService_ValidateToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	keysDir := t.TempDir()
+	rsaKey := writeKey(t, keysDir, "2025-01", newRSAKey(t))
+	cfg := &config.Config{JWTAlgorithm: service.AlgorithmRS256, JWTKeysDir: keysDir, JWTExpiration: time.Hour}
+	keyStore, err := service.NewJWTKeyStore(cfg)
+	require.NoError(t, err)
+
+	revocationService := mockport.NewMockTokenRevocationService(ctrl)
+	jwtService := service.NewJWTService(cfg, keyStore, revocationService)
+	principal := &entity.Principal{SubjectType: valueobject.STAFF, ID: 1, Role: valueobject.COOK}
+
+	// The revocation list is read within the context of the request
+	ctx := context.WithValue(context.Background(), contextKey{}, "request")
+
+	tests := []struct {
+		name       string
+		token      func() string
+		setupMocks func()
+		wantErr    bool
+	}{
+		{
+			name: "should return the principal of a valid token",
+			token: func() string {
+				accessToken, err := jwtService.GenerateToken(principal)
+				require.NoError(t, err)
+				return accessToken.Token
+			},
+			setupMocks: func() {
+				revocationService.EXPECT().IsRevoked(ctx, gomock.Any()).Return(false, nil)
+			},
+		},
+		{
+			name: "should refuse a revoked token",
+			token: func() string {
+				accessToken, err := jwtService.GenerateToken(principal)
+				require.NoError(t, err)
+				return accessToken.Token
+			},
+			setupMocks: func() {
+				revocationService.EXPECT().IsRevoked(ctx, gomock.Any()).Return(true, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "should refuse the token when the revocation list cannot be read",
+			token: func() string {
+				accessToken, err := jwtService.GenerateToken(principal)
+				require.NoError(t, err)
+				return accessToken.Token
+			},
+			setupMocks: func() {
+				revocationService.EXPECT().IsRevoked(ctx, gomock.Any()).Return(false, assert.AnError)
+			},
+			wantErr: true,
+		},
+		{
+			name: "should refuse a token signed by an unknown key",
+			token: func() string {
+				return signToken(t, jwt.SigningMethodRS256, "unknown", newRSAKey(t), principal)
+			},
+			setupMocks: func() {},
+			wantErr:    true,
+		},
+		{
+			name: "should refuse a token whose kid names a known key it was not signed with",
+			token: func() string {
+				return signToken(t, jwt.SigningMethodRS256, "2025-01", newRSAKey(t), principal)
+			},
+			setupMocks: func() {},
+			wantErr:    true,
+		},
+		{
+			name: "should refuse a token whose algorithm differs from the one of its key",
+			token: func() string {
+				return signToken(t, jwt.SigningMethodES256, "2025-01", newECKey(t), principal)
+			},
+			setupMocks: func() {},
+			wantErr:    true,
+		},
+		{
+			name: "should refuse an unsigned token",
+			token: func() string {
+				return signToken(t, jwt.SigningMethodNone, "2025-01", jwt.UnsafeAllowNoneSignatureType, principal)
+			},
+			setupMocks: func() {},
+			wantErr:    true,
+		},
+		{
+			name: "should refuse a staff token without role",
+			token: func() string {
+				return signToken(t, jwt.SigningMethodRS256, "2025-01", rsaKey, &entity.Principal{SubjectType: valueobject.STAFF, ID: 1})
+			},
+			setupMocks: func() {},
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMocks()
+
+			got, err := jwtService.ValidateToken(ctx, tt.token())
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, got)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, principal.SubjectType, got.SubjectType)
+			assert.Equal(t, principal.ID, got.ID)
+			assert.Equal(t, principal.Role, got.Role)
+			assert.NotEmpty(t, got.TokenID)
+		})
+	}
+}
+
+func TestJWTService_ValidateToken_KeyRotation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	keysDir := t.TempDir()
+	writeKey(t, keysDir, "2025-01", newRSAKey(t))
+	cfg := &config.Config{JWTAlgorithm: service.AlgorithmRS256, JWTKeysDir: keysDir, JWTExpiration: time.Hour}
+	keyStore, err := service.NewJWTKeyStore(cfg)
+	require.NoError(t, err)
+
+	revocationService := mockport.NewMockTokenRevocationService(ctrl)
+	revocationService.EXPECT().IsRevoked(gomock.Any(), gomock.Any()).Return(false, nil).AnyTimes()
+	jwtService := service.NewJWTService(cfg, keyStore, revocationService)
+	principal := &entity.Principal{SubjectType: valueobject.CUSTOMER, ID: 1}
+
+	before, err := jwtService.GenerateToken(principal)
+	require.NoError(t, err)
+
+	// The new key is deployed and the former one removed: its tokens are accepted until they expire
+	writeKey(t, keysDir, "2025-02", newECKey(t))
+	require.NoError(t, os.Remove(filepath.Join(keysDir, "2025-01.pem")))
+	require.NoError(t, keyStore.Rotate())
+
+	after, err := jwtService.GenerateToken(principal)
+	require.NoError(t, err)
+
+	assert.Equal(t, "2025-02", tokenKeyID(t, after.Token))
+
+	for _, token := range []string{before.Token, after.Token} {
+		got, err := jwtService.ValidateToken(context.Background(), token)
+		assert.NoError(t, err)
+		assert.Equal(t, principal.ID, got.ID)
+	}
+}
+
+func newRSAKey(t *testing.T) *rsa.PrivateKey {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	return key
+}
+
+func newECKey(t *testing.T) *ecdsa.PrivateKey {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	return key
+}
+
+// writeKey saves the key as <kid>.pem in the keys directory
+func writeKey(t *testing.T, keysDir, keyID string, key crypto.Signer) crypto.Signer {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+
+	data := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+	require.NoError(t, os.WriteFile(filepath.Join(keysDir, keyID+".pem"), data, 0o600))
+	return key
+}
+
+// signToken forges a token for the principal, signed with any method and key
+func signToken(t *testing.T, method jwt.SigningMethod, keyID string, key any, principal *entity.Principal) string {
+	token := jwt.NewWithClaims(method, jwt.MapClaims{
+		"sub":      strconv.FormatUint(principal.ID, 10),
+		"sub_type": principal.SubjectType.String(),
+		"role":     principal.Role.String(),
+		"jti":      "token-1",
+		"iat":      time.Now().Unix(),
+		"exp":      time.Now().Add(time.Hour).Unix(),
+	})
+	token.Header["kid"] = keyID
+
+	signed, err := token.SignedString(key)
+	require.NoError(t, err)
+	return signed
+}
+
+func tokenKeyID(t *testing.T, tokenString string) string {
+	token, _, err := jwt.NewParser().ParseUnverified(tokenString, jwt.MapClaims{})
+	require.NoError(t, err)
+
+	keyID, _ := token.Header["kid"].(string)
+	return keyID
+}
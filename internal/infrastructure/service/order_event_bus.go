@@ -0,0 +1,58 @@
+package service
+
+import (
+	"sync"
+
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/port"
+)
+
+// orderEventBus fans the order status events out to the subscribers of this process. A subscriber that lets its
+// buffer fill up is dropped instead of slowing down the publisher, and resumes from the order histories.
+type orderEventBus struct {
+	mu          sync.Mutex
+	subscribers map[chan *entity.OrderStatusEvent]struct{}
+	bufferSize  int
+}
+
+func NewOrderEventBus(bufferSize int) port.OrderEventBus {
+	return &orderEventBus{
+		subscribers: make(map[chan *entity.OrderStatusEvent]struct{}),
+		bufferSize:  bufferSize,
+	}
+}
+
+func (b *orderEventBus) Publish(event *entity.OrderStatusEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for subscriber := range b.subscribers {
+		select {
+		case subscriber <- event:
+		default:
+			delete(b.subscribers, subscriber)
+			close(subscriber)
+		}
+	}
+}
+
+func (b *orderEventBus) Subscribe() (<-chan *entity.OrderStatusEvent, func()) {
+	subscriber := make(chan *entity.OrderStatusEvent, b.bufferSize)
+
+	b.mu.Lock()
+	b.subscribers[subscriber] = struct{}{}
+	b.mu.Unlock()
+
+	unsubscribe := func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+
+		// already closed when dropped
+		if _, ok := b.subscribers[subscriber]; ok {
+			delete(b.subscribers, subscriber)
+			close(subscriber)
+		}
+	}
+
+	return subscriber, unsubscribe
+}
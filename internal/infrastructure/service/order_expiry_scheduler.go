@@ -0,0 +1,24 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/port"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/infrastructure/logger"
+)
+
+// ScheduleOrderExpiry expires the READY orders never collected and the idle OPEN orders at every interval, until the
+// returned function is called
+func ScheduleOrderExpiry(expiryUseCase port.OrderExpiryUseCase, interval time.Duration, logger *logger.Logger) (stop func()) {
+	return runEvery(interval, func(ctx context.Context) {
+		expired, err := expiryUseCase.ExpireOrders(ctx)
+		if err != nil {
+			logger.Error("failed to expire orders", "expired", expired, "error", err)
+			return
+		}
+		if expired > 0 {
+			logger.Info("idle orders expired", "expired", expired)
+		}
+	})
+}
@@ -0,0 +1,41 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/port"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/infrastructure/logger"
+)
+
+// ScheduleOrderSLAMonitor logs a warning for each order past its preparation target at every interval, once per
+// order, until the returned function is called
+func ScheduleOrderSLAMonitor(timingUseCase port.OrderTimingUseCase, interval time.Duration, logger *logger.Logger) (stop func()) {
+	// the orders already warned, kept while they are late
+	warned := make(map[uint64]struct{})
+
+	return runEvery(interval, func(ctx context.Context) {
+		orders, err := timingUseCase.ListLate(ctx)
+		if err != nil {
+			logger.Error("failed to check the order SLA", "error", err)
+			return
+		}
+
+		late := make(map[uint64]struct{}, len(orders))
+		for _, order := range orders {
+			late[order.ID] = struct{}{}
+			if _, ok := warned[order.ID]; ok {
+				continue
+			}
+
+			logger.Warn("order exceeded its preparation target",
+				"order_id", order.ID,
+				"status", order.Status.String(),
+				"queue_wait", order.Timing.QueueWait.Round(time.Second).String(),
+				"prep_time", order.Timing.PrepTime.Round(time.Second).String(),
+				"target", order.Timing.Target.String(),
+			)
+		}
+		warned = late
+	})
+}
@@ -0,0 +1,28 @@
+package service
+
+import (
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/port"
+)
+
+type passwordService struct {
+	cost int
+}
+
+func NewPasswordService() port.PasswordService {
+	return &passwordService{cost: bcrypt.DefaultCost}
+}
+
+func (s *passwordService) Hash(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), s.cost)
+	if err != nil {
+		return "", err
+	}
+
+	return string(hash), nil
+}
+
+func (s *passwordService) Compare(hash, password string) bool {
+	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil
+}
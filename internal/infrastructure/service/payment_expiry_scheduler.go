@@ -0,0 +1,23 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/port"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/infrastructure/logger"
+)
+
+// SchedulePaymentExpiry aborts the expired payments at every interval, until the returned function is called
+func SchedulePaymentExpiry(paymentUseCase port.PaymentUseCase, interval time.Duration, logger *logger.Logger) (stop func()) {
+	return runEvery(interval, func(ctx context.Context) {
+		expired, err := paymentUseCase.ExpirePayments(ctx)
+		if err != nil {
+			logger.Error("failed to expire payments", "expired", expired, "error", err)
+			return
+		}
+		if expired > 0 {
+			logger.Info("expired payments aborted", "expired", expired)
+		}
+	})
+}
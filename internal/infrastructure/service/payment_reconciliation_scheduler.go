@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/adapter/presenter"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
+	valueobject "github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/value_object"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/dto"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/port"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/infrastructure/logger"
+)
+
+// SchedulePaymentReconciliation reconciles the payments with their provider at every interval, until the returned
+// function is called. The reports are written to reportDir, or only logged when it is empty.
+func SchedulePaymentReconciliation(
+	reconciliationUseCase port.PaymentReconciliationUseCase,
+	interval time.Duration,
+	reportDir string,
+	logger *logger.Logger,
+) (stop func()) {
+	return runEvery(interval, func(ctx context.Context) {
+		report, err := reconciliationUseCase.Reconcile(ctx)
+		if err != nil {
+			logger.Error("failed to reconcile payments", "error", err)
+		}
+		if report == nil {
+			return
+		}
+
+		LogPaymentReconciliationReport(logger, report)
+
+		if reportDir == "" {
+			return
+		}
+		if path, err := WritePaymentReconciliationReport(reportDir, report); err != nil {
+			logger.Error("failed to write payment reconciliation report", "error", err)
+		} else {
+			logger.Info("payment reconciliation report written", "path", path)
+		}
+	})
+}
+
+// LogPaymentReconciliationReport logs the totals of the report, and each payment that was not matched
+func LogPaymentReconciliationReport(logger *logger.Logger, report *entity.PaymentReconciliationReport) {
+	for _, entry := range report.Entries {
+		if entry.Result == valueobject.RECONCILIATION_MATCHED || entry.Result == valueobject.RECONCILIATION_SKIPPED {
+			continue
+		}
+		logger.Warn("payment reconciled",
+			"result", entry.Result,
+			"payment_id", entry.PaymentID,
+			"resource", entry.Resource,
+			"local_status", entry.LocalStatus,
+			"provider_status", entry.ProviderStatus,
+			"reason", entry.Reason,
+		)
+	}
+
+	logger.Info("payments reconciled",
+		"matched", report.Count(valueobject.RECONCILIATION_MATCHED),
+		"fixed", report.Count(valueobject.RECONCILIATION_FIXED),
+		"orphaned", report.Count(valueobject.RECONCILIATION_ORPHANED),
+		"skipped", report.Count(valueobject.RECONCILIATION_SKIPPED),
+		"failed", report.Count(valueobject.RECONCILIATION_FAILED),
+	)
+}
+
+// WritePaymentReconciliationReport writes the report as a JSON file named after its start time, returning its path
+func WritePaymentReconciliationReport(dir string, report *entity.PaymentReconciliationReport) (string, error) {
+	data, err := presenter.NewPaymentReconciliationJsonPresenter().Present(dto.PresenterInput{Result: report})
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("error creating reconciliation report directory: %w", err)
+	}
+
+	path := filepath.Join(dir, "payment-reconciliation-"+report.StartedAt.UTC().Format("20060102T150405Z")+".json")
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return "", fmt.Errorf("error writing reconciliation report: %w", err)
+	}
+
+	return path, nil
+}
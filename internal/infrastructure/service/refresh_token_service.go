@@ -0,0 +1,38 @@
+package service
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"time"
+
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/port"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/infrastructure/config"
+)
+
+const refreshTokenSize = 32
+
+type refreshTokenService struct {
+	expiration time.Duration
+}
+
+func NewRefreshTokenService(cfg *config.Config) port.RefreshTokenService {
+	return &refreshTokenService{expiration: cfg.JWTRefreshExpiration}
+}
+
+func (s *refreshTokenService) Generate() (string, string, time.Time, error) {
+	b := make([]byte, refreshTokenSize)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", time.Time{}, err
+	}
+
+	token := base64.RawURLEncoding.EncodeToString(b)
+	return token, s.Hash(token), time.Now().Add(s.expiration), nil
+}
+
+// Hash returns the SHA-256 of the token, only the hash is persisted
+func (s *refreshTokenService) Hash(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
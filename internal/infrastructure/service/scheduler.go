@@ -0,0 +1,33 @@
+package service
+
+import (
+	"context"
+	"time"
+)
+
+// runEvery calls fn at every interval, one call at a time, until the returned function is called. Each call is given a
+// context that times out after interval and is cancelled by stop. A non-positive interval never calls fn.
+func runEvery(interval time.Duration, fn func(ctx context.Context)) (stop func()) {
+	if interval <= 0 {
+		return func() {}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ticker := time.NewTicker(interval)
+
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				runCtx, runCancel := context.WithTimeout(ctx, interval)
+				fn(runCtx)
+				runCancel()
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return cancel
+}
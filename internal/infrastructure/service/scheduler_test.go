@@ -0,0 +1,62 @@
+package service
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunEvery(t *testing.T) {
+	t.Run("should call at every interval until stopped", func(t *testing.T) {
+		var calls atomic.Int32
+		stop := runEvery(5*time.Millisecond, func(ctx context.Context) {
+			_, hasDeadline := ctx.Deadline()
+			assert.True(t, hasDeadline)
+			calls.Add(1)
+		})
+
+		assert.Eventually(t, func() bool { return calls.Load() >= 2 }, time.Second, time.Millisecond)
+		stop()
+
+		// A call already started when stopped may still finish
+		time.Sleep(20 * time.Millisecond)
+		stopped := calls.Load()
+		time.Sleep(20 * time.Millisecond)
+		assert.Equal(t, stopped, calls.Load())
+	})
+
+	t.Run("should cancel the running call when stopped", func(t *testing.T) {
+		started := make(chan struct{})
+		done := make(chan error)
+		var once atomic.Bool
+		stop := runEvery(50*time.Millisecond, func(ctx context.Context) {
+			if !once.CompareAndSwap(false, true) {
+				return
+			}
+			close(started)
+			<-ctx.Done()
+			done <- ctx.Err()
+		})
+
+		<-started
+		stop()
+
+		select {
+		case err := <-done:
+			assert.ErrorIs(t, err, context.Canceled)
+		case <-time.After(time.Second):
+			t.Fatal("the running call was not cancelled")
+		}
+	})
+
+	t.Run("should never call without an interval", func(t *testing.T) {
+		stop := runEvery(0, func(context.Context) {
+			t.Error("unexpected call")
+		})
+		time.Sleep(10 * time.Millisecond)
+		stop()
+	})
+}
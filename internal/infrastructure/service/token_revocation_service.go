@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/domain/entity"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/port"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/infrastructure/config"
+)
+
+// maxCachedTokens bounds the cache, expired entries are purged when it is reached
+const maxCachedTokens = 10000
+
+type tokenRevocationService struct {
+	gateway  port.RevokedTokenGateway
+	cacheTTL time.Duration
+
+	mu    sync.RWMutex
+	cache map[string]revocationCacheEntry
+}
+
+type revocationCacheEntry struct {
+	revoked   bool
+	expiresAt time.Time
+}
+
+// NewTokenRevocationService creates a revocation list backed by the database. Lookups are cached
+// for JWT_REVOCATION_CACHE_TTL, so a token revoked by another instance is refused after at most that long.
+func NewTokenRevocationService(gateway port.RevokedTokenGateway, cfg *config.Config) port.TokenRevocationService {
+	return &tokenRevocationService{
+		gateway:  gateway,
+		cacheTTL: cfg.JWTRevocationCacheTTL,
+		cache:    make(map[string]revocationCacheEntry),
+	}
+}
+
+func (s *tokenRevocationService) Revoke(ctx context.Context, tokenID string, expiresAt time.Time) error {
+	if err := s.gateway.Create(ctx, &entity.RevokedToken{JTI: tokenID, ExpiresAt: expiresAt}); err != nil {
+		return err
+	}
+
+	// A revoked token stays revoked, keep it cached until it expires
+	s.store(tokenID, revocationCacheEntry{revoked: true, expiresAt: expiresAt})
+	return nil
+}
+
+func (s *tokenRevocationService) IsRevoked(ctx context.Context, tokenID string) (bool, error) {
+	s.mu.RLock()
+	entry, ok := s.cache[tokenID]
+	s.mu.RUnlock()
+	if ok && time.Now().Before(entry.expiresAt) {
+		return entry.revoked, nil
+	}
+
+	revoked, err := s.gateway.ExistsByJTI(ctx, tokenID)
+	if err != nil {
+		return false, err
+	}
+
+	s.store(tokenID, revocationCacheEntry{revoked: revoked, expiresAt: time.Now().Add(s.cacheTTL)})
+	return revoked, nil
+}
+
+func (s *tokenRevocationService) store(tokenID string, entry revocationCacheEntry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.cache) >= maxCachedTokens {
+		now := time.Now()
+		for id, e := range s.cache {
+			if !now.Before(e.expiresAt) {
+				delete(s.cache, id)
+			}
+		}
+	}
+
+	s.cache[tokenID] = entry
+}
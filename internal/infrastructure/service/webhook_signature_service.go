@@ -0,0 +1,100 @@
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/core/port"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/infrastructure/config"
+)
+
+type webhookSignatureService struct {
+	secret    []byte
+	tolerance time.Duration
+}
+
+// NewWebhookSignatureService implements the Mercado Pago x-signature scheme: "ts=<unix time>,v1=<signature>",
+// where the signature is the HMAC-SHA256 of the manifest "id:<data id>;request-id:<x-request-id>;ts:<ts>;".
+// Without a secret every notification is refused.
+func NewWebhookSignatureService(cfg *config.Config) port.WebhookSignatureService {
+	return &webhookSignatureService{
+		secret:    []byte(cfg.MercadoPagoWebhookSecret),
+		tolerance: cfg.MercadoPagoWebhookTolerance,
+	}
+}
+
+func (s *webhookSignatureService) Sign(dataID, requestID string, timestamp time.Time) string {
+	ts := strconv.FormatInt(timestamp.Unix(), 10)
+	return fmt.Sprintf("ts=%s,v1=%s", ts, s.hash(dataID, requestID, ts))
+}
+
+func (s *webhookSignatureService) Verify(signature, dataID, requestID string) error {
+	if len(s.secret) == 0 {
+		return errors.New("webhook secret is not configured")
+	}
+
+	var ts, v1 string
+	for _, part := range strings.Split(signature, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+		switch key {
+		case "ts":
+			ts = value
+		case "v1":
+			v1 = value
+		}
+	}
+
+	if ts == "" || v1 == "" {
+		return errors.New("malformed signature")
+	}
+
+	sentAt, err := parseSignatureTimestamp(ts)
+	if err != nil {
+		return err
+	}
+
+	// Refuse replays of an old notification
+	if age := time.Since(sentAt); age > s.tolerance || age < -s.tolerance {
+		return errors.New("signature timestamp out of the tolerance window")
+	}
+
+	expected, err := hex.DecodeString(s.hash(dataID, requestID, ts))
+	if err != nil {
+		return err
+	}
+
+	actual, err := hex.DecodeString(v1)
+	if err != nil || !hmac.Equal(expected, actual) {
+		return errors.New("signature mismatch")
+	}
+
+	return nil
+}
+
+func (s *webhookSignatureService) hash(dataID, requestID, ts string) string {
+	// Alphanumeric IDs are signed in lower case
+	manifest := fmt.Sprintf("id:%s;request-id:%s;ts:%s;", strings.ToLower(dataID), requestID, ts)
+
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(manifest))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// parseSignatureTimestamp accepts the timestamp in seconds or milliseconds
+func parseSignatureTimestamp(ts string) (time.Time, error) {
+	value, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return time.Time{}, errors.New("invalid signature timestamp")
+	}
+
+	if value > 1e12 {
+		return time.UnixMilli(value), nil
+	}
+	return time.Unix(value, 0), nil
+}
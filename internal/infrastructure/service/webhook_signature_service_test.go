@@ -0,0 +1,137 @@
+package service_test
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/infrastructure/config"
+	"github.com/FIAP-SOAT-G20/FIAP-TechChallenge-Fase2/internal/infrastructure/service"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhookSignatureService_Verify(t *testing.T) {
+	cfg := &config.Config{MercadoPagoWebhookSecret: "secret", MercadoPagoWebhookTolerance: 5 * time.Minute}
+	signatureService := service.NewWebhookSignatureService(cfg)
+	now := time.Now()
+
+	tests := []struct {
+		name      string
+		cfg       *config.Config
+		signature string
+		dataID    string
+		requestID string
+		wantErr   bool
+	}{
+		{
+			name:      "valid signature",
+			signature: signatureService.Sign("resource-1", "request-1", now),
+			dataID:    "resource-1",
+			requestID: "request-1",
+		},
+		{
+			name:      "valid signature - data ID is signed in lower case",
+			signature: signatureService.Sign("resource-1", "request-1", now),
+			dataID:    "RESOURCE-1",
+			requestID: "request-1",
+		},
+		{
+			name:      "valid signature - timestamp in milliseconds",
+			signature: sign("secret", "resource-1", "request-1", strconv.FormatInt(now.UnixMilli(), 10)),
+			dataID:    "resource-1",
+			requestID: "request-1",
+		},
+		{
+			name:      "another data ID",
+			signature: signatureService.Sign("resource-1", "request-1", now),
+			dataID:    "resource-2",
+			requestID: "request-1",
+			wantErr:   true,
+		},
+		{
+			name:      "another request ID",
+			signature: signatureService.Sign("resource-1", "request-1", now),
+			dataID:    "resource-1",
+			requestID: "request-2",
+			wantErr:   true,
+		},
+		{
+			name:      "signed with another secret",
+			signature: service.NewWebhookSignatureService(&config.Config{MercadoPagoWebhookSecret: "another"}).Sign("resource-1", "request-1", now),
+			dataID:    "resource-1",
+			requestID: "request-1",
+			wantErr:   true,
+		},
+		{
+			name:      "timestamp before the tolerance window",
+			signature: signatureService.Sign("resource-1", "request-1", now.Add(-6*time.Minute)),
+			dataID:    "resource-1",
+			requestID: "request-1",
+			wantErr:   true,
+		},
+		{
+			name:      "timestamp after the tolerance window",
+			signature: signatureService.Sign("resource-1", "request-1", now.Add(6*time.Minute)),
+			dataID:    "resource-1",
+			requestID: "request-1",
+			wantErr:   true,
+		},
+		{
+			name:      "malformed signature - no v1",
+			signature: "ts=" + strconv.FormatInt(now.Unix(), 10),
+			dataID:    "resource-1",
+			requestID: "request-1",
+			wantErr:   true,
+		},
+		{
+			name:      "malformed signature - invalid timestamp",
+			signature: "ts=invalid,v1=abc",
+			dataID:    "resource-1",
+			requestID: "request-1",
+			wantErr:   true,
+		},
+		{
+			name:      "malformed signature - v1 is not hexadecimal",
+			signature: "ts=" + strconv.FormatInt(now.Unix(), 10) + ",v1=invalid",
+			dataID:    "resource-1",
+			requestID: "request-1",
+			wantErr:   true,
+		},
+		{
+			name:      "secret is not configured",
+			cfg:       &config.Config{MercadoPagoWebhookTolerance: 5 * time.Minute},
+			signature: signatureService.Sign("resource-1", "request-1", now),
+			dataID:    "resource-1",
+			requestID: "request-1",
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := signatureService
+			if tt.cfg != nil {
+				s = service.NewWebhookSignatureService(tt.cfg)
+			}
+
+			err := s.Verify(tt.signature, tt.dataID, tt.requestID)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+// sign signs the manifest as Mercado Pago does, with the timestamp as sent
+func sign(secret, dataID, requestID, ts string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(fmt.Sprintf("id:%s;request-id:%s;ts:%s;", dataID, requestID, ts)))
+	return fmt.Sprintf("ts=%s,v1=%s", ts, hex.EncodeToString(mac.Sum(nil)))
+}
@@ -41,3 +41,8 @@ func ReadFixtureFiles(bussinessContext string, fileNames ...string) (map[string]
 	}
 	return requests, nil
 }
+
+// Ptr returns a pointer to a copy of v
+func Ptr[T any](v T) *T {
+	return &v
+}